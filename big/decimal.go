@@ -0,0 +1,434 @@
+package big
+
+import (
+	"strconv"
+	"strings"
+)
+
+// RoundingMode は Decimal を指定の精度に丸めるときの方法です
+type RoundingMode uint8
+
+const (
+	// RoundHalfEven は最も近い値に丸め、ちょうど中間のときは最下位の桁が偶数になる方に丸めます
+	RoundHalfEven RoundingMode = iota
+	// RoundHalfUp は最も近い値に丸め、ちょうど中間のときは0から遠い方に丸めます
+	RoundHalfUp
+	// RoundDown は0に近い方に丸めます (切り捨て)
+	RoundDown
+	// RoundCeiling は正の無限大の方向に丸めます
+	RoundCeiling
+	// RoundFloor は負の無限大の方向に丸めます
+	RoundFloor
+)
+
+// DefaultDecimalPrec は精度が指定されていないときに Quo と Sqrt が使う有効桁数です
+const DefaultDecimalPrec = 34
+
+// 指数は int32 の範囲で表す
+const (
+	minDecimalExp = -1 << 31
+	maxDecimalExp = 1<<31 - 1
+)
+
+// Decimal は10進数の浮動小数点数型です
+// 値は coef * 10^exp で表され、prec が0でなければ coef は prec 桁以内に丸められます
+// ゼロ値は精度の指定がない0として使えます
+type Decimal struct {
+	coef *Int
+	exp  int32
+	prec uint32
+	mode RoundingMode
+}
+
+// NewDecimal は coef * 10^exp を表す Decimal を返します
+func NewDecimal(coef int64, exp int32) *Decimal {
+	return &Decimal{coef: NewInt(coef), exp: exp}
+}
+
+// SetPrec は z の有効桁数を prec にセットし、必要なら丸めます
+// prec が0のとき Add, Sub, Mul は丸めずに正確な値を求めます
+func (z *Decimal) SetPrec(prec uint32) *Decimal {
+	z.prec = prec
+	return z.round(prec)
+}
+
+// SetMode は z の丸め方を mode にセットします
+func (z *Decimal) SetMode(mode RoundingMode) *Decimal {
+	z.mode = mode
+	return z
+}
+
+// Prec は x の有効桁数を返します
+func (x *Decimal) Prec() uint32 {
+	return x.prec
+}
+
+// Mode は x の丸め方を返します
+func (x *Decimal) Mode() RoundingMode {
+	return x.mode
+}
+
+// Coef は x の係数を返します
+func (x *Decimal) Coef() *Int {
+	if x.coef == nil {
+		return NewInt(0)
+	}
+	return x.coef
+}
+
+// Exp は x の指数を返します
+func (x *Decimal) Exp() int32 {
+	return x.exp
+}
+
+// SetString は "-123.45" や "1.2345e-3" のような10進数の表記をscanし、z の精度に丸めます
+// 予期しない入力によって読み取りに失敗するとpanicします
+func (z *Decimal) SetString(s string) *Decimal {
+	var exp int64
+	if i := strings.IndexAny(s, "eE"); i >= 0 {
+		e, err := strconv.ParseInt(s[i+1:], 10, 32)
+		if err != nil {
+			panic(err)
+		}
+		exp = e
+		s = s[:i]
+	}
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		// 小数点以下の桁数だけ指数を下げて、係数は整数として読む
+		exp -= int64(len(s) - i - 1)
+		s = s[:i] + s[i+1:]
+	}
+	if exp < minDecimalExp || exp > maxDecimalExp {
+		panic("exponent overflow")
+	}
+	z.coef = new(Int).SetString(s)
+	z.exp = int32(exp)
+	return z.round(z.prec)
+}
+
+// String は x を10進数の文字列で返します
+// 指数が正になるか、小数点以下の0が多くなりすぎる場合は 1.2345E+6 のような指数表記を使います
+func (x *Decimal) String() string {
+	c := x.Coef()
+	ds := (&Int{abs: c.abs}).String()
+	// adj は先頭の桁を1の位に置いたときの指数
+	adj := int64(x.exp) + int64(len(ds)) - 1
+
+	var sb strings.Builder
+	if c.neg {
+		sb.WriteByte('-')
+	}
+	switch {
+	case x.exp <= 0 && adj >= -6:
+		// 指数表記を使わずに小数点を挿入する
+		point := len(ds) + int(x.exp)
+		if point <= 0 {
+			sb.WriteString("0.")
+			sb.WriteString(strings.Repeat("0", -point))
+			sb.WriteString(ds)
+		} else {
+			sb.WriteString(ds[:point])
+			if point < len(ds) {
+				sb.WriteByte('.')
+				sb.WriteString(ds[point:])
+			}
+		}
+	default:
+		sb.WriteString(ds[:1])
+		if len(ds) > 1 {
+			sb.WriteByte('.')
+			sb.WriteString(ds[1:])
+		}
+		sb.WriteByte('E')
+		if adj >= 0 {
+			sb.WriteByte('+')
+		}
+		sb.WriteString(strconv.FormatInt(adj, 10))
+	}
+	return sb.String()
+}
+
+// Cmp は x, y を比較して以下の結果を返します
+// x > y  -> 1
+// x == y -> 0
+// x < y  -> -1
+func (x *Decimal) Cmp(y *Decimal) int8 {
+	xc, yc := x.Coef(), y.Coef()
+	xs, ys := sign(xc), sign(yc)
+	switch {
+	case xs != ys:
+		if xs > ys {
+			return 1
+		}
+		return -1
+	case xs == 0:
+		return 0
+	}
+	// 先頭の桁の位置が異なれば、係数を揃えなくても絶対値の大小が決まる
+	if ax, ay := adjusted(x), adjusted(y); ax != ay {
+		r := int8(1)
+		if ax < ay {
+			r = -1
+		}
+		return r * int8(xs)
+	}
+	// 先頭の桁の位置が同じなら、指数の差は係数の桁数の差に収まる
+	xc, yc, _ = align(x, y, 0)
+	return Cmp(xc, yc)
+}
+
+// Add は z を x + y にセットし、z の精度に丸めます
+func (z *Decimal) Add(x, y *Decimal) *Decimal {
+	prec := resultPrec(z, x, y)
+	xc, yc, exp := align(x, y, prec)
+	return z.set(Add(xc, yc), exp, prec)
+}
+
+// Sub は z を x - y にセットし、z の精度に丸めます
+func (z *Decimal) Sub(x, y *Decimal) *Decimal {
+	prec := resultPrec(z, x, y)
+	xc, yc, exp := align(x, y, prec)
+	return z.set(Sub(xc, yc), exp, prec)
+}
+
+// Mul は z を x * y にセットし、z の精度に丸めます
+func (z *Decimal) Mul(x, y *Decimal) *Decimal {
+	return z.set(Mul(x.Coef(), y.Coef()), int64(x.exp)+int64(y.exp), resultPrec(z, x, y))
+}
+
+// Quo は z を x / y にセットし、z の精度に丸めます
+// 精度が指定されていないときは DefaultDecimalPrec 桁に丸めます
+// y == 0 のときpanicします
+func (z *Decimal) Quo(x, y *Decimal) *Decimal {
+	prec := resultPrec(z, x, y)
+	if prec == 0 {
+		prec = DefaultDecimalPrec
+	}
+	xc, yc := x.Coef(), y.Coef()
+	if len(yc.abs) == 0 {
+		panic("division by zero")
+	}
+	// 商が少なくとも prec+1 桁になるように x の係数を 10^s 倍してから整数の除算を行う
+	s := int(prec) + len(yc.abs) - len(xc.abs) + 1
+	if s < 0 {
+		s = 0
+	}
+	q, r := div(rightPad(xc.abs, s), yc.abs)
+	exp := int64(x.exp) - int64(y.exp) - int64(s)
+	// 割り切れなかったときは末尾に1を足して、ちょうど中間の値と区別できるようにする
+	if len(r) > 0 {
+		q = add(rightPad(q, 1), digits{1})
+		exp--
+	}
+	z.set(&Int{neg: len(q) > 0 && xc.neg != yc.neg, abs: q}, exp, prec)
+	// 割り切れた場合は余分な末尾の0を取り除き、できるだけ x, y の指数の差に近づける
+	return z.trim(int64(x.exp) - int64(y.exp))
+}
+
+// Sqrt は z を x の平方根にセットし、z の精度に丸めます
+// 精度が指定されていないときは DefaultDecimalPrec 桁に丸めます
+// x < 0 のときpanicします
+func (z *Decimal) Sqrt(x *Decimal) *Decimal {
+	prec := z.prec
+	if prec == 0 {
+		prec = x.prec
+	}
+	if prec == 0 {
+		prec = DefaultDecimalPrec
+	}
+	c, exp := x.Coef(), int64(x.exp)
+	if c.neg {
+		panic("square root of negative number")
+	}
+	ideal := exp >> 1
+	if len(c.abs) == 0 {
+		return z.set(NewInt(0), ideal, prec)
+	}
+	// 指数を偶数にし、平方根が少なくとも prec+1 桁になるように係数を 10^(2k) 倍する
+	abs := c.abs
+	if exp&1 != 0 {
+		abs = rightPad(abs, 1)
+		exp--
+	}
+	if k := int(prec) + 1 - (len(abs)+1)/2; k > 0 {
+		abs = rightPad(abs, 2*k)
+		exp -= int64(2 * k)
+	}
+	r := Sqrt(&Int{abs: abs})
+	exp /= 2
+	// 平方数でなかったときは末尾に1を足して、ちょうど中間の値と区別できるようにする
+	if cmp(mul(r.abs, r.abs), abs) != 0 {
+		r = &Int{abs: add(rightPad(r.abs, 1), digits{1})}
+		exp--
+	}
+	z.set(r, exp, prec)
+	return z.trim(ideal)
+}
+
+// set は z を coef * 10^exp にセットし、prec 桁に丸める
+func (z *Decimal) set(coef *Int, exp int64, prec uint32) *Decimal {
+	if exp < minDecimalExp || exp > maxDecimalExp {
+		panic("exponent overflow")
+	}
+	z.coef, z.exp = coef, int32(exp)
+	return z.round(prec)
+}
+
+// round は z の係数を prec 桁に丸める
+// prec が0のときは何もしない
+func (z *Decimal) round(prec uint32) *Decimal {
+	c := z.Coef()
+	n := int(prec)
+	if n == 0 || len(c.abs) <= n {
+		return z
+	}
+	abs, dropped := roundDigits(c.abs, c.neg, n, z.mode)
+	z.coef = &Int{neg: c.neg, abs: abs}
+	if int64(z.exp)+int64(dropped) > maxDecimalExp {
+		panic("exponent overflow")
+	}
+	z.exp += int32(dropped)
+	return z
+}
+
+// trim は末尾の0を取り除いて、指数を ideal を超えない範囲でできるだけ ideal に近づける
+func (z *Decimal) trim(ideal int64) *Decimal {
+	c := z.Coef()
+	abs := c.abs
+	exp := int64(z.exp)
+	for exp < ideal && len(abs) > 1 && abs[len(abs)-1] == 0 {
+		abs = abs[:len(abs)-1]
+		exp++
+	}
+	if len(abs) == 0 {
+		exp = ideal
+	}
+	z.coef, z.exp = &Int{neg: c.neg, abs: abs}, int32(exp)
+	return z
+}
+
+// roundDigits は abs を上位 n 桁に丸め、丸めた結果と落とした桁数を返す
+// 呼び出し側は 0 < n < len(abs) を保証すること
+func roundDigits(abs digits, neg bool, n int, mode RoundingMode) (digits, int) {
+	kept, rest := abs[:n], abs[n:]
+	first := rest[0]
+	sticky := false // 2桁目以降に0でない桁があるかどうか
+	for _, d := range rest[1:] {
+		if d != 0 {
+			sticky = true
+			break
+		}
+	}
+	inexact := first != 0 || sticky
+
+	var up bool
+	switch mode {
+	case RoundHalfEven:
+		up = first > 5 || (first == 5 && (sticky || kept[n-1]&1 == 1))
+	case RoundHalfUp:
+		up = first >= 5
+	case RoundDown:
+		up = false
+	case RoundCeiling:
+		up = inexact && !neg
+	case RoundFloor:
+		up = inexact && neg
+	}
+
+	dropped := len(rest)
+	if up {
+		kept = add(kept, digits{1})
+		// 999 -> 1000 のように桁が増えたら、最下位の0を落として n 桁に戻す
+		if len(kept) > n {
+			kept = kept[:n]
+			dropped++
+		}
+	}
+	return kept, dropped
+}
+
+// maxAlignDigits は丸めずに正確な値を求めるときに、指数を揃えるために係数の下位に足す0の桁数の上限
+// これを超えるほど指数の離れた数どうしの正確な和や差は求めずにpanicする
+const maxAlignDigits = 1 << 20
+
+// align は x, y の係数を小さい方の指数に揃えて返す
+// prec が0でなければ、結果を prec 桁に丸めたときに影響しない程度に小さい方の数を、丸めの結果が変わらない小さな数に置き換えてから揃える
+func align(x, y *Decimal, prec uint32) (xc, yc *Int, exp int64) {
+	xc, yc = x.Coef(), y.Coef()
+	xe, ye := int64(x.exp), int64(y.exp)
+	if prec != 0 {
+		xc, xe = sticky(xc, xe, yc, ye, prec)
+		yc, ye = sticky(yc, ye, xc, xe, prec)
+	}
+	switch {
+	case xe > ye:
+		return pad(xc, xe-ye), yc, ye
+	case xe < ye:
+		return xc, pad(yc, ye-xe), xe
+	}
+	return xc, yc, xe
+}
+
+// sticky は c * 10^e を o * 10^oe に足して prec 桁に丸めるとき、c がすべて丸めで捨てられる桁より下にあれば
+// 同じ符号で同じ桁より下にある 1 * 10^(l-1) (c が0なら 0 * 10^(l-1)) に置き換えて返す
+// l は o の最下位の桁と、丸めたときの最下位の桁の1つ下の桁のどちらよりも下の位置で、
+// そこより下にある0でない値は丸めの結果に「端数がある」ことしか影響しない
+func sticky(c *Int, e int64, o *Int, oe int64, prec uint32) (*Int, int64) {
+	if len(o.abs) == 0 {
+		return c, e
+	}
+	l := oe - 1
+	if r := oe + int64(len(o.abs)) - 1 - int64(prec) - 1; r < l {
+		l = r
+	}
+	if len(c.abs) == 0 {
+		if e < l-1 {
+			return c, l - 1
+		}
+		return c, e
+	}
+	if e+int64(len(c.abs))-1 < l {
+		return &Int{neg: c.neg, abs: digits{1}}, l - 1
+	}
+	return c, e
+}
+
+// pad は c を 10^n 倍して返す
+// 0 は桁を足さずにそのまま返し、n が maxAlignDigits を超えるときはpanicする
+func pad(c *Int, n int64) *Int {
+	if len(c.abs) == 0 {
+		return c
+	}
+	if n > maxAlignDigits {
+		panic("exponent gap too large")
+	}
+	return &Int{neg: c.neg, abs: rightPad(c.abs, int(n))}
+}
+
+// adjusted は x の先頭の桁を1の位に置いたときの指数を返す
+func adjusted(x *Decimal) int64 {
+	return int64(x.exp) + int64(len(x.Coef().abs)) - 1
+}
+
+// sign は c の符号を -1, 0, 1 で返す
+func sign(c *Int) int {
+	switch {
+	case len(c.abs) == 0:
+		return 0
+	case c.neg:
+		return -1
+	}
+	return 1
+}
+
+// resultPrec は演算結果の精度を決める
+// z に精度が指定されていればそれを使い、なければ x, y の精度の大きい方を使う
+func resultPrec(z, x, y *Decimal) uint32 {
+	if z.prec != 0 {
+		return z.prec
+	}
+	if x.prec > y.prec {
+		return x.prec
+	}
+	return y.prec
+}
@@ -0,0 +1,510 @@
+package big
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDecimal_SetString(t *testing.T) {
+	type args struct {
+		s    string
+		prec uint32
+	}
+	tests := []struct {
+		name string
+		args args
+		want *Decimal
+	}{
+		{
+			name: "integer",
+			args: args{s: "12345"},
+			want: &Decimal{coef: NewInt(12345), exp: 0},
+		},
+		{
+			name: "fraction",
+			args: args{s: "-123.45"},
+			want: &Decimal{coef: NewInt(-12345), exp: -2},
+		},
+		{
+			name: "scientific notation",
+			args: args{s: "1.2345e-10"},
+			want: &Decimal{coef: NewInt(12345), exp: -14},
+		},
+		{
+			name: "scientific notation with positive exponent",
+			args: args{s: "5E+3"},
+			want: &Decimal{coef: NewInt(5), exp: 3},
+		},
+		{
+			name: "rounded to prec",
+			args: args{s: "3.14159", prec: 3},
+			want: &Decimal{coef: NewInt(314), exp: -2, prec: 3},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := new(Decimal).SetPrec(tt.args.prec).SetString(tt.args.s)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SetString() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecimal_String(t *testing.T) {
+	type fields struct {
+		coef *Int
+		exp  int32
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		want   string
+	}{
+		{
+			name:   "integer",
+			fields: fields{coef: NewInt(12345), exp: 0},
+			want:   "12345",
+		},
+		{
+			name:   "fraction",
+			fields: fields{coef: NewInt(-12345), exp: -2},
+			want:   "-123.45",
+		},
+		{
+			name:   "leading zeros",
+			fields: fields{coef: NewInt(12), exp: -5},
+			want:   "0.00012",
+		},
+		{
+			name:   "small exponent",
+			fields: fields{coef: NewInt(12345), exp: -14},
+			want:   "1.2345E-10",
+		},
+		{
+			name:   "positive exponent",
+			fields: fields{coef: NewInt(100), exp: 1},
+			want:   "1.00E+3",
+		},
+		{
+			name:   "zero with exponent",
+			fields: fields{coef: NewInt(0), exp: -3},
+			want:   "0.000",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			x := &Decimal{coef: tt.fields.coef, exp: tt.fields.exp}
+			if got := x.String(); got != tt.want {
+				t.Errorf("String() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecimal_Add(t *testing.T) {
+	type args struct {
+		x    string
+		y    string
+		prec uint32
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "x + y",
+			args: args{x: "1.1", y: "2.20"},
+			want: "3.30",
+		},
+		{
+			name: "x + (-y)",
+			args: args{x: "1.1", y: "-2.25"},
+			want: "-1.15",
+		},
+		{
+			name: "different exponents",
+			args: args{x: "1E+3", y: "0.001"},
+			want: "1000.001",
+		},
+		{
+			name: "carry over prec",
+			args: args{x: "999.5", y: "0", prec: 3},
+			want: "1.00E+3",
+		},
+		{
+			name: "far apart exponents",
+			args: args{x: "1e2000000000", y: "1", prec: 5},
+			want: "1.0000E+2000000000",
+		},
+		{
+			name: "far apart zero",
+			args: args{x: "1.5", y: "0e-2000000000", prec: 5},
+			want: "1.5000",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			x, y := new(Decimal).SetString(tt.args.x), new(Decimal).SetString(tt.args.y)
+			if got := new(Decimal).SetPrec(tt.args.prec).Add(x, y).String(); got != tt.want {
+				t.Errorf("Add() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecimal_Sub(t *testing.T) {
+	type args struct {
+		x    string
+		y    string
+		prec uint32
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "x - y",
+			args: args{x: "5.5", y: "2.25"},
+			want: "3.25",
+		},
+		{
+			name: "x - y < 0",
+			args: args{x: "1.30", y: "1.31"},
+			want: "-0.01",
+		},
+		{
+			name: "rounded",
+			args: args{x: "10", y: "0.0001", prec: 4},
+			want: "10.00",
+		},
+		{
+			name: "far apart exponents",
+			args: args{x: "1e2000000000", y: "1", prec: 5},
+			want: "1.0000E+2000000000",
+		},
+		{
+			name: "far apart exponents (x < y)",
+			args: args{x: "1", y: "1e2000000000", prec: 5},
+			want: "-1.0000E+2000000000",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			x, y := new(Decimal).SetString(tt.args.x), new(Decimal).SetString(tt.args.y)
+			if got := new(Decimal).SetPrec(tt.args.prec).Sub(x, y).String(); got != tt.want {
+				t.Errorf("Sub() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecimal_Mul(t *testing.T) {
+	type args struct {
+		x    string
+		y    string
+		prec uint32
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "x * y",
+			args: args{x: "1.20", y: "3"},
+			want: "3.60",
+		},
+		{
+			name: "-x * y",
+			args: args{x: "-7", y: "0.25"},
+			want: "-1.75",
+		},
+		{
+			name: "rounded",
+			args: args{x: "1.2345", y: "1.2345", prec: 5},
+			want: "1.5240",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			x, y := new(Decimal).SetString(tt.args.x), new(Decimal).SetString(tt.args.y)
+			if got := new(Decimal).SetPrec(tt.args.prec).Mul(x, y).String(); got != tt.want {
+				t.Errorf("Mul() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecimal_Quo(t *testing.T) {
+	type args struct {
+		x    string
+		y    string
+		prec uint32
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "exact",
+			args: args{x: "1", y: "4"},
+			want: "0.25",
+		},
+		{
+			name: "default prec",
+			args: args{x: "2", y: "3"},
+			want: "0.6666666666666666666666666666666667",
+		},
+		{
+			name: "prec 5",
+			args: args{x: "2", y: "3", prec: 5},
+			want: "0.66667",
+		},
+		{
+			name: "ideal exponent",
+			args: args{x: "2.40", y: "2"},
+			want: "1.20",
+		},
+		{
+			name: "negative",
+			args: args{x: "-1", y: "8"},
+			want: "-0.125",
+		},
+		{
+			name: "zero",
+			args: args{x: "0.00", y: "3"},
+			want: "0.00",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			x, y := new(Decimal).SetString(tt.args.x), new(Decimal).SetString(tt.args.y)
+			if got := new(Decimal).SetPrec(tt.args.prec).Quo(x, y).String(); got != tt.want {
+				t.Errorf("Quo() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecimal_Sqrt(t *testing.T) {
+	type args struct {
+		x    string
+		prec uint32
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "perfect square",
+			args: args{x: "16"},
+			want: "4",
+		},
+		{
+			name: "default prec",
+			args: args{x: "2"},
+			want: "1.414213562373095048801688724209698",
+		},
+		{
+			name: "prec 10",
+			args: args{x: "2", prec: 10},
+			want: "1.414213562",
+		},
+		{
+			name: "fraction",
+			args: args{x: "0.0001"},
+			want: "0.01",
+		},
+		{
+			name: "zero",
+			args: args{x: "0"},
+			want: "0",
+		},
+		{
+			name: "zero with odd exponent",
+			args: args{x: "0.000"},
+			want: "0.00",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			x := new(Decimal).SetString(tt.args.x)
+			if got := new(Decimal).SetPrec(tt.args.prec).Sqrt(x).String(); got != tt.want {
+				t.Errorf("Sqrt() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecimal_Cmp(t *testing.T) {
+	type args struct {
+		x string
+		y string
+	}
+	tests := []struct {
+		name string
+		args args
+		want int8
+	}{
+		{
+			name: "x == y with different exponents",
+			args: args{x: "1.50", y: "1.5"},
+			want: 0,
+		},
+		{
+			name: "x > y",
+			args: args{x: "1.51", y: "1.5"},
+			want: 1,
+		},
+		{
+			name: "x < y",
+			args: args{x: "-2", y: "1E-9"},
+			want: -1,
+		},
+		{
+			name: "far apart exponents",
+			args: args{x: "1e2000000000", y: "1"},
+			want: 1,
+		},
+		{
+			name: "far apart negative exponents",
+			args: args{x: "-1e2000000000", y: "-1"},
+			want: -1,
+		},
+		{
+			name: "zero and tiny",
+			args: args{x: "0", y: "1e-2000000000"},
+			want: -1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			x, y := new(Decimal).SetString(tt.args.x), new(Decimal).SetString(tt.args.y)
+			if got := x.Cmp(y); got != tt.want {
+				t.Errorf("Cmp() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// 丸めの方向によって、丸めで捨てられる小さい方の数の影響が正しく残る
+func TestDecimal_Sub_sticky(t *testing.T) {
+	x, y := new(Decimal).SetString("1e2000000000"), new(Decimal).SetString("1")
+	tests := []struct {
+		mode RoundingMode
+		want string
+	}{
+		{mode: RoundHalfEven, want: "1.00E+2000000000"},
+		{mode: RoundDown, want: "9.99E+1999999999"},
+		{mode: RoundCeiling, want: "1.00E+2000000000"},
+		{mode: RoundFloor, want: "9.99E+1999999999"},
+	}
+	for _, tt := range tests {
+		if got := new(Decimal).SetPrec(3).SetMode(tt.mode).Sub(x, y).String(); got != tt.want {
+			t.Errorf("mode %d: Sub() = %v, want %v", tt.mode, got, tt.want)
+		}
+	}
+}
+
+// 精度を指定しない正確な和では、係数が巨大になるほど指数の離れた数は扱わない
+func TestDecimal_Add_exponentGap(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Add() with a huge exponent gap did not panic")
+		}
+	}()
+	x, y := new(Decimal).SetString("1e2000000000"), new(Decimal).SetString("1")
+	new(Decimal).Add(x, y)
+}
+
+func Test_roundDigits(t *testing.T) {
+	type args struct {
+		abs  digits
+		neg  bool
+		n    int
+		mode RoundingMode
+	}
+	tests := []struct {
+		name        string
+		args        args
+		want        digits
+		wantDropped int
+	}{
+		{
+			name:        "half even (tie, round to even)",
+			args:        args{abs: digits{1, 2, 5}, n: 2, mode: RoundHalfEven},
+			want:        digits{1, 2},
+			wantDropped: 1,
+		},
+		{
+			name:        "half even (tie, round up to even)",
+			args:        args{abs: digits{1, 3, 5}, n: 2, mode: RoundHalfEven},
+			want:        digits{1, 4},
+			wantDropped: 1,
+		},
+		{
+			name:        "half even (above half)",
+			args:        args{abs: digits{1, 2, 5, 0, 1}, n: 2, mode: RoundHalfEven},
+			want:        digits{1, 3},
+			wantDropped: 3,
+		},
+		{
+			name:        "half up (tie)",
+			args:        args{abs: digits{1, 2, 5}, n: 2, mode: RoundHalfUp},
+			want:        digits{1, 3},
+			wantDropped: 1,
+		},
+		{
+			name:        "down",
+			args:        args{abs: digits{1, 2, 9}, n: 2, mode: RoundDown},
+			want:        digits{1, 2},
+			wantDropped: 1,
+		},
+		{
+			name:        "ceiling (positive)",
+			args:        args{abs: digits{1, 2, 0, 1}, n: 2, mode: RoundCeiling},
+			want:        digits{1, 3},
+			wantDropped: 2,
+		},
+		{
+			name:        "ceiling (negative)",
+			args:        args{abs: digits{1, 2, 9}, neg: true, n: 2, mode: RoundCeiling},
+			want:        digits{1, 2},
+			wantDropped: 1,
+		},
+		{
+			name:        "floor (negative)",
+			args:        args{abs: digits{1, 2, 1}, neg: true, n: 2, mode: RoundFloor},
+			want:        digits{1, 3},
+			wantDropped: 1,
+		},
+		{
+			name:        "floor (positive)",
+			args:        args{abs: digits{1, 2, 9}, n: 2, mode: RoundFloor},
+			want:        digits{1, 2},
+			wantDropped: 1,
+		},
+		{
+			name:        "carry to next digit",
+			args:        args{abs: digits{9, 9, 9, 5}, n: 3, mode: RoundHalfUp},
+			want:        digits{1, 0, 0},
+			wantDropped: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, dropped := roundDigits(tt.args.abs, tt.args.neg, tt.args.n, tt.args.mode)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("roundDigits() got = %v, want %v", got, tt.want)
+			}
+			if dropped != tt.wantDropped {
+				t.Errorf("roundDigits() dropped = %v, want %v", dropped, tt.wantDropped)
+			}
+		})
+	}
+}
@@ -90,7 +90,8 @@ func mul(x, y digits) digits {
 		return digits{}
 	}
 
-	if m < karatsubaThreshold && n < karatsubaThreshold {
+	// 短いほうが閾値未満なら、長いほうと同じ長さまで padding して karatsuba にかけるより筆算のほうが速い
+	if n < karatsubaThreshold {
 		return norm(basicMul(x, y))
 	}
 
@@ -191,40 +192,33 @@ func karatsuba(x, y digits) digits {
 // div は |x| / |y| の絶対値による除算を行い、商を quo あまりを rem で返す
 // 呼び出し側は y != 0 を保証しなければならず、この条件が守られないときpanicする
 func div(x, y digits) (quo digits, rem digits) {
-	m, n := len(x), len(y)
-	if n == 0 {
+	if len(y) == 0 {
 		panic("division by zero")
 	}
 	if cmp(x, y) < 0 {
 		return digits{}, x
 	}
-	// 結果の桁数lを求める
-	l := m - n // 少なくとも x > y*10^(m-n-1) で、そのとき商の桁数は m-n
-	if cmp(x[:n-1], y) >= 0 {
-		l += 1 // y*10^(m-n) したときにxのほうが大きければさらにその桁についても商が存在する
+	// y*j (jは0-9) をあらかじめ計算しておき、各桁の商の候補として使う
+	var ys [10]digits
+	for j := range ys {
+		ys[j] = mul(y, digits{uint8(j)})
 	}
 
-	// 内部の乗算のコストをかるくするため、remは除算中の桁に関連するあまりだけ保持する
-	// y*10^l のときの10の指数ぶんの桁数はそのステップでは値が変動しないので初期値から除いておく
-	remPos := (m) - (l - 1)
-	rem = x[:remPos]
-	quo = make(digits, l)
-	for i := 0; i < l; i++ {
-		for j := 1; j < 10; j++ {
-			// 現在のあまりを超えない範囲で最大の y * j (jは1-9) を求めて今の桁の商とする
-			if cmp(mul(y, digits{uint8(j)}), rem) <= 0 {
-				quo[i] = uint8(j)
-			} else {
-				break
-			}
-		}
-		rem = sub(rem, mul(y, digits{quo[i]}))
-		if i < l-1 {
-			remPos += 1
-			rem = append(rem, x[remPos-1])
+	// あまりが y 以上になるのは y の桁数分を下ろしてからなので、上位の len(y)-1 桁はまとめて下ろしておく
+	// その後は上位の桁から1桁ずつあまりに下ろしてきて、あまりを超えない最大の y*j をその桁の商とする
+	n := len(y) - 1
+	quo = make(digits, len(x)-n)
+	rem = append(digits{}, x[:n]...)
+	for i, d := range x[n:] {
+		rem = norm(append(rem, d))
+		j := 9
+		for cmp(ys[j], rem) > 0 {
+			j--
 		}
+		quo[i] = uint8(j)
+		rem = sub(rem, ys[j])
 	}
-	return quo, rem
+	return norm(quo), rem
 }
 
 // norm は上の桁から連続して0になる部分をtrimする
@@ -293,3 +287,36 @@ func rightPad(x digits, n int) digits {
 	}
 	return abs
 }
+
+// mulAddWord は |x| * m + a を求める
+func mulAddWord(x digits, m, a uint) digits {
+	// m, a はそれぞれ高々20桁なので、結果は x の桁数 + 20 桁に収まる
+	l := len(x) + 20
+	abs := make(digits, l)
+	carry := a
+	for i := len(x) - 1; i >= 0; i-- {
+		v := uint(x[i])*m + carry
+		abs[l-len(x)+i] = uint8(v % 10)
+		carry = v / 10
+	}
+	for i := l - len(x) - 1; carry > 0; i-- {
+		abs[i] = uint8(carry % 10)
+		carry /= 10
+	}
+	return norm(abs)
+}
+
+// divWord は |x| / d の商とあまりを求める
+// 呼び出し側は d != 0 を保証しなければならず、この条件が守られないときpanicする
+func divWord(x digits, d uint) (quo digits, rem uint) {
+	if d == 0 {
+		panic("division by zero")
+	}
+	quo = make(digits, len(x))
+	for i, v := range x {
+		rem = rem*10 + uint(v)
+		quo[i] = uint8(rem / d)
+		rem %= d
+	}
+	return norm(quo), rem
+}
@@ -222,3 +222,71 @@ func Test_rightPad(t *testing.T) {
 		})
 	}
 }
+
+func Test_mulAddWord(t *testing.T) {
+	type args struct {
+		x digits
+		m uint
+		a uint
+	}
+	tests := []struct {
+		name string
+		args args
+		want digits
+	}{
+		{
+			name: "x * 256 + 255",
+			args: args{x: digits{9, 9, 9}, m: 256, a: 255},
+			want: digits{2, 5, 5, 9, 9, 9},
+		},
+		{
+			name: "empty x",
+			args: args{x: digits{}, m: 256, a: 12},
+			want: digits{1, 2},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := mulAddWord(tt.args.x, tt.args.m, tt.args.a); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("mulAddWord() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_divWord(t *testing.T) {
+	type args struct {
+		x digits
+		d uint
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantQuo digits
+		wantRem uint
+	}{
+		{
+			name:    "x / 256",
+			args:    args{x: digits{2, 5, 5, 9, 9, 9}, d: 256},
+			wantQuo: digits{9, 9, 9},
+			wantRem: 255,
+		},
+		{
+			name:    "x < d",
+			args:    args{x: digits{7}, d: 256},
+			wantQuo: digits{},
+			wantRem: 7,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotQuo, gotRem := divWord(tt.args.x, tt.args.d)
+			if !reflect.DeepEqual(gotQuo, tt.wantQuo) {
+				t.Errorf("divWord() gotQuo = %v, want %v", gotQuo, tt.wantQuo)
+			}
+			if gotRem != tt.wantRem {
+				t.Errorf("divWord() gotRem = %v, want %v", gotRem, tt.wantRem)
+			}
+		})
+	}
+}
@@ -1,7 +1,9 @@
 package big
 
 import (
+	"crypto/subtle"
 	"fmt"
+	"math/bits"
 	"strconv"
 )
 
@@ -37,6 +39,7 @@ func NewInt(x int64) *Int {
 }
 
 // SetString は入力を10進数としてscanします
+// 符号の後に 0x か 0X が続くときは16進数としてscanします
 // 予期しない入力によって読み取りに失敗するとpanicします
 func (b *Int) SetString(s string) *Int {
 	neg := false
@@ -47,20 +50,163 @@ func (b *Int) SetString(s string) *Int {
 	case '+':
 		s = s[1:]
 	}
-	abs := make(digits, len(s))
-	for i, r := range s {
-		d, err := strconv.ParseUint(string(r), 10, 8)
-		if err != nil {
-			panic(err)
+	var abs digits
+	if len(s) > 2 && s[0] == '0' && (s[1] == 'x' || s[1] == 'X') {
+		abs = digits{}
+		for _, r := range s[2:] {
+			d, err := strconv.ParseUint(string(r), 16, 8)
+			if err != nil {
+				panic(err)
+			}
+			abs = mulAddWord(abs, 16, uint(d))
+		}
+	} else {
+		abs = make(digits, len(s))
+		for i, r := range s {
+			d, err := strconv.ParseUint(string(r), 10, 8)
+			if err != nil {
+				panic(err)
+			}
+			abs[i] = uint8(d)
 		}
-		abs[i] = uint8(d)
+	}
+	b.abs = norm(abs)
+	b.neg = len(b.abs) > 0 && neg
+	return b
+}
+
+// SetBytes は buf をビッグエンディアンの符号なし整数として読み込みます
+func (b *Int) SetBytes(buf []byte) *Int {
+	abs := digits{}
+	for _, v := range buf {
+		abs = mulAddWord(abs, 256, uint(v))
 	}
 	b.abs = abs
-	b.neg = neg
+	b.neg = false
 	return b
 }
 
+// Bytes は |b| をビッグエンディアンのバイト列で返します
+// 結果は上位に0のバイトを含まない最小の長さになります
+func (b *Int) Bytes() []byte {
+	var buf []byte
+	abs := b.abs
+	for len(abs) > 0 {
+		var r uint
+		abs, r = divWord(abs, 256)
+		buf = append(buf, byte(r))
+	}
+	// 下位のバイトから求めたので反転させる
+	for i, j := 0, len(buf)-1; i < j; i, j = i+1, j-1 {
+		buf[i], buf[j] = buf[j], buf[i]
+	}
+	return buf
+}
+
+// FillBytes は |b| をビッグエンディアンで buf に上位を0で埋めて書き込み、buf を返します
+// |b| が buf に収まらないときpanicします
+func (b *Int) FillBytes(buf []byte) []byte {
+	bs := b.Bytes()
+	if len(bs) > len(buf) {
+		panic("buffer too small")
+	}
+	for i := range buf {
+		buf[i] = 0
+	}
+	copy(buf[len(buf)-len(bs):], bs)
+	return buf
+}
+
+// SetBytesLE は buf をリトルエンディアンの符号なし整数として読み込みます
+func (b *Int) SetBytesLE(buf []byte) *Int {
+	abs := digits{}
+	for i := len(buf) - 1; i >= 0; i-- {
+		abs = mulAddWord(abs, 256, uint(buf[i]))
+	}
+	b.abs = abs
+	b.neg = false
+	return b
+}
+
+// FillBytesLE は |b| をリトルエンディアンで buf に上位を0で埋めて書き込み、buf を返します
+// |b| が buf に収まらないときpanicします
+func (b *Int) FillBytesLE(buf []byte) []byte {
+	b.FillBytes(buf)
+	for i, j := 0, len(buf)-1; i < j; i, j = i+1, j-1 {
+		buf[i], buf[j] = buf[j], buf[i]
+	}
+	return buf
+}
+
+// BitLen は |b| の2進数での桁数を返します
+// 0 のビット長は0です
+func (b *Int) BitLen() int {
+	bs := b.Bytes()
+	if len(bs) == 0 {
+		return 0
+	}
+	return (len(bs)-1)*8 + bits.Len8(bs[0])
+}
+
+// Bit は |b| の i 番目 (最下位を0とする) のビットを返します
+// i < 0 のときpanicします
+func (b *Int) Bit(i int) uint {
+	if i < 0 {
+		panic("negative bit index")
+	}
+	bs := b.Bytes()
+	j := len(bs) - 1 - i/8
+	if j < 0 {
+		return 0
+	}
+	return uint(bs[j]>>uint(i%8)) & 1
+}
+
+// Bits は |b| の2進数の各桁を最下位のビットから順に並べた長さ n のスライスを返します
+// n が BitLen より大きいときは上位を0で埋め、|b| が n ビットに収まらないときpanicします
+// 10進数から2進数への変換は1回で済むので、スカラー倍算のようにすべてのビットを読むときは Bit を繰り返し呼ぶより速いです
+func (b *Int) Bits(n int) []uint {
+	bs := b.Bytes()
+	if l := len(bs); l > 0 && (l-1)*8+bits.Len8(bs[0]) > n {
+		panic("bit length too large")
+	}
+	r := make([]uint, n)
+	for i, x := range bs {
+		for j := 0; j < 8; j++ {
+			if k := (len(bs)-1-i)*8 + j; k < n {
+				r[k] = uint(x>>uint(j)) & 1
+			}
+		}
+	}
+	return r
+}
+
+// Select は v == 1 のとき x を、v == 0 のとき y を返します
+// 桁数をそろえてから両方の全桁を読み込んで選ぶので、v によって処理が分岐しません
+func Select(v int, x, y *Int) *Int {
+	n := len(x.abs)
+	if len(y.abs) > n {
+		n = len(y.abs)
+	}
+	xa := leftPad(x.abs, n-len(x.abs))
+	abs := leftPad(y.abs, n-len(y.abs))
+	subtle.ConstantTimeCopy(v, abs, xa)
+	neg := subtle.ConstantTimeSelect(v, b2i(x.neg), b2i(y.neg)) == 1
+	return &Int{neg: neg, abs: norm(abs)}
+}
+
+// b2i は真偽値を 1 か 0 にする
+func b2i(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
 func (b *Int) String() string {
+	if len(b.abs) == 0 {
+		return "0"
+	}
 	var s string
 	if b.neg {
 		s += "-"
@@ -138,7 +284,10 @@ func Div(x, y *Int) (quo *Int, rem *Int) {
 	return quo, rem
 }
 
-// Cmp はx, yが等しいかどうかを判定します
+// Cmp はx, yを比較して以下の結果を返す
+// x > y  -> 1
+// x == y -> 0
+// x < y  -> -1
 func Cmp(x, y *Int) int8 {
 	if x.neg != y.neg {
 		if x.neg {
@@ -146,5 +295,228 @@ func Cmp(x, y *Int) int8 {
 		}
 		return 1
 	}
+	// 両方負のときは絶対値が大きいほうが小さい
+	if x.neg {
+		return -cmp(x.abs, y.abs)
+	}
 	return cmp(x.abs, y.abs)
 }
+
+// Mod は x を m で割ったあまりを 0 <= r < |m| の範囲で求める
+func Mod(x, m *Int) *Int {
+	_, r := Div(x, m)
+	if r.neg {
+		r = Add(r, &Int{abs: m.abs})
+	}
+	return r
+}
+
+// Exp は x^y mod m を求める
+// m が nil のときは剰余をとらずに x^y を求める
+// y < 0 のときは m を法とした x の逆元について計算し、逆元が存在しなければ nil を返す
+func Exp(x, y, m *Int) *Int {
+	if y.neg {
+		if m == nil {
+			panic("negative exponent without modulus")
+		}
+		x = ModInverse(x, m)
+		if x == nil {
+			return nil
+		}
+		y = &Int{abs: y.abs}
+	}
+
+	// 10 と互いに素な法では Montgomery 乗算を使い、途中の剰余計算で除算をしないようにする
+	one := NewInt(1)
+	mul := func(a, b *Int) *Int { return mulMod(a, b, m) }
+	var mt *Montgomery
+	if m != nil && MontgomeryCompatible(m) {
+		mt = NewMontgomery(m)
+		x, one, mul = mt.To(x), mt.To(one), mt.Mul
+	}
+
+	// x^0 から x^9 までを事前に計算しておく
+	var table [10]*Int
+	table[0] = one
+	for i := 1; i < len(table); i++ {
+		table[i] = mul(table[i-1], x)
+	}
+
+	// y を上位の桁から見ていき、各桁 d について r = r^10 * x^d を繰り返す
+	r := one
+	for _, d := range y.abs {
+		r2 := mul(r, r)
+		r4 := mul(r2, r2)
+		r5 := mul(r4, r)
+		r = mul(r5, r5)
+		if d != 0 {
+			r = mul(r, table[d])
+		}
+	}
+	switch {
+	case mt != nil:
+		r = mt.From(r)
+	case m != nil:
+		r = Mod(r, m)
+	}
+	return r
+}
+
+// mulMod は x * y mod m を求める
+// m が nil のときは剰余をとらない
+func mulMod(x, y, m *Int) *Int {
+	z := Mul(x, y)
+	if m == nil {
+		return z
+	}
+	return Mod(z, m)
+}
+
+// GCD は a, b の最大公約数 d と、ax + by = d を満たす x, y を求める
+// d は常に非負の値となる
+func GCD(a, b *Int) (d, x, y *Int) {
+	// 拡張ユークリッドの互除法で |a|, |b| について計算し、最後に符号を合わせる
+	r0, r1 := &Int{abs: a.abs}, &Int{abs: b.abs}
+	x0, x1 := NewInt(1), NewInt(0)
+	y0, y1 := NewInt(0), NewInt(1)
+	for len(r1.abs) > 0 {
+		q, r := Div(r0, r1)
+		r0, r1 = r1, r
+		x0, x1 = x1, Sub(x0, Mul(q, x1))
+		y0, y1 = y1, Sub(y0, Mul(q, y1))
+	}
+	if a.neg {
+		x0 = Sub(Zero, x0)
+	}
+	if b.neg {
+		y0 = Sub(Zero, y0)
+	}
+	return r0, x0, y0
+}
+
+// ModInverse は m を法とした x の逆元を求める
+// 逆元が存在しないときは nil を返す
+func ModInverse(x, m *Int) *Int {
+	d, inv, _ := GCD(Mod(x, m), m)
+	if Cmp(d, NewInt(1)) != 0 {
+		return nil
+	}
+	return Mod(inv, m)
+}
+
+// Sqrt は floor(sqrt(x)) を求める
+// x < 0 のときpanicする
+func Sqrt(x *Int) *Int {
+	if x.neg {
+		panic("square root of negative number")
+	}
+	if len(x.abs) == 0 {
+		return NewInt(0)
+	}
+	// sqrt(x) 以上であることがわかっている 10^ceil(n/2) を初期値にしてニュートン法で求める
+	z := &Int{abs: rightPad(digits{1}, (len(x.abs)+1)/2)}
+	two := NewInt(2)
+	for {
+		q, _ := Div(x, z)
+		next, _ := Div(Add(z, q), two)
+		if Cmp(next, z) >= 0 {
+			return z
+		}
+		z = next
+	}
+}
+
+// Jacobi はヤコビ記号 (x/y) を求める
+// y は正の奇数でなければならず、この条件が守られないときpanicする
+func Jacobi(x, y *Int) int {
+	if y.neg || len(y.abs) == 0 || y.abs[len(y.abs)-1]%2 == 0 {
+		panic("jacobi: y must be an odd positive number")
+	}
+	a, n := Mod(x, y).abs, y.abs
+	j := 1
+	for len(a) > 0 {
+		// (2/n) = -1 となるのは n = 3, 5 mod 8 のとき
+		for a[len(a)-1]%2 == 0 {
+			a, _ = divWord(a, 2)
+			if r := mod8(n); r == 3 || r == 5 {
+				j = -j
+			}
+		}
+		// 平方剰余の相互法則で (a/n) = (n/a) に入れ替える
+		if mod8(a)%4 == 3 && mod8(n)%4 == 3 {
+			j = -j
+		}
+		a, n = n, a
+		_, a = div(a, n)
+	}
+	if cmp(n, digits{1}) == 0 {
+		return j
+	}
+	return 0
+}
+
+// mod8 は |x| mod 8 を求める
+// 1000 は 8 の倍数なので下3桁だけを見ればよい
+func mod8(x digits) int {
+	r := 0
+	for i := len(x) - 3; i < len(x); i++ {
+		if i >= 0 {
+			r = r*10 + int(x[i])
+		}
+	}
+	return r % 8
+}
+
+// ModSqrt は x^2 = a mod p となる 0 <= x < p を求める
+// p は奇素数でなければならず、a が p を法とした平方剰余でないときは nil を返す
+func ModSqrt(a, p *Int) *Int {
+	a = Mod(a, p)
+	if len(a.abs) == 0 {
+		return NewInt(0)
+	}
+	if Jacobi(a, p) != 1 {
+		return nil
+	}
+	one := NewInt(1)
+	// p = 3 mod 4 のときは a^((p+1)/4) が平方根になる
+	if mod8(p.abs)%4 == 3 {
+		e, _ := Div(Add(p, one), NewInt(4))
+		return Exp(a, e, p)
+	}
+
+	// Tonelli-Shanks のアルゴリズム
+	// p - 1 = q * 2^s (q は奇数) と分解する
+	two := NewInt(2)
+	q, s := Sub(p, one), 0
+	for q.abs[len(q.abs)-1]%2 == 0 {
+		q, _ = Div(q, two)
+		s++
+	}
+	// 平方非剰余 z を探す
+	z := two
+	for Jacobi(z, p) != -1 {
+		z = Add(z, one)
+	}
+	e, _ := Div(Add(q, one), two)
+	c := Exp(z, q, p)
+	x := Exp(a, e, p)
+	t := Exp(a, q, p)
+	m := s
+	for Cmp(t, one) != 0 {
+		// t^(2^i) = 1 となる最小の i を探す
+		i, t2 := 0, t
+		for Cmp(t2, one) != 0 {
+			t2 = mulMod(t2, t2, p)
+			i++
+		}
+		b := c
+		for j := 0; j < m-i-1; j++ {
+			b = mulMod(b, b, p)
+		}
+		x = mulMod(x, b, p)
+		c = mulMod(b, b, p)
+		t = mulMod(t, c, p)
+		m = i
+	}
+	return x
+}
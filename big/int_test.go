@@ -64,6 +64,31 @@ func TestInt_SetString(t *testing.T) {
 			args: args{s: "-123456789"},
 			want: NewInt(-123456789),
 		},
+		{
+			name: "leading zeros",
+			args: args{s: "000123"},
+			want: NewInt(123),
+		},
+		{
+			name: "negative zero",
+			args: args{s: "-0"},
+			want: Zero,
+		},
+		{
+			name: "hex",
+			args: args{s: "0xdeadBEEF"},
+			want: NewInt(3735928559),
+		},
+		{
+			name: "neg hex",
+			args: args{s: "-0X00ff"},
+			want: NewInt(-255),
+		},
+		{
+			name: "hex zero",
+			args: args{s: "0x0"},
+			want: Zero,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -75,6 +100,217 @@ func TestInt_SetString(t *testing.T) {
 	}
 }
 
+func TestInt_SetBytes(t *testing.T) {
+	type args struct {
+		buf []byte
+	}
+	tests := []struct {
+		name string
+		args args
+		want *Int
+	}{
+		{
+			name: "bytes",
+			args: args{buf: []byte{0x01, 0x02, 0x03, 0x04, 0x05}},
+			want: NewInt(4328719365),
+		},
+		{
+			name: "leading zeros",
+			args: args{buf: []byte{0x00, 0x00, 0x01, 0x00}},
+			want: NewInt(256),
+		},
+		{
+			name: "empty",
+			args: args{buf: []byte{}},
+			want: Zero,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := new(Int).SetBytes(tt.args.buf); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SetBytes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInt_Bytes(t *testing.T) {
+	tests := []struct {
+		name string
+		b    *Int
+		want []byte
+	}{
+		{
+			name: "bytes",
+			b:    NewInt(4328719365),
+			want: []byte{0x01, 0x02, 0x03, 0x04, 0x05},
+		},
+		{
+			name: "abs of negative",
+			b:    NewInt(-256),
+			want: []byte{0x01, 0x00},
+		},
+		{
+			name: "zero",
+			b:    Zero,
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.b.Bytes(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Bytes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInt_FillBytes(t *testing.T) {
+	got := NewInt(4328719365).FillBytes(make([]byte, 8))
+	want := []byte{0x00, 0x00, 0x00, 0x01, 0x02, 0x03, 0x04, 0x05}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("FillBytes() = %v, want %v", got, want)
+	}
+}
+
+func TestInt_SetBytesLE(t *testing.T) {
+	tests := []struct {
+		name string
+		in   []byte
+		want *Int
+	}{
+		{name: "empty", in: []byte{}, want: NewInt(0)},
+		{name: "one byte", in: []byte{0x2a}, want: NewInt(42)},
+		{name: "little endian", in: []byte{0x01, 0x02, 0x00}, want: NewInt(513)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := new(Int).SetBytesLE(tt.in)
+			if Cmp(got, tt.want) != 0 {
+				t.Errorf("SetBytesLE() = %v, want %v", got, tt.want)
+			}
+			if back := got.FillBytesLE(make([]byte, len(tt.in))); !reflect.DeepEqual(back, tt.in) {
+				t.Errorf("FillBytesLE() = %x, want %x", back, tt.in)
+			}
+		})
+	}
+}
+
+func TestInt_BitLen(t *testing.T) {
+	tests := []struct {
+		name string
+		x    *Int
+		want int
+	}{
+		{
+			name: "zero",
+			x:    NewInt(0),
+			want: 0,
+		},
+		{
+			name: "one",
+			x:    NewInt(1),
+			want: 1,
+		},
+		{
+			name: "255",
+			x:    NewInt(255),
+			want: 8,
+		},
+		{
+			name: "256",
+			x:    NewInt(256),
+			want: 9,
+		},
+		{
+			name: "2^64",
+			x:    new(Int).SetString("18446744073709551616"),
+			want: 65,
+		},
+		{
+			name: "negative",
+			x:    NewInt(-5),
+			want: 3,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.x.BitLen(); got != tt.want {
+				t.Errorf("BitLen() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInt_Bit(t *testing.T) {
+	// 4328719365 = 0x0102030405
+	x := NewInt(4328719365)
+	var got []uint
+	for i := 0; i < 40; i++ {
+		got = append(got, x.Bit(i))
+	}
+	want := []uint{
+		1, 0, 1, 0, 0, 0, 0, 0,
+		0, 0, 1, 0, 0, 0, 0, 0,
+		1, 1, 0, 0, 0, 0, 0, 0,
+		0, 1, 0, 0, 0, 0, 0, 0,
+		1, 0, 0, 0, 0, 0, 0, 0,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Bit() = %v, want %v", got, want)
+	}
+	if got := x.Bit(1000); got != 0 {
+		t.Errorf("Bit(1000) = %v, want 0", got)
+	}
+}
+
+func TestInt_Bits(t *testing.T) {
+	x := NewInt(4328719365)
+	for _, n := range []int{x.BitLen(), 40, 100} {
+		got := x.Bits(n)
+		if len(got) != n {
+			t.Fatalf("len(Bits(%d)) = %d, want %d", n, len(got), n)
+		}
+		for i := range got {
+			if got[i] != x.Bit(i) {
+				t.Errorf("Bits(%d)[%d] = %d, want %d", n, i, got[i], x.Bit(i))
+			}
+		}
+	}
+	if got := NewInt(0).Bits(3); !reflect.DeepEqual(got, []uint{0, 0, 0}) {
+		t.Errorf("0.Bits(3) = %v, want [0 0 0]", got)
+	}
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Bits(BitLen() - 1) did not panic")
+		}
+	}()
+	x.Bits(x.BitLen() - 1)
+}
+
+func TestSelect(t *testing.T) {
+	tests := []struct {
+		name string
+		v    int
+		x    *Int
+		y    *Int
+		want *Int
+	}{
+		{name: "select x", v: 1, x: NewInt(12345), y: NewInt(6), want: NewInt(12345)},
+		{name: "select y", v: 0, x: NewInt(12345), y: NewInt(6), want: NewInt(6)},
+		{name: "shorter x", v: 1, x: NewInt(7), y: NewInt(-98765), want: NewInt(7)},
+		{name: "negative y", v: 0, x: NewInt(7), y: NewInt(-98765), want: NewInt(-98765)},
+		{name: "zero", v: 1, x: NewInt(0), y: NewInt(100), want: NewInt(0)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Select(tt.v, tt.x, tt.y); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Select() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestInt_String(t *testing.T) {
 	type fields struct {
 		neg bool
@@ -101,6 +337,14 @@ func TestInt_String(t *testing.T) {
 			},
 			want: "-123456789",
 		},
+		{
+			name: "zero",
+			fields: fields{
+				neg: false,
+				abs: digits{},
+			},
+			want: "0",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -389,6 +633,14 @@ func TestMul(t *testing.T) {
 			},
 			want: new(Int).SetString("97546105798506325258725803993760097546164761469295351318397422648986531016613331976832801085200426877762536208901082153002591068511507392172275567749340039628145252248135650053345677488187778997104100"),
 		},
+		{
+			name: "9876543210... * 12345 (long multiplication for a short operand)",
+			args: args{
+				x: new(Int).SetString("9876543210987654321098765432109876543210987654321098765432109876543210987654321098765432109876543210"),
+				y: NewInt(12345),
+			},
+			want: new(Int).SetString("121925925939642592593964259259396425925939642592593964259259396425925939642592593964259259396425925927450"),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -464,6 +716,42 @@ func TestDiv(t *testing.T) {
 			wantQuo: Zero,
 			wantRem: NewInt(17357),
 		},
+		{
+			name: "x / 1",
+			args: args{
+				x: NewInt(99),
+				y: NewInt(1),
+			},
+			wantQuo: NewInt(99),
+			wantRem: Zero,
+		},
+		{
+			name: "same digit length",
+			args: args{
+				x: NewInt(5),
+				y: NewInt(3),
+			},
+			wantQuo: NewInt(1),
+			wantRem: NewInt(2),
+		},
+		{
+			name: "x == y",
+			args: args{
+				x: NewInt(7),
+				y: NewInt(7),
+			},
+			wantQuo: NewInt(1),
+			wantRem: Zero,
+		},
+		{
+			name: "quo has leading digit from top digits",
+			args: args{
+				x: NewInt(12345),
+				y: NewInt(12),
+			},
+			wantQuo: NewInt(1028),
+			wantRem: NewInt(9),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -477,3 +765,412 @@ func TestDiv(t *testing.T) {
 		})
 	}
 }
+
+func TestCmp(t *testing.T) {
+	type args struct {
+		x *Int
+		y *Int
+	}
+	tests := []struct {
+		name string
+		args args
+		want int8
+	}{
+		{
+			name: "x > y",
+			args: args{x: NewInt(10), y: NewInt(9)},
+			want: 1,
+		},
+		{
+			name: "x == y",
+			args: args{x: NewInt(-10), y: NewInt(-10)},
+			want: 0,
+		},
+		{
+			name: "x > -y",
+			args: args{x: NewInt(1), y: NewInt(-10)},
+			want: 1,
+		},
+		{
+			name: "-x < y",
+			args: args{x: NewInt(-10), y: NewInt(1)},
+			want: -1,
+		},
+		{
+			name: "-x < -y",
+			args: args{x: NewInt(-10), y: NewInt(-9)},
+			want: -1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Cmp(tt.args.x, tt.args.y); got != tt.want {
+				t.Errorf("Cmp() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMod(t *testing.T) {
+	type args struct {
+		x *Int
+		m *Int
+	}
+	tests := []struct {
+		name string
+		args args
+		want *Int
+	}{
+		{
+			name: "x mod m",
+			args: args{
+				x: NewInt(1735745558983),
+				m: NewInt(4984423),
+			},
+			want: NewInt(1),
+		},
+		{
+			name: "-x mod m",
+			args: args{
+				x: NewInt(-1735745558983),
+				m: NewInt(4984423),
+			},
+			want: NewInt(4984422),
+		},
+		{
+			name: "-x mod m (no rem)",
+			args: args{
+				x: NewInt(-1735745558982),
+				m: NewInt(4984423),
+			},
+			want: Zero,
+		},
+		{
+			name: "x mod -m",
+			args: args{
+				x: NewInt(-10),
+				m: NewInt(-7),
+			},
+			want: NewInt(4),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Mod(tt.args.x, tt.args.m); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Mod() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExp(t *testing.T) {
+	type args struct {
+		x *Int
+		y *Int
+		m *Int
+	}
+	tests := []struct {
+		name string
+		args args
+		want *Int
+	}{
+		{
+			name: "x^y mod m",
+			args: args{
+				x: NewInt(4),
+				y: NewInt(13),
+				m: NewInt(497),
+			},
+			want: NewInt(445),
+		},
+		{
+			name: "x^y mod m (large)",
+			args: args{
+				x: NewInt(123456789),
+				y: NewInt(987654321),
+				m: NewInt(1000000007),
+			},
+			want: NewInt(652541198),
+		},
+		{
+			name: "x^y without modulus",
+			args: args{
+				x: NewInt(3),
+				y: NewInt(200),
+				m: nil,
+			},
+			want: new(Int).SetString("265613988875874769338781322035779626829233452653394495974574961739092490901302182994384699044001"),
+		},
+		{
+			name: "x^0",
+			args: args{
+				x: NewInt(12345),
+				y: Zero,
+				m: NewInt(497),
+			},
+			want: NewInt(1),
+		},
+		{
+			name: "x^(-y) mod m",
+			args: args{
+				x: NewInt(7),
+				y: NewInt(-3),
+				m: NewInt(101),
+			},
+			want: NewInt(48),
+		},
+		{
+			name: "x^(-y) mod m (not invertible)",
+			args: args{
+				x: NewInt(7),
+				y: NewInt(-3),
+				m: NewInt(91),
+			},
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Exp(tt.args.x, tt.args.y, tt.args.m); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Exp() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGCD(t *testing.T) {
+	type args struct {
+		a *Int
+		b *Int
+	}
+	tests := []struct {
+		name  string
+		args  args
+		wantD *Int
+	}{
+		{
+			name:  "gcd(a, b)",
+			args:  args{a: NewInt(240), b: NewInt(46)},
+			wantD: NewInt(2),
+		},
+		{
+			name:  "gcd(-a, b)",
+			args:  args{a: NewInt(-240), b: NewInt(46)},
+			wantD: NewInt(2),
+		},
+		{
+			name:  "gcd(a, -b)",
+			args:  args{a: NewInt(240), b: NewInt(-46)},
+			wantD: NewInt(2),
+		},
+		{
+			name:  "coprime",
+			args:  args{a: NewInt(1000000007), b: NewInt(998244353)},
+			wantD: NewInt(1),
+		},
+		{
+			name:  "gcd(a, 0)",
+			args:  args{a: NewInt(12), b: Zero},
+			wantD: NewInt(12),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, x, y := GCD(tt.args.a, tt.args.b)
+			if !reflect.DeepEqual(d, tt.wantD) {
+				t.Errorf("GCD() d = %v, want %v", d, tt.wantD)
+			}
+			if got := Add(Mul(tt.args.a, x), Mul(tt.args.b, y)); Cmp(got, d) != 0 {
+				t.Errorf("GCD() ax + by = %v, want %v", got, d)
+			}
+		})
+	}
+}
+
+func TestModInverse(t *testing.T) {
+	type args struct {
+		x *Int
+		m *Int
+	}
+	tests := []struct {
+		name string
+		args args
+		want *Int
+	}{
+		{
+			name: "x^(-1) mod m",
+			args: args{
+				x: NewInt(2),
+				m: NewInt(101),
+			},
+			want: NewInt(51),
+		},
+		{
+			name: "x^(-1) mod m (large)",
+			args: args{
+				x: new(Int).SetString("170141183460469231731687303715884105727"),
+				m: new(Int).SetString("1000000000000000000000000000057"),
+			},
+			want: new(Int).SetString("954927250195268736883998042541"),
+		},
+		{
+			name: "-x^(-1) mod m",
+			args: args{
+				x: NewInt(-2),
+				m: NewInt(101),
+			},
+			want: NewInt(50),
+		},
+		{
+			name: "not invertible",
+			args: args{
+				x: NewInt(6),
+				m: NewInt(27),
+			},
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ModInverse(tt.args.x, tt.args.m); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ModInverse() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSqrt(t *testing.T) {
+	type args struct {
+		x *Int
+	}
+	tests := []struct {
+		name string
+		args args
+		want *Int
+	}{
+		{
+			name: "perfect square",
+			args: args{x: NewInt(144)},
+			want: NewInt(12),
+		},
+		{
+			name: "floor",
+			args: args{x: NewInt(99)},
+			want: NewInt(9),
+		},
+		{
+			name: "large",
+			args: args{x: new(Int).SetString("10000000000000000000000000000000000012345")},
+			want: new(Int).SetString("100000000000000000000"),
+		},
+		{
+			name: "zero",
+			args: args{x: Zero},
+			want: Zero,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Sqrt(tt.args.x); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Sqrt() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJacobi(t *testing.T) {
+	type args struct {
+		x *Int
+		y *Int
+	}
+	tests := []struct {
+		name string
+		args args
+		want int
+	}{
+		{
+			name: "quadratic residue",
+			args: args{x: NewInt(2), y: NewInt(41)},
+			want: 1,
+		},
+		{
+			name: "non residue",
+			args: args{x: NewInt(3), y: NewInt(41)},
+			want: -1,
+		},
+		{
+			name: "not coprime",
+			args: args{x: NewInt(21), y: NewInt(15)},
+			want: 0,
+		},
+		{
+			name: "composite y",
+			args: args{x: NewInt(1001), y: NewInt(9907)},
+			want: -1,
+		},
+		{
+			name: "negative x",
+			args: args{x: NewInt(-1), y: NewInt(13)},
+			want: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Jacobi(tt.args.x, tt.args.y); got != tt.want {
+				t.Errorf("Jacobi() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestModSqrt(t *testing.T) {
+	type args struct {
+		a *Int
+		p *Int
+	}
+	tests := []struct {
+		name string
+		args args
+	}{
+		{
+			name: "p = 3 mod 4",
+			args: args{a: NewInt(123456), p: NewInt(1000003)},
+		},
+		{
+			name: "p = 1 mod 8 (Tonelli-Shanks)",
+			args: args{a: NewInt(2), p: NewInt(41)},
+		},
+		{
+			name: "p = 1 mod 16 (Tonelli-Shanks)",
+			args: args{a: NewInt(13), p: NewInt(17)},
+		},
+		{
+			name: "P-256 prime",
+			args: args{
+				a: Mul(NewInt(987654321), NewInt(987654321)),
+				p: new(Int).SetString("115792089210356248762697446949407573530086143415290314195533631308867097853951"),
+			},
+		},
+		{
+			name: "zero",
+			args: args{a: Zero, p: NewInt(41)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ModSqrt(tt.args.a, tt.args.p)
+			if got == nil {
+				t.Fatalf("ModSqrt() = nil")
+			}
+			if sq := Mod(Mul(got, got), tt.args.p); Cmp(sq, Mod(tt.args.a, tt.args.p)) != 0 {
+				t.Errorf("ModSqrt()^2 = %v, want %v", sq, tt.args.a)
+			}
+		})
+	}
+}
+
+func TestModSqrt_nonResidue(t *testing.T) {
+	if got := ModSqrt(NewInt(3), NewInt(41)); got != nil {
+		t.Errorf("ModSqrt() = %v, want nil", got)
+	}
+}
@@ -0,0 +1,118 @@
+package big
+
+// montBase は Montgomery 乗算の内部で使う1語の大きさで、10進数の4桁をまとめて扱います
+const (
+	montBase   = 10000
+	montDigits = 4
+)
+
+// Montgomery は法 m について R = 10^(4n) (n は m を4桁ずつ区切った語数) とした Montgomery 乗算を行います
+// 除算をせずに剰余乗算ができるので、同じ法で何度も乗算するときに使います
+// 10進数の桁で表現しているため、m は 10 と互いに素 (2 でも 5 でも割り切れない) でなければなりません
+type Montgomery struct {
+	m    *Int
+	le   []int64 // m を下の語から並べたもの
+	n    int     // m の語数
+	minv int64   // -m^(-1) mod 10^4
+	r2   *Int    // R^2 mod m
+}
+
+// NewMontgomery は m を法とした Montgomery 乗算の準備をします
+// m <= 1 もしくは m が 10 と互いに素でないときpanicします
+func NewMontgomery(m *Int) *Montgomery {
+	if !MontgomeryCompatible(m) {
+		panic("modulus is not coprime to 10")
+	}
+	n := (len(m.abs) + montDigits - 1) / montDigits
+	le := toLimbs(m.abs, n)
+	// m の最下位の語 d について d * minv = -1 mod 10^4 となる minv を探す
+	var minv int64
+	for minv = 1; (le[0]*minv)%montBase != montBase-1; minv++ {
+	}
+	r := &Int{abs: rightPad(digits{1}, n*montDigits)}
+	return &Montgomery{
+		m:    &Int{abs: m.abs},
+		le:   le,
+		n:    n,
+		minv: minv,
+		r2:   Mod(Mul(r, r), m),
+	}
+}
+
+// MontgomeryCompatible は m が Montgomery 乗算の法として使えるかどうかを判定します
+func MontgomeryCompatible(m *Int) bool {
+	if m.neg || cmp(m.abs, digits{1}) <= 0 {
+		return false
+	}
+	last := m.abs[len(m.abs)-1]
+	return last%2 != 0 && last != 5
+}
+
+// Mul は x * y * R^(-1) mod m を求めます
+// 呼び出し側は 0 <= x, y < m を保証すること
+func (mt *Montgomery) Mul(x, y *Int) *Int {
+	n := mt.n
+	xl, yl := toLimbs(x.abs, n), toLimbs(y.abs, n)
+	// 下の語から1語ずつ x[i] * y を足し込み、その語が0になるように m の倍数を足して 10^4 で割っていく
+	t := make([]int64, 2*n+1)
+	for i := 0; i < n; i++ {
+		xi := xl[i]
+		if xi != 0 {
+			for j := 0; j < n; j++ {
+				t[i+j] += xi * yl[j]
+			}
+		}
+		u := (t[i] % montBase) * mt.minv % montBase
+		if u != 0 {
+			for j := 0; j < n; j++ {
+				t[i+j] += u * mt.le[j]
+			}
+		}
+		// t[i] は 10^4 の倍数になっているので上の語に繰り上げる
+		t[i+1] += t[i] / montBase
+		t[i] = 0
+	}
+	// R で割った結果が t[n:] に残るので繰り上がりを処理して通常の桁表現に戻す
+	abs := make(digits, (n+1)*montDigits)
+	var carry int64
+	for i := n; i < 2*n+1; i++ {
+		v := t[i] + carry
+		carry = v / montBase
+		v %= montBase
+		for d := 0; d < montDigits; d++ {
+			abs[len(abs)-1-(i-n)*montDigits-d] = uint8(v % 10)
+			v /= 10
+		}
+	}
+	abs = norm(abs)
+	// 結果は 2m 未満なので、m 以上なら1度だけ m を引く
+	if cmp(abs, mt.m.abs) >= 0 {
+		abs = sub(abs, mt.m.abs)
+	}
+	return &Int{abs: abs}
+}
+
+// To は x を Montgomery 表現 x * R mod m に変換します
+func (mt *Montgomery) To(x *Int) *Int {
+	return mt.Mul(Mod(x, mt.m), mt.r2)
+}
+
+// From は Montgomery 表現の x を通常の表現 x * R^(-1) mod m に戻します
+func (mt *Montgomery) From(x *Int) *Int {
+	return mt.Mul(x, NewInt(1))
+}
+
+// toLimbs は x を下の桁から4桁ずつまとめて、下の語から並べた長さ n の配列にする
+func toLimbs(x digits, n int) []int64 {
+	le := make([]int64, n)
+	for i := range x {
+		// 下から i 番目の桁
+		d := int64(x[len(x)-1-i])
+		w := i / montDigits
+		for p := i % montDigits; p > 0; p-- {
+			d *= 10
+		}
+		le[w] += d
+	}
+	return le
+}
@@ -0,0 +1,77 @@
+package big
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMontgomery_Mul(t *testing.T) {
+	type args struct {
+		m *Int
+		x *Int
+		y *Int
+	}
+	tests := []struct {
+		name string
+		args args
+		want *Int
+	}{
+		{
+			name: "small modulus",
+			args: args{
+				m: NewInt(1000003),
+				x: NewInt(123456),
+				y: NewInt(654321),
+			},
+			want: NewInt(123456 * 654321 % 1000003),
+		},
+		{
+			name: "single digit modulus",
+			args: args{
+				m: NewInt(7),
+				x: NewInt(5),
+				y: NewInt(6),
+			},
+			want: NewInt(2),
+		},
+		{
+			name: "P-256 prime",
+			args: args{
+				m: new(Int).SetString("115792089210356248762697446949407573530086143415290314195533631308867097853951"),
+				x: new(Int).SetString("48439561293906451759052585252797914202762949526041747995844080717082404635286"),
+				y: new(Int).SetString("36134250956749795798585127919587881956611106672985015071877198253568414405109"),
+			},
+			want: new(Int).SetString("58908126177458906251578054527685290833723497900791240663493461173334367443134"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mt := NewMontgomery(tt.args.m)
+			got := mt.From(mt.Mul(mt.To(tt.args.x), mt.To(tt.args.y)))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Mul() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMontgomeryCompatible(t *testing.T) {
+	tests := []struct {
+		name string
+		m    *Int
+		want bool
+	}{
+		{name: "odd", m: NewInt(1000003), want: true},
+		{name: "even", m: NewInt(1024), want: false},
+		{name: "multiple of 5", m: NewInt(1005), want: false},
+		{name: "one", m: NewInt(1), want: false},
+		{name: "negative", m: NewInt(-7), want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MontgomeryCompatible(tt.m); got != tt.want {
+				t.Errorf("MontgomeryCompatible() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
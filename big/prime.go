@@ -0,0 +1,290 @@
+package big
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"io"
+)
+
+// smallPrimes は試し割りに使う小さな素数です
+var smallPrimes = []uint{
+	3, 5, 7, 11, 13, 17, 19, 23, 29, 31, 37, 41, 43, 47, 53, 59, 61, 67, 71, 73, 79, 83, 89, 97,
+	101, 103, 107, 109, 113, 127, 131, 137, 139, 149, 151, 157, 163, 167, 173, 179, 181, 191, 193, 197, 199,
+	211, 223, 227, 229, 233, 239, 241, 251, 257, 263, 269, 271, 277, 281, 283, 293,
+}
+
+// ProbablyPrime は b が素数かどうかを小さな素数による試し割りと n 回の Miller-Rabin テスト、
+// さらに強 Lucas テストを組み合わせた Baillie-PSW テストで判定します
+// false なら b は合成数で、true なら b が合成数である確率は 4^(-n) 以下です
+// Miller-Rabin の底には2と、b 全体の SHA-256 から導出した値を使うので、同じ b には常に同じ結果を返します
+// 底を知って Miller-Rabin を通る合成数を作っても、強 Lucas テストで弾かれます (Baillie-PSW を通る合成数は知られていません)
+// n < 0 のときpanicします
+func (b *Int) ProbablyPrime(n int) bool {
+	if n < 0 {
+		panic("negative n for ProbablyPrime")
+	}
+	if b.neg || len(b.abs) == 0 {
+		return false
+	}
+	if cmp(b.abs, digits{2}) == 0 {
+		return true
+	}
+	if b.abs[len(b.abs)-1]%2 == 0 {
+		return false
+	}
+	for _, p := range smallPrimes {
+		if _, r := divWord(b.abs, p); r == 0 {
+			return cmp(b.abs, NewInt(int64(p)).abs) == 0
+		}
+	}
+	if cmp(b.abs, digits{1}) == 0 {
+		return false
+	}
+	// 試し割りを通った 293^2 未満の数は素数
+	if cmp(b.abs, NewInt(293*293).abs) < 0 {
+		return true
+	}
+	return b.millerRabin(n) && b.strongLucas()
+}
+
+// millerRabin は 2 と n-1 個の b から導出した値を底にして (n = 0 のときは2だけで) Miller-Rabin テストを行う
+func (b *Int) millerRabin(n int) bool {
+	// b - 1 = 2^s * d (d は奇数)
+	one := NewInt(1)
+	two := NewInt(2)
+	bm1 := Sub(b, one)
+	d := bm1
+	s := 0
+	for d.abs[len(d.abs)-1]%2 == 0 {
+		d, _ = Div(d, two)
+		s++
+	}
+
+	// 底は 2 <= a <= b - 2 の範囲から選ぶ
+	// b のすべての桁から決まるように、i 番目の底は SHA-256(b || i || j) (j = 0, 1, ...) を並べたバイト列から作る
+	bm3 := Sub(b, NewInt(3))
+	bb := b.Bytes()
+	if n < 1 {
+		n = 1
+	}
+	a := two
+	for i := 0; i < n; i++ {
+		if i > 0 {
+			a = Add(Mod(new(Int).SetBytes(expandBase(bb, i, len(bb)+8)), bm3), two)
+		}
+		y := Exp(a, d, b)
+		if Cmp(y, one) == 0 || Cmp(y, bm1) == 0 {
+			continue
+		}
+		composite := true
+		for j := 1; j < s; j++ {
+			y = Exp(y, two, b)
+			if Cmp(y, bm1) == 0 {
+				composite = false
+				break
+			}
+			if Cmp(y, one) == 0 {
+				break
+			}
+		}
+		if composite {
+			return false
+		}
+	}
+	return true
+}
+
+// expandBase は i 番目の底を作るための l バイトを SHA-256(b || i || j) の連結から返す
+func expandBase(b []byte, i, l int) []byte {
+	out := make([]byte, 0, l+sha256.Size)
+	var ctr [8]byte
+	binary.BigEndian.PutUint32(ctr[:4], uint32(i))
+	for j := uint32(0); len(out) < l; j++ {
+		binary.BigEndian.PutUint32(ctr[4:], j)
+		h := sha256.New()
+		h.Write(b)
+		h.Write(ctr[:])
+		out = h.Sum(out)
+	}
+	return out[:l]
+}
+
+// strongLucas は FIPS 186-4 C.3.3 の強 Lucas 確率的素数テストを奇数 b > 2 に対して行う
+// Selfridge の方法で D = 5, -7, 9, -11, ... から (D/b) = -1 となる最初の D を選び、P = 1, Q = (1 - D)/4 の Lucas 数列を使う
+// b + 1 = 2^s * d (d は奇数) として、U_d = 0 または V_(d*2^r) = 0 (0 <= r < s) なら素数とみなす
+func (b *Int) strongLucas() bool {
+	// 平方数には (D/b) = -1 となる D がないので、先に除く
+	if r := Sqrt(b); Cmp(Mul(r, r), b) == 0 {
+		return false
+	}
+	d := int64(5)
+	for {
+		j := Jacobi(NewInt(d), b)
+		if j == -1 {
+			break
+		}
+		// (D/b) = 0 なら |D| と b は共通の約数をもつ
+		if j == 0 && Cmp(NewInt(abs64(d)), b) != 0 {
+			return false
+		}
+		if d > 0 {
+			d = -(d + 2)
+		} else {
+			d = -d + 2
+		}
+	}
+	// 剰余乗算は Montgomery 表現で行う (試し割りを通った b は 10 と互いに素)
+	mt := NewMontgomery(b)
+	dd := mt.To(NewInt(d))
+	q := mt.To(NewInt((1 - d) / 4))
+
+	// b + 1 = 2^s * k (k は奇数)
+	k := Add(b, NewInt(1))
+	s := 0
+	for k.abs[len(k.abs)-1]%2 == 0 {
+		k, _ = Div(k, NewInt(2))
+		s++
+	}
+
+	// k の上位ビットから U_k, V_k, Q^k を求める (P = 1)
+	// U_2m = U_m V_m, V_2m = V_m^2 - 2Q^m
+	// U_(2m+1) = (U_2m + V_2m)/2, V_(2m+1) = (D U_2m + V_2m)/2
+	u, v, qk := mt.To(NewInt(1)), mt.To(NewInt(1)), q
+	kb := k.Bytes()
+	for i := k.BitLen() - 2; i >= 0; i-- {
+		u = mt.Mul(u, v)
+		v = subMod(mt.Mul(v, v), addMod(qk, qk, b), b)
+		qk = mt.Mul(qk, qk)
+		if kb[len(kb)-1-i/8]>>uint(i%8)&1 == 1 {
+			u, v = half(addMod(u, v, b), b), half(addMod(mt.Mul(dd, u), v, b), b)
+			qk = mt.Mul(qk, q)
+		}
+	}
+	if len(u.abs) == 0 || len(v.abs) == 0 {
+		return true
+	}
+	for r := 1; r < s; r++ {
+		v = subMod(mt.Mul(v, v), addMod(qk, qk, b), b)
+		if len(v.abs) == 0 {
+			return true
+		}
+		qk = mt.Mul(qk, qk)
+	}
+	return false
+}
+
+// addMod は 0 <= x, y < m について x + y mod m を求める
+func addMod(x, y, m *Int) *Int {
+	z := add(x.abs, y.abs)
+	if cmp(z, m.abs) >= 0 {
+		z = sub(z, m.abs)
+	}
+	return &Int{abs: z}
+}
+
+// subMod は 0 <= x, y < m について x - y mod m を求める
+func subMod(x, y, m *Int) *Int {
+	if cmp(x.abs, y.abs) >= 0 {
+		return &Int{abs: sub(x.abs, y.abs)}
+	}
+	return &Int{abs: sub(add(x.abs, m.abs), y.abs)}
+}
+
+// half は奇数 m を法として 0 <= x < m の x/2 を求める
+func half(x, m *Int) *Int {
+	abs := x.abs
+	if len(abs) > 0 && abs[len(abs)-1]%2 == 1 {
+		abs = add(abs, m.abs)
+	}
+	q, _ := divWord(abs, 2)
+	return &Int{abs: q}
+}
+
+// abs64 は x の絶対値を返す
+func abs64(x int64) int64 {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+// Prime は r から読み込んだ乱数で、ビット長がちょうど bits の素数を返します
+// 上位2ビットを立てた候補から探すので、同じビット長の素数2つの積はビット長が 2*bits になります
+// bits < 2 のときはエラーを返します
+func Prime(r io.Reader, bits int) (*Int, error) {
+	if bits < 2 {
+		return nil, errors.New("big: prime size must be at least 2-bit")
+	}
+	for {
+		p, err := candidate(r, bits, 2)
+		if err != nil {
+			return nil, err
+		}
+		if p.ProbablyPrime(20) {
+			return p, nil
+		}
+	}
+}
+
+// SafePrime は r から読み込んだ乱数で、q = (p-1)/2 も素数になるビット長がちょうど bits の素数 p (安全素数) を返します
+// bits < 3 のときはエラーを返します
+func SafePrime(r io.Reader, bits int) (*Int, error) {
+	if bits < 3 {
+		return nil, errors.New("big: safe prime size must be at least 3-bit")
+	}
+	one := NewInt(1)
+	two := NewInt(2)
+	// 小さな素数で q と 2q+1 をふるうのは、q がそれらの素数より大きいときだけ
+	sieve := bits-1 > 9
+	for {
+		// q の最上位ビットを立てれば 2q+1 のビット長は bits になる
+		q, err := candidate(r, bits-1, 1)
+		if err != nil {
+			return nil, err
+		}
+		// q から2ずつ増やしながら探し、小さな素数の剰余は差分で更新する
+		rems := make([]uint, len(smallPrimes))
+		for i, sp := range smallPrimes {
+			_, rems[i] = divWord(q.abs, sp)
+		}
+	NextDelta:
+		for delta := uint(0); delta < 1<<20; delta += 2 {
+			if sieve {
+				for i, sp := range smallPrimes {
+					// q = 0 (mod sp) なら q が、q = (sp-1)/2 (mod sp) なら 2q+1 が sp で割り切れる
+					if m := (rems[i] + delta) % sp; m == 0 || m == (sp-1)/2 {
+						continue NextDelta
+					}
+				}
+			}
+			cq := Add(q, NewInt(int64(delta)))
+			p := Add(Mul(cq, two), one)
+			if p.BitLen() != bits {
+				break
+			}
+			// 2を底とした p の Fermat テストでほとんどの候補を落としてから、両方を Miller-Rabin で確かめる
+			if Cmp(Exp(two, Sub(p, one), p), one) != 0 {
+				continue
+			}
+			if cq.ProbablyPrime(20) && p.ProbablyPrime(20) {
+				return p, nil
+			}
+		}
+	}
+}
+
+// candidate は r から読み込んだ乱数で、上位 top ビットと最下位ビットを立てたビット長 bits の奇数を返す
+func candidate(r io.Reader, bits, top int) (*Int, error) {
+	buf := make([]byte, (bits+7)/8)
+	if _, err := io.ReadFull(r, buf); err != nil {
+		return nil, err
+	}
+	// bits を超える上位のビットを落とす
+	buf[0] &= byte(0xff >> uint(len(buf)*8-bits))
+	for i := bits - top; i < bits; i++ {
+		buf[len(buf)-1-i/8] |= 1 << uint(i%8)
+	}
+	buf[len(buf)-1] |= 1
+	return new(Int).SetBytes(buf), nil
+}
@@ -0,0 +1,133 @@
+package big
+
+import (
+	"crypto/rand"
+	stdbig "math/big"
+	"testing"
+)
+
+func TestInt_ProbablyPrime(t *testing.T) {
+	tests := []struct {
+		name string
+		x    *Int
+		want bool
+	}{
+		{name: "zero", x: NewInt(0), want: false},
+		{name: "one", x: NewInt(1), want: false},
+		{name: "two", x: NewInt(2), want: true},
+		{name: "three", x: NewInt(3), want: true},
+		{name: "four", x: NewInt(4), want: false},
+		{name: "small prime", x: NewInt(293), want: true},
+		{name: "negative", x: NewInt(-7), want: false},
+		{name: "product of small primes", x: NewInt(3 * 5 * 7 * 11), want: false},
+		{name: "prime above trial division", x: NewInt(85853), want: true},
+		// Carmichael 数と強擬素数
+		{name: "Carmichael 561", x: NewInt(561), want: false},
+		{name: "Carmichael 1194649", x: NewInt(1194649), want: false},
+		{name: "strong pseudoprime to base 2", x: NewInt(3215031751), want: false},
+		{name: "strong pseudoprime to bases 2 and 3", x: NewInt(1373653), want: false},
+		{name: "strong pseudoprime to bases 2 to 23", x: new(Int).SetString("3825123056546413051"), want: false},
+		{name: "2^61 - 1", x: new(Int).SetString("2305843009213693951"), want: true},
+		{name: "2^127 - 1", x: new(Int).SetString("170141183460469231731687303715884105727"), want: true},
+		{name: "(2^61 - 1)(2^89 - 1)", x: Mul(new(Int).SetString("2305843009213693951"), new(Int).SetString("618970019642690137449562111")), want: false},
+		{name: "2^255 - 19", x: Sub(Exp(NewInt(2), NewInt(255), nil), NewInt(19)), want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.x.ProbablyPrime(20); got != tt.want {
+				t.Errorf("ProbablyPrime() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInt_ProbablyPrime_std(t *testing.T) {
+	// math/big の ProbablyPrime と小さな奇数について結果を比べる
+	for i := int64(1); i < 5000; i += 2 {
+		want := stdbig.NewInt(i).ProbablyPrime(20)
+		if got := NewInt(i).ProbablyPrime(20); got != want {
+			t.Errorf("ProbablyPrime(%d) = %v, want %v", i, got, want)
+		}
+	}
+}
+
+// Miller-Rabin の底が2だけでも、底2の強擬素数は強 Lucas テストで弾かれる
+func TestInt_ProbablyPrime_baseTwoOnly(t *testing.T) {
+	for _, x := range []*Int{NewInt(1373653), NewInt(25326001), new(Int).SetString("3825123056546413051")} {
+		if !x.millerRabin(1) {
+			t.Fatalf("%v is not a strong pseudoprime to base 2", x)
+		}
+		if x.ProbablyPrime(0) {
+			t.Errorf("ProbablyPrime(%v, 0) = true, want false", x)
+		}
+	}
+}
+
+func TestInt_strongLucas(t *testing.T) {
+	tests := []struct {
+		name string
+		x    *Int
+		want bool
+	}{
+		{name: "prime", x: NewInt(85853), want: true},
+		{name: "2^61 - 1", x: new(Int).SetString("2305843009213693951"), want: true},
+		{name: "2^127 - 1", x: new(Int).SetString("170141183460469231731687303715884105727"), want: true},
+		// 強 Lucas 擬素数は Lucas テストを通るが Miller-Rabin で弾かれる
+		{name: "strong Lucas pseudoprime 5459", x: NewInt(5459), want: true},
+		{name: "strong Lucas pseudoprime 5777", x: NewInt(5777), want: true},
+		{name: "strong Lucas pseudoprime 10877", x: NewInt(10877), want: true},
+		{name: "strong pseudoprime to base 2", x: NewInt(1373653), want: false},
+		{name: "square", x: NewInt(1009 * 1009), want: false},
+		{name: "composite", x: NewInt(1009 * 1013), want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.x.strongLucas(); got != tt.want {
+				t.Errorf("strongLucas() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+	for _, x := range []int64{5459, 5777, 10877} {
+		if NewInt(x).ProbablyPrime(20) {
+			t.Errorf("ProbablyPrime(%d) = true, want false", x)
+		}
+	}
+}
+
+func TestPrime(t *testing.T) {
+	for _, bits := range []int{2, 3, 8, 9, 16, 64, 129, 256} {
+		p, err := Prime(rand.Reader, bits)
+		if err != nil {
+			t.Fatalf("Prime(%d) error = %v", bits, err)
+		}
+		if got := p.BitLen(); got != bits {
+			t.Errorf("Prime(%d).BitLen() = %d", bits, got)
+		}
+		if !new(stdbig.Int).SetBytes(p.Bytes()).ProbablyPrime(20) {
+			t.Errorf("Prime(%d) = %v is not prime", bits, p)
+		}
+	}
+	if _, err := Prime(rand.Reader, 1); err == nil {
+		t.Errorf("Prime(1) error = nil")
+	}
+}
+
+func TestSafePrime(t *testing.T) {
+	for _, bits := range []int{3, 5, 8, 11, 64, 128, 256} {
+		p, err := SafePrime(rand.Reader, bits)
+		if err != nil {
+			t.Fatalf("SafePrime(%d) error = %v", bits, err)
+		}
+		if got := p.BitLen(); got != bits {
+			t.Errorf("SafePrime(%d).BitLen() = %d", bits, got)
+		}
+		sp := new(stdbig.Int).SetBytes(p.Bytes())
+		q := new(stdbig.Int).Rsh(sp, 1)
+		if !sp.ProbablyPrime(20) || !q.ProbablyPrime(20) {
+			t.Errorf("SafePrime(%d) = %v is not a safe prime", bits, p)
+		}
+	}
+	if _, err := SafePrime(rand.Reader, 2); err == nil {
+		t.Errorf("SafePrime(2) error = nil")
+	}
+}
@@ -0,0 +1,40 @@
+package big
+
+import (
+	"io"
+)
+
+// RandInt は r から読み込んだ乱数で 0 <= x < n の一様な整数を返します
+// n <= 0 のときpanicします
+func RandInt(r io.Reader, n *Int) (*Int, error) {
+	return randInt(r, n, Zero)
+}
+
+// RandNonZeroInt は r から読み込んだ乱数で 1 <= x < n の一様な整数を返します
+// 秘密鍵やナンスのように0を除いたスカラーを選ぶときに使います
+// n <= 1 のときpanicします
+func RandNonZeroInt(r io.Reader, n *Int) (*Int, error) {
+	return randInt(r, n, NewInt(1))
+}
+
+// randInt は min <= x < n の一様な整数を返す
+// n のビット長を超える上位のビットを落としてから、範囲外の値を棄却して読み直す
+func randInt(r io.Reader, n, min *Int) (*Int, error) {
+	if Cmp(n, min) <= 0 {
+		panic("empty range")
+	}
+	l := n.BitLen()
+	buf := make([]byte, (l+7)/8)
+	for {
+		if _, err := io.ReadFull(r, buf); err != nil {
+			return nil, err
+		}
+		if excess := len(buf)*8 - l; excess > 0 {
+			buf[0] &= byte(0xff >> uint(excess))
+		}
+		x := new(Int).SetBytes(buf)
+		if Cmp(x, min) >= 0 && Cmp(x, n) < 0 {
+			return x, nil
+		}
+	}
+}
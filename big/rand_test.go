@@ -0,0 +1,65 @@
+package big
+
+import (
+	"bytes"
+	"crypto/rand"
+	"errors"
+	"io"
+	"testing"
+)
+
+func TestRandInt(t *testing.T) {
+	tests := []struct {
+		name string
+		rand func(io.Reader, *Int) (*Int, error)
+		n    int64
+		min  int64
+	}{
+		{name: "RandInt", rand: RandInt, n: 5, min: 0},
+		{name: "RandInt one", rand: RandInt, n: 1, min: 0},
+		{name: "RandNonZeroInt", rand: RandNonZeroInt, n: 5, min: 1},
+		{name: "RandNonZeroInt two", rand: RandNonZeroInt, n: 2, min: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// 範囲内のすべての値が出て、範囲外の値は出ない
+			seen := make(map[string]bool)
+			for i := 0; i < 200; i++ {
+				x, err := tt.rand(rand.Reader, NewInt(tt.n))
+				if err != nil {
+					t.Fatal(err)
+				}
+				if Cmp(x, NewInt(tt.min)) < 0 || Cmp(x, NewInt(tt.n)) >= 0 {
+					t.Fatalf("got %v, want %d <= x < %d", x, tt.min, tt.n)
+				}
+				seen[x.String()] = true
+			}
+			if want := int(tt.n - tt.min); len(seen) != want {
+				t.Errorf("got %d distinct values, want %d", len(seen), want)
+			}
+		})
+	}
+}
+
+func TestRandInt_rejection(t *testing.T) {
+	// n = 5 は3ビットなので上位5ビットを落とし、0x07 (7) と 0x05 (5) は棄却、0xfb (3) を受理する
+	r := bytes.NewReader([]byte{0x07, 0x05, 0xfb, 0x01})
+	x, err := RandInt(r, NewInt(5))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if Cmp(x, NewInt(3)) != 0 {
+		t.Errorf("RandInt() = %v, want 3", x)
+	}
+	// RandNonZeroInt は0も棄却する
+	r = bytes.NewReader([]byte{0x00, 0x08, 0x02})
+	if x, err = RandNonZeroInt(r, NewInt(5)); err != nil {
+		t.Fatal(err)
+	}
+	if Cmp(x, NewInt(2)) != 0 {
+		t.Errorf("RandNonZeroInt() = %v, want 2", x)
+	}
+	if _, err := RandInt(bytes.NewReader([]byte{0x07}), NewInt(5)); !errors.Is(err, io.EOF) {
+		t.Errorf("RandInt(short reader) error = %v, want %v", err, io.EOF)
+	}
+}
@@ -0,0 +1,162 @@
+package big
+
+import "strings"
+
+// Rat は分子と分母を Int で持つ有理数型です
+// 常に既約分数に正規化され、符号は分子が持ちます (分母は常に正)
+// NewRat, SetFrac, SetString のいずれかで初期化して使います
+type Rat struct {
+	a *Int // 分子
+	b *Int // 分母
+}
+
+// NewRat は a/b を表す Rat を返します
+// b == 0 のときpanicします
+func NewRat(a, b int64) *Rat {
+	return new(Rat).SetFrac(NewInt(a), NewInt(b))
+}
+
+// SetFrac は z を a/b にセットします
+// b == 0 のときpanicします
+func (z *Rat) SetFrac(a, b *Int) *Rat {
+	z.a, z.b = a, b
+	return z.norm()
+}
+
+// SetInt は z を x/1 にセットします
+func (z *Rat) SetInt(x *Int) *Rat {
+	z.a, z.b = x, NewInt(1)
+	return z
+}
+
+// SetString は "a/b" もしくは "a" の形式の入力を10進数としてscanします
+// 予期しない入力によって読み取りに失敗するとpanicします
+func (z *Rat) SetString(s string) *Rat {
+	if i := strings.IndexByte(s, '/'); i >= 0 {
+		return z.SetFrac(new(Int).SetString(s[:i]), new(Int).SetString(s[i+1:]))
+	}
+	return z.SetInt(new(Int).SetString(s))
+}
+
+// Num は x の分子を返します
+func (x *Rat) Num() *Int {
+	return x.a
+}
+
+// Denom は x の分母を返します
+func (x *Rat) Denom() *Int {
+	return x.b
+}
+
+func (x *Rat) String() string {
+	return x.a.String() + "/" + x.b.String()
+}
+
+// norm は分母の符号を分子に移し、分子と分母を最大公約数で割って既約分数にする
+func (z *Rat) norm() *Rat {
+	if len(z.b.abs) == 0 {
+		panic("division by zero")
+	}
+	if len(z.a.abs) == 0 {
+		z.a, z.b = NewInt(0), NewInt(1)
+		return z
+	}
+	a := &Int{neg: z.a.neg != z.b.neg, abs: z.a.abs}
+	b := &Int{abs: z.b.abs}
+	d, _, _ := GCD(a, b)
+	z.a, _ = Div(a, d)
+	z.b, _ = Div(b, d)
+	return z
+}
+
+// Add は z を x + y にセットします
+func (z *Rat) Add(x, y *Rat) *Rat {
+	// a/b + c/d = (ad + cb) / bd
+	return z.SetFrac(Add(Mul(x.a, y.b), Mul(y.a, x.b)), Mul(x.b, y.b))
+}
+
+// Sub は z を x - y にセットします
+func (z *Rat) Sub(x, y *Rat) *Rat {
+	// a/b - c/d = (ad - cb) / bd
+	return z.SetFrac(Sub(Mul(x.a, y.b), Mul(y.a, x.b)), Mul(x.b, y.b))
+}
+
+// Mul は z を x * y にセットします
+func (z *Rat) Mul(x, y *Rat) *Rat {
+	return z.SetFrac(Mul(x.a, y.a), Mul(x.b, y.b))
+}
+
+// Quo は z を x / y にセットします
+// y == 0 のときpanicします
+func (z *Rat) Quo(x, y *Rat) *Rat {
+	// (a/b) / (c/d) = ad / bc
+	return z.SetFrac(Mul(x.a, y.b), Mul(x.b, y.a))
+}
+
+// Cmp は x, y を比較して以下の結果を返します
+// x > y  -> 1
+// x == y -> 0
+// x < y  -> -1
+func (x *Rat) Cmp(y *Rat) int8 {
+	// 分母は常に正なので a/b と c/d の比較は ad と cb の比較になる
+	return Cmp(Mul(x.a, y.b), Mul(y.a, x.b))
+}
+
+// FloatString は x を小数点以下 prec 桁の10進数の文字列で返します
+// 最後の桁は四捨五入 (0から遠い方への丸め) されます
+func (x *Rat) FloatString(prec int) string {
+	// |a| * 10^prec / b を整数で求めてから小数点を挿入する
+	q, r := div(mul(x.a.abs, rightPad(digits{1}, prec)), x.b.abs)
+	// あまりが分母の半分以上なら切り上げ
+	if cmp(mul(r, digits{2}), x.b.abs) >= 0 {
+		q = add(q, digits{1})
+	}
+	// 整数部が0のときも1桁は残るように、少なくとも prec+1 桁になるよう上位を0で埋める
+	if len(q) < prec+1 {
+		q = leftPad(q, prec+1-len(q))
+	}
+
+	var sb strings.Builder
+	if x.a.neg {
+		sb.WriteByte('-')
+	}
+	for i, d := range q {
+		if i == len(q)-prec {
+			sb.WriteByte('.')
+		}
+		sb.WriteByte('0' + d)
+	}
+	return sb.String()
+}
+
+// ContinuedFraction は x の連分数展開 [a0; a1, a2, ...] を返します
+// a0 = floor(x) で、a1 以降は正の整数になります
+func (x *Rat) ContinuedFraction() []*Int {
+	var cf []*Int
+	a, b := x.a, x.b
+	for len(b.abs) > 0 {
+		// a/b = q + r/b (0 <= r < b) として、b/r について展開を続ける
+		q, r := Div(a, b)
+		if r.neg {
+			q = Sub(q, NewInt(1))
+			r = Add(r, b)
+		}
+		cf = append(cf, q)
+		a, b = b, r
+	}
+	return cf
+}
+
+// Convergents は連分数 [a0; a1, a2, ...] の近似分数 h0/k0, h1/k1, ... を返します
+func Convergents(cf []*Int) []*Rat {
+	// h(n) = a(n) * h(n-1) + h(n-2), k(n) = a(n) * k(n-1) + k(n-2)
+	hPrev, h := NewInt(0), NewInt(1)
+	kPrev, k := NewInt(1), NewInt(0)
+	rs := make([]*Rat, len(cf))
+	for i, a := range cf {
+		hPrev, h = h, Add(Mul(a, h), hPrev)
+		kPrev, k = k, Add(Mul(a, k), kPrev)
+		rs[i] = new(Rat).SetFrac(h, k)
+	}
+	return rs
+}
@@ -0,0 +1,338 @@
+package big
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNewRat(t *testing.T) {
+	type args struct {
+		a int64
+		b int64
+	}
+	tests := []struct {
+		name string
+		args args
+		want *Rat
+	}{
+		{
+			name: "reduced by gcd",
+			args: args{a: 6, b: 8},
+			want: &Rat{a: NewInt(3), b: NewInt(4)},
+		},
+		{
+			name: "negative denominator",
+			args: args{a: 6, b: -8},
+			want: &Rat{a: NewInt(-3), b: NewInt(4)},
+		},
+		{
+			name: "negative numerator and denominator",
+			args: args{a: -6, b: -8},
+			want: &Rat{a: NewInt(3), b: NewInt(4)},
+		},
+		{
+			name: "zero",
+			args: args{a: 0, b: -8},
+			want: &Rat{a: NewInt(0), b: NewInt(1)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewRat(tt.args.a, tt.args.b); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewRat() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRat_SetString(t *testing.T) {
+	type args struct {
+		s string
+	}
+	tests := []struct {
+		name string
+		args args
+		want *Rat
+	}{
+		{
+			name: "a/b",
+			args: args{s: "10/4"},
+			want: NewRat(5, 2),
+		},
+		{
+			name: "-a/b",
+			args: args{s: "-10/4"},
+			want: NewRat(-5, 2),
+		},
+		{
+			name: "integer",
+			args: args{s: "42"},
+			want: NewRat(42, 1),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := new(Rat).SetString(tt.args.s); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SetString() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRat_Add(t *testing.T) {
+	type args struct {
+		x *Rat
+		y *Rat
+	}
+	tests := []struct {
+		name string
+		args args
+		want *Rat
+	}{
+		{
+			name: "x + y",
+			args: args{x: NewRat(1, 6), y: NewRat(1, 3)},
+			want: NewRat(1, 2),
+		},
+		{
+			name: "x + (-y)",
+			args: args{x: NewRat(1, 6), y: NewRat(-1, 3)},
+			want: NewRat(-1, 6),
+		},
+		{
+			name: "x + (-x) = 0",
+			args: args{x: NewRat(5, 7), y: NewRat(-5, 7)},
+			want: NewRat(0, 1),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := new(Rat).Add(tt.args.x, tt.args.y); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Add() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRat_Sub(t *testing.T) {
+	type args struct {
+		x *Rat
+		y *Rat
+	}
+	tests := []struct {
+		name string
+		args args
+		want *Rat
+	}{
+		{
+			name: "x - y",
+			args: args{x: NewRat(1, 2), y: NewRat(1, 3)},
+			want: NewRat(1, 6),
+		},
+		{
+			name: "x - y < 0",
+			args: args{x: NewRat(1, 3), y: NewRat(1, 2)},
+			want: NewRat(-1, 6),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := new(Rat).Sub(tt.args.x, tt.args.y); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Sub() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRat_Mul(t *testing.T) {
+	type args struct {
+		x *Rat
+		y *Rat
+	}
+	tests := []struct {
+		name string
+		args args
+		want *Rat
+	}{
+		{
+			name: "x * y",
+			args: args{x: NewRat(2, 3), y: NewRat(9, 4)},
+			want: NewRat(3, 2),
+		},
+		{
+			name: "-x * y",
+			args: args{x: NewRat(-2, 3), y: NewRat(9, 4)},
+			want: NewRat(-3, 2),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := new(Rat).Mul(tt.args.x, tt.args.y); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Mul() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRat_Quo(t *testing.T) {
+	type args struct {
+		x *Rat
+		y *Rat
+	}
+	tests := []struct {
+		name string
+		args args
+		want *Rat
+	}{
+		{
+			name: "x / y",
+			args: args{x: NewRat(2, 3), y: NewRat(4, 9)},
+			want: NewRat(3, 2),
+		},
+		{
+			name: "x / -y",
+			args: args{x: NewRat(2, 3), y: NewRat(-4, 9)},
+			want: NewRat(-3, 2),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := new(Rat).Quo(tt.args.x, tt.args.y); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Quo() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRat_Cmp(t *testing.T) {
+	type args struct {
+		x *Rat
+		y *Rat
+	}
+	tests := []struct {
+		name string
+		args args
+		want int8
+	}{
+		{
+			name: "x > y",
+			args: args{x: NewRat(2, 3), y: NewRat(3, 5)},
+			want: 1,
+		},
+		{
+			name: "x == y",
+			args: args{x: NewRat(2, 3), y: NewRat(4, 6)},
+			want: 0,
+		},
+		{
+			name: "x < y (negative)",
+			args: args{x: NewRat(-2, 3), y: NewRat(-3, 5)},
+			want: -1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.args.x.Cmp(tt.args.y); got != tt.want {
+				t.Errorf("Cmp() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRat_FloatString(t *testing.T) {
+	type args struct {
+		x    *Rat
+		prec int
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "round up",
+			args: args{x: NewRat(2, 3), prec: 5},
+			want: "0.66667",
+		},
+		{
+			name: "round down",
+			args: args{x: NewRat(1, 3), prec: 5},
+			want: "0.33333",
+		},
+		{
+			name: "leading zeros in fraction",
+			args: args{x: NewRat(1, 1000), prec: 4},
+			want: "0.0010",
+		},
+		{
+			name: "negative",
+			args: args{x: NewRat(-22, 7), prec: 3},
+			want: "-3.143",
+		},
+		{
+			name: "no fraction",
+			args: args{x: NewRat(5, 2), prec: 0},
+			want: "3",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.args.x.FloatString(tt.args.prec); got != tt.want {
+				t.Errorf("FloatString() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRat_ContinuedFraction(t *testing.T) {
+	type args struct {
+		x *Rat
+	}
+	tests := []struct {
+		name string
+		args args
+		want []*Int
+	}{
+		{
+			name: "415/93",
+			args: args{x: NewRat(415, 93)},
+			want: []*Int{NewInt(4), NewInt(2), NewInt(6), NewInt(7)},
+		},
+		{
+			name: "-415/93",
+			args: args{x: NewRat(-415, 93)},
+			want: []*Int{NewInt(-5), NewInt(1), NewInt(1), NewInt(6), NewInt(7)},
+		},
+		{
+			name: "integer",
+			args: args{x: NewRat(3, 1)},
+			want: []*Int{NewInt(3)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.args.x.ContinuedFraction(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ContinuedFraction() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConvergents(t *testing.T) {
+	// Wiener's attack の例 (N = 90581, e = 17993) で、e/N の近似分数の中に k/d = 1/5 が現れる
+	cf := NewRat(17993, 90581).ContinuedFraction()
+	want := []*Rat{
+		NewRat(0, 1),
+		NewRat(1, 5),
+		NewRat(29, 146),
+		NewRat(117, 589),
+		NewRat(146, 735),
+		NewRat(555, 2794),
+		NewRat(1256, 6323),
+		NewRat(5579, 28086),
+		NewRat(17993, 90581),
+	}
+	if got := Convergents(cf); !reflect.DeepEqual(got, want) {
+		t.Errorf("Convergents() = %v, want %v", got, want)
+	}
+}
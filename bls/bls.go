@@ -0,0 +1,258 @@
+// Package bls は draft-irtf-cfrg-bls-signature-05 の BLS 署名を BLS12-381 の上で提供します
+//
+// 秘密鍵 sk に対して公開鍵は pk = [sk]g、メッセージ m の署名は σ = [sk]H(m) で、
+// 検証は e(g, σ) = e(pk, H(m)) をペアリングで確かめます
+// 署名は足し合わせて1つにまとめられ (Aggregate)、まとめた署名も1回のペアリングの積で検証できます
+//
+// 鍵と署名の群の割り当てには次の2つがあります
+//   - minimal-pubkey-size: 公開鍵が G1 (48バイト)、署名が G2 (96バイト)。Ethereum はこちらを使います
+//   - minimal-signature-size: 公開鍵が G2 (96バイト)、署名が G1 (48バイト)
+//
+// まとめた署名で自分の鍵を他人の鍵から作る rogue key 攻撃を防ぐ方法には次の3つがあり、それぞれ別の DST を使います
+//   - basic: AggregateVerify でメッセージがすべて異なることを要求する
+//   - message augmentation: 公開鍵をメッセージの先頭につけて署名する
+//   - proof of possession: 秘密鍵を持っていることの証明 (PopProve) を公開鍵とともに確かめておく
+//
+// 鍵と署名は draft の通りバイト列で扱い、点は ZCash の圧縮形式で表します
+// メッセージは RFC 9380 の BLS12381G1_XMD:SHA-256_SSWU_RO_ または BLS12381G2_XMD:SHA-256_SSWU_RO_ で群に写します
+package bls
+
+import (
+	"errors"
+
+	"github.com/convto/mycrypto/big"
+	"github.com/convto/mycrypto/ec"
+	"github.com/convto/mycrypto/h2c"
+	"github.com/convto/mycrypto/pairing"
+)
+
+var (
+	// ErrInvalidPrivateKey は秘密鍵が 1 <= sk < r の32バイトの整数でないことを表します
+	ErrInvalidPrivateKey = errors.New("bls: invalid private key")
+	// ErrInvalidSignature は署名が群の点の圧縮形式でないか、位数 r の部分群に含まれないことを表します
+	ErrInvalidSignature = errors.New("bls: invalid signature")
+	// ErrNoSignatures はまとめる署名がないことを表します
+	ErrNoSignatures = errors.New("bls: no signatures to aggregate")
+)
+
+// curve は BLS12-381
+var curve = pairing.BLS12381()
+
+// mode は rogue key 攻撃を防ぐ方法
+type mode int
+
+const (
+	basic mode = iota
+	aug
+	pop
+)
+
+// Scheme は鍵と署名の群の割り当てと、rogue key 攻撃を防ぐ方法の組です
+type Scheme struct {
+	// minSig は公開鍵を G2、署名を G1 に置くかどうか
+	minSig bool
+	mode   mode
+	// dst は署名でメッセージを群に写すときの DST で、popDST は proof of possession で公開鍵を写すときの DST
+	dst    []byte
+	popDST []byte
+}
+
+// newScheme は draft 4.2 の ciphersuite の名前を DST にした Scheme を返す
+func newScheme(minSig bool, m mode) *Scheme {
+	suite := "BLS12381G2_XMD:SHA-256_SSWU_RO_"
+	if minSig {
+		suite = "BLS12381G1_XMD:SHA-256_SSWU_RO_"
+	}
+	tag := [...]string{basic: "NUL_", aug: "AUG_", pop: "POP_"}[m]
+	return &Scheme{
+		minSig: minSig,
+		mode:   m,
+		dst:    []byte("BLS_SIG_" + suite + tag),
+		popDST: []byte("BLS_POP_" + suite + tag),
+	}
+}
+
+var (
+	minPkBasic  = newScheme(false, basic)
+	minSigBasic = newScheme(true, basic)
+	minPkAug    = newScheme(false, aug)
+	minSigAug   = newScheme(true, aug)
+	minPkPop    = newScheme(false, pop)
+	minSigPop   = newScheme(true, pop)
+)
+
+// MinPkBasic は公開鍵が G1 の basic の方式を返します
+func MinPkBasic() *Scheme {
+	return minPkBasic
+}
+
+// MinSigBasic は署名が G1 の basic の方式を返します
+func MinSigBasic() *Scheme {
+	return minSigBasic
+}
+
+// MinPkAug は公開鍵が G1 の message augmentation の方式を返します
+func MinPkAug() *Scheme {
+	return minPkAug
+}
+
+// MinSigAug は署名が G1 の message augmentation の方式を返します
+func MinSigAug() *Scheme {
+	return minSigAug
+}
+
+// MinPkPop は公開鍵が G1 の proof of possession の方式を返します
+// Ethereum のコンセンサス層の署名はこの方式です
+func MinPkPop() *Scheme {
+	return minPkPop
+}
+
+// MinSigPop は署名が G1 の proof of possession の方式を返します
+func MinSigPop() *Scheme {
+	return minSigPop
+}
+
+// SkToPk は秘密鍵 sk の公開鍵を返します
+func (s *Scheme) SkToPk(sk []byte) ([]byte, error) {
+	x, err := parsePrivateKey(sk)
+	if err != nil {
+		return nil, err
+	}
+	return s.pkGenerator().mul(x).bytes(), nil
+}
+
+// KeyValidate は pk が公開鍵として正しいかどうかを判定します
+// 群の点の圧縮形式で、位数 r の部分群に含まれ、無限遠点でないことを確かめます
+func (s *Scheme) KeyValidate(pk []byte) bool {
+	_, ok := s.parsePublicKey(pk)
+	return ok
+}
+
+// Sign は秘密鍵 sk で msg に署名します
+// message augmentation の方式では公開鍵を msg の先頭につけて署名します
+func (s *Scheme) Sign(sk, msg []byte) ([]byte, error) {
+	x, err := parsePrivateKey(sk)
+	if err != nil {
+		return nil, err
+	}
+	if s.mode == aug {
+		msg = append(s.pkGenerator().mul(x).bytes(), msg...)
+	}
+	return s.hashToSig(msg, s.dst).mul(x).bytes(), nil
+}
+
+// Verify は sig が公開鍵 pk による msg の署名かどうかを判定します
+func (s *Scheme) Verify(pk, msg, sig []byte) bool {
+	return s.AggregateVerify([][]byte{pk}, [][]byte{msg}, sig)
+}
+
+// Aggregate は署名 sigs をまとめた1つの署名を返します
+// 署名のどれかが正しい点の表現でないときは ErrInvalidSignature を返します
+func (s *Scheme) Aggregate(sigs [][]byte) ([]byte, error) {
+	if len(sigs) == 0 {
+		return nil, ErrNoSignatures
+	}
+	var r *element
+	for _, b := range sigs {
+		p, ok := s.parseSignature(b)
+		if !ok {
+			return nil, ErrInvalidSignature
+		}
+		if r == nil {
+			r = p
+		} else {
+			r = r.add(p)
+		}
+	}
+	return r.bytes(), nil
+}
+
+// AggregateVerify は sig が各 i について公開鍵 pks[i] による msgs[i] の署名をまとめたものかどうかを判定します
+// basic の方式ではメッセージがすべて異なることも要求します
+func (s *Scheme) AggregateVerify(pks, msgs [][]byte, sig []byte) bool {
+	if len(pks) == 0 || len(pks) != len(msgs) {
+		return false
+	}
+	if s.mode == basic {
+		seen := make(map[string]bool, len(msgs))
+		for _, m := range msgs {
+			if seen[string(m)] {
+				return false
+			}
+			seen[string(m)] = true
+		}
+	}
+
+	ps := make([]*element, len(pks))
+	ms := make([][]byte, len(msgs))
+	for i := range pks {
+		p, ok := s.parsePublicKey(pks[i])
+		if !ok {
+			return false
+		}
+		ps[i] = p
+		ms[i] = msgs[i]
+		if s.mode == aug {
+			ms[i] = append(append([]byte{}, pks[i]...), msgs[i]...)
+		}
+	}
+	return s.coreAggregateVerify(ps, ms, sig, s.dst)
+}
+
+// coreAggregateVerify は sig が公開鍵の群の点 pks[i] による msgs[i] の署名をまとめたものかどうかを判定する
+// e(-g, σ) * e(pk_1, H(m_1)) * ... * e(pk_n, H(m_n)) = 1 を1回のペアリングの積で確かめる
+func (s *Scheme) coreAggregateVerify(pks []*element, msgs [][]byte, sig, dst []byte) bool {
+	sigma, ok := s.parseSignature(sig)
+	if !ok {
+		return false
+	}
+	var ps []*ec.Point
+	var qs []*pairing.G2
+	ps, qs = appendPair(ps, qs, s.pkGenerator().neg(), sigma)
+	for i := range pks {
+		ps, qs = appendPair(ps, qs, pks[i], s.hashToSig(msgs[i], dst))
+	}
+	return curve.PairingCheck(ps, qs)
+}
+
+// pkGenerator は公開鍵の群の生成元を返す
+func (s *Scheme) pkGenerator() *element {
+	if s.minSig {
+		return &element{g2: curve.G2Generator()}
+	}
+	return &element{g1: curve.G1().Generator()}
+}
+
+// hashToSig は msg を署名の群に写す
+func (s *Scheme) hashToSig(msg, dst []byte) *element {
+	if s.minSig {
+		return &element{g1: h2c.BLS12381G1(dst).HashToCurve(msg)}
+	}
+	return &element{g2: h2c.BLS12381G2(dst).HashToCurve(msg)}
+}
+
+// parsePublicKey は公開鍵の群の圧縮形式を読み込み、無限遠点は拒否する
+func (s *Scheme) parsePublicKey(b []byte) (*element, bool) {
+	p, ok := parsePoint(b, s.minSig)
+	if !ok || p.isInfinity() {
+		return nil, false
+	}
+	return p, true
+}
+
+// parseSignature は署名の群の圧縮形式を読み込む
+func (s *Scheme) parseSignature(b []byte) (*element, bool) {
+	return parsePoint(b, !s.minSig)
+}
+
+// parsePrivateKey は32バイトのビッグエンディアンの秘密鍵を読み込む
+func parsePrivateKey(sk []byte) (*big.Int, error) {
+	if len(sk) != 32 {
+		return nil, ErrInvalidPrivateKey
+	}
+	x := new(big.Int).SetBytes(sk)
+	if big.Cmp(x, big.Zero) == 0 || big.Cmp(x, curve.Order()) >= 0 {
+		return nil, ErrInvalidPrivateKey
+	}
+	return x, nil
+}
@@ -0,0 +1,183 @@
+package bls
+
+import (
+	"bytes"
+	"testing"
+)
+
+var schemes = []struct {
+	name   string
+	scheme *Scheme
+}{
+	{name: "MinPkBasic", scheme: MinPkBasic()},
+	{name: "MinSigBasic", scheme: MinSigBasic()},
+	{name: "MinPkAug", scheme: MinPkAug()},
+	{name: "MinSigAug", scheme: MinSigAug()},
+	{name: "MinPkPop", scheme: MinPkPop()},
+	{name: "MinSigPop", scheme: MinSigPop()},
+}
+
+// testKey は i から決まる秘密鍵と公開鍵を返す
+func testKey(t *testing.T, s *Scheme, i byte) (sk, pk []byte) {
+	t.Helper()
+	sk, err := KeyGen(bytes.Repeat([]byte{i}, 32), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pk, err = s.SkToPk(sk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return sk, pk
+}
+
+func TestSignVerify(t *testing.T) {
+	for _, tt := range schemes {
+		t.Run(tt.name, func(t *testing.T) {
+			s := tt.scheme
+			sk, pk := testKey(t, s, 1)
+			pkLen, sigLen := 48, 96
+			if s.minSig {
+				pkLen, sigLen = 96, 48
+			}
+			if len(pk) != pkLen {
+				t.Errorf("len(pk) = %d, want %d", len(pk), pkLen)
+			}
+
+			msg := []byte("hello")
+			sig, err := s.Sign(sk, msg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(sig) != sigLen {
+				t.Errorf("len(sig) = %d, want %d", len(sig), sigLen)
+			}
+			if !s.Verify(pk, msg, sig) {
+				t.Errorf("Verify() = false, want true")
+			}
+		})
+	}
+}
+
+// 方式ごとに DST が異なるので、ある方式の署名は他の方式では検証できない
+func TestSign_domainSeparation(t *testing.T) {
+	sk, pk := testKey(t, MinPkBasic(), 1)
+	msg := []byte("hello")
+	sig, err := MinPkBasic().Sign(sk, msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if MinPkPop().Verify(pk, msg, sig) {
+		t.Errorf("MinPkPop().Verify(MinPkBasic signature) = true, want false")
+	}
+}
+
+func TestSign_invalidPrivateKey(t *testing.T) {
+	s := MinPkPop()
+	for _, sk := range [][]byte{
+		make([]byte, 32),
+		curve.Order().FillBytes(make([]byte, 32)),
+		make([]byte, 31),
+	} {
+		if _, err := s.Sign(sk, []byte("msg")); err != ErrInvalidPrivateKey {
+			t.Errorf("Sign(%x) error = %v, want %v", sk, err, ErrInvalidPrivateKey)
+		}
+	}
+}
+
+func TestKeyValidate(t *testing.T) {
+	s := MinPkPop()
+	_, pk := testKey(t, s, 1)
+	if !s.KeyValidate(pk) {
+		t.Errorf("KeyValidate(pk) = false, want true")
+	}
+
+	infinity := make([]byte, 48)
+	infinity[0] = 0xc0
+	// 非圧縮形式の公開鍵は受け付けない
+	uncompressed := curve.G1Bytes(curve.G1().Generator())
+	for _, b := range [][]byte{infinity, uncompressed, pk[:47]} {
+		if s.KeyValidate(b) {
+			t.Errorf("KeyValidate(%x) = true, want false", b)
+		}
+	}
+}
+
+func TestAggregateVerify(t *testing.T) {
+	for _, tt := range []struct {
+		name   string
+		scheme *Scheme
+	}{schemes[0], schemes[3]} {
+		t.Run(tt.name, func(t *testing.T) {
+			s := tt.scheme
+			var pks, msgs, sigs [][]byte
+			for i := byte(1); i <= 2; i++ {
+				sk, pk := testKey(t, s, i)
+				msg := []byte{'m', i}
+				sig, err := s.Sign(sk, msg)
+				if err != nil {
+					t.Fatal(err)
+				}
+				pks, msgs, sigs = append(pks, pk), append(msgs, msg), append(sigs, sig)
+			}
+			agg, err := s.Aggregate(sigs)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !s.AggregateVerify(pks, msgs, agg) {
+				t.Errorf("AggregateVerify() = false, want true")
+			}
+			if s.AggregateVerify(pks, [][]byte{msgs[1], msgs[0]}, agg) {
+				t.Errorf("AggregateVerify(swapped messages) = true, want false")
+			}
+		})
+	}
+}
+
+// basic の方式では同じメッセージを含む AggregateVerify を拒否し、message augmentation の方式では受け付ける
+func TestAggregateVerify_sameMessage(t *testing.T) {
+	tests := []struct {
+		scheme *Scheme
+		want   bool
+	}{
+		{scheme: MinSigBasic(), want: false},
+		{scheme: MinSigAug(), want: true},
+	}
+	for _, tt := range tests {
+		s := tt.scheme
+		msg := []byte("same")
+		var pks, sigs [][]byte
+		for i := byte(1); i <= 2; i++ {
+			sk, pk := testKey(t, s, i)
+			sig, err := s.Sign(sk, msg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			pks, sigs = append(pks, pk), append(sigs, sig)
+		}
+		agg, err := s.Aggregate(sigs)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := s.AggregateVerify(pks, [][]byte{msg, msg}, agg); got != tt.want {
+			t.Errorf("%s: AggregateVerify(same message) = %v, want %v", s.dst, got, tt.want)
+		}
+	}
+}
+
+func TestAggregate_invalidSignature(t *testing.T) {
+	s := MinSigBasic()
+	sk, _ := testKey(t, s, 1)
+	sig, err := s.Sign(sk, []byte("msg"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	bad := append([]byte{}, sig...)
+	bad[0] &^= 0x80
+	if _, err := s.Aggregate([][]byte{sig, bad}); err != ErrInvalidSignature {
+		t.Errorf("Aggregate(invalid) error = %v, want %v", err, ErrInvalidSignature)
+	}
+	if _, err := s.Aggregate(nil); err != ErrNoSignatures {
+		t.Errorf("Aggregate(nil) error = %v, want %v", err, ErrNoSignatures)
+	}
+}
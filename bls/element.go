@@ -0,0 +1,85 @@
+package bls
+
+import (
+	"github.com/convto/mycrypto/big"
+	"github.com/convto/mycrypto/ec"
+	"github.com/convto/mycrypto/pairing"
+)
+
+// element は G1 または G2 の点で、g1 と g2 のどちらか一方だけを持つ
+// 公開鍵と署名の群は方式によって入れ替わるので、共通の操作をここにまとめる
+type element struct {
+	g1 *ec.Point
+	g2 *pairing.G2
+}
+
+// isInfinity は e が無限遠点かどうかを判定する
+func (e *element) isInfinity() bool {
+	if e.g1 != nil {
+		return e.g1.IsInfinity()
+	}
+	return e.g2.IsInfinity()
+}
+
+// add は e + f を返す
+func (e *element) add(f *element) *element {
+	if e.g1 != nil {
+		return &element{g1: e.g1.Add(f.g1)}
+	}
+	return &element{g2: e.g2.Add(f.g2)}
+}
+
+// neg は -e を返す
+func (e *element) neg() *element {
+	if e.g1 != nil {
+		return &element{g1: e.g1.Neg()}
+	}
+	return &element{g2: e.g2.Neg()}
+}
+
+// mul は秘密のスカラー k について [k]e を返す
+func (e *element) mul(k *big.Int) *element {
+	if e.g1 != nil {
+		return &element{g1: e.g1.ScalarMult(k)}
+	}
+	return &element{g2: e.g2.ScalarMult(k)}
+}
+
+// bytes は e の ZCash の圧縮形式を返す
+func (e *element) bytes() []byte {
+	if e.g1 != nil {
+		return curve.G1BytesCompressed(e.g1)
+	}
+	return e.g2.BytesCompressed()
+}
+
+// parsePoint は ZCash の圧縮形式の G1 または G2 の点を読み込む
+// 曲線上にない点や位数 r の部分群に含まれない点は拒否する
+func parsePoint(b []byte, g2 bool) (*element, bool) {
+	if g2 {
+		if len(b) != 96 {
+			return nil, false
+		}
+		q, err := curve.G2SetBytes(b)
+		if err != nil {
+			return nil, false
+		}
+		return &element{g2: q}, true
+	}
+	if len(b) != 48 {
+		return nil, false
+	}
+	p, err := curve.G1SetBytes(b)
+	if err != nil {
+		return nil, false
+	}
+	return &element{g1: p}, true
+}
+
+// appendPair はペアリング e(x, y) の引数を G1 の点と G2 の点に振り分けて ps, qs に加える
+func appendPair(ps []*ec.Point, qs []*pairing.G2, x, y *element) ([]*ec.Point, []*pairing.G2) {
+	if x.g1 != nil {
+		return append(ps, x.g1), append(qs, y.g2)
+	}
+	return append(ps, y.g1), append(qs, x.g2)
+}
@@ -0,0 +1,68 @@
+package bls
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"errors"
+
+	"github.com/convto/mycrypto/big"
+)
+
+// ErrShortIKM は鍵の素材が32バイトより短いことを表します
+var ErrShortIKM = errors.New("bls: IKM must be at least 32 bytes")
+
+// keyGenSalt は KeyGen の最初の salt
+var keyGenSalt = []byte("BLS-SIG-KEYGEN-SALT-")
+
+// KeyGen は draft 2.3 の KeyGen で、秘密の鍵の素材 ikm から32バイトの秘密鍵を導出します
+// keyInfo は同じ ikm から別の鍵を導出するための任意の文字列で、空でもかまいません
+// salt を SHA-256 で更新しながら HKDF で r を法とする整数を導出し、0 でなくなるまで繰り返します
+func KeyGen(ikm, keyInfo []byte) ([]byte, error) {
+	if len(ikm) < 32 {
+		return nil, ErrShortIKM
+	}
+	salt := keyGenSalt
+	for {
+		h := sha256.Sum256(salt)
+		salt = h[:]
+		if sk := deriveKey(ikm, salt, keyInfo); sk != nil {
+			return sk, nil
+		}
+	}
+}
+
+// deriveKey は1つの salt について HKDF-SHA256 で秘密鍵を導出し、0 になったときは nil を返す
+// PRK = HKDF-Extract(salt, IKM || I2OSP(0, 1)), OKM = HKDF-Expand(PRK, key_info || I2OSP(L, 2), L), SK = OS2IP(OKM) mod r
+// L = 48 は r のビット長に128ビットを足した長さで、還元の偏りを無視できるようにする
+func deriveKey(ikm, salt, keyInfo []byte) []byte {
+	const l = 48
+	prk := hkdfExtract(salt, append(append([]byte{}, ikm...), 0))
+	okm := hkdfExpand(prk, append(append([]byte{}, keyInfo...), 0, l), l)
+	x := big.Mod(new(big.Int).SetBytes(okm), curve.Order())
+	if big.Cmp(x, big.Zero) == 0 {
+		return nil
+	}
+	return x.FillBytes(make([]byte, 32))
+}
+
+// hkdfExtract は RFC 5869 の HKDF-Extract を HMAC-SHA256 で計算する
+func hkdfExtract(salt, ikm []byte) []byte {
+	mac := hmac.New(sha256.New, salt)
+	mac.Write(ikm)
+	return mac.Sum(nil)
+}
+
+// hkdfExpand は RFC 5869 の HKDF-Expand を HMAC-SHA256 で計算する
+// T(i) = HMAC(PRK, T(i-1) || info || i) をつなげた先頭 l バイトを返す
+func hkdfExpand(prk, info []byte, l int) []byte {
+	var okm, t []byte
+	for i := byte(1); len(okm) < l; i++ {
+		mac := hmac.New(sha256.New, prk)
+		mac.Write(t)
+		mac.Write(info)
+		mac.Write([]byte{i})
+		t = mac.Sum(nil)
+		okm = append(okm, t...)
+	}
+	return okm[:l]
+}
@@ -0,0 +1,76 @@
+package bls
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+)
+
+// EIP-2333 の derive_master_SK は KeyGen(seed, "") と同じ
+func TestKeyGen_EIP2333(t *testing.T) {
+	tests := []struct {
+		seed string
+		want string
+	}{
+		{
+			seed: "c55257c360c07c72029aebc1b53c05ed0362ada38ead3e3e9efa3708e53495531f09a6987599d18264c1e1c92f2cf141630c7a3c4ab7c81b2f001698e7463b04",
+			// 6083874454709270928345386274498605044986640685124978867557563392430687146096
+			want: "0d7359d57963ab8fbbde1852dcf553fedbc31f464d80ee7d40ae683122b45070",
+		},
+		{
+			seed: "3141592653589793238462643383279502884197169399375105820974944592",
+			// 29757020647961307431480504535336562678282505419141012933316116377660817309383
+			want: "41c9e07822b092a93fd6797396338c3ada4170cc81829fdfce6b5d34bd5e7ec7",
+		},
+	}
+	for _, tt := range tests {
+		seed, _ := hex.DecodeString(tt.seed)
+		want, _ := hex.DecodeString(tt.want)
+		got, err := KeyGen(seed, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(got, want) {
+			t.Errorf("KeyGen(%s) = %x, want %x", tt.seed, got, want)
+		}
+	}
+}
+
+func TestKeyGen(t *testing.T) {
+	ikm := bytes.Repeat([]byte{0x42}, 32)
+	a, err := KeyGen(ikm, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := KeyGen(ikm, []byte("second key"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Equal(a, b) {
+		t.Errorf("KeyGen with different key_info returned the same key")
+	}
+	if _, err := parsePrivateKey(a); err != nil {
+		t.Errorf("KeyGen() = %x is not a valid private key", a)
+	}
+
+	if _, err := KeyGen(ikm[:31], nil); err != ErrShortIKM {
+		t.Errorf("KeyGen(31 bytes) error = %v, want %v", err, ErrShortIKM)
+	}
+}
+
+// RFC 5869 A.1 の HKDF-SHA256 のテストベクター
+func TestHKDF(t *testing.T) {
+	ikm, _ := hex.DecodeString("0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b")
+	salt, _ := hex.DecodeString("000102030405060708090a0b0c")
+	info, _ := hex.DecodeString("f0f1f2f3f4f5f6f7f8f9")
+	wantPRK, _ := hex.DecodeString("077709362c2e32df0ddc3f0dc47bba6390b6c73bb50f9c3122ec844ad7c2b3e5")
+	wantOKM, _ := hex.DecodeString("3cb25f25faacd57a90434f64d0362f2a2d2d0a90cf1a5a4c5db02d56ecc4c5bf34007208d5b887185865")
+
+	prk := hkdfExtract(salt, ikm)
+	if !bytes.Equal(prk, wantPRK) {
+		t.Errorf("hkdfExtract() = %x, want %x", prk, wantPRK)
+	}
+	if okm := hkdfExpand(prk, info, 42); !bytes.Equal(okm, wantOKM) {
+		t.Errorf("hkdfExpand() = %x, want %x", okm, wantOKM)
+	}
+}
@@ -0,0 +1,57 @@
+package bls
+
+// PopProve は秘密鍵 sk を持っていることの証明を返します
+// 証明は公開鍵そのものへの署名で、メッセージの署名とは別の DST で公開鍵を群に写します
+// proof of possession の方式でないときはpanicします
+func (s *Scheme) PopProve(sk []byte) ([]byte, error) {
+	s.checkPop()
+	x, err := parsePrivateKey(sk)
+	if err != nil {
+		return nil, err
+	}
+	pk := s.pkGenerator().mul(x).bytes()
+	return s.hashToSig(pk, s.popDST).mul(x).bytes(), nil
+}
+
+// PopVerify は proof が公開鍵 pk の持ち主による証明かどうかを判定します
+// 証明を確かめた公開鍵だけを FastAggregateVerify に渡すことで rogue key 攻撃を防げます
+// proof of possession の方式でないときはpanicします
+func (s *Scheme) PopVerify(pk, proof []byte) bool {
+	s.checkPop()
+	p, ok := s.parsePublicKey(pk)
+	if !ok {
+		return false
+	}
+	return s.coreAggregateVerify([]*element{p}, [][]byte{pk}, proof, s.popDST)
+}
+
+// FastAggregateVerify は sig が公開鍵 pks の全員による同じメッセージ msg の署名をまとめたものかどうかを判定します
+// 公開鍵を足し合わせてから検証するので、ペアリングは公開鍵の数によらず2つで済みます
+// pks はどれも PopVerify で証明を確かめてある必要があります
+// proof of possession の方式でないときはpanicします
+func (s *Scheme) FastAggregateVerify(pks [][]byte, msg, sig []byte) bool {
+	s.checkPop()
+	if len(pks) == 0 {
+		return false
+	}
+	var agg *element
+	for _, b := range pks {
+		p, ok := s.parsePublicKey(b)
+		if !ok {
+			return false
+		}
+		if agg == nil {
+			agg = p
+		} else {
+			agg = agg.add(p)
+		}
+	}
+	return s.coreAggregateVerify([]*element{agg}, [][]byte{msg}, sig, s.dst)
+}
+
+// checkPop は s が proof of possession の方式であることを確かめ、そうでなければpanicする
+func (s *Scheme) checkPop() {
+	if s.mode != pop {
+		panic("bls: proof of possession is not supported by the scheme")
+	}
+}
@@ -0,0 +1,60 @@
+package bls
+
+import (
+	"testing"
+)
+
+func TestPop(t *testing.T) {
+	for _, tt := range schemes[4:] {
+		t.Run(tt.name, func(t *testing.T) {
+			s := tt.scheme
+			sk, pk := testKey(t, s, 1)
+			proof, err := s.PopProve(sk)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !s.PopVerify(pk, proof) {
+				t.Errorf("PopVerify() = false, want true")
+			}
+		})
+	}
+}
+
+// rogue key 攻撃: pk' = [a]g - pk1 を公開すると、pk1 + pk' = [a]g になり
+// pk1 の持ち主が署名していなくても [a]H(m) が2人の署名として FastAggregateVerify を通ってしまう
+// pk' の秘密鍵は誰も知らないので、PopProve の証明を作れず PopVerify で弾かれる
+func TestFastAggregateVerify_rogueKey(t *testing.T) {
+	s := MinPkPop()
+	_, pk1 := testKey(t, s, 1)
+	a, pkA := testKey(t, s, 2)
+	p1, _ := s.parsePublicKey(pk1)
+	pA, _ := s.parsePublicKey(pkA)
+	rogue := pA.add(p1.neg()).bytes()
+
+	msg := []byte("pay 100 to the attacker")
+	forged, err := s.Sign(a, msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !s.FastAggregateVerify([][]byte{pk1, rogue}, msg, forged) {
+		t.Fatalf("rogue key forgery is rejected without proof of possession")
+	}
+
+	// 攻撃者は自分の鍵 a の証明しか作れない
+	proof, err := s.PopProve(a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.PopVerify(rogue, proof) {
+		t.Errorf("PopVerify(rogue key) = true, want false")
+	}
+}
+
+func TestPop_unsupported(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("FastAggregateVerify with MinPkBasic did not panic")
+		}
+	}()
+	MinPkBasic().FastAggregateVerify(nil, nil, nil)
+}
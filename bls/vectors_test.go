@@ -0,0 +1,199 @@
+package bls
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+)
+
+// minPkPopVectors は MinPkPop 方式の sign, verify, aggregate などのケースを input/output の形で並べたもの
+// バイト列は 0x 付きの16進数で書かれている
+type minPkPopVectors struct {
+	Sign []struct {
+		Input struct {
+			Privkey hexBytes `json:"privkey"`
+			Message hexBytes `json:"message"`
+		} `json:"input"`
+		Output *hexBytes `json:"output"`
+	} `json:"sign"`
+	Verify []struct {
+		Input struct {
+			Pubkey    hexBytes `json:"pubkey"`
+			Message   hexBytes `json:"message"`
+			Signature hexBytes `json:"signature"`
+		} `json:"input"`
+		Output bool `json:"output"`
+	} `json:"verify"`
+	Aggregate []struct {
+		Input  []hexBytes `json:"input"`
+		Output *hexBytes  `json:"output"`
+	} `json:"aggregate"`
+	FastAggregateVerify []struct {
+		Input struct {
+			Pubkeys   []hexBytes `json:"pubkeys"`
+			Message   hexBytes   `json:"message"`
+			Signature hexBytes   `json:"signature"`
+		} `json:"input"`
+		Output bool `json:"output"`
+	} `json:"fast_aggregate_verify"`
+	AggregateVerify []struct {
+		Input struct {
+			Pubkeys   []hexBytes `json:"pubkeys"`
+			Messages  []hexBytes `json:"messages"`
+			Signature hexBytes   `json:"signature"`
+		} `json:"input"`
+		Output bool `json:"output"`
+	} `json:"aggregate_verify"`
+}
+
+// hexBytes は 0x 付きの16進数で書かれたバイト列
+type hexBytes []byte
+
+func (b *hexBytes) UnmarshalText(text []byte) error {
+	v, err := hex.DecodeString(strings.TrimPrefix(string(text), "0x"))
+	if err != nil {
+		return err
+	}
+	*b = v
+	return nil
+}
+
+func readMinPkPopVectors(t *testing.T) *minPkPopVectors {
+	t.Helper()
+	data, err := os.ReadFile("testdata/min_pk_pop.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var v minPkPopVectors
+	if err := json.Unmarshal(data, &v); err != nil {
+		t.Fatal(err)
+	}
+	return &v
+}
+
+func TestMinPkPop_sign(t *testing.T) {
+	s := MinPkPop()
+	for _, tt := range readMinPkPopVectors(t).Sign {
+		got, err := s.Sign(tt.Input.Privkey, tt.Input.Message)
+		if tt.Output == nil {
+			if err == nil {
+				t.Errorf("Sign(%x) error = nil, want error", tt.Input.Privkey)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(got, *tt.Output) {
+			t.Errorf("Sign(%x, %x) = %x, want %x", tt.Input.Privkey, tt.Input.Message, got, *tt.Output)
+		}
+	}
+}
+
+func TestMinPkPop_verify(t *testing.T) {
+	s := MinPkPop()
+	for _, tt := range readMinPkPopVectors(t).Verify {
+		if got := s.Verify(tt.Input.Pubkey, tt.Input.Message, tt.Input.Signature); got != tt.Output {
+			t.Errorf("Verify(%x, %x, %x) = %v, want %v", tt.Input.Pubkey, tt.Input.Message, tt.Input.Signature, got, tt.Output)
+		}
+	}
+}
+
+func TestMinPkPop_aggregate(t *testing.T) {
+	s := MinPkPop()
+	for _, tt := range readMinPkPopVectors(t).Aggregate {
+		sigs := make([][]byte, len(tt.Input))
+		for i := range tt.Input {
+			sigs[i] = tt.Input[i]
+		}
+		got, err := s.Aggregate(sigs)
+		if tt.Output == nil {
+			if err == nil {
+				t.Errorf("Aggregate(%x) error = nil, want error", sigs)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(got, *tt.Output) {
+			t.Errorf("Aggregate(%x) = %x, want %x", sigs, got, *tt.Output)
+		}
+	}
+}
+
+func TestMinPkPop_fastAggregateVerify(t *testing.T) {
+	s := MinPkPop()
+	for i, tt := range readMinPkPopVectors(t).FastAggregateVerify {
+		pks := make([][]byte, len(tt.Input.Pubkeys))
+		for j := range pks {
+			pks[j] = tt.Input.Pubkeys[j]
+		}
+		if got := s.FastAggregateVerify(pks, tt.Input.Message, tt.Input.Signature); got != tt.Output {
+			t.Errorf("case %d: FastAggregateVerify() = %v, want %v", i, got, tt.Output)
+		}
+	}
+}
+
+func TestMinPkPop_aggregateVerify(t *testing.T) {
+	s := MinPkPop()
+	for i, tt := range readMinPkPopVectors(t).AggregateVerify {
+		pks := make([][]byte, len(tt.Input.Pubkeys))
+		msgs := make([][]byte, len(tt.Input.Messages))
+		for j := range pks {
+			pks[j] = tt.Input.Pubkeys[j]
+		}
+		for j := range msgs {
+			msgs[j] = tt.Input.Messages[j]
+		}
+		if got := s.AggregateVerify(pks, msgs, tt.Input.Signature); got != tt.Output {
+			t.Errorf("case %d: AggregateVerify() = %v, want %v", i, got, tt.Output)
+		}
+	}
+}
+
+// 参照実装のテストベクターは1行に msg, ikm, 署名が並ぶ basic の方式のもの
+// 鍵の導出は draft-03 までの KeyGen で、最初の salt をハッシュせずに HKDF に渡す
+// 署名の計算に時間がかかるので、先頭の一部だけを使う
+const referenceVectors = 3
+
+func TestReferenceVectors(t *testing.T) {
+	tests := []struct {
+		file   string
+		scheme *Scheme
+	}{
+		{file: "sig_g2_basic_P256.txt", scheme: MinPkBasic()},
+		{file: "sig_g1_basic_P256.txt", scheme: MinSigBasic()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			f, err := os.Open("testdata/" + tt.file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+			sc := bufio.NewScanner(f)
+			for n := 0; n < referenceVectors && sc.Scan(); n++ {
+				fields := strings.Fields(sc.Text())
+				msg, _ := hex.DecodeString(fields[0])
+				ikm, _ := hex.DecodeString(fields[1])
+				want, _ := hex.DecodeString(fields[2])
+				sk := deriveKey(ikm, keyGenSalt, nil)
+				got, err := tt.scheme.Sign(sk, msg)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !bytes.Equal(got, want) {
+					t.Errorf("line %d: Sign() = %x, want %x", n+1, got, want)
+				}
+			}
+			if err := sc.Err(); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
@@ -0,0 +1,113 @@
+// Package dh は有限体上の Diffie-Hellman 鍵共有を提供します
+// RFC 3526 の MODP グループと RFC 7919 の ffdhe グループを組み込みで持ち、安全素数による独自の群も生成できます
+package dh
+
+import (
+	"errors"
+	"io"
+
+	"github.com/convto/mycrypto/big"
+)
+
+var (
+	// ErrInvalidPublicValue は相手の公開値が 1 < y < p-1 の範囲にないか、位数 q の部分群に含まれないことを表します
+	ErrInvalidPublicValue = errors.New("dh: invalid public value")
+	// ErrInvalidExponentSize は秘密の指数のビット長が群の位数に対して大きすぎることを表します
+	ErrInvalidExponentSize = errors.New("dh: invalid exponent size")
+)
+
+// Group は素数 p を法とする乗法群の、位数 q の部分群とその生成元 g です
+type Group struct {
+	P *big.Int
+	G *big.Int
+	// Q は G の位数の素数で、安全素数の群では (P-1)/2 です
+	Q *big.Int
+}
+
+// PublicKey は公開値 y = g^x mod p です
+type PublicKey struct {
+	Group *Group
+	Y     *big.Int
+}
+
+// PrivateKey は秘密の指数 x と対応する公開値です
+type PrivateKey struct {
+	PublicKey
+	X *big.Int
+}
+
+// GenerateParameters は r から読み込んだ乱数で、ビット長が bits の安全素数 p = 2q + 1 による群を生成します
+// 生成元には位数 q の部分群に含まれる (平方剰余である) 2 か 4 を使います
+func GenerateParameters(r io.Reader, bits int) (*Group, error) {
+	p, err := big.SafePrime(r, bits)
+	if err != nil {
+		return nil, err
+	}
+	q, _ := big.Div(big.Sub(p, big.NewInt(1)), big.NewInt(2))
+	// 安全素数 p (> 7) は p = 3 mod 4 で、2 が平方剰余になるのは p = 7 mod 8 のとき
+	g := big.NewInt(2)
+	if big.Jacobi(g, p) != 1 {
+		g = big.NewInt(4)
+	}
+	return &Group{P: p, G: g, Q: q}, nil
+}
+
+// GenerateKey は r から読み込んだ乱数で秘密の指数 x を選び、鍵の組を返します
+// bits が正なら x は 2 <= x < 2^bits の範囲から選び、0 なら 1 <= x < q の範囲から選びます
+// 短い指数は鍵の生成と共有が速くなりますが、RFC 7919 5.2 にあるように群の強度の2倍以上のビット長が必要です
+// (たとえば ffdhe2048 なら 225 ビット以上)
+func GenerateKey(r io.Reader, grp *Group, bits int) (*PrivateKey, error) {
+	if bits < 0 || bits >= grp.Q.BitLen() {
+		return nil, ErrInvalidExponentSize
+	}
+	var x *big.Int
+	for {
+		var err error
+		if bits == 0 {
+			x, err = big.RandNonZeroInt(r, grp.Q)
+		} else {
+			x, err = big.RandNonZeroInt(r, big.Exp(big.NewInt(2), big.NewInt(int64(bits)), nil))
+		}
+		if err != nil {
+			return nil, err
+		}
+		if bits == 0 || big.Cmp(x, big.NewInt(1)) > 0 {
+			break
+		}
+	}
+	return &PrivateKey{
+		PublicKey: PublicKey{Group: grp, Y: big.Exp(grp.G, x, grp.P)},
+		X:         x,
+	}, nil
+}
+
+// Validate は公開値 y が 1 < y < p-1 の範囲にあり、y^q = 1 mod p を満たすかを確かめます (NIST SP 800-56A 5.6.2.3.1)
+// 安全素数の群では y^q = 1 は y が平方剰余であることと同値なので、累乗の代わりにヤコビ記号で判定します
+func (grp *Group) Validate(y *big.Int) error {
+	one := big.NewInt(1)
+	pm1 := big.Sub(grp.P, one)
+	if big.Cmp(y, one) <= 0 || big.Cmp(y, pm1) >= 0 {
+		return ErrInvalidPublicValue
+	}
+	if big.Cmp(big.Add(big.Mul(grp.Q, big.NewInt(2)), one), grp.P) == 0 {
+		if big.Jacobi(y, grp.P) != 1 {
+			return ErrInvalidPublicValue
+		}
+		return nil
+	}
+	if big.Cmp(big.Exp(y, grp.Q, grp.P), one) != 0 {
+		return ErrInvalidPublicValue
+	}
+	return nil
+}
+
+// SharedSecret は相手の公開値 y を検証してから共有秘密 z = y^x mod p を計算し、p と同じバイト長になるよう上位を0で埋めて返します
+// RFC 7919 5.1 にあるように、上位の0を取り除かずにそのまま鍵導出に使います
+func (priv *PrivateKey) SharedSecret(y *big.Int) ([]byte, error) {
+	grp := priv.Group
+	if err := grp.Validate(y); err != nil {
+		return nil, err
+	}
+	z := big.Exp(y, priv.X, grp.P)
+	return z.FillBytes(make([]byte, (grp.P.BitLen()+7)/8)), nil
+}
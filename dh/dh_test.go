@@ -0,0 +1,152 @@
+package dh
+
+import (
+	"bytes"
+	"crypto/rand"
+	"errors"
+	stdbig "math/big"
+	"testing"
+
+	"github.com/convto/mycrypto/big"
+)
+
+func TestSharedSecret(t *testing.T) {
+	tests := []struct {
+		name string
+		grp  *Group
+		bits int
+	}{
+		{name: "MODP2048", grp: MODP2048(), bits: 256},
+		{name: "FFDHE2048", grp: FFDHE2048(), bits: 225},
+		{name: "FFDHE3072", grp: FFDHE3072(), bits: 64},
+		{name: "MODP8192", grp: MODP8192(), bits: 32},
+		{name: "full size exponent", grp: testGroup(t), bits: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			alice, err := GenerateKey(rand.Reader, tt.grp, tt.bits)
+			if err != nil {
+				t.Fatalf("GenerateKey() error = %v", err)
+			}
+			bob, err := GenerateKey(rand.Reader, tt.grp, tt.bits)
+			if err != nil {
+				t.Fatalf("GenerateKey() error = %v", err)
+			}
+			if tt.bits > 0 && alice.X.BitLen() > tt.bits {
+				t.Errorf("X.BitLen() = %v, want <= %v", alice.X.BitLen(), tt.bits)
+			}
+			za, err := alice.SharedSecret(bob.Y)
+			if err != nil {
+				t.Fatalf("SharedSecret() error = %v", err)
+			}
+			zb, err := bob.SharedSecret(alice.Y)
+			if err != nil {
+				t.Fatalf("SharedSecret() error = %v", err)
+			}
+			if !bytes.Equal(za, zb) {
+				t.Errorf("shared secrets differ: %x, %x", za, zb)
+			}
+			if want := (tt.grp.P.BitLen() + 7) / 8; len(za) != want {
+				t.Errorf("len(SharedSecret()) = %v, want %v", len(za), want)
+			}
+			// 標準ライブラリで g^(xa*xb) mod p を計算して比べる
+			sp := new(stdbig.Int).SetBytes(tt.grp.P.Bytes())
+			e := new(stdbig.Int).Mul(new(stdbig.Int).SetBytes(alice.X.Bytes()), new(stdbig.Int).SetBytes(bob.X.Bytes()))
+			want := new(stdbig.Int).Exp(new(stdbig.Int).SetBytes(tt.grp.G.Bytes()), e, sp)
+			if want.Cmp(new(stdbig.Int).SetBytes(za)) != 0 {
+				t.Errorf("SharedSecret() = %x, want %x", za, want)
+			}
+		})
+	}
+}
+
+func TestGroup_Validate(t *testing.T) {
+	grp := FFDHE2048()
+	one := big.NewInt(1)
+	pm1 := big.Sub(grp.P, one)
+	tests := []struct {
+		name string
+		y    *big.Int
+		want error
+	}{
+		{name: "g", y: grp.G, want: nil},
+		{name: "g^2", y: big.Exp(grp.G, big.NewInt(2), grp.P), want: nil},
+		{name: "0", y: big.NewInt(0), want: ErrInvalidPublicValue},
+		{name: "1", y: one, want: ErrInvalidPublicValue},
+		{name: "p - 1", y: pm1, want: ErrInvalidPublicValue},
+		{name: "p", y: grp.P, want: ErrInvalidPublicValue},
+		{name: "negative", y: big.NewInt(-2), want: ErrInvalidPublicValue},
+		// p = 2q + 1 で -g は平方非剰余なので位数が 2q になる
+		{name: "not in subgroup", y: big.Sub(grp.P, grp.G), want: ErrInvalidPublicValue},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := grp.Validate(tt.y); !errors.Is(err, tt.want) {
+				t.Errorf("Validate() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestGroup_Validate_notSafePrime(t *testing.T) {
+	// 安全素数でない群では y^q = 1 mod p で判定する
+	// p = 31 で 2 が生成する位数 q = 5 の部分群 {1, 2, 4, 8, 16} で確かめる
+	grp := &Group{P: big.NewInt(31), G: big.NewInt(2), Q: big.NewInt(5)}
+	for y := int64(2); y < 30; y++ {
+		want := y == 2 || y == 4 || y == 8 || y == 16
+		if got := grp.Validate(big.NewInt(y)) == nil; got != want {
+			t.Errorf("Validate(%d) = %v, want %v", y, got, want)
+		}
+	}
+}
+
+func TestSharedSecret_invalid(t *testing.T) {
+	grp := MODP2048()
+	priv, err := GenerateKey(rand.Reader, grp, 64)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := priv.SharedSecret(big.NewInt(1)); !errors.Is(err, ErrInvalidPublicValue) {
+		t.Errorf("SharedSecret() error = %v, want %v", err, ErrInvalidPublicValue)
+	}
+}
+
+func TestGenerateKey_invalid(t *testing.T) {
+	grp := MODP2048()
+	for _, bits := range []int{-1, 2047, 4096} {
+		if _, err := GenerateKey(rand.Reader, grp, bits); !errors.Is(err, ErrInvalidExponentSize) {
+			t.Errorf("GenerateKey(%d) error = %v, want %v", bits, err, ErrInvalidExponentSize)
+		}
+	}
+}
+
+func TestGenerateParameters(t *testing.T) {
+	for _, bits := range []int{64, 128, 256} {
+		grp, err := GenerateParameters(rand.Reader, bits)
+		if err != nil {
+			t.Fatalf("GenerateParameters(%d) error = %v", bits, err)
+		}
+		if got := grp.P.BitLen(); got != bits {
+			t.Errorf("P.BitLen() = %v, want %v", got, bits)
+		}
+		sp := new(stdbig.Int).SetBytes(grp.P.Bytes())
+		sq := new(stdbig.Int).SetBytes(grp.Q.Bytes())
+		if !sp.ProbablyPrime(20) || !sq.ProbablyPrime(20) || new(stdbig.Int).Rsh(sp, 1).Cmp(sq) != 0 {
+			t.Errorf("P = %v is not a safe prime with Q = %v", grp.P, grp.Q)
+		}
+		// g の位数は q
+		sg := new(stdbig.Int).SetBytes(grp.G.Bytes())
+		if new(stdbig.Int).Exp(sg, sq, sp).Cmp(stdbig.NewInt(1)) != 0 {
+			t.Errorf("G = %v is not in the subgroup of order Q", grp.G)
+		}
+	}
+}
+
+// testGroup は秘密の指数を q の範囲全体から選ぶテストのための小さな群を生成する
+func testGroup(t *testing.T) *Group {
+	grp, err := GenerateParameters(rand.Reader, 128)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return grp
+}
@@ -0,0 +1,70 @@
+package dh
+
+import (
+	stdbig "math/big"
+	"testing"
+
+	"github.com/convto/mycrypto/big"
+)
+
+func TestGroups(t *testing.T) {
+	tests := []struct {
+		name string
+		grp  *Group
+		bits int
+		// 先頭と末尾の64ビットはいずれも1で、その内側の下位64ビットを確かめる
+		low string
+	}{
+		{name: "MODP2048", grp: MODP2048(), bits: 2048, low: "15728E5A8AACAA68"},
+		{name: "MODP3072", grp: MODP3072(), bits: 3072, low: "4B82D120A93AD2CA"},
+		{name: "MODP4096", grp: MODP4096(), bits: 4096, low: "4DF435C934063199"},
+		{name: "MODP6144", grp: MODP6144(), bits: 6144, low: "E694F91E6DCC4024"},
+		{name: "MODP8192", grp: MODP8192(), bits: 8192, low: "60C980DD98EDD3DF"},
+		{name: "FFDHE2048", grp: FFDHE2048(), bits: 2048, low: "886B423861285C97"},
+		{name: "FFDHE3072", grp: FFDHE3072(), bits: 3072, low: "25E41D2B66C62E37"},
+		{name: "FFDHE4096", grp: FFDHE4096(), bits: 4096, low: "C68A007E5E655F6A"},
+		{name: "FFDHE6144", grp: FFDHE6144(), bits: 6144, low: "A40E329CD0E40E65"},
+		{name: "FFDHE8192", grp: FFDHE8192(), bits: 8192, low: "D68C8BB7C5C6424C"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := tt.grp.P
+			if got := p.BitLen(); got != tt.bits {
+				t.Errorf("P.BitLen() = %v, want %v", got, tt.bits)
+			}
+			b := p.Bytes()
+			for i := 0; i < 8; i++ {
+				if b[i] != 0xff || b[len(b)-1-i] != 0xff {
+					t.Fatalf("P = %x does not start and end with 64 one bits", b)
+				}
+			}
+			low := new(big.Int).SetBytes(b[len(b)-16 : len(b)-8])
+			if want := new(big.Int).SetString("0x" + tt.low); big.Cmp(low, want) != 0 {
+				t.Errorf("low bits of P = %x, want %s", low.Bytes(), tt.low)
+			}
+			// p と q = (p-1)/2 がともに素数であることを標準ライブラリで確かめる
+			sp := new(stdbig.Int).SetBytes(b)
+			sq := new(stdbig.Int).SetBytes(tt.grp.Q.Bytes())
+			if sq.Lsh(sq, 1).Add(sq, stdbig.NewInt(1)).Cmp(sp) != 0 {
+				t.Errorf("Q != (P-1)/2")
+			}
+			if !sp.ProbablyPrime(0) || !new(stdbig.Int).SetBytes(tt.grp.Q.Bytes()).ProbablyPrime(0) {
+				t.Errorf("P is not a safe prime")
+			}
+			if err := tt.grp.Validate(tt.grp.G); err != nil {
+				t.Errorf("Validate(G) error = %v", err)
+			}
+		})
+	}
+}
+
+func TestGroups_copy(t *testing.T) {
+	g := MODP2048()
+	g.P.SetString("23")
+	g.G.SetString("5")
+	g.Q.SetString("11")
+	got := MODP2048()
+	if got.P.BitLen() != 2048 || big.Cmp(got.G, big.NewInt(2)) != 0 || got.Q.BitLen() != 2047 {
+		t.Errorf("MODP2048() = %+v after modifying a previous result", got)
+	}
+}
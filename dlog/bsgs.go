@@ -0,0 +1,76 @@
+package dlog
+
+import "github.com/convto/mycrypto/big"
+
+// BabyStepGiantStep は baby-step giant-step 法で g^x = h を満たす 0 <= x < n を求めます
+// n は g の位数かその倍数を指定します
+// 時間、メモリともに O(sqrt(n)) 必要なので、小さい位数の群に対して使います
+func BabyStepGiantStep(grp Group, g, h Element, n *big.Int) (*big.Int, error) {
+	t, err := NewBSGSTable(grp, g, n, n)
+	if err != nil {
+		return nil, err
+	}
+	return t.Log(h)
+}
+
+// BSGSTable は baby-step giant-step 法の baby step を記録した表です
+// 表は群と g と範囲だけで決まるので、同じ g についての探索に何度でも使えます
+// 作成に O(sqrt(n)) の時間とメモリ、1回の探索に O(sqrt(n)) の時間がかかります
+type BSGSTable struct {
+	grp   Group
+	n     *big.Int
+	m     *big.Int
+	baby  map[string]*big.Int
+	giant Element
+}
+
+// NewBSGSTable は g^x = h を満たす 0 <= x < n を求める表を作ります
+// order は g の位数かその倍数で、giant step の g^(-m) を g^(order-m) として求めるのに使います
+// n か order が正でないときは ErrInvalidOrder を返します
+func NewBSGSTable(grp Group, g Element, n, order *big.Int) (*BSGSTable, error) {
+	if big.Cmp(n, big.Zero) <= 0 || big.Cmp(order, big.Zero) <= 0 {
+		return nil, ErrInvalidOrder
+	}
+	// m = ceil(sqrt(n))
+	m := big.Sqrt(n)
+	if big.Cmp(big.Mul(m, m), n) < 0 {
+		m = big.Add(m, big.NewInt(1))
+	}
+
+	// baby step: g^j (0 <= j < m) を記録しておく
+	baby := make(map[string]*big.Int)
+	e := grp.Identity()
+	one := big.NewInt(1)
+	for j := big.NewInt(0); big.Cmp(j, m) < 0; j = big.Add(j, one) {
+		if _, ok := baby[e.String()]; !ok {
+			baby[e.String()] = j
+		}
+		e = grp.Op(e, g)
+	}
+	return &BSGSTable{
+		grp:   grp,
+		n:     n,
+		m:     m,
+		baby:  baby,
+		giant: grp.Exp(g, big.Mod(big.Sub(order, m), order)),
+	}, nil
+}
+
+// Log は g^x = h を満たす 0 <= x < n を求めます
+// 範囲内に解がないときは ErrNotFound を返します
+func (t *BSGSTable) Log(h Element) (*big.Int, error) {
+	// giant step: h * g^(-m*i) が baby step に含まれていれば x = i*m + j
+	// i の小さい順に探すので最初に見つかった x が最小の解になる
+	gamma := h
+	one := big.NewInt(1)
+	for i := big.NewInt(0); big.Cmp(i, t.m) < 0; i = big.Add(i, one) {
+		if j, ok := t.baby[gamma.String()]; ok {
+			if x := big.Add(big.Mul(i, t.m), j); big.Cmp(x, t.n) < 0 {
+				return x, nil
+			}
+			return nil, ErrNotFound
+		}
+		gamma = t.grp.Op(gamma, t.giant)
+	}
+	return nil, ErrNotFound
+}
@@ -0,0 +1,112 @@
+package dlog
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/convto/mycrypto/big"
+)
+
+func TestBabyStepGiantStep(t *testing.T) {
+	type args struct {
+		grp Group
+		g   Element
+		h   Element
+		n   *big.Int
+	}
+	tests := []struct {
+		name string
+		args args
+		want *big.Int
+	}{
+		{
+			name: "Z_1019*",
+			args: args{
+				grp: NewZpStar(big.NewInt(1019)),
+				g:   big.NewInt(2),
+				h:   big.NewInt(550),
+				n:   big.NewInt(1018),
+			},
+			want: big.NewInt(777),
+		},
+		{
+			name: "h = 1",
+			args: args{
+				grp: NewZpStar(big.NewInt(1019)),
+				g:   big.NewInt(2),
+				h:   big.NewInt(1),
+				n:   big.NewInt(1018),
+			},
+			want: big.NewInt(0),
+		},
+		{
+			name: "elliptic curve",
+			args: args{
+				grp: testCurve,
+				g:   testCurveG,
+				h:   &toyPoint{x: big.NewInt(8509), y: big.NewInt(1504)},
+				n:   testCurveN,
+			},
+			want: big.NewInt(4321),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := BabyStepGiantStep(tt.args.grp, tt.args.g, tt.args.h, tt.args.n)
+			if err != nil {
+				t.Fatalf("BabyStepGiantStep() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("BabyStepGiantStep() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBabyStepGiantStep_notFound(t *testing.T) {
+	// 生成元 2 は 4 の生成する位数 509 の部分群 (平方剰余) に含まれない
+	_, err := BabyStepGiantStep(NewZpStar(big.NewInt(1019)), big.NewInt(4), big.NewInt(2), big.NewInt(509))
+	if err != ErrNotFound {
+		t.Errorf("BabyStepGiantStep() error = %v, want %v", err, ErrNotFound)
+	}
+}
+
+func TestBabyStepGiantStep_invalidOrder(t *testing.T) {
+	grp := NewZpStar(big.NewInt(1019))
+	for _, n := range []*big.Int{big.NewInt(0), big.NewInt(-1018)} {
+		if _, err := BabyStepGiantStep(grp, big.NewInt(2), big.NewInt(550), n); err != ErrInvalidOrder {
+			t.Errorf("BabyStepGiantStep(n = %v) error = %v, want %v", n, err, ErrInvalidOrder)
+		}
+	}
+}
+
+func TestBSGSTable(t *testing.T) {
+	// 位数 1018 の生成元 2 について、0 <= x < 100 の範囲だけを探す表
+	grp := NewZpStar(big.NewInt(1019))
+	g := big.NewInt(2)
+	tbl, err := NewBSGSTable(grp, g, big.NewInt(100), big.NewInt(1018))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		x       int64
+		wantErr error
+	}{
+		{x: 0},
+		{x: 9},
+		{x: 10},
+		{x: 99},
+		{x: 100, wantErr: ErrNotFound},
+		{x: 777, wantErr: ErrNotFound},
+	}
+	for _, tt := range tests {
+		got, err := tbl.Log(grp.Exp(g, big.NewInt(tt.x)))
+		if err != tt.wantErr {
+			t.Errorf("Log(g^%v) error = %v, want %v", tt.x, err, tt.wantErr)
+			continue
+		}
+		if err == nil && big.Cmp(got, big.NewInt(tt.x)) != 0 {
+			t.Errorf("Log(g^%v) = %v, want %v", tt.x, got, tt.x)
+		}
+	}
+}
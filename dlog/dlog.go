@@ -0,0 +1,93 @@
+// Package dlog は巡回群上の離散対数問題 g^x = h を解くアルゴリズムを提供します
+// 安全素数を使わない DH パラメータがなぜ危険なのかを確かめたり、パラメータの検証処理をテストするために使います
+package dlog
+
+import (
+	"errors"
+
+	"github.com/convto/mycrypto/big"
+)
+
+var (
+	// ErrNotFound は離散対数が見つからなかったことを表します
+	ErrNotFound = errors.New("dlog: logarithm not found")
+	// ErrInvalidOrder は位数や探索する範囲の大きさが正でないことを表します
+	ErrInvalidOrder = errors.New("dlog: invalid order")
+)
+
+var (
+	// bsgsMaxOrder 以下の位数では BabyStepGiantStep を使う
+	// それより大きいと表のメモリが大きくなりすぎるので PollardRho を使う
+	bsgsMaxOrder = big.NewInt(1 << 20)
+	// smoothBound は位数を試し割りするときの素数の上限
+	smoothBound = 1 << 16
+)
+
+// Log は g^x = h を満たす 0 <= x < n を求めます
+// n は g の位数かその倍数を指定します
+// n が小さければ BabyStepGiantStep、n が小さな素因数を持てば PohligHellman、それ以外は PollardRho で解きます
+func Log(grp Group, g, h Element, n *big.Int) (*big.Int, error) {
+	if big.Cmp(n, bsgsMaxOrder) <= 0 {
+		return BabyStepGiantStep(grp, g, h, n)
+	}
+	factors := factorize(n, smoothBound)
+	if len(factors) > 1 || factors[0].E > 1 {
+		return PohligHellman(grp, g, h, factors)
+	}
+	return PollardRho(grp, g, h, n)
+}
+
+// LogModP は g^x = h (mod p) を満たす 0 <= x < p-1 を求めます
+func LogModP(g, h, p *big.Int) (*big.Int, error) {
+	return Log(NewZpStar(p), big.Mod(g, p), big.Mod(h, p), big.Sub(p, big.NewInt(1)))
+}
+
+// LogInterval は g^x = h を満たす a <= x <= b を Kangaroo で求めます
+func LogInterval(grp Group, g, h Element, a, b *big.Int) (*big.Int, error) {
+	return Kangaroo(grp, g, h, a, b)
+}
+
+// factorize は n を bound 未満の素数で試し割りして素因数分解する
+// 割り切れずに残った部分は素数かどうかにかかわらず1つの因数として最後に加える
+func factorize(n *big.Int, bound int) []Factor {
+	var factors []Factor
+	one := big.NewInt(1)
+	for _, p := range primes(bound) {
+		bp := big.NewInt(int64(p))
+		if big.Cmp(big.Mul(bp, bp), n) > 0 {
+			break
+		}
+		e := 0
+		for {
+			q, r := big.Div(n, bp)
+			if big.Cmp(r, big.Zero) != 0 {
+				break
+			}
+			n = q
+			e++
+		}
+		if e > 0 {
+			factors = append(factors, Factor{P: bp, E: e})
+		}
+	}
+	if big.Cmp(n, one) > 0 {
+		factors = append(factors, Factor{P: n, E: 1})
+	}
+	return factors
+}
+
+// primes はエラトステネスの篩で n 未満の素数を列挙する
+func primes(n int) []int {
+	composite := make([]bool, n)
+	var ps []int
+	for i := 2; i < n; i++ {
+		if composite[i] {
+			continue
+		}
+		ps = append(ps, i)
+		for j := i * i; j < n; j += i {
+			composite[j] = true
+		}
+	}
+	return ps
+}
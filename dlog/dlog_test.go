@@ -0,0 +1,151 @@
+package dlog
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/convto/mycrypto/big"
+)
+
+func TestLog(t *testing.T) {
+	type args struct {
+		grp Group
+		g   Element
+		h   Element
+		n   *big.Int
+	}
+	tests := []struct {
+		name string
+		args args
+		want *big.Int
+	}{
+		{
+			name: "small order (baby-step giant-step)",
+			args: args{
+				grp: NewZpStar(big.NewInt(1019)),
+				g:   big.NewInt(2),
+				h:   big.NewInt(550),
+				n:   big.NewInt(1018),
+			},
+			want: big.NewInt(777),
+		},
+		{
+			name: "smooth order (Pohlig-Hellman)",
+			args: args{
+				grp: NewZpStar(smoothP),
+				g:   big.NewInt(2),
+				h:   new(big.Int).SetString("173175112373571499751660651939716"),
+				n:   big.Sub(smoothP, big.NewInt(1)),
+			},
+			want: new(big.Int).SetString("123456789012345678901234567"),
+		},
+		{
+			name: "prime order (Pollard rho)",
+			args: args{
+				grp: NewZpStar(big.NewInt(20000159)),
+				g:   big.NewInt(4),
+				h:   big.NewInt(17407327),
+				n:   big.NewInt(10000079),
+			},
+			want: big.NewInt(1234567),
+		},
+		{
+			name: "elliptic curve",
+			args: args{
+				grp: testCurve,
+				g:   testCurveG,
+				h:   &toyPoint{x: big.NewInt(8509), y: big.NewInt(1504)},
+				n:   testCurveN,
+			},
+			want: big.NewInt(4321),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Log(tt.args.grp, tt.args.g, tt.args.h, tt.args.n)
+			if err != nil {
+				t.Fatalf("Log() error = %v", err)
+			}
+			if big.Cmp(got, tt.want) != 0 {
+				t.Errorf("Log() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLogModP(t *testing.T) {
+	type args struct {
+		g *big.Int
+		h *big.Int
+		p *big.Int
+	}
+	tests := []struct {
+		name string
+		args args
+	}{
+		{
+			name: "smooth p - 1",
+			args: args{
+				g: big.NewInt(2),
+				h: new(big.Int).SetString("173175112373571499751660651939716"),
+				p: smoothP,
+			},
+		},
+		{
+			name: "safe prime p = 2q + 1",
+			args: args{
+				g: big.NewInt(4),
+				h: big.NewInt(17407327),
+				p: big.NewInt(20000159),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := LogModP(tt.args.g, tt.args.h, tt.args.p)
+			if err != nil {
+				t.Fatalf("LogModP() error = %v", err)
+			}
+			if h := big.Exp(tt.args.g, got, tt.args.p); big.Cmp(h, tt.args.h) != 0 {
+				t.Errorf("g^LogModP() = %v, want %v", h, tt.args.h)
+			}
+		})
+	}
+}
+
+func Test_factorize(t *testing.T) {
+	type args struct {
+		n     *big.Int
+		bound int
+	}
+	tests := []struct {
+		name string
+		args args
+		want []Factor
+	}{
+		{
+			name: "smooth",
+			args: args{n: big.NewInt(9846), bound: 1000},
+			want: []Factor{
+				{P: big.NewInt(2), E: 1},
+				{P: big.NewInt(3), E: 2},
+				{P: big.NewInt(547), E: 1},
+			},
+		},
+		{
+			name: "large prime factor left",
+			args: args{n: big.NewInt(20000158), bound: 1000},
+			want: []Factor{
+				{P: big.NewInt(2), E: 1},
+				{P: big.NewInt(10000079), E: 1},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := factorize(tt.args.n, tt.args.bound); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("factorize() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package dlog
+
+import "github.com/convto/mycrypto/big"
+
+// Element は群の元を表します
+// 探索中にmapのキーや分割の判定に文字列表現を使うため、同じ元は常に同じ文字列を返さなければなりません
+type Element interface {
+	String() string
+}
+
+// Group は離散対数を求める対象となる巡回群を表します
+// 演算は乗法的に表記しますが、楕円曲線のような加法群も Op を点の加算、Exp をスカラー倍とすれば扱えます
+type Group interface {
+	// Identity は単位元を返します
+	Identity() Element
+	// Op は x と y の群演算の結果を返します
+	Op(x, y Element) Element
+	// Exp は x を k 回演算した結果を返します。呼び出し側は k >= 0 を保証します
+	Exp(x Element, k *big.Int) Element
+	// Equal は x と y が同じ元かどうかを判定します
+	Equal(x, y Element) bool
+}
+
+// ZpStar は素数 p を法とする乗法群 Z_p* です
+type ZpStar struct {
+	P *big.Int
+}
+
+// NewZpStar は p を法とする乗法群を返します
+func NewZpStar(p *big.Int) *ZpStar {
+	return &ZpStar{P: p}
+}
+
+func (g *ZpStar) Identity() Element {
+	return big.NewInt(1)
+}
+
+func (g *ZpStar) Op(x, y Element) Element {
+	return big.Mod(big.Mul(x.(*big.Int), y.(*big.Int)), g.P)
+}
+
+func (g *ZpStar) Exp(x Element, k *big.Int) Element {
+	return big.Exp(x.(*big.Int), k, g.P)
+}
+
+func (g *ZpStar) Equal(x, y Element) bool {
+	return big.Cmp(x.(*big.Int), y.(*big.Int)) == 0
+}
@@ -0,0 +1,80 @@
+package dlog
+
+import (
+	"fmt"
+
+	"github.com/convto/mycrypto/big"
+)
+
+// toyCurve は楕円曲線 y^2 = x^3 + ax + b (mod p) の有理点がなす加法群
+// 同じ解法が楕円曲線上でも使えることを確かめるためのテスト用の実装
+type toyCurve struct {
+	p, a, b *big.Int
+}
+
+// toyPoint は曲線上の点で、inf が true のとき無限遠点を表す
+type toyPoint struct {
+	x, y *big.Int
+	inf  bool
+}
+
+func (pt *toyPoint) String() string {
+	if pt.inf {
+		return "inf"
+	}
+	return fmt.Sprintf("(%s,%s)", pt.x, pt.y)
+}
+
+func (c *toyCurve) Identity() Element {
+	return &toyPoint{inf: true}
+}
+
+func (c *toyCurve) Op(x, y Element) Element {
+	p, q := x.(*toyPoint), y.(*toyPoint)
+	switch {
+	case p.inf:
+		return q
+	case q.inf:
+		return p
+	case big.Cmp(p.x, q.x) == 0 && big.Cmp(big.Mod(big.Add(p.y, q.y), c.p), big.Zero) == 0:
+		return &toyPoint{inf: true}
+	}
+	var l *big.Int
+	if big.Cmp(p.x, q.x) == 0 {
+		// 接線の傾き (3x^2 + a) / 2y
+		num := big.Add(big.Mul(big.NewInt(3), big.Mul(p.x, p.x)), c.a)
+		l = big.Mul(num, big.ModInverse(big.Mul(big.NewInt(2), p.y), c.p))
+	} else {
+		// 2点を通る直線の傾き (y2 - y1) / (x2 - x1)
+		l = big.Mul(big.Sub(q.y, p.y), big.ModInverse(big.Sub(q.x, p.x), c.p))
+	}
+	x3 := big.Mod(big.Sub(big.Sub(big.Mul(l, l), p.x), q.x), c.p)
+	y3 := big.Mod(big.Sub(big.Mul(l, big.Sub(p.x, x3)), p.y), c.p)
+	return &toyPoint{x: x3, y: y3}
+}
+
+func (c *toyCurve) Exp(x Element, k *big.Int) Element {
+	// k を2で割りながら double-and-add でスカラー倍を求める
+	r, d := c.Identity(), x
+	two := big.NewInt(2)
+	for big.Cmp(k, big.Zero) > 0 {
+		var bit *big.Int
+		k, bit = big.Div(k, two)
+		if big.Cmp(bit, big.Zero) != 0 {
+			r = c.Op(r, d)
+		}
+		d = c.Op(d, d)
+	}
+	return r
+}
+
+func (c *toyCurve) Equal(x, y Element) bool {
+	return x.String() == y.String()
+}
+
+// y^2 = x^3 + 2x + 3 (mod 10007) は位数 9846 = 2 * 3^2 * 547 の巡回群で、(4, 2622) はその生成元
+var (
+	testCurve  = &toyCurve{p: big.NewInt(10007), a: big.NewInt(2), b: big.NewInt(3)}
+	testCurveG = &toyPoint{x: big.NewInt(4), y: big.NewInt(2622)}
+	testCurveN = big.NewInt(9846)
+)
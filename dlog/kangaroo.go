@@ -0,0 +1,59 @@
+package dlog
+
+import "github.com/convto/mycrypto/big"
+
+// kangarooMaxAttempts は kangaroo 法で解が見つからなかったときに跳躍の規則を変えて再試行する回数の上限
+const kangarooMaxAttempts = 8
+
+// Kangaroo は Pollard の kangaroo (lambda) 法で g^x = h を満たす a <= x <= b を求めます
+// 計算量は区間の幅 w = b - a に対して O(sqrt(w)) で、群の位数が大きくても解の範囲がわかっているときに使います
+func Kangaroo(grp Group, g, h Element, a, b *big.Int) (*big.Int, error) {
+	w := big.Sub(b, a)
+	if big.Cmp(w, big.Zero) < 0 {
+		return nil, ErrNotFound
+	}
+
+	// 跳躍幅を 2^0, 2^1, ..., 2^(k-1) として、その平均が sqrt(w)/2 程度になるように k を選ぶ
+	mean, _ := big.Div(big.Sqrt(w), big.NewInt(2))
+	two := big.NewInt(2)
+	jumps := []*big.Int{big.NewInt(1)}
+	for sum := big.NewInt(1); big.Cmp(sum, big.Mul(mean, big.NewInt(int64(len(jumps))))) < 0; {
+		next := big.Mul(jumps[len(jumps)-1], two)
+		jumps = append(jumps, next)
+		sum = big.Add(sum, next)
+	}
+	steps := make([]Element, len(jumps))
+	for i, s := range jumps {
+		steps[i] = grp.Exp(g, s)
+	}
+
+	for salt := byte(0); salt < kangarooMaxAttempts; salt++ {
+		jump := func(e Element) (Element, *big.Int) {
+			i := partition(e, salt, uint64(len(jumps)))
+			return grp.Op(e, steps[i]), jumps[i]
+		}
+
+		// tame kangaroo: g^b から 2*sqrt(w) 回跳んで止まった位置に罠を置く
+		tame, dt := grp.Exp(g, b), big.NewInt(0)
+		n := big.Add(big.Mul(big.Sqrt(w), two), big.NewInt(1))
+		for i := big.NewInt(0); big.Cmp(i, n) < 0; i = big.Add(i, big.NewInt(1)) {
+			var d *big.Int
+			tame, d = jump(tame)
+			dt = big.Add(dt, d)
+		}
+
+		// wild kangaroo: h から跳び始め、罠にかかれば b + dt = x + dw となる
+		// 罠の位置を通り過ぎたら失敗
+		wild, dw := h, big.NewInt(0)
+		limit := big.Add(w, dt)
+		for big.Cmp(dw, limit) <= 0 {
+			if grp.Equal(wild, tame) {
+				return big.Sub(big.Add(b, dt), dw), nil
+			}
+			var d *big.Int
+			wild, d = jump(wild)
+			dw = big.Add(dw, d)
+		}
+	}
+	return nil, ErrNotFound
+}
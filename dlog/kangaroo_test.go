@@ -0,0 +1,56 @@
+package dlog
+
+import (
+	"testing"
+
+	"github.com/convto/mycrypto/big"
+)
+
+func TestKangaroo(t *testing.T) {
+	type args struct {
+		grp Group
+		g   Element
+		h   Element
+		a   *big.Int
+		b   *big.Int
+	}
+	tests := []struct {
+		name string
+		args args
+		want *big.Int
+	}{
+		{
+			name: "x in [500000000000, 500001000000]",
+			args: args{
+				grp: NewZpStar(big.NewInt(2000000000000447)),
+				g:   big.NewInt(4),
+				h:   big.NewInt(1874573824570202),
+				a:   big.NewInt(500000000000),
+				b:   big.NewInt(500001000000),
+			},
+			want: big.NewInt(500000123456),
+		},
+		{
+			name: "elliptic curve",
+			args: args{
+				grp: testCurve,
+				g:   testCurveG,
+				h:   &toyPoint{x: big.NewInt(8509), y: big.NewInt(1504)},
+				a:   big.NewInt(4000),
+				b:   big.NewInt(5000),
+			},
+			want: big.NewInt(4321),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Kangaroo(tt.args.grp, tt.args.g, tt.args.h, tt.args.a, tt.args.b)
+			if err != nil {
+				t.Fatalf("Kangaroo() error = %v", err)
+			}
+			if big.Cmp(got, tt.want) != 0 {
+				t.Errorf("Kangaroo() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
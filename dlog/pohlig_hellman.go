@@ -0,0 +1,74 @@
+package dlog
+
+import "github.com/convto/mycrypto/big"
+
+// Factor は素因数 P とその指数 E の組です
+type Factor struct {
+	P *big.Int
+	E int
+}
+
+// PohligHellman は Pohlig-Hellman 法で g^x = h を満たす 0 <= x < n を求めます
+// n = p1^e1 * p2^e2 * ... は g の位数かその倍数で、factors にはその素因数分解を指定します
+// 計算量は最大の素因数 p に対して O(sqrt(p)) なので、位数が小さい素因数の積 (smooth) になる群では位数が大きくても解けてしまいます
+func PohligHellman(grp Group, g, h Element, factors []Factor) (*big.Int, error) {
+	n := big.NewInt(1)
+	for _, f := range factors {
+		n = big.Mul(n, big.Exp(f.P, big.NewInt(int64(f.E)), nil))
+	}
+
+	// 各素因数のべき p^e について x mod p^e を求め、中国剰余定理で x mod n を復元する
+	x, m := big.NewInt(0), big.NewInt(1)
+	for _, f := range factors {
+		pe := big.Exp(f.P, big.NewInt(int64(f.E)), nil)
+		cofactor, _ := big.Div(n, pe)
+		xi, err := primePowerLog(grp, grp.Exp(g, cofactor), grp.Exp(h, cofactor), f)
+		if err != nil {
+			return nil, err
+		}
+		x, m = crt(x, m, xi, pe)
+	}
+	return x, nil
+}
+
+// primePowerLog は位数が p^e を割り切る g について g^x = h を満たす 0 <= x < p^e を求める
+// x = x0 + x1*p + ... + x_(e-1)*p^(e-1) として、位数 p の部分群での離散対数を1桁ずつ解く
+func primePowerLog(grp Group, g, h Element, f Factor) (*big.Int, error) {
+	pe := big.Exp(f.P, big.NewInt(int64(f.E)), nil)
+	// gamma = g^(p^(e-1)) は位数 p (または1) の元
+	gamma := grp.Exp(g, big.Exp(f.P, big.NewInt(int64(f.E-1)), nil))
+	x := big.NewInt(0)
+	pk := big.NewInt(1)
+	for k := 0; k < f.E; k++ {
+		// hk = (g^(-x) * h)^(p^(e-1-k)) は gamma^xk と一致する
+		gx := grp.Exp(g, big.Mod(big.Sub(pe, x), pe))
+		hk := grp.Exp(grp.Op(gx, h), big.Exp(f.P, big.NewInt(int64(f.E-1-k)), nil))
+		xk, err := primeOrderLog(grp, gamma, hk, f.P)
+		if err != nil {
+			return nil, err
+		}
+		x = big.Add(x, big.Mul(xk, pk))
+		pk = big.Mul(pk, f.P)
+	}
+	return x, nil
+}
+
+// primeOrderLog は位数 p の群での離散対数を p の大きさに応じた方法で求める
+func primeOrderLog(grp Group, g, h Element, p *big.Int) (*big.Int, error) {
+	if grp.Equal(h, grp.Identity()) {
+		return big.NewInt(0), nil
+	}
+	if big.Cmp(p, bsgsMaxOrder) <= 0 {
+		return BabyStepGiantStep(grp, g, h, p)
+	}
+	return PollardRho(grp, g, h, p)
+}
+
+// crt は x = a1 mod m1, x = a2 mod m2 を満たす x mod m1*m2 を求める
+// 呼び出し側は gcd(m1, m2) = 1 を保証すること
+func crt(a1, m1, a2, m2 *big.Int) (x, m *big.Int) {
+	// x = a1 + m1 * ((a2 - a1) * m1^(-1) mod m2)
+	t := big.Mod(big.Mul(big.Sub(a2, a1), big.ModInverse(m1, m2)), m2)
+	m = big.Mul(m1, m2)
+	return big.Mod(big.Add(a1, big.Mul(m1, t)), m), m
+}
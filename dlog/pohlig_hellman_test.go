@@ -0,0 +1,90 @@
+package dlog
+
+import (
+	"testing"
+
+	"github.com/convto/mycrypto/big"
+)
+
+// p - 1 が小さな素数の積になる (smooth な) 素数
+var (
+	smoothP        = new(big.Int).SetString("418117799361928360526935886274763")
+	smoothPFactors = []Factor{
+		{P: big.NewInt(2), E: 1},
+		{P: big.NewInt(3), E: 1},
+		{P: big.NewInt(67), E: 1},
+		{P: big.NewInt(617), E: 1},
+		{P: big.NewInt(709), E: 1},
+		{P: big.NewInt(769), E: 1},
+		{P: big.NewInt(941), E: 1},
+		{P: big.NewInt(991), E: 1},
+		{P: big.NewInt(1013), E: 1},
+		{P: big.NewInt(1097), E: 1},
+		{P: big.NewInt(1123), E: 1},
+		{P: big.NewInt(1451), E: 1},
+		{P: big.NewInt(1831), E: 1},
+	}
+)
+
+func TestPohligHellman(t *testing.T) {
+	type args struct {
+		grp     Group
+		g       Element
+		h       Element
+		factors []Factor
+	}
+	tests := []struct {
+		name string
+		args args
+		want *big.Int
+	}{
+		{
+			name: "Z_p* with smooth p-1",
+			args: args{
+				grp:     NewZpStar(smoothP),
+				g:       big.NewInt(2),
+				h:       new(big.Int).SetString("173175112373571499751660651939716"),
+				factors: smoothPFactors,
+			},
+			want: new(big.Int).SetString("123456789012345678901234567"),
+		},
+		{
+			name: "Z_p* (p - 1 = 2 * 509)",
+			args: args{
+				grp: NewZpStar(big.NewInt(1019)),
+				g:   big.NewInt(2),
+				h:   big.NewInt(550),
+				factors: []Factor{
+					{P: big.NewInt(2), E: 1},
+					{P: big.NewInt(509), E: 1},
+				},
+			},
+			want: big.NewInt(777),
+		},
+		{
+			name: "elliptic curve (n = 2 * 3^2 * 547)",
+			args: args{
+				grp: testCurve,
+				g:   testCurveG,
+				h:   &toyPoint{x: big.NewInt(8509), y: big.NewInt(1504)},
+				factors: []Factor{
+					{P: big.NewInt(2), E: 1},
+					{P: big.NewInt(3), E: 2},
+					{P: big.NewInt(547), E: 1},
+				},
+			},
+			want: big.NewInt(4321),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := PohligHellman(tt.args.grp, tt.args.g, tt.args.h, tt.args.factors)
+			if err != nil {
+				t.Fatalf("PohligHellman() error = %v", err)
+			}
+			if big.Cmp(got, tt.want) != 0 {
+				t.Errorf("PohligHellman() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
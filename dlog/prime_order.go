@@ -0,0 +1,106 @@
+package dlog
+
+import (
+	"github.com/convto/mycrypto/big"
+	"github.com/convto/mycrypto/dh"
+	"github.com/convto/mycrypto/ec"
+)
+
+// PrimeOrderGroup は ElGamal のような離散対数問題に基づく暗号で使う素数位数 q の巡回群です
+// 演算は Group と同じく乗法的に表記し、楕円曲線では Op が点の加算、Exp がスカラー倍になります
+type PrimeOrderGroup interface {
+	Group
+	// Generator は位数 q の生成元 g を返します
+	Generator() Element
+	// Order は群の位数 q を返します
+	Order() *big.Int
+	// Contains は x が群の元かどうかを判定します
+	Contains(x Element) bool
+}
+
+// ZpGroup は素数 p を法とする乗法群の、素数位数 q の部分群です
+// DDH 仮定が成り立つのは位数 q の部分群の中だけなので、扱う元はこの部分群の元に限ります
+type ZpGroup struct {
+	*ZpStar
+	G *big.Int
+	Q *big.Int
+}
+
+// NewZpGroup は p を法とする乗法群の、位数 q の元 g が生成する部分群を返します
+func NewZpGroup(p, q, g *big.Int) *ZpGroup {
+	return &ZpGroup{ZpStar: NewZpStar(p), G: g, Q: q}
+}
+
+// FromDH は dh パッケージの群 (RFC 3526 や RFC 7919 のグループなど) を素数位数の群として返します
+func FromDH(grp *dh.Group) *ZpGroup {
+	return NewZpGroup(grp.P, grp.Q, grp.G)
+}
+
+func (g *ZpGroup) Generator() Element {
+	return g.G
+}
+
+func (g *ZpGroup) Order() *big.Int {
+	return g.Q
+}
+
+// Contains は 1 <= x < p かつ x^q = 1 mod p かどうかを判定します
+func (g *ZpGroup) Contains(x Element) bool {
+	v, ok := x.(*big.Int)
+	if !ok || big.Cmp(v, big.NewInt(1)) < 0 || big.Cmp(v, g.P) >= 0 {
+		return false
+	}
+	return big.Cmp(big.Exp(v, g.Q, g.P), big.NewInt(1)) == 0
+}
+
+// CurveGroup は楕円曲線の生成元 G が生成する位数 n の部分群です
+type CurveGroup struct {
+	Curve *ec.Curve
+}
+
+// NewCurveGroup は曲線 c の生成元が生成する部分群を返します
+func NewCurveGroup(c *ec.Curve) *CurveGroup {
+	return &CurveGroup{Curve: c}
+}
+
+func (g *CurveGroup) Identity() Element {
+	return g.Curve.Infinity()
+}
+
+func (g *CurveGroup) Op(x, y Element) Element {
+	return x.(*ec.Point).Add(y.(*ec.Point))
+}
+
+// Exp は kx を返します
+// x が生成元のときは comb テーブルを使う ScalarBaseMult で計算します
+func (g *CurveGroup) Exp(x Element, k *big.Int) Element {
+	p := x.(*ec.Point)
+	if p == g.Curve.Generator() {
+		return g.Curve.ScalarBaseMult(k)
+	}
+	return p.ScalarMult(k)
+}
+
+func (g *CurveGroup) Equal(x, y Element) bool {
+	return x.(*ec.Point).Equal(y.(*ec.Point))
+}
+
+func (g *CurveGroup) Generator() Element {
+	return g.Curve.Generator()
+}
+
+func (g *CurveGroup) Order() *big.Int {
+	return g.Curve.N()
+}
+
+// Contains は x がこの曲線の点で、余因子が1でない曲線では nx が無限遠点になるかどうかを判定します
+func (g *CurveGroup) Contains(x Element) bool {
+	p, ok := x.(*ec.Point)
+	if !ok || p.Curve() != g.Curve {
+		return false
+	}
+	if big.Cmp(g.Curve.H(), big.NewInt(1)) == 0 {
+		return true
+	}
+	return p.ScalarMult(g.Curve.N()).IsInfinity()
+}
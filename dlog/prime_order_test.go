@@ -0,0 +1,102 @@
+package dlog
+
+import (
+	"crypto/rand"
+	"testing"
+
+	"github.com/convto/mycrypto/big"
+	"github.com/convto/mycrypto/dh"
+	"github.com/convto/mycrypto/ec"
+)
+
+// testZpGroup は p が1024ビット、q が160ビットの FIPS 186-4 の DSA パラメータによる群です
+var testZpGroup = NewZpGroup(
+	new(big.Int).SetString("0x8f0e2cfd1ca6047c541b387aa36676e91c29aa6475dc61b601d4fc51cbae565817a223ed05cc28a8047dbe68516c9a04c3b688de6ed95ef4e7dd4d626721e2915ae20eda84e161b81f62c2067cf0349668516536150aba77d91c7a9e0d9f6715d8d2c10ff872bba84973dfc09414a183af6a319b7e4d9f6bc5383872c72ddf8b"),
+	new(big.Int).SetString("0xf82bf6fd6aa20c4706dd36e19202f835d333cb53"),
+	new(big.Int).SetString("0x390267fd31c85bfa709afb3eade45fc83db97a971daa3fc7df23817046288c8068ba2fca2604097d3d4b1f1ffe181773df52ca8132ee00baf8966fae831ed04ce653288a410cff1920036fa8502f90248fcb8ddda44ab5f2dddd268045b42904ab20c3670e60fdfb5be06d4f633bdeaee4909d1bbbbf2b8c42f9b0b26bd7ce1"),
+)
+
+// testPrimeOrderGroups はテストに使う群で、Z_p* の部分群と楕円曲線の両方を含みます
+var testPrimeOrderGroups = []struct {
+	name string
+	grp  PrimeOrderGroup
+}{
+	{name: "Zp 1024/160", grp: testZpGroup},
+	{name: "P-256", grp: NewCurveGroup(ec.P256())},
+	{name: "secp256k1", grp: NewCurveGroup(ec.Secp256k1())},
+}
+
+func TestPrimeOrderGroup_Generator(t *testing.T) {
+	for _, tt := range append(testPrimeOrderGroups, struct {
+		name string
+		grp  PrimeOrderGroup
+	}{name: "FFDHE2048", grp: FromDH(dh.FFDHE2048())}) {
+		t.Run(tt.name, func(t *testing.T) {
+			grp := tt.grp
+			g := grp.Generator()
+			if !grp.Contains(g) {
+				t.Errorf("Contains(g) = false, want true")
+			}
+			// g^q は単位元で、g^(q+1) は g に戻る
+			q := grp.Order()
+			if got := grp.Exp(g, q); !grp.Equal(got, grp.Identity()) {
+				t.Errorf("g^q = %v, want identity", got)
+			}
+			if got := grp.Exp(g, big.Add(q, big.NewInt(1))); !grp.Equal(got, g) {
+				t.Errorf("g^(q+1) = %v, want g", got)
+			}
+		})
+	}
+}
+
+func TestZpGroup_Contains(t *testing.T) {
+	grp := testZpGroup
+	p := grp.P
+	tests := []struct {
+		name string
+		x    Element
+		want bool
+	}{
+		{name: "1", x: big.NewInt(1), want: true},
+		{name: "g^2", x: big.Exp(grp.G, big.NewInt(2), p), want: true},
+		{name: "0", x: big.NewInt(0), want: false},
+		{name: "p", x: p, want: false},
+		{name: "g + p", x: big.Add(grp.G, p), want: false},
+		// p - 1 の位数は2なので位数 q の部分群に含まれない
+		{name: "p - 1", x: big.Sub(p, big.NewInt(1)), want: false},
+		{name: "2", x: big.NewInt(2), want: false},
+		{name: "point", x: ec.P256().Generator(), want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := grp.Contains(tt.x); got != tt.want {
+				t.Errorf("Contains() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCurveGroup_Contains(t *testing.T) {
+	grp := NewCurveGroup(ec.P256())
+	k, err := big.RandNonZeroInt(rand.Reader, grp.Order())
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name string
+		x    Element
+		want bool
+	}{
+		{name: "kG", x: ec.P256().ScalarBaseMult(k), want: true},
+		{name: "infinity", x: ec.P256().Infinity(), want: true},
+		{name: "other curve", x: ec.Secp256k1().Generator(), want: false},
+		{name: "integer", x: big.NewInt(1), want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := grp.Contains(tt.x); got != tt.want {
+				t.Errorf("Contains() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
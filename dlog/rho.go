@@ -0,0 +1,103 @@
+package dlog
+
+import (
+	"hash/fnv"
+
+	"github.com/convto/mycrypto/big"
+)
+
+const (
+	// rhoMaxAttempts は Pollard rho 法で衝突から解が得られなかったときに初期値を変えて再試行する回数の上限
+	rhoMaxAttempts = 16
+	// rhoMaxCandidates は衝突から得られる解の候補をすべて試す上限
+	rhoMaxCandidates = 1 << 16
+)
+
+// PollardRho は Pollard rho 法で g^x = h を満たす 0 <= x < n を求めます
+// n は g の位数かその倍数を指定します
+// 期待計算量は O(sqrt(n)) ですがメモリはほとんど使わないので、BabyStepGiantStep では表が大きくなりすぎる群に対して使います
+func PollardRho(grp Group, g, h Element, n *big.Int) (*big.Int, error) {
+	if big.Cmp(n, big.Zero) <= 0 {
+		return nil, ErrInvalidOrder
+	}
+	for attempt := int64(1); attempt <= rhoMaxAttempts; attempt++ {
+		x, ok := rho(grp, g, h, n, big.NewInt(attempt))
+		if ok {
+			return x, nil
+		}
+	}
+	return nil, ErrNotFound
+}
+
+// rhoState は g^a * h^b で表される walk 上の点
+type rhoState struct {
+	e    Element
+	a, b *big.Int
+}
+
+// rho は g^a0 * h を初期値として walk を行い、衝突から x を求める
+// 衝突から x が定まらなかったときは ok = false を返す
+func rho(grp Group, g, h Element, n, a0 *big.Int) (x *big.Int, ok bool) {
+	one := big.NewInt(1)
+	step := func(s rhoState) rhoState {
+		// 元を3つの集合に分割し、それぞれ g を掛ける、2乗する、h を掛ける のいずれかで次の点に進む
+		switch partition(s.e, 0, 3) {
+		case 0:
+			return rhoState{e: grp.Op(s.e, g), a: big.Mod(big.Add(s.a, one), n), b: s.b}
+		case 1:
+			return rhoState{e: grp.Op(s.e, s.e), a: big.Mod(big.Add(s.a, s.a), n), b: big.Mod(big.Add(s.b, s.b), n)}
+		default:
+			return rhoState{e: grp.Op(s.e, h), a: s.a, b: big.Mod(big.Add(s.b, one), n)}
+		}
+	}
+
+	// Floyd の循環検出で tortoise と hare が一致する点を探す
+	start := rhoState{e: grp.Op(grp.Exp(g, a0), h), a: big.Mod(a0, n), b: one}
+	tortoise, hare := step(start), step(step(start))
+	for !grp.Equal(tortoise.e, hare.e) {
+		tortoise = step(tortoise)
+		hare = step(step(hare))
+	}
+
+	// g^a1 * h^b1 = g^a2 * h^b2 より (b1 - b2) * x = (a2 - a1) mod n を解く
+	db := big.Mod(big.Sub(tortoise.b, hare.b), n)
+	da := big.Mod(big.Sub(hare.a, tortoise.a), n)
+	return solveLinear(grp, g, h, db, da, n)
+}
+
+// solveLinear は a * x = b mod n を満たす x のうち g^x = h となるものを探す
+// gcd(a, n) = d のとき解の候補は d 個あるので、それぞれ g^x = h となるか確かめる
+func solveLinear(grp Group, g, h Element, a, b, n *big.Int) (x *big.Int, ok bool) {
+	if big.Cmp(a, big.Zero) == 0 {
+		return nil, false
+	}
+	d, _, _ := big.GCD(a, n)
+	if big.Cmp(big.Mod(b, d), big.Zero) != 0 {
+		return nil, false
+	}
+	// 候補が多すぎると全て試すのに時間がかかるので、初期値を変えてやり直した方がよい
+	if big.Cmp(d, big.NewInt(rhoMaxCandidates)) > 0 {
+		return nil, false
+	}
+	nd, _ := big.Div(n, d)
+	ad, _ := big.Div(a, d)
+	bd, _ := big.Div(b, d)
+	x0 := big.Mod(big.Mul(bd, big.ModInverse(ad, nd)), nd)
+	one := big.NewInt(1)
+	for k := big.NewInt(0); big.Cmp(k, d) < 0; k = big.Add(k, one) {
+		x = big.Add(x0, big.Mul(k, nd))
+		if grp.Equal(grp.Exp(g, x), h) {
+			return x, true
+		}
+	}
+	return nil, false
+}
+
+// partition は元の文字列表現のハッシュ値によって元を k 個の集合のいずれかに分類する
+// salt を変えると異なる分割になる
+func partition(e Element, salt byte, k uint64) uint64 {
+	f := fnv.New64a()
+	f.Write([]byte{salt})
+	f.Write([]byte(e.String()))
+	return f.Sum64() % k
+}
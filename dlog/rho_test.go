@@ -0,0 +1,59 @@
+package dlog
+
+import (
+	"testing"
+
+	"github.com/convto/mycrypto/big"
+)
+
+func TestPollardRho(t *testing.T) {
+	type args struct {
+		grp Group
+		g   Element
+		h   Element
+		n   *big.Int
+	}
+	tests := []struct {
+		name string
+		args args
+		want *big.Int
+	}{
+		{
+			name: "prime order subgroup of Z_p* (p = 2q + 1)",
+			args: args{
+				grp: NewZpStar(big.NewInt(20000159)),
+				g:   big.NewInt(4),
+				h:   big.NewInt(17407327),
+				n:   big.NewInt(10000079),
+			},
+			want: big.NewInt(1234567),
+		},
+		{
+			name: "elliptic curve (composite order)",
+			args: args{
+				grp: testCurve,
+				g:   testCurveG,
+				h:   &toyPoint{x: big.NewInt(8509), y: big.NewInt(1504)},
+				n:   testCurveN,
+			},
+			want: big.NewInt(4321),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := PollardRho(tt.args.grp, tt.args.g, tt.args.h, tt.args.n)
+			if err != nil {
+				t.Fatalf("PollardRho() error = %v", err)
+			}
+			if big.Cmp(got, tt.want) != 0 {
+				t.Errorf("PollardRho() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPollardRho_invalidOrder(t *testing.T) {
+	if _, err := PollardRho(NewZpStar(big.NewInt(1019)), big.NewInt(2), big.NewInt(550), big.NewInt(0)); err != ErrInvalidOrder {
+		t.Errorf("PollardRho(n = 0) error = %v, want %v", err, ErrInvalidOrder)
+	}
+}
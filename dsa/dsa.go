@@ -0,0 +1,129 @@
+// Package dsa は FIPS 186-4 の DSA 署名を提供します
+// ドメインパラメータ (p, q, g) は付録 A の検証可能な手順で生成し、生成に使ったシードから誰でも検証できます
+// 署名はメッセージのハッシュ値 (digest) に対して行い、ハッシュ関数の選択は呼び出し側に任せます
+package dsa
+
+import (
+	"errors"
+	"io"
+
+	"github.com/convto/mycrypto/big"
+)
+
+var (
+	// ErrInvalidParameterSizes は p, q のビット長 (L, N) が FIPS 186-4 4.2 の組み合わせでないか、ハッシュ関数の出力が N より短いことを表します
+	ErrInvalidParameterSizes = errors.New("dsa: invalid parameter sizes")
+	// ErrUnsupportedHash はハッシュ関数が利用できないことを表します
+	ErrUnsupportedHash = errors.New("dsa: unsupported hash function")
+	// ErrInvalidSeed はシードから素数の q や p が得られないことを表します
+	ErrInvalidSeed = errors.New("dsa: seed does not generate primes")
+	// ErrInvalidParameters はドメインパラメータがシードから生成し直した値と一致しないか、満たすべき条件を満たさないことを表します
+	ErrInvalidParameters = errors.New("dsa: invalid domain parameters")
+)
+
+// Parameters は DSA のドメインパラメータで、g は p を法とする乗法群の位数 q の部分群の生成元です
+type Parameters struct {
+	P *big.Int
+	Q *big.Int
+	G *big.Int
+}
+
+// PublicKey は公開鍵 y = g^x mod p です
+type PublicKey struct {
+	Parameters *Parameters
+	Y          *big.Int
+}
+
+// PrivateKey は秘密鍵 x と対応する公開鍵です
+type PrivateKey struct {
+	PublicKey
+	X *big.Int
+}
+
+// Signature は DSA の署名 (r, s) です
+type Signature struct {
+	R *big.Int
+	S *big.Int
+}
+
+// GenerateKey は r から読み込んだ乱数で 1 <= x < q の秘密鍵を選び、鍵ペアを返します
+func GenerateKey(r io.Reader, params *Parameters) (*PrivateKey, error) {
+	x, err := big.RandNonZeroInt(r, params.Q)
+	if err != nil {
+		return nil, err
+	}
+	return &PrivateKey{
+		PublicKey: PublicKey{Parameters: params, Y: big.Exp(params.G, x, params.P)},
+		X:         x,
+	}, nil
+}
+
+// Sign は r から読み込んだ乱数をナンスにして digest に署名します (FIPS 186-4 4.6)
+func Sign(r io.Reader, priv *PrivateKey, digest []byte) (*Signature, error) {
+	params := priv.Parameters
+	for {
+		k, err := big.RandNonZeroInt(r, params.Q)
+		if err != nil {
+			return nil, err
+		}
+		if sig := sign(priv, k, digest); sig != nil {
+			return sig, nil
+		}
+	}
+}
+
+// Verify は sig が公開鍵 pub による digest の正しい署名かどうかを判定します (FIPS 186-4 4.7)
+func Verify(pub *PublicKey, digest []byte, sig *Signature) bool {
+	params := pub.Parameters
+	p, q := params.P, params.Q
+	if !inRange(pub.Y, p) || !inRange(sig.R, q) || !inRange(sig.S, q) {
+		return false
+	}
+	// v = (g^u1 y^u2 mod p) mod q が r と合うかを確かめる
+	z := bits2int(digest, q.BitLen())
+	w := modInverse(sig.S, q)
+	u1 := big.Mod(big.Mul(z, w), q)
+	u2 := big.Mod(big.Mul(sig.R, w), q)
+	v := big.Mod(big.Mul(big.Exp(params.G, u1, p), big.Exp(pub.Y, u2, p)), p)
+	return big.Cmp(big.Mod(v, q), sig.R) == 0
+}
+
+// sign はナンス k で署名を計算します
+// r = 0 または s = 0 になったときは別のナンスでやり直すために nil を返します
+func sign(priv *PrivateKey, k *big.Int, digest []byte) *Signature {
+	params := priv.Parameters
+	q := params.Q
+	r := big.Mod(big.Exp(params.G, k, params.P), q)
+	if big.Cmp(r, big.Zero) == 0 {
+		return nil
+	}
+	// s = k^-1 (z + xr) mod q
+	z := bits2int(digest, q.BitLen())
+	s := big.Mod(big.Mul(modInverse(k, q), big.Add(z, big.Mul(priv.X, r))), q)
+	if big.Cmp(s, big.Zero) == 0 {
+		return nil
+	}
+	return &Signature{R: r, S: s}
+}
+
+// modInverse は素数 q を法とした x の逆元を x^(q-2) mod q で求める
+func modInverse(x, q *big.Int) *big.Int {
+	return big.Exp(x, big.Sub(q, big.NewInt(2)), q)
+}
+
+// bits2int はバイト列の上位 qlen ビットを整数として取り出す (FIPS 186-4 4.6 の z)
+func bits2int(b []byte, qlen int) *big.Int {
+	if l := (qlen + 7) / 8; len(b) > l {
+		b = b[:l]
+	}
+	x := new(big.Int).SetBytes(b)
+	if excess := len(b)*8 - qlen; excess > 0 {
+		x, _ = big.Div(x, big.Exp(big.NewInt(2), big.NewInt(int64(excess)), nil))
+	}
+	return x
+}
+
+// inRange は 1 <= x < n かどうかを判定する
+func inRange(x, n *big.Int) bool {
+	return big.Cmp(x, big.NewInt(1)) >= 0 && big.Cmp(x, n) < 0
+}
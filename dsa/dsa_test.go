@@ -0,0 +1,116 @@
+package dsa
+
+import (
+	stddsa "crypto/dsa"
+	"crypto/rand"
+	"crypto/sha256"
+	stdbig "math/big"
+	"testing"
+
+	"github.com/convto/mycrypto/big"
+)
+
+// testParameters は testdata/pqg.txt の最初の (L, N) = (1024, 160) のパラメータを返す
+func testParameters(t *testing.T) *Parameters {
+	return readPQGVectors(t)[0].params
+}
+
+func TestSign(t *testing.T) {
+	params := testParameters(t)
+	digest := sha256.Sum256([]byte("mycrypto"))
+	priv, err := GenerateKey(rand.Reader, params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sig, err := Sign(rand.Reader, priv, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !Verify(&priv.PublicKey, digest[:], sig) {
+		t.Errorf("Verify() = false, want true")
+	}
+
+	other := sha256.Sum256([]byte("other message"))
+	q := params.Q
+	tests := []struct {
+		name   string
+		pub    *PublicKey
+		digest []byte
+		sig    *Signature
+	}{
+		{name: "other digest", pub: &priv.PublicKey, digest: other[:], sig: sig},
+		{name: "swapped r, s", pub: &priv.PublicKey, digest: digest[:], sig: &Signature{R: sig.S, S: sig.R}},
+		{name: "r = 0", pub: &priv.PublicKey, digest: digest[:], sig: &Signature{R: big.Zero, S: sig.S}},
+		{name: "s = 0", pub: &priv.PublicKey, digest: digest[:], sig: &Signature{R: sig.R, S: big.Zero}},
+		{name: "r + q", pub: &priv.PublicKey, digest: digest[:], sig: &Signature{R: big.Add(sig.R, q), S: sig.S}},
+		{name: "s + q", pub: &priv.PublicKey, digest: digest[:], sig: &Signature{R: sig.R, S: big.Add(sig.S, q)}},
+		{name: "y = 0", pub: &PublicKey{Parameters: params, Y: big.Zero}, digest: digest[:], sig: sig},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if Verify(tt.pub, tt.digest, tt.sig) {
+				t.Errorf("Verify() = true, want false")
+			}
+		})
+	}
+}
+
+// TestSign_crossCheck は crypto/dsa と互いの署名を検証できることを確かめる
+// crypto/dsa はハッシュ値を切り詰めないので、q のバイト長に切り詰めたものを渡す
+func TestSign_crossCheck(t *testing.T) {
+	params := testParameters(t)
+	digest := sha256.Sum256([]byte("mycrypto"))
+	priv, err := GenerateKey(rand.Reader, params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	std := &stddsa.PrivateKey{
+		PublicKey: stddsa.PublicKey{
+			Parameters: stddsa.Parameters{P: toStd(params.P), Q: toStd(params.Q), G: toStd(params.G)},
+			Y:          toStd(priv.Y),
+		},
+		X: toStd(priv.X),
+	}
+
+	sig, err := Sign(rand.Reader, priv, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !stddsa.Verify(&std.PublicKey, digest[:20], toStd(sig.R), toStd(sig.S)) {
+		t.Errorf("crypto/dsa.Verify() = false, want true")
+	}
+
+	r, s, err := stddsa.Sign(rand.Reader, std, digest[:20])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !Verify(&priv.PublicKey, digest[:], &Signature{R: new(big.Int).SetBytes(r.Bytes()), S: new(big.Int).SetBytes(s.Bytes())}) {
+		t.Errorf("Verify() = false, want true")
+	}
+}
+
+func Test_bits2int(t *testing.T) {
+	tests := []struct {
+		name string
+		b    []byte
+		qlen int
+		want int64
+	}{
+		{name: "shorter", b: []byte{0x12, 0x34}, qlen: 24, want: 0x1234},
+		{name: "same length", b: []byte{0x12, 0x34}, qlen: 16, want: 0x1234},
+		{name: "truncate bytes", b: []byte{0x12, 0x34, 0x56}, qlen: 16, want: 0x1234},
+		{name: "truncate bits", b: []byte{0x12, 0x34}, qlen: 12, want: 0x123},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := bits2int(tt.b, tt.qlen); big.Cmp(got, big.NewInt(tt.want)) != 0 {
+				t.Errorf("bits2int() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// toStd は math/big の整数に変換する
+func toStd(x *big.Int) *stdbig.Int {
+	return new(stdbig.Int).SetBytes(x.Bytes())
+}
@@ -0,0 +1,206 @@
+package dsa
+
+import (
+	"crypto"
+	"io"
+
+	"github.com/convto/mycrypto/big"
+)
+
+// millerRabinRounds は素数判定で行う Miller-Rabin テストの回数で、FIPS 186-4 表 C.1 の値をビット長ごとに持ちます
+var millerRabinRounds = map[int]int{
+	160:  40,
+	224:  56,
+	256:  64,
+	1024: 40,
+	2048: 56,
+	3072: 64,
+}
+
+// ggen は A.2.3 で g を導出するときにシードの後ろにつける値です
+var ggen = []byte("ggen")
+
+// Seed は FIPS 186-4 付録 A の手順でパラメータを生成したときの入力です
+// パラメータと一緒に公開すると、誰でも生成をやり直して p, q, g が恣意的に選ばれていないことを確かめられます
+type Seed struct {
+	// Hash は生成に使ったハッシュ関数で、出力のビット長は N 以上でなければなりません
+	Hash crypto.Hash
+	// DomainParameterSeed は q の元になったシードで、N ビット以上の長さがあります
+	DomainParameterSeed []byte
+	// Counter は p が見つかるまでに捨てた候補の数です
+	Counter int
+	// Index は g の導出に使った値で、同じ p, q から用途ごとに異なる g を作れます
+	Index byte
+}
+
+// GenerateParameters は r から読み込んだシードで、p のビット長が L、q のビット長が N のドメインパラメータを生成します
+// p, q は A.1.1.2、g は Index = 1 として A.2.3 の手順で求め、検証に使う値を Seed として返します
+// (L, N) は (1024, 160), (2048, 224), (2048, 256), (3072, 256) のいずれかです
+func GenerateParameters(r io.Reader, L, N int, h crypto.Hash) (*Parameters, *Seed, error) {
+	if err := checkSizes(L, N, h); err != nil {
+		return nil, nil, err
+	}
+	seed := make([]byte, N/8)
+	for {
+		if _, err := io.ReadFull(r, seed); err != nil {
+			return nil, nil, err
+		}
+		p, q, counter, err := GeneratePQ(L, N, h, seed)
+		if err == ErrInvalidSeed {
+			continue
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		s := &Seed{Hash: h, DomainParameterSeed: append([]byte(nil), seed...), Counter: counter, Index: 1}
+		g, err := GenerateG(p, q, s)
+		if err != nil {
+			return nil, nil, err
+		}
+		return &Parameters{P: p, Q: q, G: g}, s, nil
+	}
+}
+
+// GeneratePQ は FIPS 186-4 A.1.1.2 の手順でシード seed から p, q を決定的に求め、p が見つかったときの counter と一緒に返します
+// seed から得た q が素数でないときや、4L 個の候補に素数の p がないときは ErrInvalidSeed を返すので、別のシードでやり直します
+func GeneratePQ(L, N int, h crypto.Hash, seed []byte) (p, q *big.Int, counter int, err error) {
+	return generatePQ(L, N, h, seed, 4*L-1)
+}
+
+// generatePQ は counter が maxCounter を超えるまで p の候補を試す
+func generatePQ(L, N int, h crypto.Hash, seed []byte, maxCounter int) (p, q *big.Int, counter int, err error) {
+	if err := checkSizes(L, N, h); err != nil {
+		return nil, nil, 0, err
+	}
+	if len(seed)*8 < N {
+		return nil, nil, 0, ErrInvalidSeed
+	}
+	// U = Hash(seed) mod 2^(N-1) として q = 2^(N-1) + U + 1 - (U mod 2)
+	// N は8の倍数なので、下位 N ビットの最上位ビットと最下位ビットを立てればよい
+	u := hashSum(h, seed)
+	u = u[len(u)-N/8:]
+	u[0] |= 0x80
+	u[len(u)-1] |= 1
+	q = new(big.Int).SetBytes(u)
+	if !q.ProbablyPrime(millerRabinRounds[N]) {
+		return nil, nil, 0, ErrInvalidSeed
+	}
+
+	// p の候補ごとに n + 1 個のハッシュ値を連結して L - 1 ビットの W を作る
+	outlen := h.Size() * 8
+	n := (L+outlen-1)/outlen - 1
+	twoQ := big.Mul(q, big.NewInt(2))
+	one := big.NewInt(1)
+	s := append([]byte(nil), seed...)
+	w := make([]byte, L/8)
+	for counter = 0; counter <= maxCounter; counter++ {
+		// V_j = Hash((seed + offset + j) mod 2^seedlen) を下の位から詰め、
+		// W = V_0 + V_1 * 2^outlen + ... + (V_n mod 2^b) * 2^(n*outlen) とする
+		for j := 0; j <= n; j++ {
+			increment(s)
+			v := hashSum(h, s)
+			end := len(w) - j*len(v)
+			if end < len(v) {
+				v = v[len(v)-end:]
+			}
+			copy(w[end-len(v):end], v)
+		}
+		// X = W + 2^(L-1) を 2q で割った余り c について p = X - (c - 1) とすると p = 1 mod 2q になる
+		w[0] |= 0x80
+		x := new(big.Int).SetBytes(w)
+		_, c := big.Div(x, twoQ)
+		p = big.Add(big.Sub(x, c), one)
+		if p.BitLen() == L && p.ProbablyPrime(millerRabinRounds[L]) {
+			return p, q, counter, nil
+		}
+	}
+	return nil, nil, 0, ErrInvalidSeed
+}
+
+// GenerateG は FIPS 186-4 A.2.3 の手順で、シードと seed.Index から位数 q の部分群の生成元 g を決定的に求めます
+// W = Hash(seed || "ggen" || index || count) として g = W^((p-1)/q) mod p が 2 以上になるまで count を増やします
+func GenerateG(p, q *big.Int, seed *Seed) (*big.Int, error) {
+	if !seed.Hash.Available() {
+		return nil, ErrUnsupportedHash
+	}
+	e, _ := big.Div(big.Sub(p, big.NewInt(1)), q)
+	two := big.NewInt(2)
+	u := append(append(append([]byte(nil), seed.DomainParameterSeed...), ggen...), seed.Index, 0, 0)
+	for count := 1; count <= 0xffff; count++ {
+		u[len(u)-2], u[len(u)-1] = byte(count>>8), byte(count)
+		w := new(big.Int).SetBytes(hashSum(seed.Hash, u))
+		if g := big.Exp(w, e, p); big.Cmp(g, two) >= 0 {
+			return g, nil
+		}
+	}
+	return nil, ErrInvalidSeed
+}
+
+// ValidateParameters は seed から A.1.1.3 と A.2.4 の手順で p, q, g を生成し直し、params と一致するかを確かめます
+// 一致しないときや、g が位数 q の部分群の 2 以上の元でないときは ErrInvalidParameters を返します
+func ValidateParameters(params *Parameters, seed *Seed) error {
+	p, q, g := params.P, params.Q, params.G
+	L, N := p.BitLen(), q.BitLen()
+	if err := checkSizes(L, N, seed.Hash); err != nil {
+		return err
+	}
+	if seed.Counter < 0 || seed.Counter > 4*L-1 {
+		return ErrInvalidParameters
+	}
+	// A.1.1.3 では counter 番目の候補で初めて素数になることを確かめるので、それより先の候補は試さない
+	pp, qq, counter, err := generatePQ(L, N, seed.Hash, seed.DomainParameterSeed, seed.Counter)
+	if err != nil {
+		return ErrInvalidParameters
+	}
+	if counter != seed.Counter || big.Cmp(pp, p) != 0 || big.Cmp(qq, q) != 0 {
+		return ErrInvalidParameters
+	}
+
+	// A.2.2 の部分的な検証: 2 <= g <= p-1 かつ g^q = 1 mod p
+	if big.Cmp(g, big.NewInt(2)) < 0 || big.Cmp(g, big.Sub(p, big.NewInt(1))) > 0 {
+		return ErrInvalidParameters
+	}
+	if big.Cmp(big.Exp(g, q, p), big.NewInt(1)) != 0 {
+		return ErrInvalidParameters
+	}
+	gg, err := GenerateG(p, q, seed)
+	if err != nil || big.Cmp(gg, g) != 0 {
+		return ErrInvalidParameters
+	}
+	return nil
+}
+
+// checkSizes は (L, N) が FIPS 186-4 4.2 の組み合わせで、h の出力が N ビット以上あるかを確かめる
+func checkSizes(L, N int, h crypto.Hash) error {
+	switch {
+	case L == 1024 && N == 160:
+	case L == 2048 && (N == 224 || N == 256):
+	case L == 3072 && N == 256:
+	default:
+		return ErrInvalidParameterSizes
+	}
+	if !h.Available() {
+		return ErrUnsupportedHash
+	}
+	if h.Size()*8 < N {
+		return ErrInvalidParameterSizes
+	}
+	return nil
+}
+
+// hashSum は b のハッシュ値を返す
+func hashSum(h crypto.Hash, b []byte) []byte {
+	hh := h.New()
+	hh.Write(b)
+	return hh.Sum(nil)
+}
+
+// increment は b をビッグエンディアンの整数として 2^(8*len(b)) を法として1を足す
+func increment(b []byte) {
+	for i := len(b) - 1; i >= 0; i-- {
+		b[i]++
+		if b[i] != 0 {
+			return
+		}
+	}
+}
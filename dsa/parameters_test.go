@@ -0,0 +1,214 @@
+package dsa
+
+import (
+	"bufio"
+	"crypto"
+	"crypto/rand"
+	_ "crypto/sha1"
+	_ "crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/convto/mycrypto/big"
+)
+
+// pqgVector は testdata/pqg.txt の1つのパラメータです
+type pqgVector struct {
+	L, N   int
+	params *Parameters
+	seed   *Seed
+}
+
+var pqgHashes = map[string]crypto.Hash{
+	"SHA-1":   crypto.SHA1,
+	"SHA-224": crypto.SHA224,
+	"SHA-256": crypto.SHA256,
+}
+
+// readPQGVectors は NIST CAVP の PQGGen の応答ファイルと同じ形式の testdata/pqg.txt を読み込む
+// "[mod = L=..., N=..., SHA-...]" の行でサイズとハッシュ関数が決まり、"名前 = 値" の行が続く
+func readPQGVectors(t *testing.T) []pqgVector {
+	f, err := os.Open("testdata/pqg.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	var vs []pqgVector
+	var v pqgVector
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		line := strings.TrimSpace(s.Text())
+		if strings.HasPrefix(line, "[mod = ") {
+			// [mod = L=1024, N=160, SHA-1]
+			fields := strings.Split(strings.Trim(strings.TrimPrefix(line, "[mod = "), "]"), ", ")
+			v.L, _ = strconv.Atoi(strings.TrimPrefix(fields[0], "L="))
+			v.N, _ = strconv.Atoi(strings.TrimPrefix(fields[1], "N="))
+			v.seed = &Seed{Hash: pqgHashes[fields[2]]}
+			continue
+		}
+		kv := strings.SplitN(line, " = ", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		value := kv[1]
+		switch kv[0] {
+		case "P":
+			v.params = &Parameters{P: hexInt(value)}
+			v.seed = &Seed{Hash: v.seed.Hash}
+		case "Q":
+			v.params.Q = hexInt(value)
+		case "domain_parameter_seed":
+			v.seed.DomainParameterSeed = mustHex(value)
+		case "counter":
+			v.seed.Counter, _ = strconv.Atoi(value)
+		case "index":
+			v.seed.Index = mustHex(value)[0]
+		case "G":
+			v.params.G = hexInt(value)
+			vs = append(vs, v)
+		}
+	}
+	if err := s.Err(); err != nil {
+		t.Fatal(err)
+	}
+	return vs
+}
+
+func TestGeneratePQ_vectors(t *testing.T) {
+	for _, v := range readPQGVectors(t) {
+		// 2048 ビット以上の p の探索は時間がかかるので 1024 ビットのベクトルだけ p まで再現する
+		if v.L > 1024 {
+			continue
+		}
+		t.Run(strconv.Itoa(v.L)+"/"+strconv.Itoa(v.N)+"/"+strconv.Itoa(v.seed.Counter), func(t *testing.T) {
+			p, q, counter, err := GeneratePQ(v.L, v.N, v.seed.Hash, v.seed.DomainParameterSeed)
+			if err != nil {
+				t.Fatalf("GeneratePQ() error = %v", err)
+			}
+			if big.Cmp(p, v.params.P) != 0 || big.Cmp(q, v.params.Q) != 0 || counter != v.seed.Counter {
+				t.Errorf("GeneratePQ() = %v, %v, %v, want %v, %v, %v", p, q, counter, v.params.P, v.params.Q, v.seed.Counter)
+			}
+			if err := ValidateParameters(v.params, v.seed); err != nil {
+				t.Errorf("ValidateParameters() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestGenerateG_vectors(t *testing.T) {
+	for _, v := range readPQGVectors(t) {
+		t.Run(strconv.Itoa(v.L)+"/"+strconv.Itoa(v.N)+"/"+strconv.Itoa(int(v.seed.Index)), func(t *testing.T) {
+			g, err := GenerateG(v.params.P, v.params.Q, v.seed)
+			if err != nil {
+				t.Fatalf("GenerateG() error = %v", err)
+			}
+			if big.Cmp(g, v.params.G) != 0 {
+				t.Errorf("GenerateG() = %v, want %v", g, v.params.G)
+			}
+			// index を変えると別の生成元になる
+			other := *v.seed
+			other.Index++
+			if g, err := GenerateG(v.params.P, v.params.Q, &other); err != nil || big.Cmp(g, v.params.G) == 0 {
+				t.Errorf("GenerateG() with other index = %v, %v", g, err)
+			}
+		})
+	}
+}
+
+func TestGenerateParameters(t *testing.T) {
+	params, seed, err := GenerateParameters(rand.Reader, 1024, 160, crypto.SHA256)
+	if err != nil {
+		t.Fatalf("GenerateParameters() error = %v", err)
+	}
+	if params.P.BitLen() != 1024 || params.Q.BitLen() != 160 {
+		t.Errorf("bit lengths = %v, %v, want 1024, 160", params.P.BitLen(), params.Q.BitLen())
+	}
+	if len(seed.DomainParameterSeed) != 20 || seed.Index != 1 {
+		t.Errorf("seed = %x, index %v", seed.DomainParameterSeed, seed.Index)
+	}
+	if err := ValidateParameters(params, seed); err != nil {
+		t.Errorf("ValidateParameters() error = %v", err)
+	}
+}
+
+func TestGenerateParameters_invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		L, N int
+		hash crypto.Hash
+		want error
+	}{
+		{name: "unapproved sizes", L: 1024, N: 256, hash: crypto.SHA256, want: ErrInvalidParameterSizes},
+		{name: "small L", L: 512, N: 160, hash: crypto.SHA256, want: ErrInvalidParameterSizes},
+		{name: "hash shorter than N", L: 2048, N: 256, hash: crypto.SHA224, want: ErrInvalidParameterSizes},
+		{name: "unavailable hash", L: 2048, N: 256, hash: crypto.BLAKE2b_256, want: ErrUnsupportedHash},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := GenerateParameters(rand.Reader, tt.L, tt.N, tt.hash); !errors.Is(err, tt.want) {
+				t.Errorf("GenerateParameters() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateParameters_invalid(t *testing.T) {
+	var v pqgVector
+	for _, vv := range readPQGVectors(t) {
+		if vv.L == 1024 {
+			v = vv
+			break
+		}
+	}
+	one := big.NewInt(1)
+	tests := []struct {
+		name   string
+		modify func(params *Parameters, seed *Seed)
+	}{
+		{name: "other seed", modify: func(_ *Parameters, seed *Seed) { seed.DomainParameterSeed[0] ^= 1 }},
+		{name: "short seed", modify: func(_ *Parameters, seed *Seed) { seed.DomainParameterSeed = seed.DomainParameterSeed[1:] }},
+		{name: "smaller counter", modify: func(_ *Parameters, seed *Seed) { seed.Counter-- }},
+		{name: "larger counter", modify: func(_ *Parameters, seed *Seed) { seed.Counter++ }},
+		{name: "counter out of range", modify: func(_ *Parameters, seed *Seed) { seed.Counter = 4 * 1024 }},
+		{name: "other index", modify: func(_ *Parameters, seed *Seed) { seed.Index++ }},
+		{name: "other hash", modify: func(_ *Parameters, seed *Seed) { seed.Hash = crypto.SHA256 }},
+		{name: "g = 1", modify: func(params *Parameters, _ *Seed) { params.G = one }},
+		{name: "g = p - 1", modify: func(params *Parameters, _ *Seed) { params.G = big.Sub(params.P, one) }},
+		// g^2 も位数 q の部分群の元だが、シードから導出した値ではない
+		{name: "g squared", modify: func(params *Parameters, _ *Seed) { params.G = big.Exp(params.G, big.NewInt(2), params.P) }},
+		{name: "other q", modify: func(params *Parameters, _ *Seed) { params.Q = big.Add(params.Q, big.NewInt(2)) }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := *v.params
+			seed := *v.seed
+			seed.DomainParameterSeed = append([]byte(nil), seed.DomainParameterSeed...)
+			tt.modify(&params, &seed)
+			if err := ValidateParameters(&params, &seed); !errors.Is(err, ErrInvalidParameters) {
+				t.Errorf("ValidateParameters() error = %v, want %v", err, ErrInvalidParameters)
+			}
+		})
+	}
+}
+
+// hexInt は16進数の文字列を整数にする
+func hexInt(s string) *big.Int {
+	return new(big.Int).SetBytes(mustHex(s))
+}
+
+// mustHex は16進数の文字列をバイト列にする
+func mustHex(s string) []byte {
+	if len(s)%2 == 1 {
+		s = "0" + s
+	}
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
@@ -0,0 +1,80 @@
+package dsa
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"os"
+	"strconv"
+	"testing"
+
+	"github.com/convto/mycrypto/big"
+)
+
+// wycheproofFile は Wycheproof の DSA 検証ベクトル (IEEE P1363 形式の署名) のファイルです
+type wycheproofFile struct {
+	TestGroups []struct {
+		PublicKey struct {
+			P string `json:"p"`
+			Q string `json:"q"`
+			G string `json:"g"`
+			Y string `json:"y"`
+		} `json:"publicKey"`
+		Sha   string `json:"sha"`
+		Tests []struct {
+			TcID    int      `json:"tcId"`
+			Comment string   `json:"comment"`
+			Msg     string   `json:"msg"`
+			Sig     string   `json:"sig"`
+			Result  string   `json:"result"`
+			Flags   []string `json:"flags"`
+		} `json:"tests"`
+	} `json:"testGroups"`
+}
+
+// testWycheproof は testdata のベクトルを読み込み、r || s 形式の署名を検証した結果を比べる
+func testWycheproof(t *testing.T, file string) {
+	data, err := os.ReadFile("testdata/" + file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var f wycheproofFile
+	if err := json.Unmarshal(data, &f); err != nil {
+		t.Fatal(err)
+	}
+	for _, g := range f.TestGroups {
+		if g.Sha != "SHA-256" {
+			t.Fatalf("unsupported group: %s", g.Sha)
+		}
+		pub := &PublicKey{
+			Parameters: &Parameters{P: hexInt(g.PublicKey.P), Q: hexInt(g.PublicKey.Q), G: hexInt(g.PublicKey.G)},
+			Y:          hexInt(g.PublicKey.Y),
+		}
+		l := (pub.Parameters.Q.BitLen() + 7) / 8
+		for _, tt := range g.Tests {
+			t.Run(strconv.Itoa(tt.TcID), func(t *testing.T) {
+				digest := sha256.Sum256(mustHex(tt.Msg))
+				b := mustHex(tt.Sig)
+				got := false
+				if len(b) == 2*l {
+					sig := &Signature{R: new(big.Int).SetBytes(b[:l]), S: new(big.Int).SetBytes(b[l:])}
+					got = Verify(pub, digest[:], sig)
+				}
+				if want := tt.Result == "valid"; got != want {
+					t.Errorf("%s (%v): got %v, want %v", tt.Comment, tt.Flags, got, tt.Result)
+				}
+			})
+		}
+	}
+}
+
+func TestWycheproof(t *testing.T) {
+	for _, file := range []string{
+		// N = 224 なので SHA-256 のハッシュ値は上位224ビットに切り詰める
+		"dsa_2048_224_sha256_p1363_test.json",
+		"dsa_2048_256_sha256_p1363_test.json",
+	} {
+		t.Run(file, func(t *testing.T) {
+			testWycheproof(t, file)
+		})
+	}
+}
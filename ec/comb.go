@@ -0,0 +1,85 @@
+package ec
+
+import (
+	"crypto/subtle"
+
+	"github.com/convto/mycrypto/big"
+)
+
+// combWidth は生成元 G の comb テーブルの歯の数です
+const combWidth = 5
+
+// CombTable は固定された点 p の倍数を事前に計算した Lim-Lee の comb テーブルです
+// ビット長 l のスカラーを w 本の歯で d = ceil(l/w) 列に分け、
+// 2^w 個の点 T[i] = Σ_{i の j ビット目が1} 2^(jd) p を並べておくことで、
+// d 回の2倍算と加算だけで kp を計算します
+type CombTable struct {
+	c *Curve
+	w int
+	d int
+	// entries[i] は T[i] を encodeProj で並べたバイト列
+	entries [][]byte
+}
+
+// NewCombTable は位数 n の点 p について歯の数 w の comb テーブルを作ります
+func NewCombTable(p *Point, w int) *CombTable {
+	if w < 1 || w > 8 {
+		panic("ec: comb width must be between 1 and 8")
+	}
+	c := p.c
+	l := c.n.BitLen()
+	d := (l + w - 1) / w
+
+	// base[j] = 2^(jd) p
+	base := make([]*Point, w)
+	base[0] = p
+	for j := 1; j < w; j++ {
+		q := base[j-1]
+		for i := 0; i < d; i++ {
+			q = q.Double()
+		}
+		base[j] = q
+	}
+
+	entries := make([][]byte, 1<<uint(w))
+	points := make([]*Point, 1<<uint(w))
+	points[0] = c.Infinity()
+	entries[0] = c.encodeProj(points[0].toProj())
+	for i := 1; i < len(points); i++ {
+		// 最上位のビットを除いた添字の点に base を足す
+		j := 0
+		for i>>uint(j+1) != 0 {
+			j++
+		}
+		points[i] = points[i&^(1<<uint(j))].Add(base[j])
+		entries[i] = c.encodeProj(points[i].toProj())
+	}
+	return &CombTable{c: c, w: w, d: d, entries: entries}
+}
+
+// ScalarMult は kp を返します
+// k は n で還元され、テーブルの参照は全要素を走査する定数時間の選択で行います
+func (t *CombTable) ScalarMult(k *big.Int) *Point {
+	c := t.c
+	k = big.Mod(k, c.n)
+	kb := k.Bits(t.w * t.d)
+	r := c.Infinity().toProj()
+	for i := t.d - 1; i >= 0; i-- {
+		r = c.addComplete(r, r)
+		idx := 0
+		for j := 0; j < t.w; j++ {
+			idx |= int(kb[i+j*t.d]) << uint(j)
+		}
+		r = c.addComplete(r, t.lookup(idx))
+	}
+	return c.fromProj(r)
+}
+
+// lookup は T[idx] を、idx によらず全要素を読み込んで選択します
+func (t *CombTable) lookup(idx int) *projPoint {
+	buf := make([]byte, len(t.entries[0]))
+	for i, e := range t.entries {
+		subtle.ConstantTimeCopy(subtle.ConstantTimeEq(int32(i), int32(idx)), buf, e)
+	}
+	return t.c.decodeProj(buf)
+}
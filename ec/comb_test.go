@@ -0,0 +1,61 @@
+package ec
+
+import (
+	"testing"
+
+	"github.com/convto/mycrypto/big"
+)
+
+func TestCombTable_ScalarMult(t *testing.T) {
+	for _, c := range []*Curve{P256(), Secp256k1(), toyCurve} {
+		p := c.Generator().Double()
+		for _, w := range []int{1, 4} {
+			table := NewCombTable(p, w)
+			for _, k := range scalarMultTests(t, c) {
+				t.Run(c.Name()+"/"+k.String(), func(t *testing.T) {
+					if got, want := table.ScalarMult(k), doubleAndAdd(p, k); !got.Equal(want) {
+						t.Errorf("ScalarMult() = %v, want %v", got, want)
+					}
+				})
+			}
+		}
+	}
+}
+
+func TestCurve_ScalarBaseMult(t *testing.T) {
+	c := P384()
+	for _, k := range scalarMultTests(t, c) {
+		t.Run(k.String(), func(t *testing.T) {
+			if got, want := c.ScalarBaseMult(k), (WNAF{W: 5}).ScalarMult(c.Generator(), k); !got.Equal(want) {
+				t.Errorf("ScalarBaseMult() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestCurve_addComplete(t *testing.T) {
+	for _, c := range []*Curve{P256(), Secp256k1()} {
+		g := c.Generator()
+		g3 := c.ScalarBaseMult(big.NewInt(3))
+		tests := []struct {
+			name string
+			p    *Point
+			q    *Point
+		}{
+			{"P + Q", g, g3},
+			{"P + P", g3, g3},
+			{"P + (-P)", g3, g3.Neg()},
+			{"O + P", c.Infinity(), g},
+			{"P + O", g, c.Infinity()},
+			{"O + O", c.Infinity(), c.Infinity()},
+		}
+		for _, tt := range tests {
+			t.Run(c.Name()+"/"+tt.name, func(t *testing.T) {
+				got := c.fromProj(c.addComplete(tt.p.toProj(), tt.q.toProj()))
+				if want := tt.p.Add(tt.q); !got.Equal(want) {
+					t.Errorf("addComplete() = %v, want %v", got, want)
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package ec
+
+import (
+	"crypto/subtle"
+
+	"github.com/convto/mycrypto/field"
+)
+
+// projPoint は射影座標 (X : Y : Z) (x = X/Z, y = Y/Z) で表した点です
+// 無限遠点は (0 : 1 : 0) です
+// 定数時間のスカラー倍算では、無限遠点や同じ点の加算で分岐しない完全加算公式を使うためにこの表現を使います
+type projPoint struct {
+	x *field.Element
+	y *field.Element
+	z *field.Element
+}
+
+// toProj は Jacobian 座標の点を射影座標に変換します
+func (p *Point) toProj() *projPoint {
+	if p.IsInfinity() {
+		return &projPoint{x: p.c.f.Zero(), y: p.c.f.One(), z: p.c.f.Zero()}
+	}
+	// (X/Z^2, Y/Z^3) = (XZ/Z^3, Y/Z^3)
+	return &projPoint{x: p.x.Mul(p.z), y: p.y, z: p.z.Square().Mul(p.z)}
+}
+
+// fromProj は射影座標の点を Jacobian 座標に変換します
+func (c *Curve) fromProj(p *projPoint) *Point {
+	if p.z.IsZero() {
+		return c.Infinity()
+	}
+	// (X/Z, Y/Z) = (XZ/Z^2, YZ^2/Z^3)
+	return &Point{c: c, x: p.x.Mul(p.z), y: p.y.Mul(p.z.Square()), z: p.z}
+}
+
+// addComplete は Renes-Costello-Batina の完全加算公式 (Algorithm 1) で p + q を返します
+// 曲線の位数が奇数であれば p, q が無限遠点や同じ点でも同じ手順で正しく計算できます
+func (c *Curve) addComplete(p, q *projPoint) *projPoint {
+	a := c.a
+	b3 := c.b.Add(c.b).Add(c.b)
+
+	t0 := p.x.Mul(q.x)
+	t1 := p.y.Mul(q.y)
+	t2 := p.z.Mul(q.z)
+	t3 := p.x.Add(p.y).Mul(q.x.Add(q.y))
+	t4 := t0.Add(t1)
+	t3 = t3.Sub(t4)
+	t4 = p.x.Add(p.z).Mul(q.x.Add(q.z))
+	t5 := t0.Add(t2)
+	t4 = t4.Sub(t5)
+	t5 = p.y.Add(p.z).Mul(q.y.Add(q.z))
+	x3 := t1.Add(t2)
+	t5 = t5.Sub(x3)
+	z3 := a.Mul(t4)
+	x3 = b3.Mul(t2)
+	z3 = x3.Add(z3)
+	x3 = t1.Sub(z3)
+	z3 = t1.Add(z3)
+	y3 := x3.Mul(z3)
+	t1 = t0.Add(t0).Add(t0)
+	t2 = a.Mul(t2)
+	t4 = b3.Mul(t4)
+	t1 = t1.Add(t2)
+	t2 = a.Mul(t0.Sub(t2))
+	t4 = t4.Add(t2)
+	t2 = t1.Mul(t4)
+	y3 = y3.Add(t2)
+	t2 = t5.Mul(t4)
+	x3 = t3.Mul(x3).Sub(t2)
+	t2 = t3.Mul(t1)
+	z3 = t5.Mul(z3).Add(t2)
+	return &projPoint{x: x3, y: y3, z: z3}
+}
+
+// encodeProj は X, Y, Z を固定長のバイト列に並べます
+// 定数時間の選択や交換はこのバイト列に対して行います
+func (c *Curve) encodeProj(p *projPoint) []byte {
+	l := c.byteLen()
+	buf := make([]byte, 3*l)
+	copy(buf[:l], p.x.Bytes())
+	copy(buf[l:2*l], p.y.Bytes())
+	copy(buf[2*l:], p.z.Bytes())
+	return buf
+}
+
+// decodeProj は encodeProj で並べたバイト列から点を読み込みます
+func (c *Curve) decodeProj(buf []byte) *projPoint {
+	l := c.byteLen()
+	x, err := c.f.SetBytes(buf[:l])
+	if err != nil {
+		panic(err)
+	}
+	y, err := c.f.SetBytes(buf[l : 2*l])
+	if err != nil {
+		panic(err)
+	}
+	z, err := c.f.SetBytes(buf[2*l:])
+	if err != nil {
+		panic(err)
+	}
+	return &projPoint{x: x, y: y, z: z}
+}
+
+// cswap は swap == 1 のときだけ p と q を入れ替えた組を、分岐せずに返します
+func (c *Curve) cswap(p, q *projPoint, swap int) (*projPoint, *projPoint) {
+	pb := c.encodeProj(p)
+	qb := c.encodeProj(q)
+	tmp := make([]byte, len(pb))
+	copy(tmp, pb)
+	subtle.ConstantTimeCopy(swap, pb, qb)
+	subtle.ConstantTimeCopy(swap, qb, tmp)
+	return c.decodeProj(pb), c.decodeProj(qb)
+}
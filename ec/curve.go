@@ -0,0 +1,139 @@
+// Package ec は素体 GF(p) 上の短 Weierstrass 曲線 y^2 = x^3 + ax + b の楕円曲線演算を提供します
+// 点は内部で Jacobian 座標 (X, Y, Z) (x = X/Z^2, y = Y/Z^3) で保持し、加算と2倍算で逆元の計算を避けます
+package ec
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/convto/mycrypto/big"
+	"github.com/convto/mycrypto/field"
+)
+
+var (
+	// ErrNotOnCurve は点が曲線上にないことを表します
+	ErrNotOnCurve = errors.New("ec: point is not on the curve")
+	// ErrInvalidEncoding は点のバイト表現が SEC1 の形式でないことを表します
+	ErrInvalidEncoding = errors.New("ec: invalid point encoding")
+)
+
+// Curve は (p, a, b, G, n, h) で定義される楕円曲線です
+type Curve struct {
+	name string
+	f    *field.Field
+	a    *field.Element
+	b    *field.Element
+	g    *Point
+	n    *big.Int
+	h    *big.Int
+	// a = -3 のときは2倍算で乗算を減らせる
+	aIsMinus3 bool
+	// G の comb テーブルは最初の ScalarBaseMult で作る
+	combOnce sync.Once
+	comb     *CombTable
+}
+
+// NewCurve は y^2 = x^3 + ax + b mod p の曲線を、位数 n の生成元 G = (gx, gy) と余因子 h とともに返します
+// G が曲線上にないときはpanicします
+func NewCurve(name string, p, a, b, gx, gy, n, h *big.Int) *Curve {
+	f := field.New(p)
+	c := &Curve{
+		name: name,
+		f:    f,
+		a:    f.NewElement(a),
+		b:    f.NewElement(b),
+		n:    n,
+		h:    h,
+	}
+	c.aIsMinus3 = c.a.Equal(f.NewElement(big.NewInt(-3)))
+	g, err := c.NewPoint(gx, gy)
+	if err != nil {
+		panic("ec: generator is not on the curve")
+	}
+	c.g = g
+	return c
+}
+
+// Name は曲線の名前を返します
+func (c *Curve) Name() string {
+	return c.name
+}
+
+// Field は座標の属する素体 GF(p) を返します
+func (c *Curve) Field() *field.Field {
+	return c.f
+}
+
+// P は素体の位数 p を返します
+func (c *Curve) P() *big.Int {
+	return c.f.Modulus()
+}
+
+// A は曲線の係数 a を返します
+func (c *Curve) A() *big.Int {
+	return c.a.Int()
+}
+
+// B は曲線の係数 b を返します
+func (c *Curve) B() *big.Int {
+	return c.b.Int()
+}
+
+// N は生成元 G の位数 n を返します
+func (c *Curve) N() *big.Int {
+	return c.n
+}
+
+// H は余因子 h を返します
+func (c *Curve) H() *big.Int {
+	return c.h
+}
+
+// Generator は生成元 G を返します
+func (c *Curve) Generator() *Point {
+	return c.g
+}
+
+// Infinity は無限遠点を返します
+func (c *Curve) Infinity() *Point {
+	return &Point{c: c, x: c.f.One(), y: c.f.One(), z: c.f.Zero()}
+}
+
+// IsOnCurve は 0 <= x, y < p の (x, y) が曲線上の点かどうかを判定します
+func (c *Curve) IsOnCurve(x, y *big.Int) bool {
+	p := c.f.Modulus()
+	if big.Cmp(x, big.Zero) < 0 || big.Cmp(x, p) >= 0 || big.Cmp(y, big.Zero) < 0 || big.Cmp(y, p) >= 0 {
+		return false
+	}
+	fx := c.f.NewElement(x)
+	fy := c.f.NewElement(y)
+	return fy.Square().Equal(c.rhs(fx))
+}
+
+// NewPoint は座標 (x, y) の点を返します
+// 曲線上にないときは ErrNotOnCurve を返します
+func (c *Curve) NewPoint(x, y *big.Int) (*Point, error) {
+	if !c.IsOnCurve(x, y) {
+		return nil, ErrNotOnCurve
+	}
+	return &Point{c: c, x: c.f.NewElement(x), y: c.f.NewElement(y), z: c.f.One()}, nil
+}
+
+// ScalarBaseMult は kG を返します
+// G の comb テーブルを使い、k は秘密でもよいものとして定数時間で計算します
+func (c *Curve) ScalarBaseMult(k *big.Int) *Point {
+	c.combOnce.Do(func() {
+		c.comb = NewCombTable(c.g, combWidth)
+	})
+	return c.comb.ScalarMult(k)
+}
+
+// rhs は x^3 + ax + b を返します
+func (c *Curve) rhs(x *field.Element) *field.Element {
+	return x.Square().Mul(x).Add(c.a.Mul(x)).Add(c.b)
+}
+
+// byteLen は座標のバイト表現の長さを返します
+func (c *Curve) byteLen() int {
+	return c.f.ByteLen()
+}
@@ -0,0 +1,98 @@
+package ec
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/convto/mycrypto/big"
+)
+
+// hexInt は16進数の文字列を整数にします
+func hexInt(s string) *big.Int {
+	if len(s)%2 == 1 {
+		s = "0" + s
+	}
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		panic(err)
+	}
+	return new(big.Int).SetBytes(b)
+}
+
+func TestCurve_order(t *testing.T) {
+	for _, c := range []*Curve{P224(), P256(), P384(), P521(), Secp256k1()} {
+		t.Run(c.Name(), func(t *testing.T) {
+			if got := c.ScalarBaseMult(c.N()); !got.IsInfinity() {
+				t.Errorf("nG = %v, want infinity", got)
+			}
+			if got, want := c.ScalarBaseMult(big.Sub(c.N(), big.NewInt(1))), c.Generator().Neg(); !got.Equal(want) {
+				t.Errorf("(n-1)G = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestCurve_IsOnCurve(t *testing.T) {
+	c := P256()
+	gx, gy := c.Generator().Affine()
+	type args struct {
+		x *big.Int
+		y *big.Int
+	}
+	tests := []struct {
+		name string
+		args args
+		want bool
+	}{
+		{
+			name: "generator",
+			args: args{x: gx, y: gy},
+			want: true,
+		},
+		{
+			name: "off curve",
+			args: args{x: gx, y: big.Add(gy, big.NewInt(1))},
+			want: false,
+		},
+		{
+			name: "y >= p",
+			args: args{x: gx, y: big.Add(gy, c.P())},
+			want: false,
+		},
+		{
+			name: "negative x",
+			args: args{x: big.Sub(gx, c.P()), y: gy},
+			want: false,
+		},
+		{
+			name: "origin",
+			args: args{x: big.NewInt(0), y: big.NewInt(0)},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := c.IsOnCurve(tt.args.x, tt.args.y); got != tt.want {
+				t.Errorf("IsOnCurve() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewCurve(t *testing.T) {
+	// y^2 = x^3 + 2x + 3 mod 97 上の点 (3, 6) で生成される位数5の部分群
+	c := NewCurve("toy", big.NewInt(97), big.NewInt(2), big.NewInt(3), big.NewInt(3), big.NewInt(6), big.NewInt(5), big.NewInt(20))
+	if got := c.ScalarBaseMult(big.NewInt(5)); !got.IsInfinity() {
+		t.Errorf("5G = %v, want infinity", got)
+	}
+	if got := c.A(); big.Cmp(got, big.NewInt(2)) != 0 {
+		t.Errorf("A() = %v, want 2", got)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("NewCurve() did not panic for generator off the curve")
+		}
+	}()
+	NewCurve("toy", big.NewInt(97), big.NewInt(2), big.NewInt(3), big.NewInt(3), big.NewInt(7), big.NewInt(5), big.NewInt(20))
+}
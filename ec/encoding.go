@@ -0,0 +1,85 @@
+package ec
+
+import (
+	"github.com/convto/mycrypto/big"
+)
+
+// SEC1 の点のバイト表現の先頭バイト
+const (
+	tagInfinity     = 0x00
+	tagCompressed   = 0x02
+	tagUncompressed = 0x04
+)
+
+// Bytes は p を SEC1 の非圧縮形式 0x04 || x || y で返します
+// 無限遠点は 0x00 の1バイトになります
+func (p *Point) Bytes() []byte {
+	if p.IsInfinity() {
+		return []byte{tagInfinity}
+	}
+	l := p.c.byteLen()
+	x, y := p.Affine()
+	buf := make([]byte, 1+2*l)
+	buf[0] = tagUncompressed
+	x.FillBytes(buf[1 : 1+l])
+	y.FillBytes(buf[1+l:])
+	return buf
+}
+
+// BytesCompressed は p を SEC1 の圧縮形式 (0x02 | y の偶奇) || x で返します
+// 無限遠点は 0x00 の1バイトになります
+func (p *Point) BytesCompressed() []byte {
+	if p.IsInfinity() {
+		return []byte{tagInfinity}
+	}
+	l := p.c.byteLen()
+	x, y := p.Affine()
+	buf := make([]byte, 1+l)
+	buf[0] = tagCompressed
+	if isOdd(y) {
+		buf[0] |= 1
+	}
+	x.FillBytes(buf[1:])
+	return buf
+}
+
+// SetBytes は SEC1 の非圧縮形式または圧縮形式のバイト列から点を読み込みます
+// 圧縮形式では y^2 = x^3 + ax + b の平方根から y を復元します
+// 形式が正しくないときは ErrInvalidEncoding を、曲線上の点でないときは ErrNotOnCurve を返します
+func (c *Curve) SetBytes(b []byte) (*Point, error) {
+	l := c.byteLen()
+	switch {
+	case len(b) == 1 && b[0] == tagInfinity:
+		return c.Infinity(), nil
+	case len(b) == 1+2*l && b[0] == tagUncompressed:
+		x := new(big.Int).SetBytes(b[1 : 1+l])
+		y := new(big.Int).SetBytes(b[1+l:])
+		return c.NewPoint(x, y)
+	case len(b) == 1+l && (b[0] == tagCompressed || b[0] == tagCompressed|1):
+		x := new(big.Int).SetBytes(b[1:])
+		if big.Cmp(x, c.P()) >= 0 {
+			return nil, ErrInvalidEncoding
+		}
+		fx := c.f.NewElement(x)
+		fy, ok := c.rhs(fx).Sqrt()
+		if !ok {
+			return nil, ErrNotOnCurve
+		}
+		// y = 0 のときは -y も 0 なので、奇数を指定するタグは SEC1 2.3.4 に従ってエラーにする
+		if fy.IsZero() && b[0]&1 == 1 {
+			return nil, ErrInvalidEncoding
+		}
+		if isOdd(fy.Int()) != (b[0]&1 == 1) {
+			fy = fy.Neg()
+		}
+		return &Point{c: c, x: fx, y: fy, z: c.f.One()}, nil
+	default:
+		return nil, ErrInvalidEncoding
+	}
+}
+
+// isOdd は x が奇数かどうかを判定します
+func isOdd(x *big.Int) bool {
+	b := x.Bytes()
+	return len(b) > 0 && b[len(b)-1]&1 == 1
+}
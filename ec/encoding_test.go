@@ -0,0 +1,172 @@
+package ec
+
+import (
+	"crypto/elliptic"
+	"encoding/hex"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/convto/mycrypto/big"
+)
+
+func TestCurve_SetBytes(t *testing.T) {
+	tests := []struct {
+		name string
+		c    *Curve
+		in   string
+		x    string
+		y    string
+	}{
+		{
+			// crypto/elliptic の TestMarshalCompressed より
+			name: "P-256 compressed odd",
+			c:    P256(),
+			in:   "031e3987d9f9ea9d7dd7155a56a86b2009e1e0ab332f962d10d8beb6406ab1ad79",
+			x:    "13671033352574878777044637384712060483119675368076128232297328793087057702265",
+			y:    "66200849279091436748794323380043701364391950689352563629885086590854940586447",
+		},
+		{
+			name: "P-256 compressed even",
+			c:    P256(),
+			in:   "021e3987d9f9ea9d7dd7155a56a86b2009e1e0ab332f962d10d8beb6406ab1ad79",
+			x:    "13671033352574878777044637384712060483119675368076128232297328793087057702265",
+			y:    "49591239931264812013903123569363872165694192725937750565648544718012157267504",
+		},
+		{
+			// crypto/elliptic の TestP224Overflow より
+			name: "P-224 uncompressed",
+			c:    P224(),
+			in:   "049b535b45fb0a2072398a6831834624c7e32ccfd5a4b933bceaf77f1dd945e08bbe5178f5edf5e733388f196d2a631d2e075bb16cbfeea15b",
+			x:    "16357696098828298314739606484210511542564610803197893304138457382685",
+			y:    "22881513076996345509910383077229209328584240248660314504019707863387",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, _ := hex.DecodeString(tt.in)
+			p, err := tt.c.SetBytes(b)
+			if err != nil {
+				t.Fatalf("SetBytes() error = %v", err)
+			}
+			x, y := p.Affine()
+			if x.String() != tt.x || y.String() != tt.y {
+				t.Errorf("SetBytes() = (%v, %v), want (%v, %v)", x, y, tt.x, tt.y)
+			}
+			var got []byte
+			if len(b) == 1+tt.c.byteLen() {
+				got = p.BytesCompressed()
+			} else {
+				got = p.Bytes()
+			}
+			if !reflect.DeepEqual(got, b) {
+				t.Errorf("encoding = %x, want %x", got, b)
+			}
+		})
+	}
+}
+
+func TestCurve_SetBytes_invalid(t *testing.T) {
+	c := P256()
+	tests := []struct {
+		name string
+		in   string
+		want error
+	}{
+		{
+			// crypto/elliptic の TestMarshalCompressed より。x^3 + ax + b が平方非剰余
+			name: "no square root",
+			in:   "02fd4bf61763b46581fd9174d623516cf3c81edd40e29ffa2777fb6cb0ae3ce535",
+			want: ErrNotOnCurve,
+		},
+		{
+			name: "x >= p",
+			in:   "02ffffffff00000001000000000000000000000001000000000000000000000000",
+			want: ErrInvalidEncoding,
+		},
+		{
+			name: "off curve",
+			in:   "04" + "0000000000000000000000000000000000000000000000000000000000000000" + "0000000000000000000000000000000000000000000000000000000000000000",
+			want: ErrNotOnCurve,
+		},
+		{
+			name: "invalid tag",
+			in:   "051e3987d9f9ea9d7dd7155a56a86b2009e1e0ab332f962d10d8beb6406ab1ad79",
+			want: ErrInvalidEncoding,
+		},
+		{
+			name: "short",
+			in:   "021e39",
+			want: ErrInvalidEncoding,
+		},
+		{
+			name: "empty",
+			in:   "",
+			want: ErrInvalidEncoding,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, _ := hex.DecodeString(tt.in)
+			if _, err := c.SetBytes(b); !errors.Is(err, tt.want) {
+				t.Errorf("SetBytes() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+// TestCurve_SetBytes_zeroY は y = 0 の点の圧縮形式で、偶数のタグだけを受け付けることを確かめる
+func TestCurve_SetBytes_zeroY(t *testing.T) {
+	// y^2 = x^3 + 2x + 3 mod 97 では x = 30 のとき右辺が 0 になる
+	c := NewCurve("toy", big.NewInt(97), big.NewInt(2), big.NewInt(3), big.NewInt(3), big.NewInt(6), big.NewInt(5), big.NewInt(20))
+	p, err := c.SetBytes([]byte{0x02, 30})
+	if err != nil {
+		t.Fatalf("SetBytes(02 || x) error = %v", err)
+	}
+	if x, y := p.Affine(); big.Cmp(x, big.NewInt(30)) != 0 || big.Cmp(y, big.Zero) != 0 {
+		t.Errorf("SetBytes(02 || x) = (%v, %v), want (30, 0)", x, y)
+	}
+	if _, err := c.SetBytes([]byte{0x03, 30}); !errors.Is(err, ErrInvalidEncoding) {
+		t.Errorf("SetBytes(03 || x) error = %v, want %v", err, ErrInvalidEncoding)
+	}
+}
+
+func TestPoint_Bytes(t *testing.T) {
+	curves := []struct {
+		c   *Curve
+		std elliptic.Curve
+	}{
+		{P224(), elliptic.P224()},
+		{P256(), elliptic.P256()},
+		{P384(), elliptic.P384()},
+		{P521(), elliptic.P521()},
+	}
+	for _, cc := range curves {
+		t.Run(cc.c.Name(), func(t *testing.T) {
+			p := cc.c.ScalarBaseMult(big.NewInt(123456789))
+			x, y := p.Affine()
+			if got, want := p.Bytes(), elliptic.Marshal(cc.std, toStd(x), toStd(y)); !reflect.DeepEqual(got, want) {
+				t.Errorf("Bytes() = %x, want %x", got, want)
+			}
+			if got, want := p.BytesCompressed(), elliptic.MarshalCompressed(cc.std, toStd(x), toStd(y)); !reflect.DeepEqual(got, want) {
+				t.Errorf("BytesCompressed() = %x, want %x", got, want)
+			}
+			for _, b := range [][]byte{p.Bytes(), p.BytesCompressed()} {
+				got, err := cc.c.SetBytes(b)
+				if err != nil {
+					t.Fatalf("SetBytes() error = %v", err)
+				}
+				if !got.Equal(p) {
+					t.Errorf("SetBytes() = %v, want %v", got, p)
+				}
+			}
+		})
+	}
+	inf := P256().Infinity()
+	if got := inf.Bytes(); !reflect.DeepEqual(got, []byte{0}) {
+		t.Errorf("Bytes() = %x, want 00", got)
+	}
+	if got, err := P256().SetBytes([]byte{0}); err != nil || !got.IsInfinity() {
+		t.Errorf("SetBytes(00) = %v, %v, want infinity", got, err)
+	}
+}
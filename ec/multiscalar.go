@@ -0,0 +1,89 @@
+package ec
+
+import (
+	"math/bits"
+
+	"github.com/convto/mycrypto/big"
+)
+
+// MultiScalarMult は k_0 p_0 + k_1 p_1 + ... + k_(m-1) p_(m-1) を Pippenger のバケット法で求めます
+// スカラーを c ビットの窓に区切り、窓ごとに同じ桁の点をバケットにまとめて足すので、個別にスカラー倍して足すより加算の回数が少なくなります
+// 実行時間がスカラーに依存するので、署名のバッチ検証のように公開されたスカラーにだけ使います
+// points と scalars の長さが異なるときや、空のとき、点が同じ曲線上にないときはpanicします
+func MultiScalarMult(points []*Point, scalars []*big.Int) *Point {
+	if len(points) != len(scalars) {
+		panic("ec: mismatched number of points and scalars")
+	}
+	if len(points) == 0 {
+		panic("ec: no points")
+	}
+	c := points[0].c
+
+	// 負のスカラーは点の符号に移し、各スカラーをビッグエンディアンのバイト列で持っておく
+	ps := make([]*Point, len(points))
+	ks := make([][]byte, len(points))
+	maxBits := 0
+	for i, p := range points {
+		p.check(points[0])
+		k := scalars[i]
+		if big.Cmp(k, big.Zero) < 0 {
+			p = p.Neg()
+			k = big.Sub(big.Zero, k)
+		}
+		ps[i] = p
+		ks[i] = k.Bytes()
+		if l := k.BitLen(); l > maxBits {
+			maxBits = l
+		}
+	}
+
+	w := windowSize(len(points))
+	buckets := make([]*Point, 1<<uint(w))
+	r := c.Infinity()
+	for top := (maxBits + w - 1) / w * w; top > 0; top -= w {
+		for j := 0; j < w; j++ {
+			r = r.Double()
+		}
+		for j := range buckets {
+			buckets[j] = c.Infinity()
+		}
+		for i, p := range ps {
+			if d := window(ks[i], top-w, w); d != 0 {
+				buckets[d] = buckets[d].Add(p)
+			}
+		}
+		// Σ d * B_d を上位のバケットからの累積和の和で求める
+		sum, acc := c.Infinity(), c.Infinity()
+		for d := len(buckets) - 1; d > 0; d-- {
+			sum = sum.Add(buckets[d])
+			acc = acc.Add(sum)
+		}
+		r = r.Add(acc)
+	}
+	return r
+}
+
+// windowSize は点の数 m に対して、加算の回数がおおよそ最小になる窓の幅 log2(m) 程度を返します
+func windowSize(m int) int {
+	w := bits.Len(uint(m)) - 1
+	if w < 2 {
+		return 2
+	}
+	if w > 16 {
+		return 16
+	}
+	return w
+}
+
+// window はビッグエンディアンのバイト列 k の lo ビット目から w ビットを取り出します
+func window(k []byte, lo, w int) int {
+	d := 0
+	for i := lo + w - 1; i >= lo; i-- {
+		d <<= 1
+		byteIdx := len(k) - 1 - i/8
+		if byteIdx >= 0 && k[byteIdx]>>uint(i%8)&1 == 1 {
+			d |= 1
+		}
+	}
+	return d
+}
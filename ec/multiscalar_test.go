@@ -0,0 +1,38 @@
+package ec
+
+import (
+	"testing"
+
+	"github.com/convto/mycrypto/big"
+)
+
+func TestMultiScalarMult(t *testing.T) {
+	for _, c := range []*Curve{P256(), Secp256k1(), toyCurve} {
+		t.Run(c.Name(), func(t *testing.T) {
+			for _, m := range []int{1, 2, 5, 40} {
+				points := make([]*Point, m)
+				scalars := make([]*big.Int, m)
+				want := c.Infinity()
+				for i := range points {
+					points[i] = c.ScalarBaseMult(big.NewInt(int64(3*i + 1)))
+					ks := scalarMultTests(t, c)
+					scalars[i] = ks[i%len(ks)]
+					want = want.Add(doubleAndAdd(points[i], scalars[i]))
+				}
+				if got := MultiScalarMult(points, scalars); !got.Equal(want) {
+					t.Errorf("MultiScalarMult(m = %v) = %v, want %v", m, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestMultiScalarMult_cancel(t *testing.T) {
+	// kG + (-k)G は無限遠点になる
+	c := Secp256k1()
+	k := big.Sub(c.N(), big.NewInt(12345))
+	g := c.Generator()
+	if got := MultiScalarMult([]*Point{g, g}, []*big.Int{k, big.Sub(big.Zero, k)}); !got.IsInfinity() {
+		t.Errorf("MultiScalarMult() = %v, want infinity", got)
+	}
+}
@@ -0,0 +1,61 @@
+package ec
+
+import (
+	"github.com/convto/mycrypto/big"
+)
+
+var (
+	p224      = newNISTCurve("P-224", "26959946667150639794667015087019630673557916260026308143510066298881", "18958286285566608000408668544493926415504680968679321075787234672564", "19277929113566293071110308034699488026831934219452440156649784352033", "19926808758034470970197974370888749184205991990603949537637343198772", "26959946667150639794667015087019625940457807714424391721682722368061")
+	p256      = newNISTCurve("P-256", "115792089210356248762697446949407573530086143415290314195533631308867097853951", "41058363725152142129326129780047268409114441015993725554835256314039467401291", "48439561293906451759052585252797914202762949526041747995844080717082404635286", "36134250956749795798585127919587881956611106672985015071877198253568414405109", "115792089210356248762697446949407573529996955224135760342422259061068512044369")
+	p384      = newNISTCurve("P-384", "39402006196394479212279040100143613805079739270465446667948293404245721771496870329047266088258938001861606973112319", "27580193559959705877849011840389048093056905856361568521428707301988689241309860865136260764883745107765439761230575", "26247035095799689268623156744566981891852923491109213387815615900925518854738050089022388053975719786650872476732087", "8325710961489029985546751289520108179287853048861315594709205902480503199884419224438643760392947333078086511627871", "39402006196394479212279040100143613805079739270465446667946905279627659399113263569398956308152294913554433653942643")
+	p521      = newNISTCurve("P-521", "6864797660130609714981900799081393217269435300143305409394463459185543183397656052122559640661454554977296311391480858037121987999716643812574028291115057151", "1093849038073734274511112390766805569936207598951683748994586394495953116150735016013708737573759623248592132296706313309438452531591012912142327488478985984", "2661740802050217063228768716723360960729859168756973147706671368418802944996427808491545080627771902352094241225065558662157113545570916814161637315895999846", "3757180025770020463545507224491183603594455134769762486694567779615544477440556316691234405012945539562144444537289428522585666729196580810124344277578376784", "6864797660130609714981900799081393217269435300143305409394463459185543183397655394245057746333217197532963996371363321113864768612440380340372808892707005449")
+	secp256k1 = NewCurve(
+		"secp256k1",
+		new(big.Int).SetString("115792089237316195423570985008687907853269984665640564039457584007908834671663"),
+		big.NewInt(0),
+		big.NewInt(7),
+		new(big.Int).SetString("55066263022277343669578718895168534326250603453777594175500187360389116729240"),
+		new(big.Int).SetString("32670510020758816978083085130507043184471273380659243275938904335757337482424"),
+		new(big.Int).SetString("115792089237316195423570985008687907852837564279074904382605163141518161494337"),
+		big.NewInt(1),
+	)
+)
+
+// P224 は NIST P-224 (secp224r1) を返します
+func P224() *Curve {
+	return p224
+}
+
+// P256 は NIST P-256 (secp256r1) を返します
+func P256() *Curve {
+	return p256
+}
+
+// P384 は NIST P-384 (secp384r1) を返します
+func P384() *Curve {
+	return p384
+}
+
+// P521 は NIST P-521 (secp521r1) を返します
+func P521() *Curve {
+	return p521
+}
+
+// Secp256k1 は SEC 2 の secp256k1 を返します
+func Secp256k1() *Curve {
+	return secp256k1
+}
+
+// newNISTCurve は a = -3, h = 1 の NIST 曲線を10進数の文字列のパラメータから作ります
+func newNISTCurve(name, p, b, gx, gy, n string) *Curve {
+	return NewCurve(
+		name,
+		new(big.Int).SetString(p),
+		big.NewInt(-3),
+		new(big.Int).SetString(b),
+		new(big.Int).SetString(gx),
+		new(big.Int).SetString(gy),
+		new(big.Int).SetString(n),
+		big.NewInt(1),
+	)
+}
@@ -0,0 +1,141 @@
+package ec
+
+import (
+	"github.com/convto/mycrypto/big"
+	"github.com/convto/mycrypto/field"
+)
+
+// Point は Jacobian 座標 (X, Y, Z) で表した曲線上の点です
+// Z = 0 のときは無限遠点を表します
+type Point struct {
+	c *Curve
+	x *field.Element
+	y *field.Element
+	z *field.Element
+}
+
+// Curve は p が属する曲線を返します
+func (p *Point) Curve() *Curve {
+	return p.c
+}
+
+// IsInfinity は p が無限遠点かどうかを判定します
+func (p *Point) IsInfinity() bool {
+	return p.z.IsZero()
+}
+
+// Affine は p のアフィン座標 (x, y) を返します
+// 無限遠点のときは nil, nil を返します
+func (p *Point) Affine() (x, y *big.Int) {
+	if p.IsInfinity() {
+		return nil, nil
+	}
+	zinv := p.z.Inv()
+	zinv2 := zinv.Square()
+	return p.x.Mul(zinv2).Int(), p.y.Mul(zinv2).Mul(zinv).Int()
+}
+
+func (p *Point) String() string {
+	if p.IsInfinity() {
+		return "infinity"
+	}
+	x, y := p.Affine()
+	return "(" + x.String() + ", " + y.String() + ")"
+}
+
+// Equal は p と q が同じ点かどうかを判定します
+func (p *Point) Equal(q *Point) bool {
+	p.check(q)
+	if p.IsInfinity() || q.IsInfinity() {
+		return p.IsInfinity() && q.IsInfinity()
+	}
+	// X1/Z1^2 = X2/Z2^2 かつ Y1/Z1^3 = Y2/Z2^3 を分母を払って比べる
+	z1z1 := p.z.Square()
+	z2z2 := q.z.Square()
+	if !p.x.Mul(z2z2).Equal(q.x.Mul(z1z1)) {
+		return false
+	}
+	return p.y.Mul(z2z2).Mul(q.z).Equal(q.y.Mul(z1z1).Mul(p.z))
+}
+
+// Neg は -p を返します
+func (p *Point) Neg() *Point {
+	return &Point{c: p.c, x: p.x, y: p.y.Neg(), z: p.z}
+}
+
+// Add は p + q を返します
+func (p *Point) Add(q *Point) *Point {
+	p.check(q)
+	if p.IsInfinity() {
+		return q
+	}
+	if q.IsInfinity() {
+		return p
+	}
+	// add-2007-bl
+	z1z1 := p.z.Square()
+	z2z2 := q.z.Square()
+	u1 := p.x.Mul(z2z2)
+	u2 := q.x.Mul(z1z1)
+	s1 := p.y.Mul(q.z).Mul(z2z2)
+	s2 := q.y.Mul(p.z).Mul(z1z1)
+	h := u2.Sub(u1)
+	r := s2.Sub(s1)
+	if h.IsZero() {
+		// x 座標が等しいときは同じ点か互いに逆の点
+		if r.IsZero() {
+			return p.Double()
+		}
+		return p.c.Infinity()
+	}
+	r = r.Add(r)
+	i := h.Add(h).Square()
+	j := h.Mul(i)
+	v := u1.Mul(i)
+	x3 := r.Square().Sub(j).Sub(v).Sub(v)
+	s1j := s1.Mul(j)
+	y3 := r.Mul(v.Sub(x3)).Sub(s1j).Sub(s1j)
+	z3 := p.z.Add(q.z).Square().Sub(z1z1).Sub(z2z2).Mul(h)
+	return &Point{c: p.c, x: x3, y: y3, z: z3}
+}
+
+// Sub は p - q を返します
+func (p *Point) Sub(q *Point) *Point {
+	return p.Add(q.Neg())
+}
+
+// Double は 2p を返します
+func (p *Point) Double() *Point {
+	if p.IsInfinity() || p.y.IsZero() {
+		return p.c.Infinity()
+	}
+	// dbl-2007-bl
+	xx := p.x.Square()
+	yy := p.y.Square()
+	yyyy := yy.Square()
+	zz := p.z.Square()
+	s := p.x.Add(yy).Square().Sub(xx).Sub(yyyy)
+	s = s.Add(s)
+	var m *field.Element
+	if p.c.aIsMinus3 {
+		// a = -3 なら 3X^2 - 3Z^4 = 3(X - Z^2)(X + Z^2)
+		m = p.x.Sub(zz).Mul(p.x.Add(zz))
+		m = m.Add(m).Add(m)
+	} else {
+		m = xx.Add(xx).Add(xx).Add(p.c.a.Mul(zz.Square()))
+	}
+	x3 := m.Square().Sub(s).Sub(s)
+	y8 := yyyy.Add(yyyy)
+	y8 = y8.Add(y8)
+	y8 = y8.Add(y8)
+	y3 := m.Mul(s.Sub(x3)).Sub(y8)
+	z3 := p.y.Add(p.z).Square().Sub(yy).Sub(zz)
+	return &Point{c: p.c, x: x3, y: y3, z: z3}
+}
+
+// check は p と q が同じ曲線上の点であることを確かめ、異なる場合はpanicする
+func (p *Point) check(q *Point) {
+	if p.c != q.c {
+		panic("ec: points on different curves")
+	}
+}
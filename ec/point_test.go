@@ -0,0 +1,247 @@
+package ec
+
+import (
+	"crypto/elliptic"
+	"crypto/rand"
+	stdbig "math/big"
+	"testing"
+
+	"github.com/convto/mycrypto/big"
+)
+
+// crypto/elliptic の p224_test.go のベクトルから抜粋
+var p224BaseMultTests = []struct {
+	k    string
+	x, y string
+}{
+	{"1", "b70e0cbd6bb4bf7f321390b94a03c1d356c21122343280d6115c1d21", "bd376388b5f723fb4c22dfe6cd4375a05a07476444d5819985007e34"},
+	{"2", "706a46dc76dcb76798e60e6d89474788d16dc18032d268fd1a704fa6", "1c2b76a7bc25e7702a704fa986892849fca629487acf3709d2e4e8bb"},
+	{"3", "df1b1d66a551d0d31eff822558b9d2cc75c2180279fe0d08fd896d04", "a3f7f03cadd0be444c0aa56830130ddf77d317344e1af3591981a925"},
+	{"7", "db2f6be630e246a5cf7d99b85194b123d487e2d466b94b24a03c3e28", "f3a30085497f2f611ee2517b163ef8c53b715d18bb4e4808d02b963"},
+	{"16", "b6ec4fe1777382404ef679997ba8d1cc5cd8e85349259f590c4c66d", "3399d464345906b11b00e363ef429221f2ec720d2f665d7dead5b482"},
+	{"20", "fcc7f2b45df1cd5a3c0c0731ca47a8af75cfb0347e8354eefe782455", "d5d7110274cba7cdee90e1a8b0d394c376a5573db6be0bf2747f530"},
+	{"112233445566778899", "61f077c6f62ed802dad7c2f38f5c67f2cc453601e61bd076bb46179e", "2272f9e9f5933e70388ee652513443b5e289dd135dcc0d0299b225e4"},
+	{"112233445566778899112233445566778899", "29895f0af496bfc62b6ef8d8a65c88c613949b03668aab4f0429e35", "3ea6e53f9a841f2019ec24bde1a75677aa9b5902e61081c01064de93"},
+	{"6950511619965839450988900688150712778015737983940691968051900319680", "ab689930bcae4a4aa5f5cb085e823e8ae30fd365eb1da4aba9cf0379", "3345a121bbd233548af0d210654eb40bab788a03666419be6fbd34e7"},
+	{"13479972933410060327035789020509431695094902435494295338570602119423", "bdb6a8817c1f89da1c2f3dd8e97feb4494f2ed302a4ce2bc7f5f4025", "4c7020d57c00411889462d77a5438bb4e97d177700bf7243a07f1680"},
+	{"26959946667150639794667015087019625940457807714424391721682722368059", "706a46dc76dcb76798e60e6d89474788d16dc18032d268fd1a704fa6", "e3d4895843da188fd58fb0567976d7b50359d6b78530c8f62d1b1746"},
+	{"26959946667150639794667015087019625940457807714424391721682722368060", "b70e0cbd6bb4bf7f321390b94a03c1d356c21122343280d6115c1d21", "42c89c774a08dc04b3dd201932bc8a5ea5f8b89bbb2a7e667aff81cd"},
+}
+
+// crypto/elliptic の p256_test.go のベクトル
+var p256MultTests = []struct {
+	k          string
+	xIn, yIn   string
+	xOut, yOut string
+}{
+	{
+		"2a265f8bcbdcaf94d58519141e578124cb40d64a501fba9c11847b28965bc737",
+		"023819813ac969847059028ea88a1f30dfbcde03fc791d3a252c6b41211882ea",
+		"f93e4ae433cc12cf2a43fc0ef26400c0e125508224cdb649380f25479148a4ad",
+		"4d4de80f1534850d261075997e3049321a0864082d24a917863366c0724f5ae3",
+		"a22d2b7f7818a3563e0f7a76c9bf0921ac55e06e2e4d11795b233824b1db8cc0",
+	},
+	{
+		"313f72ff9fe811bf573176231b286a3bdb6f1b14e05c40146590727a71c3bccd",
+		"cc11887b2d66cbae8f4d306627192522932146b42f01d3c6f92bd5c8ba739b06",
+		"a2f08a029cd06b46183085bae9248b0ed15b70280c7ef13a457f5af382426031",
+		"831c3f6b5f762d2f461901577af41354ac5f228c2591f84f8a6e51e2e3f17991",
+		"93f90934cd0ef2c698cc471c60a93524e87ab31ca2412252337f364513e43684",
+	},
+}
+
+// toStd は big.Int を math/big の Int に変換します
+func toStd(x *big.Int) *stdbig.Int {
+	return new(stdbig.Int).SetBytes(x.Bytes())
+}
+
+func TestCurve_ScalarBaseMult_P224(t *testing.T) {
+	c := P224()
+	for _, tt := range p224BaseMultTests {
+		t.Run(tt.k, func(t *testing.T) {
+			x, y := c.ScalarBaseMult(new(big.Int).SetString(tt.k)).Affine()
+			if big.Cmp(x, hexInt(tt.x)) != 0 || big.Cmp(y, hexInt(tt.y)) != 0 {
+				t.Errorf("ScalarBaseMult() = (%x, %x), want (%s, %s)", x.Bytes(), y.Bytes(), tt.x, tt.y)
+			}
+		})
+	}
+}
+
+func TestPoint_ScalarMult_P256(t *testing.T) {
+	c := P256()
+	for _, tt := range p256MultTests {
+		t.Run(tt.k, func(t *testing.T) {
+			p, err := c.NewPoint(hexInt(tt.xIn), hexInt(tt.yIn))
+			if err != nil {
+				t.Fatal(err)
+			}
+			x, y := p.ScalarMult(hexInt(tt.k)).Affine()
+			if big.Cmp(x, hexInt(tt.xOut)) != 0 || big.Cmp(y, hexInt(tt.yOut)) != 0 {
+				t.Errorf("ScalarMult() = (%x, %x), want (%s, %s)", x.Bytes(), y.Bytes(), tt.xOut, tt.yOut)
+			}
+		})
+	}
+}
+
+func TestCurve_ScalarBaseMult_secp256k1(t *testing.T) {
+	c := Secp256k1()
+	tests := []struct {
+		name string
+		k    *big.Int
+		x    string
+		y    string
+	}{
+		{
+			name: "2G",
+			k:    big.NewInt(2),
+			x:    "c6047f9441ed7d6d3045406e95c07cd85c778e4b8cef3ca7abac09b95c709ee5",
+			y:    "1ae168fea63dc339a3c58419466ceaeef7f632653266d0e1236431a950cfe52a",
+		},
+		{
+			name: "3G",
+			k:    big.NewInt(3),
+			x:    "f9308a019258c31049344f85f89d5229b531c845836f99b08601f113bce036f9",
+			y:    "388f7b0f632de8140fe337e62a37f3566500a99934c2231b6cb9fd7584b8e672",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			x, y := c.ScalarBaseMult(tt.k).Affine()
+			if big.Cmp(x, hexInt(tt.x)) != 0 || big.Cmp(y, hexInt(tt.y)) != 0 {
+				t.Errorf("ScalarBaseMult() = (%x, %x), want (%s, %s)", x.Bytes(), y.Bytes(), tt.x, tt.y)
+			}
+		})
+	}
+}
+
+// TestPoint_crossCheck はランダムなスカラーで crypto/elliptic と結果を比べる
+func TestPoint_crossCheck(t *testing.T) {
+	curves := []struct {
+		c   *Curve
+		std elliptic.Curve
+	}{
+		{P224(), elliptic.P224()},
+		{P256(), elliptic.P256()},
+		{P384(), elliptic.P384()},
+		{P521(), elliptic.P521()},
+	}
+	for _, cc := range curves {
+		t.Run(cc.c.Name(), func(t *testing.T) {
+			k1, err := cc.c.Field().Random(rand.Reader)
+			if err != nil {
+				t.Fatal(err)
+			}
+			k2, err := cc.c.Field().Random(rand.Reader)
+			if err != nil {
+				t.Fatal(err)
+			}
+			p := cc.c.ScalarBaseMult(k1.Int())
+			q := p.ScalarMult(k2.Int())
+			sx, sy := cc.std.ScalarBaseMult(k1.Int().Bytes())
+			if x, y := p.Affine(); toStd(x).Cmp(sx) != 0 || toStd(y).Cmp(sy) != 0 {
+				t.Errorf("ScalarBaseMult() = %v, want (%v, %v)", p, sx, sy)
+			}
+			tx, ty := cc.std.ScalarMult(sx, sy, k2.Int().Bytes())
+			if x, y := q.Affine(); toStd(x).Cmp(tx) != 0 || toStd(y).Cmp(ty) != 0 {
+				t.Errorf("ScalarMult() = %v, want (%v, %v)", q, tx, ty)
+			}
+			ax, ay := cc.std.Add(sx, sy, tx, ty)
+			if x, y := p.Add(q).Affine(); toStd(x).Cmp(ax) != 0 || toStd(y).Cmp(ay) != 0 {
+				t.Errorf("Add() = %v, want (%v, %v)", p.Add(q), ax, ay)
+			}
+			dx, dy := cc.std.Double(sx, sy)
+			if x, y := p.Double().Affine(); toStd(x).Cmp(dx) != 0 || toStd(y).Cmp(dy) != 0 {
+				t.Errorf("Double() = %v, want (%v, %v)", p.Double(), dx, dy)
+			}
+		})
+	}
+}
+
+func TestPoint_Add(t *testing.T) {
+	c := Secp256k1()
+	g := c.Generator()
+	g2 := g.Double()
+	g3 := c.ScalarBaseMult(big.NewInt(3))
+	tests := []struct {
+		name string
+		p    *Point
+		q    *Point
+		want *Point
+	}{
+		{
+			name: "G + 2G",
+			p:    g,
+			q:    g2,
+			want: g3,
+		},
+		{
+			name: "G + G",
+			p:    g,
+			q:    g,
+			want: g2,
+		},
+		{
+			name: "G + (-G)",
+			p:    g,
+			q:    g.Neg(),
+			want: c.Infinity(),
+		},
+		{
+			name: "O + G",
+			p:    c.Infinity(),
+			q:    g,
+			want: g,
+		},
+		{
+			name: "3G - 2G",
+			p:    g3,
+			q:    g2.Neg(),
+			want: g,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.p.Add(tt.q); !got.Equal(tt.want) {
+				t.Errorf("Add() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPoint_ScalarMult(t *testing.T) {
+	c := P256()
+	g := c.Generator()
+	tests := []struct {
+		name string
+		k    *big.Int
+		want *Point
+	}{
+		{
+			name: "0G",
+			k:    big.NewInt(0),
+			want: c.Infinity(),
+		},
+		{
+			name: "1G",
+			k:    big.NewInt(1),
+			want: g,
+		},
+		{
+			name: "-1G",
+			k:    big.NewInt(-1),
+			want: g.Neg(),
+		},
+		{
+			name: "(n+2)G",
+			k:    big.Add(c.N(), big.NewInt(2)),
+			want: g.Double(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := g.ScalarMult(tt.k); !got.Equal(tt.want) {
+				t.Errorf("ScalarMult() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package ec
+
+import (
+	"github.com/convto/mycrypto/big"
+)
+
+// ScalarMultiplier はスカラー倍算 kp のアルゴリズムです
+//
+// 秘密のスカラーには Ladder を、署名検証のように公開されたスカラーには高速な WNAF を使います
+// どちらも同じ結果を返すので、用途に応じて差し替えられます
+// ただし土台の big.Int は10進数の可変長表現なので、個々の体演算の時間までは一定になりません
+type ScalarMultiplier interface {
+	ScalarMult(p *Point, k *big.Int) *Point
+}
+
+// ScalarMult は kp を返します
+// k は秘密でもよいものとして Ladder で計算します
+func (p *Point) ScalarMult(k *big.Int) *Point {
+	return Ladder{}.ScalarMult(p, k)
+}
+
+// Ladder は Montgomery ladder によるスカラー倍算です
+// k のビットによらず毎回同じ加算と2倍算を行い、点の入れ替えもバイト列の定数時間の選択で行います
+type Ladder struct{}
+
+// ScalarMult は kp を返します
+// 余因子が1の曲線では k を n で還元し、ループの回数を n のビット長に固定します
+func (Ladder) ScalarMult(p *Point, k *big.Int) *Point {
+	c := p.c
+	if big.Cmp(c.h, big.NewInt(1)) == 0 {
+		k = big.Mod(k, c.n)
+	} else if big.Cmp(k, big.Zero) < 0 {
+		p = p.Neg()
+		k = big.Sub(big.Zero, k)
+	}
+	l := c.n.BitLen()
+	if kl := k.BitLen(); kl > l {
+		l = kl
+	}
+
+	// R1 - R0 = p を保ちながら上位のビットから処理する
+	r0 := c.Infinity().toProj()
+	r1 := p.toProj()
+	swap := 0
+	kb := k.Bits(l)
+	for i := l - 1; i >= 0; i-- {
+		bit := int(kb[i])
+		r0, r1 = c.cswap(r0, r1, swap^bit)
+		swap = bit
+		r1 = c.addComplete(r0, r1)
+		r0 = c.addComplete(r0, r0)
+	}
+	r0, _ = c.cswap(r0, r1, swap)
+	return c.fromProj(r0)
+}
+
+// WNAF は幅 W の windowed NAF によるスカラー倍算です
+// 実行時間や加算の回数が k に依存するので、公開されたスカラーにだけ使います
+type WNAF struct {
+	// W は窓の幅で、2 <= W <= 8 の範囲で指定します
+	W int
+}
+
+// ScalarMult は kp を返します
+func (m WNAF) ScalarMult(p *Point, k *big.Int) *Point {
+	if m.W < 2 || m.W > 8 {
+		panic("ec: wNAF width must be between 2 and 8")
+	}
+	if big.Cmp(k, big.Zero) < 0 {
+		p = p.Neg()
+		k = big.Sub(big.Zero, k)
+	}
+
+	// p, 3p, 5p, ..., (2^(W-1) - 1)p を事前に計算しておく
+	table := make([]*Point, 1<<uint(m.W-2))
+	table[0] = p
+	p2 := p.Double()
+	for i := 1; i < len(table); i++ {
+		table[i] = table[i-1].Add(p2)
+	}
+
+	naf := wnaf(k, m.W)
+	r := p.c.Infinity()
+	for i := len(naf) - 1; i >= 0; i-- {
+		r = r.Double()
+		switch d := naf[i]; {
+		case d > 0:
+			r = r.Add(table[d/2])
+		case d < 0:
+			r = r.Sub(table[-d/2])
+		}
+	}
+	return r
+}
+
+// wnaf は k >= 0 の幅 w の NAF 表現を下位の桁から返します
+// 各桁は0か |d| < 2^(w-1) の奇数で、0でない桁の間には少なくとも w-1 個の0が並びます
+func wnaf(k *big.Int, w int) []int {
+	mod := big.NewInt(1 << uint(w))
+	half := 1 << uint(w-1)
+	two := big.NewInt(2)
+	var naf []int
+	for big.Cmp(k, big.Zero) > 0 {
+		// k が奇数なら k mod 2^w を -2^(w-1) < d < 2^(w-1) の範囲にとって k から引く
+		// w <= 8 なので k mod 2^w は1バイトに収まる
+		_, r := big.Div(k, mod)
+		d := 0
+		if b := r.Bytes(); len(b) > 0 && b[0]&1 == 1 {
+			d = int(b[0])
+			if d >= half {
+				d -= 1 << uint(w)
+			}
+			k = big.Sub(k, big.NewInt(int64(d)))
+		}
+		naf = append(naf, d)
+		k, _ = big.Div(k, two)
+	}
+	return naf
+}
@@ -0,0 +1,111 @@
+package ec
+
+import (
+	"crypto/rand"
+	"testing"
+
+	"github.com/convto/mycrypto/big"
+)
+
+// toyCurve は y^2 = x^3 + 2x + 3 mod 97 で、G = (3, 6) の位数は5、余因子は20
+var toyCurve = NewCurve("toy", big.NewInt(97), big.NewInt(2), big.NewInt(3), big.NewInt(3), big.NewInt(6), big.NewInt(5), big.NewInt(20))
+
+// doubleAndAdd は比較用の素朴な double-and-add です
+func doubleAndAdd(p *Point, k *big.Int) *Point {
+	if big.Cmp(k, big.Zero) < 0 {
+		p = p.Neg()
+		k = big.Sub(big.Zero, k)
+	}
+	r := p.c.Infinity()
+	for i := k.BitLen() - 1; i >= 0; i-- {
+		r = r.Double()
+		if k.Bit(i) == 1 {
+			r = r.Add(p)
+		}
+	}
+	return r
+}
+
+func scalarMultTests(t *testing.T, c *Curve) []*big.Int {
+	t.Helper()
+	k, err := c.Field().Random(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return []*big.Int{
+		big.NewInt(0),
+		big.NewInt(1),
+		big.NewInt(2),
+		big.NewInt(-7),
+		big.Sub(c.N(), big.NewInt(1)),
+		c.N(),
+		big.Add(c.N(), big.NewInt(3)),
+		k.Int(),
+	}
+}
+
+func TestScalarMultiplier(t *testing.T) {
+	multipliers := []struct {
+		name string
+		m    ScalarMultiplier
+	}{
+		{"Ladder", Ladder{}},
+		{"WNAF/2", WNAF{W: 2}},
+		{"WNAF/4", WNAF{W: 4}},
+		{"WNAF/5", WNAF{W: 5}},
+	}
+	for _, c := range []*Curve{P256(), toyCurve} {
+		p := c.Generator().Double()
+		for _, k := range scalarMultTests(t, c) {
+			want := doubleAndAdd(p, k)
+			for _, mm := range multipliers {
+				t.Run(c.Name()+"/"+mm.name+"/"+k.String(), func(t *testing.T) {
+					if got := mm.m.ScalarMult(p, k); !got.Equal(want) {
+						t.Errorf("ScalarMult() = %v, want %v", got, want)
+					}
+				})
+			}
+		}
+	}
+}
+
+func TestLadder_ScalarMult_cofactor(t *testing.T) {
+	// 位数50の点なので n = 5 で還元してはいけない
+	p, err := toyCurve.NewPoint(big.NewInt(0), big.NewInt(10))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, k := range []*big.Int{big.NewInt(7), big.NewInt(-3), big.NewInt(50), big.NewInt(123)} {
+		if got, want := (Ladder{}).ScalarMult(p, k), doubleAndAdd(p, k); !got.Equal(want) {
+			t.Errorf("ScalarMult(%v) = %v, want %v", k, got, want)
+		}
+	}
+}
+
+func Test_wnaf(t *testing.T) {
+	for _, w := range []int{2, 3, 5, 8} {
+		k := new(big.Int).SetString("115792089210356248762697446949407573529996955224135760342422259061068512044369")
+		naf := wnaf(k, w)
+		// Σ d_i 2^i が k に戻り、0でない桁の間隔が w 以上あることを確かめる
+		sum := big.NewInt(0)
+		last := -w
+		for i := len(naf) - 1; i >= 0; i-- {
+			sum = big.Add(big.Mul(sum, big.NewInt(2)), big.NewInt(int64(naf[i])))
+		}
+		for i, d := range naf {
+			if d == 0 {
+				continue
+			}
+			if d%2 == 0 || d >= 1<<uint(w-1) || d <= -(1<<uint(w-1)) {
+				t.Errorf("w = %d: invalid digit %d", w, d)
+			}
+			if i-last < w {
+				t.Errorf("w = %d: nonzero digits at %d and %d", w, last, i)
+			}
+			last = i
+		}
+		if big.Cmp(sum, k) != 0 {
+			t.Errorf("w = %d: Σ naf = %v, want %v", w, sum, k)
+		}
+	}
+}
@@ -0,0 +1,149 @@
+// Package ecdsa は ec パッケージの短 Weierstrass 曲線上の ECDSA 署名を提供します
+// 署名はメッセージのハッシュ値 (digest) に対して行い、ハッシュ関数の選択は呼び出し側に任せます
+package ecdsa
+
+import (
+	"errors"
+	"hash"
+	"io"
+
+	"github.com/convto/mycrypto/big"
+	"github.com/convto/mycrypto/ec"
+)
+
+// ErrInvalidPrivateKey は秘密鍵が 1 <= d < n の範囲にないことを表します
+var ErrInvalidPrivateKey = errors.New("ecdsa: private key out of range")
+
+// PublicKey は ECDSA の公開鍵 Q = dG です
+type PublicKey struct {
+	Curve *ec.Curve
+	Q     *ec.Point
+}
+
+// PrivateKey は ECDSA の秘密鍵 d です
+type PrivateKey struct {
+	PublicKey
+	D *big.Int
+}
+
+// Signature は ECDSA の署名 (r, s) です
+type Signature struct {
+	R *big.Int
+	S *big.Int
+}
+
+// NewPublicKey は曲線 c 上の点 (x, y) を公開鍵として返します
+// 曲線上にない場合は ec.ErrNotOnCurve を返します
+func NewPublicKey(c *ec.Curve, x, y *big.Int) (*PublicKey, error) {
+	q, err := c.NewPoint(x, y)
+	if err != nil {
+		return nil, err
+	}
+	return &PublicKey{Curve: c, Q: q}, nil
+}
+
+// NewPrivateKey は 1 <= d < n の d から秘密鍵を返します
+// 範囲外のときは ErrInvalidPrivateKey を返します
+func NewPrivateKey(c *ec.Curve, d *big.Int) (*PrivateKey, error) {
+	if !inRange(d, c.N()) {
+		return nil, ErrInvalidPrivateKey
+	}
+	return &PrivateKey{PublicKey: PublicKey{Curve: c, Q: c.ScalarBaseMult(d)}, D: d}, nil
+}
+
+// GenerateKey は r から読み込んだ乱数で曲線 c の鍵ペアを生成します
+func GenerateKey(c *ec.Curve, r io.Reader) (*PrivateKey, error) {
+	d, err := big.RandNonZeroInt(r, c.N())
+	if err != nil {
+		return nil, err
+	}
+	return NewPrivateKey(c, d)
+}
+
+// Sign は r から読み込んだ乱数をナンスにして digest に署名します
+func Sign(r io.Reader, priv *PrivateKey, digest []byte) (*Signature, error) {
+	for {
+		k, err := big.RandNonZeroInt(r, priv.Curve.N())
+		if err != nil {
+			return nil, err
+		}
+		if sig := sign(priv, k, digest); sig != nil {
+			return sig, nil
+		}
+	}
+}
+
+// SignDeterministic は RFC 6979 の決定的なナンスで digest に署名します
+// h には digest を計算したハッシュ関数を渡し、ナンス生成の HMAC-DRBG にも同じものを使います
+func SignDeterministic(priv *PrivateKey, h func() hash.Hash, digest []byte) *Signature {
+	g := newNonceGenerator(priv.Curve.N(), priv.D, h, digest)
+	for {
+		if sig := sign(priv, g.next(), digest); sig != nil {
+			return sig
+		}
+	}
+}
+
+// Verify は sig が公開鍵 pub による digest の正しい署名かどうかを判定します
+func Verify(pub *PublicKey, digest []byte, sig *Signature) bool {
+	c := pub.Curve
+	n := c.N()
+	if pub.Q.IsInfinity() {
+		return false
+	}
+	if !inRange(sig.R, n) || !inRange(sig.S, n) {
+		return false
+	}
+	// R = (e/s)G + (r/s)Q の x 座標が r と合うかを確かめる
+	// スカラーは公開された値なので Q の倍算には wNAF を使う
+	e := hashToInt(digest, n)
+	w := big.ModInverse(sig.S, n)
+	u1 := big.Mod(big.Mul(e, w), n)
+	u2 := big.Mod(big.Mul(sig.R, w), n)
+	p := c.ScalarBaseMult(u1).Add(ec.WNAF{W: 5}.ScalarMult(pub.Q, u2))
+	if p.IsInfinity() {
+		return false
+	}
+	x, _ := p.Affine()
+	return big.Cmp(big.Mod(x, n), sig.R) == 0
+}
+
+// sign はナンス k で署名を計算します
+// r = 0 または s = 0 になったときは別のナンスでやり直すために nil を返します
+func sign(priv *PrivateKey, k *big.Int, digest []byte) *Signature {
+	n := priv.Curve.N()
+	x, _ := priv.Curve.ScalarBaseMult(k).Affine()
+	r := big.Mod(x, n)
+	if big.Cmp(r, big.Zero) == 0 {
+		return nil
+	}
+	// s = k^-1 (e + rd) mod n
+	e := hashToInt(digest, n)
+	s := big.Mod(big.Mul(big.ModInverse(k, n), big.Add(e, big.Mul(r, priv.D))), n)
+	if big.Cmp(s, big.Zero) == 0 {
+		return nil
+	}
+	return &Signature{R: r, S: s}
+}
+
+// hashToInt は digest の上位 n のビット長分を整数として取り出します (SEC1 4.1.3 の e)
+func hashToInt(digest []byte, n *big.Int) *big.Int {
+	return bits2int(digest, n.BitLen())
+}
+
+// bits2int はバイト列の上位 qlen ビットを整数として取り出します
+func bits2int(b []byte, qlen int) *big.Int {
+	if l := (qlen + 7) / 8; len(b) > l {
+		b = b[:l]
+	}
+	x := new(big.Int).SetBytes(b)
+	if excess := len(b)*8 - qlen; excess > 0 {
+		x, _ = big.Div(x, big.Exp(big.NewInt(2), big.NewInt(int64(excess)), nil))
+	}
+	return x
+}
+
+// inRange は 1 <= x < n かどうかを判定します
+func inRange(x, n *big.Int) bool {
+	return big.Cmp(x, big.NewInt(1)) >= 0 && big.Cmp(x, n) < 0
+}
@@ -0,0 +1,148 @@
+package ecdsa
+
+import (
+	stdecdsa "crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"testing"
+
+	"github.com/convto/mycrypto/big"
+	"github.com/convto/mycrypto/ec"
+)
+
+func TestSign(t *testing.T) {
+	digest := sha256.Sum256([]byte("mycrypto"))
+	for _, c := range []*ec.Curve{ec.P256(), ec.Secp256k1()} {
+		t.Run(c.Name(), func(t *testing.T) {
+			priv, err := GenerateKey(c, rand.Reader)
+			if err != nil {
+				t.Fatal(err)
+			}
+			sig, err := Sign(rand.Reader, priv, digest[:])
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !Verify(&priv.PublicKey, digest[:], sig) {
+				t.Errorf("Verify() = false, want true")
+			}
+			// (r, n - s) も正しい署名になる
+			if !Verify(&priv.PublicKey, digest[:], &Signature{R: sig.R, S: big.Sub(c.N(), sig.S)}) {
+				t.Errorf("Verify() with negated s = false, want true")
+			}
+
+			other := sha256.Sum256([]byte("other message"))
+			if Verify(&priv.PublicKey, other[:], sig) {
+				t.Errorf("Verify() with other digest = true, want false")
+			}
+			if Verify(&priv.PublicKey, digest[:], &Signature{R: sig.S, S: sig.R}) {
+				t.Errorf("Verify() with swapped r, s = true, want false")
+			}
+		})
+	}
+}
+
+// TestSign_crossCheck は crypto/ecdsa と互いの署名を検証できることを確かめる
+func TestSign_crossCheck(t *testing.T) {
+	digest := sha256.Sum256([]byte("mycrypto"))
+	priv, err := GenerateKey(ec.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	std, err := stdecdsa.ParseRawPrivateKey(elliptic.P256(), priv.D.FillBytes(make([]byte, 32)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sig, err := Sign(rand.Reader, priv, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !stdecdsa.VerifyASN1(&std.PublicKey, digest[:], sig.MarshalDER()) {
+		t.Errorf("crypto/ecdsa rejected the signature")
+	}
+
+	b, err := stdecdsa.SignASN1(rand.Reader, std, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdSig, err := ParseDER(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !Verify(&priv.PublicKey, digest[:], stdSig) {
+		t.Errorf("Verify() rejected the signature from crypto/ecdsa")
+	}
+}
+
+func TestVerify_outOfRange(t *testing.T) {
+	c := ec.P256()
+	digest := sha256.Sum256([]byte("mycrypto"))
+	priv, err := NewPrivateKey(c, big.NewInt(12345))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sig := SignDeterministic(priv, sha256.New, digest[:])
+	tests := []struct {
+		name string
+		sig  *Signature
+	}{
+		{"r = 0", &Signature{R: big.NewInt(0), S: sig.S}},
+		{"s = 0", &Signature{R: sig.R, S: big.NewInt(0)}},
+		{"r + n", &Signature{R: big.Add(sig.R, c.N()), S: sig.S}},
+		{"s + n", &Signature{R: sig.R, S: big.Add(sig.S, c.N())}},
+		{"negative r", &Signature{R: big.Sub(big.Zero, sig.R), S: sig.S}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if Verify(&priv.PublicKey, digest[:], tt.sig) {
+				t.Errorf("Verify() = true, want false")
+			}
+		})
+	}
+}
+
+func TestNewPrivateKey(t *testing.T) {
+	c := ec.P256()
+	for _, d := range []*big.Int{big.NewInt(0), c.N(), big.NewInt(-1)} {
+		if _, err := NewPrivateKey(c, d); !errors.Is(err, ErrInvalidPrivateKey) {
+			t.Errorf("NewPrivateKey(%v) error = %v, want %v", d, err, ErrInvalidPrivateKey)
+		}
+	}
+}
+
+func Test_bits2int(t *testing.T) {
+	tests := []struct {
+		name string
+		b    []byte
+		qlen int
+		want *big.Int
+	}{
+		{
+			name: "shorter than qlen",
+			b:    []byte{0x01, 0x02},
+			qlen: 32,
+			want: big.NewInt(0x0102),
+		},
+		{
+			name: "truncate bytes",
+			b:    []byte{0x01, 0x02, 0x03, 0x04},
+			qlen: 16,
+			want: big.NewInt(0x0102),
+		},
+		{
+			name: "truncate bits",
+			b:    []byte{0xff, 0xff, 0xff},
+			qlen: 9,
+			want: big.NewInt(0x1ff),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := bits2int(tt.b, tt.qlen); big.Cmp(got, tt.want) != 0 {
+				t.Errorf("bits2int() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,147 @@
+package ecdsa
+
+import (
+	"errors"
+
+	"github.com/convto/mycrypto/big"
+	"github.com/convto/mycrypto/ec"
+)
+
+// ErrInvalidSignature は署名のバイト表現が正しい形式でないことを表します
+var ErrInvalidSignature = errors.New("ecdsa: invalid signature encoding")
+
+// ASN.1 のタグ
+const (
+	tagInteger  = 0x02
+	tagSequence = 0x30
+)
+
+// MarshalDER は sig を ASN.1 DER の SEQUENCE { r INTEGER, s INTEGER } で返します
+func (sig *Signature) MarshalDER() []byte {
+	body := append(derInteger(sig.R), derInteger(sig.S)...)
+	return append(derHeader(tagSequence, len(body)), body...)
+}
+
+// ParseDER は ASN.1 DER の署名を読み込みます
+// BER で許される冗長な長さや整数の表現、末尾の余分なバイトは ErrInvalidSignature として拒否します
+func ParseDER(b []byte) (*Signature, error) {
+	body, rest, err := derParse(b, tagSequence)
+	if err != nil || len(rest) != 0 {
+		return nil, ErrInvalidSignature
+	}
+	r, body, err := derParseInteger(body)
+	if err != nil {
+		return nil, err
+	}
+	s, body, err := derParseInteger(body)
+	if err != nil {
+		return nil, err
+	}
+	if len(body) != 0 {
+		return nil, ErrInvalidSignature
+	}
+	return &Signature{R: r, S: s}, nil
+}
+
+// MarshalRaw は sig を n のバイト長で固定した r || s (IEEE P1363 形式) で返します
+func (sig *Signature) MarshalRaw(c *ec.Curve) []byte {
+	l := (c.N().BitLen() + 7) / 8
+	buf := make([]byte, 2*l)
+	sig.R.FillBytes(buf[:l])
+	sig.S.FillBytes(buf[l:])
+	return buf
+}
+
+// ParseRaw は r || s 形式の署名を読み込みます
+// 長さが n のバイト長の2倍でないときは ErrInvalidSignature を返します
+func ParseRaw(c *ec.Curve, b []byte) (*Signature, error) {
+	l := (c.N().BitLen() + 7) / 8
+	if len(b) != 2*l {
+		return nil, ErrInvalidSignature
+	}
+	return &Signature{R: new(big.Int).SetBytes(b[:l]), S: new(big.Int).SetBytes(b[l:])}, nil
+}
+
+// IsLowS は s <= n/2 かどうかを判定します
+func (sig *Signature) IsLowS(c *ec.Curve) bool {
+	half, _ := big.Div(c.N(), big.NewInt(2))
+	return big.Cmp(sig.S, half) <= 0
+}
+
+// NormalizeS は s > n/2 のとき s を n - s に置き換えた署名を返します
+// (r, s) と (r, n - s) はどちらも正しい署名なので、s を小さい方にそろえて署名の展性をなくします
+func (sig *Signature) NormalizeS(c *ec.Curve) *Signature {
+	if sig.IsLowS(c) {
+		return sig
+	}
+	return &Signature{R: sig.R, S: big.Sub(c.N(), sig.S)}
+}
+
+// derHeader はタグと長さのバイト列を返します
+func derHeader(tag byte, l int) []byte {
+	if l < 0x80 {
+		return []byte{tag, byte(l)}
+	}
+	var lb []byte
+	for ; l > 0; l >>= 8 {
+		lb = append([]byte{byte(l)}, lb...)
+	}
+	return append([]byte{tag, 0x80 | byte(len(lb))}, lb...)
+}
+
+// derInteger は非負の整数 x の DER 表現を返します
+// 最上位ビットが立つときは負の数と区別するために 0x00 を前に付けます
+func derInteger(x *big.Int) []byte {
+	b := x.Bytes()
+	if len(b) == 0 || b[0]&0x80 != 0 {
+		b = append([]byte{0x00}, b...)
+	}
+	return append(derHeader(tagInteger, len(b)), b...)
+}
+
+// derParse は先頭の tag の要素の中身と残りのバイト列を返します
+func derParse(b []byte, tag byte) (body, rest []byte, err error) {
+	if len(b) < 2 || b[0] != tag {
+		return nil, nil, ErrInvalidSignature
+	}
+	l := int(b[1])
+	b = b[2:]
+	if l&0x80 != 0 {
+		// 長形式は長さが 0x80 以上のときだけ、先頭に0のない最短のバイト数で表す
+		n := l & 0x7f
+		if n == 0 || n > 4 || len(b) < n || b[0] == 0 {
+			return nil, nil, ErrInvalidSignature
+		}
+		l = 0
+		for _, v := range b[:n] {
+			l = l<<8 | int(v)
+		}
+		if l < 0x80 {
+			return nil, nil, ErrInvalidSignature
+		}
+		b = b[n:]
+	}
+	if len(b) < l {
+		return nil, nil, ErrInvalidSignature
+	}
+	return b[:l], b[l:], nil
+}
+
+// derParseInteger は先頭の正の INTEGER を読み込みます
+func derParseInteger(b []byte) (*big.Int, []byte, error) {
+	body, rest, err := derParse(b, tagInteger)
+	if err != nil {
+		return nil, nil, err
+	}
+	switch {
+	case len(body) == 0:
+		return nil, nil, ErrInvalidSignature
+	case body[0]&0x80 != 0:
+		// 負の数は署名の値として現れない
+		return nil, nil, ErrInvalidSignature
+	case len(body) > 1 && body[0] == 0 && body[1]&0x80 == 0:
+		// 不要な先頭の 0x00 は DER では許されない
+		return nil, nil, ErrInvalidSignature
+	}
+	return new(big.Int).SetBytes(body), rest, nil
+}
@@ -0,0 +1,139 @@
+package ecdsa
+
+import (
+	"encoding/hex"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/convto/mycrypto/big"
+	"github.com/convto/mycrypto/ec"
+)
+
+func TestSignature_MarshalDER(t *testing.T) {
+	tests := []struct {
+		name string
+		sig  *Signature
+		want string
+	}{
+		{
+			name: "small values",
+			sig:  &Signature{R: big.NewInt(1), S: big.NewInt(2)},
+			want: "3006020101020102",
+		},
+		{
+			name: "high bit needs leading zero",
+			sig:  &Signature{R: big.NewInt(0x80), S: big.NewInt(0x7f)},
+			want: "30070202008002017f",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want, _ := hex.DecodeString(tt.want)
+			got := tt.sig.MarshalDER()
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("MarshalDER() = %x, want %s", got, tt.want)
+			}
+			parsed, err := ParseDER(got)
+			if err != nil {
+				t.Fatalf("ParseDER() error = %v", err)
+			}
+			if !reflect.DeepEqual(parsed, tt.sig) {
+				t.Errorf("ParseDER() = %v, want %v", parsed, tt.sig)
+			}
+		})
+	}
+}
+
+func TestSignature_MarshalDER_longForm(t *testing.T) {
+	// P-521 の署名は SEQUENCE の長さが 0x80 以上になる
+	c := ec.P521()
+	n1 := big.Sub(c.N(), big.NewInt(1))
+	sig := &Signature{R: n1, S: n1}
+	b := sig.MarshalDER()
+	if b[1] != 0x81 {
+		t.Errorf("length = %x, want long form", b[1])
+	}
+	got, err := ParseDER(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, sig) {
+		t.Errorf("ParseDER() = %v, want %v", got, sig)
+	}
+}
+
+func TestParseDER_invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+	}{
+		{"empty", ""},
+		{"not a sequence", "3106020101020102"},
+		{"trailing data", "300602010102010200"},
+		{"trailing data in sequence", "30080201010201020500"},
+		{"negative integer", "30060201ff020102"},
+		{"leading zero", "300702020001020102"},
+		{"empty integer", "30050200020102"},
+		{"non-minimal long form length", "308106020101020102"},
+		{"truncated", "3006020101"},
+		{"missing s", "3003020101"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := hex.DecodeString(tt.in)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := ParseDER(b); !errors.Is(err, ErrInvalidSignature) {
+				t.Errorf("ParseDER() error = %v, want %v", err, ErrInvalidSignature)
+			}
+		})
+	}
+}
+
+func TestSignature_MarshalRaw(t *testing.T) {
+	c := ec.P256()
+	sig := &Signature{R: big.NewInt(1), S: big.NewInt(0x0203)}
+	b := sig.MarshalRaw(c)
+	if len(b) != 64 || b[31] != 0x01 || b[62] != 0x02 || b[63] != 0x03 {
+		t.Errorf("MarshalRaw() = %x", b)
+	}
+	got, err := ParseRaw(c, b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, sig) {
+		t.Errorf("ParseRaw() = %v, want %v", got, sig)
+	}
+	if _, err := ParseRaw(c, b[1:]); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("ParseRaw() error = %v, want %v", err, ErrInvalidSignature)
+	}
+}
+
+func TestSignature_NormalizeS(t *testing.T) {
+	c := ec.Secp256k1()
+	half, _ := big.Div(c.N(), big.NewInt(2))
+	tests := []struct {
+		name string
+		s    *big.Int
+		want *big.Int
+	}{
+		{"low", big.NewInt(5), big.NewInt(5)},
+		{"n/2", half, half},
+		{"n/2 + 1", big.Add(half, big.NewInt(1)), half},
+		{"n - 1", big.Sub(c.N(), big.NewInt(1)), big.NewInt(1)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sig := &Signature{R: big.NewInt(1), S: tt.s}
+			got := sig.NormalizeS(c)
+			if big.Cmp(got.S, tt.want) != 0 {
+				t.Errorf("NormalizeS() = %v, want %v", got.S, tt.want)
+			}
+			if !got.IsLowS(c) {
+				t.Errorf("IsLowS() = false after NormalizeS()")
+			}
+		})
+	}
+}
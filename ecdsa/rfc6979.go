@@ -0,0 +1,74 @@
+package ecdsa
+
+import (
+	"crypto/hmac"
+	"hash"
+
+	"github.com/convto/mycrypto/big"
+)
+
+// nonceGenerator は RFC 6979 3.2 の HMAC-DRBG でナンス k を順に生成します
+type nonceGenerator struct {
+	n       *big.Int
+	h       func() hash.Hash
+	k       []byte
+	v       []byte
+	started bool
+}
+
+// newNonceGenerator は秘密鍵 x と digest から RFC 6979 3.2 の手順 b から f までを行った状態を返します
+func newNonceGenerator(n, x *big.Int, h func() hash.Hash, digest []byte) *nonceGenerator {
+	rlen := (n.BitLen() + 7) / 8
+	hlen := h().Size()
+	g := &nonceGenerator{
+		n: n,
+		h: h,
+		k: make([]byte, hlen),
+		v: make([]byte, hlen),
+	}
+	for i := range g.v {
+		g.v[i] = 0x01
+	}
+
+	// bits2octets(h1) = int2octets(bits2int(h1) mod q)
+	z := big.Mod(bits2int(digest, n.BitLen()), n)
+	seed := append(x.FillBytes(make([]byte, rlen)), z.FillBytes(make([]byte, rlen))...)
+
+	g.k = g.mac(g.v, []byte{0x00}, seed)
+	g.v = g.mac(g.v)
+	g.k = g.mac(g.v, []byte{0x01}, seed)
+	g.v = g.mac(g.v)
+	return g
+}
+
+// next は 1 <= k < n の次のナンスを返します (RFC 6979 3.2 の手順 h)
+func (g *nonceGenerator) next() *big.Int {
+	qlen := g.n.BitLen()
+	for {
+		// 前回のナンスが使えなかったときは K, V を更新してから生成し直す
+		if g.started {
+			g.k = g.mac(g.v, []byte{0x00})
+			g.v = g.mac(g.v)
+		}
+		g.started = true
+
+		var t []byte
+		for len(t)*8 < qlen {
+			g.v = g.mac(g.v)
+			t = append(t, g.v...)
+		}
+		k := bits2int(t, qlen)
+		if inRange(k, g.n) {
+			return k
+		}
+	}
+}
+
+// mac は HMAC_K(data[0] || data[1] || ...) を返します
+func (g *nonceGenerator) mac(data ...[]byte) []byte {
+	m := hmac.New(g.h, g.k)
+	for _, d := range data {
+		m.Write(d)
+	}
+	return m.Sum(nil)
+}
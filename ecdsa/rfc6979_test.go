@@ -0,0 +1,151 @@
+package ecdsa
+
+import (
+	"crypto"
+	stdecdsa "crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"hash"
+	"reflect"
+	"testing"
+
+	"github.com/convto/mycrypto/big"
+	"github.com/convto/mycrypto/ec"
+)
+
+func TestSignDeterministic_RFC6979(t *testing.T) {
+	// RFC 6979 A.2 の SHA-256 のベクトル (crypto/ecdsa の TestRFC6979 と同じもの)
+	// "wv[vnX" は k の生成で1回目の候補が範囲外になり、やり直しが起きるメッセージ
+	tests := []struct {
+		curve *ec.Curve
+		d     string
+		x     string
+		y     string
+		msg   string
+		r     string
+		s     string
+	}{
+		{
+			curve: ec.P224(),
+			d:     "F220266E1105BFE3083E03EC7A3A654651F45E37167E88600BF257C1",
+			x:     "00CF08DA5AD719E42707FA431292DEA11244D64FC51610D94B130D6C",
+			y:     "EEAB6F3DEBE455E3DBF85416F7030CBD94F34F2D6F232C69F3C1385A",
+			msg:   "sample",
+			r:     "61AA3DA010E8E8406C656BC477A7A7189895E7E840CDFE8FF42307BA",
+			s:     "BC814050DAB5D23770879494F9E0A680DC1AF7161991BDE692B10101",
+		},
+		{
+			curve: ec.P224(),
+			d:     "F220266E1105BFE3083E03EC7A3A654651F45E37167E88600BF257C1",
+			x:     "00CF08DA5AD719E42707FA431292DEA11244D64FC51610D94B130D6C",
+			y:     "EEAB6F3DEBE455E3DBF85416F7030CBD94F34F2D6F232C69F3C1385A",
+			msg:   "test",
+			r:     "AD04DDE87B84747A243A631EA47A1BA6D1FAA059149AD2440DE6FBA6",
+			s:     "178D49B1AE90E3D8B629BE3DB5683915F4E8C99FDF6E666CF37ADCFD",
+		},
+		{
+			curve: ec.P256(),
+			d:     "C9AFA9D845BA75166B5C215767B1D6934E50C3DB36E89B127B8A622B120F6721",
+			x:     "60FED4BA255A9D31C961EB74C6356D68C049B8923B61FA6CE669622E60F29FB6",
+			y:     "7903FE1008B8BC99A41AE9E95628BC64F2F1B20C2D7E9F5177A3C294D4462299",
+			msg:   "sample",
+			r:     "EFD48B2AACB6A8FD1140DD9CD45E81D69D2C877B56AAF991C34D0EA84EAF3716",
+			s:     "F7CB1C942D657C41D436C7A1B6E29F65F3E900DBB9AFF4064DC4AB2F843ACDA8",
+		},
+		{
+			curve: ec.P256(),
+			d:     "C9AFA9D845BA75166B5C215767B1D6934E50C3DB36E89B127B8A622B120F6721",
+			x:     "60FED4BA255A9D31C961EB74C6356D68C049B8923B61FA6CE669622E60F29FB6",
+			y:     "7903FE1008B8BC99A41AE9E95628BC64F2F1B20C2D7E9F5177A3C294D4462299",
+			msg:   "test",
+			r:     "F1ABB023518351CD71D881567B1EA663ED3EFCF6C5132B354F28D3B0B7D38367",
+			s:     "019F4113742A2B14BD25926B49C649155F267E60D3814B4C0CC84250E46F0083",
+		},
+		{
+			curve: ec.P256(),
+			d:     "C9AFA9D845BA75166B5C215767B1D6934E50C3DB36E89B127B8A622B120F6721",
+			x:     "60FED4BA255A9D31C961EB74C6356D68C049B8923B61FA6CE669622E60F29FB6",
+			y:     "7903FE1008B8BC99A41AE9E95628BC64F2F1B20C2D7E9F5177A3C294D4462299",
+			msg:   "wv[vnX",
+			r:     "EFD9073B652E76DA1B5A019C0E4A2E3FA529B035A6ABB91EF67F0ED7A1F21234",
+			s:     "3DB4706C9D9F4A4FE13BB5E08EF0FAB53A57DBAB2061C83A35FA411C68D2BA33",
+		},
+		{
+			curve: ec.P384(),
+			d:     "6B9D3DAD2E1B8C1C05B19875B6659F4DE23C3B667BF297BA9AA47740787137D896D5724E4C70A825F872C9EA60D2EDF5",
+			x:     "EC3A4E415B4E19A4568618029F427FA5DA9A8BC4AE92E02E06AAE5286B300C64DEF8F0EA9055866064A254515480BC13",
+			y:     "8015D9B72D7D57244EA8EF9AC0C621896708A59367F9DFB9F54CA84B3F1C9DB1288B231C3AE0D4FE7344FD2533264720",
+			msg:   "sample",
+			r:     "21B13D1E013C7FA1392D03C5F99AF8B30C570C6F98D4EA8E354B63A21D3DAA33BDE1E888E63355D92FA2B3C36D8FB2CD",
+			s:     "F3AA443FB107745BF4BD77CB3891674632068A10CA67E3D45DB2266FA7D1FEEBEFDC63ECCD1AC42EC0CB8668A4FA0AB0",
+		},
+		{
+			curve: ec.P521(),
+			d:     "0FAD06DAA62BA3B25D2FB40133DA757205DE67F5BB0018FEE8C86E1B68C7E75CAA896EB32F1F47C70855836A6D16FCC1466F6D8FBEC67DB89EC0C08B0E996B83538",
+			x:     "1894550D0785932E00EAA23B694F213F8C3121F86DC97A04E5A7167DB4E5BCD371123D46E45DB6B5D5370A7F20FB633155D38FFA16D2BD761DCAC474B9A2F5023A4",
+			y:     "0493101C962CD4D2FDDF782285E64584139C2F91B47F87FF82354D6630F746A28A0DB25741B5B34A828008B22ACC23F924FAAFBD4D33F81EA66956DFEAA2BFDFCF5",
+			msg:   "sample",
+			r:     "1511BB4D675114FE266FC4372B87682BAECC01D3CC62CF2303C92B3526012659D16876E25C7C1E57648F23B73564D67F61C6F14D527D54972810421E7D87589E1A7",
+			s:     "04A171143A83163D6DF460AAF61522695F207A58B95C0644D87E52AA1A347916E4F7A72930B1BC06DBE22CE3F58264AFD23704CBB63B29B931F7DE6C9D949A7ECFC",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.curve.Name()+"/"+tt.msg, func(t *testing.T) {
+			priv, err := NewPrivateKey(tt.curve, hexInt(tt.d))
+			if err != nil {
+				t.Fatal(err)
+			}
+			x, y := priv.Q.Affine()
+			if big.Cmp(x, hexInt(tt.x)) != 0 || big.Cmp(y, hexInt(tt.y)) != 0 {
+				t.Errorf("public key = %v, want (%s, %s)", priv.Q, tt.x, tt.y)
+			}
+			digest := sha256.Sum256([]byte(tt.msg))
+			sig := SignDeterministic(priv, sha256.New, digest[:])
+			want := &Signature{R: hexInt(tt.r), S: hexInt(tt.s)}
+			if !reflect.DeepEqual(sig, want) {
+				t.Errorf("SignDeterministic() = (%v, %v), want (%v, %v)", sig.R, sig.S, want.R, want.S)
+			}
+			if !Verify(&priv.PublicKey, digest[:], sig) {
+				t.Errorf("Verify() = false")
+			}
+		})
+	}
+}
+
+// TestSignDeterministic_crossCheck は SHA-256 以外のハッシュ関数で crypto/ecdsa の決定的署名と比べる
+func TestSignDeterministic_crossCheck(t *testing.T) {
+	d := hexInt("C9AFA9D845BA75166B5C215767B1D6934E50C3DB36E89B127B8A622B120F6721")
+	priv, err := NewPrivateKey(ec.P256(), d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	std, err := stdecdsa.ParseRawPrivateKey(elliptic.P256(), d.FillBytes(make([]byte, 32)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name string
+		h    func() hash.Hash
+		opt  crypto.Hash
+	}{
+		{"SHA-1", sha1.New, crypto.SHA1},
+		{"SHA-384", sha512.New384, crypto.SHA384},
+		{"SHA-512", sha512.New, crypto.SHA512},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := tt.h()
+			h.Write([]byte("sample"))
+			digest := h.Sum(nil)
+			want, err := std.Sign(nil, digest, tt.opt)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := SignDeterministic(priv, tt.h, digest).MarshalDER(); !reflect.DeepEqual(got, want) {
+				t.Errorf("SignDeterministic() = %x, want %x", got, want)
+			}
+		})
+	}
+}
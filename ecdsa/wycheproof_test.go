@@ -0,0 +1,104 @@
+package ecdsa
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"strconv"
+	"testing"
+
+	"github.com/convto/mycrypto/big"
+	"github.com/convto/mycrypto/ec"
+)
+
+// wycheproofFile は Wycheproof の ECDSA 検証ベクトルのファイルです
+type wycheproofFile struct {
+	TestGroups []struct {
+		PublicKey struct {
+			Curve string `json:"curve"`
+			Wx    string `json:"wx"`
+			Wy    string `json:"wy"`
+		} `json:"publicKey"`
+		Sha   string `json:"sha"`
+		Tests []struct {
+			TcID    int      `json:"tcId"`
+			Comment string   `json:"comment"`
+			Msg     string   `json:"msg"`
+			Sig     string   `json:"sig"`
+			Result  string   `json:"result"`
+			Flags   []string `json:"flags"`
+		} `json:"tests"`
+	} `json:"testGroups"`
+}
+
+var wycheproofCurves = map[string]*ec.Curve{
+	"secp256r1": ec.P256(),
+	"secp256k1": ec.Secp256k1(),
+}
+
+// testWycheproof は testdata のベクトルを読み込み、parse で署名を読んで検証した結果を比べる
+// valid 以外の結果 (invalid) はすべて拒否されなければならない
+func testWycheproof(t *testing.T, file string, parse func(c *ec.Curve, b []byte) (*Signature, error), lowS bool) {
+	data, err := os.ReadFile("testdata/" + file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var f wycheproofFile
+	if err := json.Unmarshal(data, &f); err != nil {
+		t.Fatal(err)
+	}
+	for _, g := range f.TestGroups {
+		c, ok := wycheproofCurves[g.PublicKey.Curve]
+		if !ok || g.Sha != "SHA-256" {
+			t.Fatalf("unsupported group: %s, %s", g.PublicKey.Curve, g.Sha)
+		}
+		pub, err := NewPublicKey(c, hexInt(g.PublicKey.Wx), hexInt(g.PublicKey.Wy))
+		if err != nil {
+			t.Fatalf("invalid public key: %v", err)
+		}
+		for _, tt := range g.Tests {
+			t.Run(strconv.Itoa(tt.TcID), func(t *testing.T) {
+				msg, _ := hex.DecodeString(tt.Msg)
+				b, _ := hex.DecodeString(tt.Sig)
+				digest := sha256.Sum256(msg)
+				got := false
+				if sig, err := parse(c, b); err == nil {
+					got = Verify(pub, digest[:], sig) && (!lowS || sig.IsLowS(c))
+				}
+				if want := tt.Result == "valid"; got != want {
+					t.Errorf("%s (%v): got %v, want %v", tt.Comment, tt.Flags, got, tt.Result)
+				}
+			})
+		}
+	}
+}
+
+func TestWycheproof_DER(t *testing.T) {
+	testWycheproof(t, "ecdsa_secp256r1_sha256_test.json", func(_ *ec.Curve, b []byte) (*Signature, error) {
+		return ParseDER(b)
+	}, false)
+}
+
+func TestWycheproof_P1363(t *testing.T) {
+	testWycheproof(t, "ecdsa_secp256r1_sha256_p1363_test.json", ParseRaw, false)
+}
+
+func TestWycheproof_bitcoin(t *testing.T) {
+	// Bitcoin の署名は DER で、s が n/2 以下であることも要求する
+	testWycheproof(t, "ecdsa_secp256k1_sha256_bitcoin_test.json", func(_ *ec.Curve, b []byte) (*Signature, error) {
+		return ParseDER(b)
+	}, true)
+}
+
+// hexInt は16進数の文字列を整数にします
+func hexInt(s string) *big.Int {
+	if len(s)%2 == 1 {
+		s = "0" + s
+	}
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		panic(err)
+	}
+	return new(big.Int).SetBytes(b)
+}
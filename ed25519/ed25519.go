@@ -0,0 +1,202 @@
+// Package ed25519 は RFC 8032 の Ed25519 署名と、その変種の Ed25519ctx と Ed25519ph を提供します
+// 鍵と署名は RFC 8032 で定められたバイト列の形式で扱います
+package ed25519
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/sha512"
+	"errors"
+	"io"
+
+	"github.com/convto/mycrypto/big"
+	"github.com/convto/mycrypto/edwards25519"
+)
+
+const (
+	// PublicKeySize は公開鍵のバイト長です
+	PublicKeySize = 32
+	// PrivateKeySize は秘密鍵 (シードと公開鍵を並べたもの) のバイト長です
+	PrivateKeySize = 64
+	// SignatureSize は署名のバイト長です
+	SignatureSize = 64
+	// SeedSize は秘密鍵のシードのバイト長です
+	SeedSize = 32
+)
+
+var (
+	// ErrInvalidSignature は署名の検証に失敗したことを表します
+	ErrInvalidSignature = errors.New("ed25519: invalid signature")
+	// ErrInvalidOptions は Options の組み合わせが RFC 8032 の変種のどれにも当てはまらないことを表します
+	ErrInvalidOptions = errors.New("ed25519: invalid options")
+)
+
+// PublicKey は Ed25519 の公開鍵 A = sB の32バイトの表現です
+type PublicKey []byte
+
+// PrivateKey は Ed25519 の秘密鍵で、32バイトのシードと公開鍵を並べたものです
+type PrivateKey []byte
+
+// Public は秘密鍵に対応する公開鍵を返します
+func (priv PrivateKey) Public() PublicKey {
+	pub := make([]byte, PublicKeySize)
+	copy(pub, priv[SeedSize:])
+	return pub
+}
+
+// Seed は秘密鍵のシードを返します
+func (priv PrivateKey) Seed() []byte {
+	seed := make([]byte, SeedSize)
+	copy(seed, priv[:SeedSize])
+	return seed
+}
+
+// Options は署名と検証で使う RFC 8032 の変種を選びます
+//
+// Hash が0で Context が空なら Ed25519、Hash が0で Context があれば Ed25519ctx、
+// Hash が crypto.SHA512 なら Ed25519ph で、メッセージには SHA-512 のハッシュ値を渡します
+type Options struct {
+	Hash    crypto.Hash
+	Context string
+}
+
+// GenerateKey は r から読み込んだシードで鍵ペアを生成します
+func GenerateKey(r io.Reader) (PublicKey, PrivateKey, error) {
+	seed := make([]byte, SeedSize)
+	if _, err := io.ReadFull(r, seed); err != nil {
+		return nil, nil, err
+	}
+	priv := NewKeyFromSeed(seed)
+	return priv.Public(), priv, nil
+}
+
+// NewKeyFromSeed は32バイトのシードから秘密鍵を作ります
+// シードの長さが異なるときはpanicします
+func NewKeyFromSeed(seed []byte) PrivateKey {
+	if len(seed) != SeedSize {
+		panic("ed25519: bad seed length")
+	}
+	s, _ := expand(seed)
+	priv := make([]byte, 0, PrivateKeySize)
+	priv = append(priv, seed...)
+	return append(priv, edwards25519.ScalarBaseMult(s).Bytes()...)
+}
+
+// Sign は Ed25519 で message に署名します
+// 秘密鍵の長さが異なるときはpanicします
+func Sign(priv PrivateKey, message []byte) []byte {
+	sig, err := SignWithOptions(priv, message, &Options{})
+	if err != nil {
+		panic(err)
+	}
+	return sig
+}
+
+// Verify は sig が公開鍵 pub による message の Ed25519 の署名かどうかを判定します
+func Verify(pub PublicKey, message, sig []byte) bool {
+	return VerifyWithOptions(pub, message, sig, &Options{}) == nil
+}
+
+// SignWithOptions は opts で選んだ変種で message に署名します
+func SignWithOptions(priv PrivateKey, message []byte, opts *Options) ([]byte, error) {
+	if len(priv) != PrivateKeySize {
+		panic("ed25519: bad private key length")
+	}
+	dom, err := opts.dom2(message)
+	if err != nil {
+		return nil, err
+	}
+	s, prefix := expand(priv[:SeedSize])
+	pub := priv[SeedSize:]
+
+	// r = H(dom2 || prefix || M), R = rB
+	r := hashToScalar(dom, prefix, message)
+	rb := edwards25519.ScalarBaseMult(r).Bytes()
+
+	// S = r + H(dom2 || R || A || M) s mod l
+	k := hashToScalar(dom, rb, pub, message)
+	sc := big.Mod(big.Add(r, big.Mul(k, s)), edwards25519.L())
+
+	sig := make([]byte, 0, SignatureSize)
+	sig = append(sig, rb...)
+	return append(sig, sc.FillBytesLE(make([]byte, 32))...), nil
+}
+
+// VerifyWithOptions は sig が公開鍵 pub による message の opts で選んだ変種の署名かどうかを検証します
+// 署名が正しくないときは ErrInvalidSignature を返します
+func VerifyWithOptions(pub PublicKey, message, sig []byte, opts *Options) error {
+	dom, err := opts.dom2(message)
+	if err != nil {
+		return err
+	}
+	if len(pub) != PublicKeySize || len(sig) != SignatureSize {
+		return ErrInvalidSignature
+	}
+	a, err := new(edwards25519.Point).SetBytes(pub)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+	r, err := new(edwards25519.Point).SetBytes(sig[:32])
+	if err != nil {
+		return ErrInvalidSignature
+	}
+	s := new(big.Int).SetBytesLE(sig[32:])
+	if big.Cmp(s, edwards25519.L()) >= 0 {
+		return ErrInvalidSignature
+	}
+
+	// RFC 8032 5.1.7 に従い余因子をかけた [8][S]B = [8]R + [8][k]A で検証する
+	k := hashToScalar(dom, sig[:32], pub, message)
+	lhs := edwards25519.ScalarBaseMult(s).MultByCofactor()
+	rhs := r.Add(a.ScalarMult(k)).MultByCofactor()
+	if !lhs.Equal(rhs) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// dom2 は opts で選んだ変種の dom2(phflag, context) を返します
+// Ed25519 では空のバイト列になります
+func (opts *Options) dom2(message []byte) ([]byte, error) {
+	if len(opts.Context) > 255 {
+		return nil, ErrInvalidOptions
+	}
+	var phflag byte
+	switch opts.Hash {
+	case 0:
+		if opts.Context == "" {
+			return nil, nil
+		}
+	case crypto.SHA512:
+		if len(message) != sha512.Size {
+			return nil, ErrInvalidOptions
+		}
+		phflag = 1
+	default:
+		return nil, ErrInvalidOptions
+	}
+	var b bytes.Buffer
+	b.WriteString("SigEd25519 no Ed25519 collisions")
+	b.WriteByte(phflag)
+	b.WriteByte(byte(len(opts.Context)))
+	b.WriteString(opts.Context)
+	return b.Bytes(), nil
+}
+
+// expand はシードのハッシュ値から、ビットを調整したスカラー s と nonce 生成用の prefix を取り出す
+func expand(seed []byte) (s *big.Int, prefix []byte) {
+	h := sha512.Sum512(seed)
+	h[0] &= 248
+	h[31] &= 127
+	h[31] |= 64
+	return new(big.Int).SetBytesLE(h[:32]), h[32:]
+}
+
+// hashToScalar は bs を連結した SHA-512 のハッシュ値をリトルエンディアンの整数として l で還元する
+func hashToScalar(bs ...[]byte) *big.Int {
+	h := sha512.New()
+	for _, b := range bs {
+		h.Write(b)
+	}
+	return big.Mod(new(big.Int).SetBytesLE(h.Sum(nil)), edwards25519.L())
+}
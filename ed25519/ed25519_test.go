@@ -0,0 +1,220 @@
+package ed25519
+
+import (
+	"bufio"
+	"bytes"
+	"crypto"
+	stded25519 "crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha512"
+	"encoding/hex"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+)
+
+// rfc8032Tests は RFC 8032 7.1 から 7.3 のテストベクタ
+var rfc8032Tests = []struct {
+	name string
+	sk   string
+	pk   string
+	msg  string
+	opts *Options
+	sig  string
+}{
+	{
+		name: "Ed25519 TEST 1",
+		sk:   "9d61b19deffd5a60ba844af492ec2cc44449c5697b326919703bac031cae7f60",
+		pk:   "d75a980182b10ab7d54bfed3c964073a0ee172f3daa62325af021a68f707511a",
+		msg:  "",
+		opts: &Options{},
+		sig:  "e5564300c360ac729086e2cc806e828a84877f1eb8e5d974d873e065224901555fb8821590a33bacc61e39701cf9b46bd25bf5f0595bbe24655141438e7a100b",
+	},
+	{
+		name: "Ed25519 TEST 2",
+		sk:   "4ccd089b28ff96da9db6c346ec114e0f5b8a319f35aba624da8cf6ed4fb8a6fb",
+		pk:   "3d4017c3e843895a92b70aa74d1b7ebc9c982ccf2ec4968cc0cd55f12af4660c",
+		msg:  "72",
+		opts: &Options{},
+		sig:  "92a009a9f0d4cab8720e820b5f642540a2b27b5416503f8fb3762223ebdb69da085ac1e43e15996e458f3613d0f11d8c387b2eaeb4302aeeb00d291612bb0c00",
+	},
+	{
+		name: "Ed25519 TEST 3",
+		sk:   "c5aa8df43f9f837bedb7442f31dcb7b166d38535076f094b85ce3a2e0b4458f7",
+		pk:   "fc51cd8e6218a1a38da47ed00230f0580816ed13ba3303ac5deb911548908025",
+		msg:  "af82",
+		opts: &Options{},
+		sig:  "6291d657deec24024827e69c3abe01a30ce548a284743a445e3680d7db5ac3ac18ff9b538d16f290ae67f760984dc6594a7c15e9716ed28dc027beceea1ec40a",
+	},
+	{
+		name: "Ed25519 TEST SHA(abc)",
+		sk:   "833fe62409237b9d62ec77587520911e9a759cec1d19755b7da901b96dca3d42",
+		pk:   "ec172b93ad5e563bf4932c70e1245034c35467ef2efd4d64ebf819683467e2bf",
+		msg:  "ddaf35a193617abacc417349ae20413112e6fa4e89a97ea20a9eeee64b55d39a2192992a274fc1a836ba3c23a3feebbd454d4423643ce80e2a9ac94fa54ca49f",
+		opts: &Options{},
+		sig:  "dc2a4459e7369633a52b1bf277839a00201009a3efbf3ecb69bea2186c26b58909351fc9ac90b3ecfdfbc7c66431e0303dca179c138ac17ad9bef1177331a704",
+	},
+	{
+		name: "Ed25519ctx foo",
+		sk:   "0305334e381af78f141cb666f6199f57bc3495335a256a95bd2a55bf546663f6",
+		pk:   "dfc9425e4f968f7f0c29f0259cf5f9aed6851c2bb4ad8bfb860cfee0ab248292",
+		msg:  "f726936d19c800494e3fdaff20b276a8",
+		opts: &Options{Context: "foo"},
+		sig:  "55a4cc2f70a54e04288c5f4cd1e45a7bb520b36292911876cada7323198dd87a8b36950b95130022907a7fb7c4e9b2d5f6cca685a587b4b21f4b888e4e7edb0d",
+	},
+	{
+		name: "Ed25519ctx bar",
+		sk:   "0305334e381af78f141cb666f6199f57bc3495335a256a95bd2a55bf546663f6",
+		pk:   "dfc9425e4f968f7f0c29f0259cf5f9aed6851c2bb4ad8bfb860cfee0ab248292",
+		msg:  "f726936d19c800494e3fdaff20b276a8",
+		opts: &Options{Context: "bar"},
+		sig:  "fc60d5872fc46b3aa69f8b5b4351d5808f92bcc044606db097abab6dbcb1aee3216c48e8b3b66431b5b186d1d28f8ee15a5ca2df6668346291c2043d4eb3e90d",
+	},
+	{
+		name: "Ed25519ctx foo message",
+		sk:   "0305334e381af78f141cb666f6199f57bc3495335a256a95bd2a55bf546663f6",
+		pk:   "dfc9425e4f968f7f0c29f0259cf5f9aed6851c2bb4ad8bfb860cfee0ab248292",
+		msg:  "508e9e6882b979fea900f62adceaca35",
+		opts: &Options{Context: "foo"},
+		sig:  "8b70c1cc8310e1de20ac53ce28ae6e7207f33c3295e03bb5c0732a1d20dc64908922a8b052cf99b7c4fe107a5abb5b2c4085ae75890d02df26269d8945f84b0b",
+	},
+	{
+		name: "Ed25519ctx foo key",
+		sk:   "ab9c2853ce297ddab85c993b3ae14bcad39b2c682beabc27d6d4eb20711d6560",
+		pk:   "0f1d1274943b91415889152e893d80e93275a1fc0b65fd71b4b0dda10ad7d772",
+		msg:  "f726936d19c800494e3fdaff20b276a8",
+		opts: &Options{Context: "foo"},
+		sig:  "21655b5f1aa965996b3f97b3c849eafba922a0a62992f73b3d1b73106a84ad85e9b86a7b6005ea868337ff2d20a7f5fbd4cd10b0be49a68da2b2e0dc0ad8960f",
+	},
+	{
+		name: "Ed25519ph abc",
+		sk:   "833fe62409237b9d62ec77587520911e9a759cec1d19755b7da901b96dca3d42",
+		pk:   "ec172b93ad5e563bf4932c70e1245034c35467ef2efd4d64ebf819683467e2bf",
+		msg:  "616263",
+		opts: &Options{Hash: crypto.SHA512},
+		sig:  "98a70222f0b8121aa9d30f813d683f809e462b469c7ff87639499bb94e6dae4131f85042463c2a355a2003d062adf5aaa10b8c61e636062aaad11c2a26083406",
+	},
+}
+
+func mustHex(t *testing.T, s string) []byte {
+	t.Helper()
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func TestRFC8032(t *testing.T) {
+	for _, tt := range rfc8032Tests {
+		t.Run(tt.name, func(t *testing.T) {
+			priv := NewKeyFromSeed(mustHex(t, tt.sk))
+			if got := hex.EncodeToString(priv.Public()); got != tt.pk {
+				t.Errorf("Public() = %v, want %v", got, tt.pk)
+			}
+			msg := mustHex(t, tt.msg)
+			if tt.opts.Hash == crypto.SHA512 {
+				h := sha512.Sum512(msg)
+				msg = h[:]
+			}
+			sig, err := SignWithOptions(priv, msg, tt.opts)
+			if err != nil {
+				t.Fatalf("SignWithOptions() error = %v", err)
+			}
+			if got := hex.EncodeToString(sig); got != tt.sig {
+				t.Errorf("SignWithOptions() = %v, want %v", got, tt.sig)
+			}
+			if err := VerifyWithOptions(priv.Public(), msg, sig, tt.opts); err != nil {
+				t.Errorf("VerifyWithOptions() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestVerifyWithOptions_invalid(t *testing.T) {
+	tt := rfc8032Tests[4]
+	pub := PublicKey(mustHex(t, tt.pk))
+	msg := mustHex(t, tt.msg)
+	sig := mustHex(t, tt.sig)
+
+	// S に l を足しても同じ点になるが、RFC 8032 では S < l でなければならない
+	overflowS := append([]byte{}, sig...)
+	overflowS[32+31] += 0x10
+
+	tests := []struct {
+		name string
+		pub  []byte
+		msg  []byte
+		sig  []byte
+		opts *Options
+		want error
+	}{
+		{name: "other context", pub: pub, msg: msg, sig: sig, opts: &Options{Context: "bar"}, want: ErrInvalidSignature},
+		{name: "no context", pub: pub, msg: msg, sig: sig, opts: &Options{}, want: ErrInvalidSignature},
+		{name: "modified message", pub: pub, msg: []byte("hello"), sig: sig, opts: tt.opts, want: ErrInvalidSignature},
+		{name: "S >= l", pub: pub, msg: msg, sig: overflowS, opts: tt.opts, want: ErrInvalidSignature},
+		{name: "short signature", pub: pub, msg: msg, sig: sig[:63], opts: tt.opts, want: ErrInvalidSignature},
+		{name: "invalid public key", pub: mustHex(t, "0200000000000000000000000000000000000000000000000000000000000000"), msg: msg, sig: sig, opts: tt.opts, want: ErrInvalidSignature},
+		{name: "long context", pub: pub, msg: msg, sig: sig, opts: &Options{Context: strings.Repeat("a", 256)}, want: ErrInvalidOptions},
+		{name: "unsupported hash", pub: pub, msg: msg, sig: sig, opts: &Options{Hash: crypto.SHA256}, want: ErrInvalidOptions},
+		{name: "prehash length", pub: pub, msg: msg, sig: sig, opts: &Options{Hash: crypto.SHA512}, want: ErrInvalidOptions},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := VerifyWithOptions(tt.pub, tt.msg, tt.sig, tt.opts); !errors.Is(err, tt.want) {
+				t.Errorf("VerifyWithOptions() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestSign(t *testing.T) {
+	pub, priv, err := GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg := []byte("test message")
+	sig := Sign(priv, msg)
+	if !Verify(pub, msg, sig) {
+		t.Errorf("Verify() = false, want true")
+	}
+	if !stded25519.Verify(stded25519.PublicKey(pub), msg, sig) {
+		t.Errorf("crypto/ed25519 rejected the signature")
+	}
+	wrong := append([]byte{}, msg...)
+	wrong[0] ^= 0xff
+	if Verify(pub, wrong, sig) {
+		t.Errorf("Verify() = true for a modified message")
+	}
+}
+
+func TestSign_supercop(t *testing.T) {
+	// SUPERCOP の ref10 のテストベクタ
+	f, err := os.Open("testdata/sign.input")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	s := bufio.NewScanner(f)
+	for n := 0; s.Scan(); n++ {
+		// 秘密鍵:公開鍵:メッセージ:署名とメッセージ:
+		parts := strings.Split(s.Text(), ":")
+		if len(parts) != 5 {
+			t.Fatalf("bad line %d", n)
+		}
+		priv := PrivateKey(mustHex(t, parts[0]))
+		pub := mustHex(t, parts[1])
+		msg := mustHex(t, parts[2])
+		want := mustHex(t, parts[3])[:SignatureSize]
+		if got := NewKeyFromSeed(priv.Seed()); !bytes.Equal(got, priv) {
+			t.Errorf("line %d: NewKeyFromSeed() = %x, want %x", n, got, priv)
+		}
+		if got := Sign(priv, msg); !bytes.Equal(got, want) {
+			t.Errorf("line %d: Sign() = %x, want %x", n, got, want)
+		}
+		if !Verify(pub, msg, want) {
+			t.Errorf("line %d: Verify() = false", n)
+		}
+	}
+}
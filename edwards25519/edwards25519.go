@@ -0,0 +1,168 @@
+// Package edwards25519 は Ed25519 で使うねじれ Edwards 曲線 -x^2 + y^2 = 1 + dx^2y^2 (mod 2^255 - 19) の群演算を提供します
+// 点は拡張座標 (X : Y : Z : T) (x = X/Z, y = Y/Z, xy = T/Z) で保持し、加算と2倍算で逆元の計算を避けます
+// この曲線の位数は 8l (l は素数) で、生成元 B は位数 l の部分群を生成します
+package edwards25519
+
+import (
+	"errors"
+
+	"github.com/convto/mycrypto/big"
+	"github.com/convto/mycrypto/field"
+)
+
+var (
+	// p = 2^255 - 19
+	p = big.Sub(big.Exp(big.NewInt(2), big.NewInt(255), nil), big.NewInt(19))
+	// l = 2^252 + 27742317777372353535851937790883648493 は B の位数
+	l = big.Add(big.Exp(big.NewInt(2), big.NewInt(252), nil), new(big.Int).SetString("27742317777372353535851937790883648493"))
+
+	fe = field.New(p)
+	// d = -121665/121666
+	d  = fe.NewElement(big.NewInt(-121665)).Mul(fe.NewElement(big.NewInt(121666)).Inv())
+	d2 = d.Add(d)
+
+	// B は y = 4/5 で x が偶数の点
+	generator = func() *Point {
+		y := fe.NewElement(big.NewInt(4)).Mul(fe.NewElement(big.NewInt(5)).Inv())
+		var buf [32]byte
+		copy(buf[:], y.Int().FillBytesLE(make([]byte, 32)))
+		g, err := new(Point).SetBytes(buf[:])
+		if err != nil {
+			panic("edwards25519: invalid generator")
+		}
+		return g
+	}()
+)
+
+// P は座標の素体の位数 2^255 - 19 を返します
+func P() *big.Int {
+	return p
+}
+
+// L は生成元 B の位数 l を返します
+func L() *big.Int {
+	return l
+}
+
+// Point は拡張座標 (X : Y : Z : T) で表した曲線上の点です
+// 単位元は (0 : 1 : 1 : 0) です
+type Point struct {
+	x *field.Element
+	y *field.Element
+	z *field.Element
+	t *field.Element
+}
+
+// ErrNotOnCurve は座標が曲線上の点を表さないことを表します
+var ErrNotOnCurve = errors.New("edwards25519: point is not on the curve")
+
+// NewPoint はアフィン座標 (x, y) の点を返します
+// 0 <= x, y < p でないときや、曲線上にないときは ErrNotOnCurve を返します
+func NewPoint(x, y *big.Int) (*Point, error) {
+	if big.Cmp(x, big.Zero) < 0 || big.Cmp(x, p) >= 0 || big.Cmp(y, big.Zero) < 0 || big.Cmp(y, p) >= 0 {
+		return nil, ErrNotOnCurve
+	}
+	fx, fy := fe.NewElement(x), fe.NewElement(y)
+	xx, yy := fx.Square(), fy.Square()
+	if !yy.Sub(xx).Equal(fe.One().Add(d.Mul(xx).Mul(yy))) {
+		return nil, ErrNotOnCurve
+	}
+	return &Point{x: fx, y: fy, z: fe.One(), t: fx.Mul(fy)}, nil
+}
+
+// NewIdentityPoint は単位元を返します
+func NewIdentityPoint() *Point {
+	return &Point{x: fe.Zero(), y: fe.One(), z: fe.One(), t: fe.Zero()}
+}
+
+// NewGeneratorPoint は生成元 B を返します
+func NewGeneratorPoint() *Point {
+	return generator
+}
+
+// Affine は p のアフィン座標 (x, y) を返します
+func (p *Point) Affine() (x, y *big.Int) {
+	zinv := p.z.Inv()
+	return p.x.Mul(zinv).Int(), p.y.Mul(zinv).Int()
+}
+
+func (p *Point) String() string {
+	x, y := p.Affine()
+	return "(" + x.String() + ", " + y.String() + ")"
+}
+
+// Equal は p と q が同じ点かどうかを判定します
+func (p *Point) Equal(q *Point) bool {
+	// X1/Z1 = X2/Z2 かつ Y1/Z1 = Y2/Z2 を分母を払って比べる
+	return p.x.Mul(q.z).Equal(q.x.Mul(p.z)) && p.y.Mul(q.z).Equal(q.y.Mul(p.z))
+}
+
+// IsIdentity は p が単位元かどうかを判定します
+func (p *Point) IsIdentity() bool {
+	return p.x.IsZero() && p.y.Equal(p.z)
+}
+
+// Neg は -p を返します
+func (p *Point) Neg() *Point {
+	return &Point{x: p.x.Neg(), y: p.y, z: p.z, t: p.t.Neg()}
+}
+
+// Add は p + q を返します
+// d が平方非剰余なので、この加算公式は単位元や同じ点どうしでも例外なく使えます
+func (p *Point) Add(q *Point) *Point {
+	// add-2008-hwcd-3 (a = -1)
+	a := p.y.Sub(p.x).Mul(q.y.Sub(q.x))
+	b := p.y.Add(p.x).Mul(q.y.Add(q.x))
+	c := p.t.Mul(d2).Mul(q.t)
+	dd := p.z.Mul(q.z)
+	dd = dd.Add(dd)
+	e := b.Sub(a)
+	f := dd.Sub(c)
+	g := dd.Add(c)
+	h := b.Add(a)
+	return &Point{x: e.Mul(f), y: g.Mul(h), z: f.Mul(g), t: e.Mul(h)}
+}
+
+// Sub は p - q を返します
+func (p *Point) Sub(q *Point) *Point {
+	return p.Add(q.Neg())
+}
+
+// Double は 2p を返します
+func (p *Point) Double() *Point {
+	// dbl-2008-hwcd (a = -1)
+	a := p.x.Square()
+	b := p.y.Square()
+	c := p.z.Square()
+	c = c.Add(c)
+	dd := a.Neg()
+	e := p.x.Add(p.y).Square().Sub(a).Sub(b)
+	g := dd.Add(b)
+	f := g.Sub(c)
+	h := dd.Sub(b)
+	return &Point{x: e.Mul(f), y: g.Mul(h), z: f.Mul(g), t: e.Mul(h)}
+}
+
+// MultByCofactor は 8p を返します
+func (p *Point) MultByCofactor() *Point {
+	return p.Double().Double().Double()
+}
+
+// IsSmallOrder は p の位数が余因子 8 を割り切る (8p が単位元になる) かどうかを判定します
+func (p *Point) IsSmallOrder() bool {
+	return p.MultByCofactor().IsIdentity()
+}
+
+// IsTorsionFree は p が位数 l の部分群に属する (lp が単位元になる) かどうかを判定します
+// l は公開された定数なので、Montgomery ladder ではなく素朴な double-and-add で計算します
+func (p *Point) IsTorsionFree() bool {
+	r := NewIdentityPoint()
+	lb := l.Bits(l.BitLen())
+	for i := len(lb) - 1; i >= 0; i-- {
+		r = r.Double()
+		if lb[i] == 1 {
+			r = r.Add(p)
+		}
+	}
+	return r.IsIdentity()
+}
@@ -0,0 +1,121 @@
+package edwards25519
+
+import (
+	"testing"
+
+	"github.com/convto/mycrypto/big"
+)
+
+func TestPoint_Add(t *testing.T) {
+	b := NewGeneratorPoint()
+	b2 := b.Add(b)
+	b3 := b2.Add(b)
+	tests := []struct {
+		name string
+		got  *Point
+		want *Point
+	}{
+		{name: "B + B = 2B", got: b.Add(b), want: b.Double()},
+		{name: "2B + B = B + 2B", got: b2.Add(b), want: b.Add(b2)},
+		{name: "3B - B = 2B", got: b3.Sub(b), want: b2},
+		{name: "B + O = B", got: b.Add(NewIdentityPoint()), want: b},
+		{name: "O + O = O", got: NewIdentityPoint().Add(NewIdentityPoint()), want: NewIdentityPoint()},
+		{name: "B - B = O", got: b.Sub(b), want: NewIdentityPoint()},
+		{name: "2(2B) = 3B + B", got: b2.Double(), want: b3.Add(b)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !tt.got.Equal(tt.want) {
+				t.Errorf("got %v, want %v", tt.got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPoint_onCurve(t *testing.T) {
+	// -x^2 + y^2 = 1 + dx^2y^2 と T = XY/Z を確かめる
+	b := NewGeneratorPoint()
+	for _, p := range []*Point{NewIdentityPoint(), b, b.Double(), b.Double().Add(b), b.Neg()} {
+		zinv := p.z.Inv()
+		x, y := p.x.Mul(zinv), p.y.Mul(zinv)
+		xx, yy := x.Square(), y.Square()
+		if !yy.Sub(xx).Equal(fe.One().Add(d.Mul(xx).Mul(yy))) {
+			t.Errorf("%v is not on the curve", p)
+		}
+		if !p.t.Mul(p.z).Equal(p.x.Mul(p.y)) {
+			t.Errorf("%v has inconsistent T", p)
+		}
+	}
+}
+
+func TestPoint_IsSmallOrder(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want bool
+	}{
+		{name: "identity", in: "0100000000000000000000000000000000000000000000000000000000000000", want: true},
+		{name: "order 2", in: "ecffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff7f", want: true},
+		{name: "order 4", in: "0000000000000000000000000000000000000000000000000000000000000000", want: true},
+		{name: "order 4 negative", in: "0000000000000000000000000000000000000000000000000000000000000080", want: true},
+		{name: "order 8", in: "26e8958fc2b227b045c3f489f2ef98f0d5dfac05d3c63339b13802886d53fc05", want: true},
+		{name: "order 8 negative", in: "c7176a703d4dd84fba3c0b760d10670f2a2053fa2c39ccc64ec7fd7792ac037a", want: true},
+		{name: "generator", in: "5866666666666666666666666666666666666666666666666666666666666666", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := mustDecode(t, tt.in)
+			if got := p.IsSmallOrder(); got != tt.want {
+				t.Errorf("IsSmallOrder() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPoint_IsTorsionFree(t *testing.T) {
+	b := NewGeneratorPoint()
+	small := mustDecode(t, "26e8958fc2b227b045c3f489f2ef98f0d5dfac05d3c63339b13802886d53fc05")
+	tests := []struct {
+		name string
+		p    *Point
+		want bool
+	}{
+		{name: "identity", p: NewIdentityPoint(), want: true},
+		{name: "generator", p: b, want: true},
+		{name: "7B", p: b.ScalarMult(big.NewInt(7)), want: true},
+		{name: "order 8", p: small, want: false},
+		{name: "B + order 8", p: b.Add(small), want: false},
+		{name: "8(B + order 8)", p: b.Add(small).MultByCofactor(), want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.p.IsTorsionFree(); got != tt.want {
+				t.Errorf("IsTorsionFree() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewPoint(t *testing.T) {
+	bx, by := NewGeneratorPoint().Affine()
+	got, err := NewPoint(bx, by)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got.Equal(NewGeneratorPoint()) {
+		t.Errorf("NewPoint(B) = %v, want %v", got, NewGeneratorPoint())
+	}
+	if !got.Double().Equal(NewGeneratorPoint().Double()) {
+		t.Errorf("NewPoint(B).Double() = %v, want 2B", got.Double())
+	}
+
+	for _, c := range [][2]*big.Int{
+		{bx, big.Add(by, big.NewInt(1))},
+		{big.Add(bx, p), by},
+		{big.NewInt(-1), big.NewInt(1)},
+	} {
+		if _, err := NewPoint(c[0], c[1]); err != ErrNotOnCurve {
+			t.Errorf("NewPoint(%v, %v) error = %v, want %v", c[0], c[1], err, ErrNotOnCurve)
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package edwards25519
+
+import (
+	"errors"
+
+	"github.com/convto/mycrypto/big"
+)
+
+// ErrInvalidEncoding は点のバイト表現が RFC 8032 5.1.2 の形式でないことを表します
+var ErrInvalidEncoding = errors.New("edwards25519: invalid point encoding")
+
+// Bytes は p を RFC 8032 5.1.2 の32バイトの表現で返します
+// y をリトルエンディアンで並べ、最上位ビットに x の最下位ビットを入れます
+func (p *Point) Bytes() []byte {
+	x, y := p.Affine()
+	b := y.FillBytesLE(make([]byte, 32))
+	b[31] |= byte(x.Bit(0) << 7)
+	return b
+}
+
+// SetBytes は RFC 8032 5.1.3 の手順で32バイトの表現から点を読み込み、p に設定して返します
+// y が p 以上のときや、対応する x が存在しないときは ErrInvalidEncoding を返します
+func (p *Point) SetBytes(b []byte) (*Point, error) {
+	if len(b) != 32 {
+		return nil, ErrInvalidEncoding
+	}
+	buf := make([]byte, 32)
+	copy(buf, b)
+	sign := uint(buf[31] >> 7)
+	buf[31] &= 0x7f
+	yi := new(big.Int).SetBytesLE(buf)
+	if big.Cmp(yi, P()) >= 0 {
+		return nil, ErrInvalidEncoding
+	}
+	// x^2 = (y^2 - 1) / (dy^2 + 1)
+	y := fe.NewElement(yi)
+	yy := y.Square()
+	u := yy.Sub(fe.One())
+	v := d.Mul(yy).Add(fe.One())
+	x, ok := u.Mul(v.Inv()).Sqrt()
+	if !ok {
+		return nil, ErrInvalidEncoding
+	}
+	if x.IsZero() && sign == 1 {
+		return nil, ErrInvalidEncoding
+	}
+	if x.Int().Bit(0) != sign {
+		x = x.Neg()
+	}
+	p.x, p.y, p.z, p.t = x, y, fe.One(), x.Mul(y)
+	return p, nil
+}
@@ -0,0 +1,69 @@
+package edwards25519
+
+import (
+	"encoding/hex"
+	"errors"
+	"testing"
+)
+
+// mustDecode は16進数の点の表現を読み込む
+func mustDecode(t *testing.T, s string) *Point {
+	t.Helper()
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p, err := new(Point).SetBytes(b)
+	if err != nil {
+		t.Fatalf("SetBytes(%s) error = %v", s, err)
+	}
+	return p
+}
+
+func TestPoint_Bytes(t *testing.T) {
+	tests := []struct {
+		name string
+		p    *Point
+		want string
+	}{
+		{name: "identity", p: NewIdentityPoint(), want: "0100000000000000000000000000000000000000000000000000000000000000"},
+		{name: "generator", p: NewGeneratorPoint(), want: "5866666666666666666666666666666666666666666666666666666666666666"},
+		{
+			// RFC 8032 7.1 TEST 1 の公開鍵
+			name: "RFC 8032 TEST 1",
+			p:    mustDecode(t, "d75a980182b10ab7d54bfed3c964073a0ee172f3daa62325af021a68f707511a"),
+			want: "d75a980182b10ab7d54bfed3c964073a0ee172f3daa62325af021a68f707511a",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := hex.EncodeToString(tt.p.Bytes()); got != tt.want {
+				t.Errorf("Bytes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPoint_SetBytes_invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+	}{
+		{name: "short", in: "58666666666666666666666666666666666666666666666666666666666666"},
+		{name: "y = p", in: "edffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff7f"},
+		{name: "y = p + 1", in: "eeffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff7f"},
+		{name: "x = 0 with sign bit", in: "0100000000000000000000000000000000000000000000000000000000000080"},
+		{name: "not on curve", in: "0200000000000000000000000000000000000000000000000000000000000000"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := hex.DecodeString(tt.in)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := new(Point).SetBytes(b); !errors.Is(err, ErrInvalidEncoding) {
+				t.Errorf("SetBytes() error = %v, want %v", err, ErrInvalidEncoding)
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package edwards25519
+
+import (
+	"github.com/convto/mycrypto/big"
+	"github.com/convto/mycrypto/field"
+)
+
+// ScalarMult は kp を返します
+// Montgomery ladder で k のビットによらず同じ加算と2倍算を行い、点の入れ替えも座標ごとの定数時間の選択で行います
+// p が小さい位数の成分を持つこともあるので k は l で還元しません
+func (p *Point) ScalarMult(k *big.Int) *Point {
+	if big.Cmp(k, big.Zero) < 0 {
+		p = p.Neg()
+		k = big.Sub(big.Zero, k)
+	}
+	n := l.BitLen()
+	if kl := k.BitLen(); kl > n {
+		n = kl
+	}
+
+	// R1 - R0 = p を保ちながら上位のビットから処理する
+	r0 := NewIdentityPoint()
+	r1 := p
+	swap := 0
+	kb := k.Bits(n)
+	for i := n - 1; i >= 0; i-- {
+		bit := int(kb[i])
+		r0, r1 = cswap(r0, r1, swap^bit)
+		swap = bit
+		r1 = r0.Add(r1)
+		r0 = r0.Double()
+	}
+	r0, _ = cswap(r0, r1, swap)
+	return r0
+}
+
+// ScalarBaseMult は kB を返します
+// k は l で還元してから計算します
+func ScalarBaseMult(k *big.Int) *Point {
+	return generator.ScalarMult(big.Mod(k, l))
+}
+
+// cswap は swap == 1 のときだけ p と q を入れ替えた組を、分岐せずに返します
+func cswap(p, q *Point, swap int) (*Point, *Point) {
+	return selectPoint(swap, q, p), selectPoint(swap, p, q)
+}
+
+// selectPoint は v == 1 のとき p を、v == 0 のとき q を座標ごとの field.Select で返します
+func selectPoint(v int, p, q *Point) *Point {
+	return &Point{
+		x: field.Select(v, p.x, q.x),
+		y: field.Select(v, p.y, q.y),
+		z: field.Select(v, p.z, q.z),
+		t: field.Select(v, p.t, q.t),
+	}
+}
@@ -0,0 +1,73 @@
+package edwards25519
+
+import (
+	stded25519 "crypto/ed25519"
+	"crypto/sha512"
+	"encoding/hex"
+	"testing"
+
+	"github.com/convto/mycrypto/big"
+)
+
+// doubleAndAdd は加算と2倍算を素朴に繰り返して kp を求める
+func doubleAndAdd(p *Point, k int) *Point {
+	r := NewIdentityPoint()
+	for i := 0; i < k; i++ {
+		r = r.Add(p)
+	}
+	return r
+}
+
+func TestPoint_ScalarMult(t *testing.T) {
+	b := NewGeneratorPoint()
+	small := mustDecode(t, "26e8958fc2b227b045c3f489f2ef98f0d5dfac05d3c63339b13802886d53fc05")
+	tests := []struct {
+		name string
+		p    *Point
+		k    *big.Int
+		want *Point
+	}{
+		{name: "0B", p: b, k: big.NewInt(0), want: NewIdentityPoint()},
+		{name: "1B", p: b, k: big.NewInt(1), want: b},
+		{name: "13B", p: b, k: big.NewInt(13), want: doubleAndAdd(b, 13)},
+		{name: "-3B", p: b, k: big.NewInt(-3), want: doubleAndAdd(b, 3).Neg()},
+		{name: "lB", p: b, k: L(), want: NewIdentityPoint()},
+		{name: "(l+2)B", p: b, k: big.Add(L(), big.NewInt(2)), want: b.Double()},
+		{name: "5 * order 8", p: small, k: big.NewInt(5), want: doubleAndAdd(small, 5)},
+		{name: "8 * order 8", p: small, k: big.NewInt(8), want: NewIdentityPoint()},
+		{name: "l * order 8", p: small, k: L(), want: doubleAndAdd(small, 5)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.p.ScalarMult(tt.k); !got.Equal(tt.want) {
+				t.Errorf("ScalarMult() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScalarBaseMult(t *testing.T) {
+	// crypto/ed25519 の公開鍵はシードのハッシュから作ったスカラー s について sB を符号化したもの
+	seeds := []string{
+		"9d61b19deffd5a60ba844af492ec2cc44449c5697b326919703bac031cae7f60",
+		"4ccd089b28ff96da9db6c346ec114e0f5b8a319f35aba624da8cf6ed4fb8a6fb",
+		"0000000000000000000000000000000000000000000000000000000000000000",
+	}
+	for _, s := range seeds {
+		t.Run(s, func(t *testing.T) {
+			seed, err := hex.DecodeString(s)
+			if err != nil {
+				t.Fatal(err)
+			}
+			h := sha512.Sum512(seed)
+			h[0] &= 248
+			h[31] &= 127
+			h[31] |= 64
+			k := new(big.Int).SetBytesLE(h[:32])
+			want := stded25519.NewKeyFromSeed(seed).Public().(stded25519.PublicKey)
+			if got := ScalarBaseMult(k).Bytes(); hex.EncodeToString(got) != hex.EncodeToString(want) {
+				t.Errorf("ScalarBaseMult() = %x, want %x", got, want)
+			}
+		})
+	}
+}
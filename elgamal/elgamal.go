@@ -0,0 +1,95 @@
+// Package elgamal は素数位数の巡回群上の ElGamal 暗号を提供します
+// 群には Z_p* の部分群と楕円曲線の両方を使え、暗号文どうしの積が平文の積の暗号文になる準同型性を持ちます
+// 平文を g^m として暗号化する指数 ElGamal では、暗号文の積が平文の和になるので投票の集計などに使えます
+package elgamal
+
+import (
+	"errors"
+	"io"
+
+	"github.com/convto/mycrypto/big"
+	"github.com/convto/mycrypto/dlog"
+)
+
+// ErrInvalidElement は平文や暗号文の成分が群の元でないことを表します
+var ErrInvalidElement = errors.New("elgamal: element is not in the group")
+
+// PublicKey は公開鍵 y = g^x です
+type PublicKey struct {
+	Group dlog.PrimeOrderGroup
+	Y     dlog.Element
+}
+
+// PrivateKey は秘密鍵 x と対応する公開鍵です
+type PrivateKey struct {
+	PublicKey
+	X *big.Int
+}
+
+// Ciphertext は暗号文 (c1, c2) = (g^k, m * y^k) です
+type Ciphertext struct {
+	C1 dlog.Element
+	C2 dlog.Element
+}
+
+// GenerateKey は r から読み込んだ乱数で 1 <= x < q の秘密鍵を選び、鍵ペアを返します
+func GenerateKey(r io.Reader, grp dlog.PrimeOrderGroup) (*PrivateKey, error) {
+	x, err := big.RandNonZeroInt(r, grp.Order())
+	if err != nil {
+		return nil, err
+	}
+	return &PrivateKey{
+		PublicKey: PublicKey{Group: grp, Y: grp.Exp(grp.Generator(), x)},
+		X:         x,
+	}, nil
+}
+
+// Encrypt は r から読み込んだ乱数で群の元 m を暗号化します
+// m が群の元でないときは ErrInvalidElement を返します
+func Encrypt(r io.Reader, pub *PublicKey, m dlog.Element) (*Ciphertext, error) {
+	if !pub.Group.Contains(m) {
+		return nil, ErrInvalidElement
+	}
+	return encrypt(r, pub, m)
+}
+
+// Decrypt は暗号文を復号して m = c2 / c1^x を返します
+// 暗号文の成分が群の元でないときは ErrInvalidElement を返します
+func Decrypt(priv *PrivateKey, ct *Ciphertext) (dlog.Element, error) {
+	grp := priv.Group
+	if !grp.Contains(ct.C1) || !grp.Contains(ct.C2) {
+		return nil, ErrInvalidElement
+	}
+	// c1^(-x) は c1^(q-x) として求める
+	s := grp.Exp(ct.C1, big.Sub(grp.Order(), priv.X))
+	return grp.Op(ct.C2, s), nil
+}
+
+// Rerandomize は r から読み込んだ乱数で、同じ平文の新しい暗号文を返します
+// 単位元の暗号文 (g^k', y^k') を掛けるので、秘密鍵がなくても元の暗号文と結びつけられない暗号文になります
+func Rerandomize(r io.Reader, pub *PublicKey, ct *Ciphertext) (*Ciphertext, error) {
+	one, err := encrypt(r, pub, pub.Group.Identity())
+	if err != nil {
+		return nil, err
+	}
+	return Mul(pub.Group, ct, one), nil
+}
+
+// Mul は2つの暗号文の成分ごとの積を返します
+// 結果は平文の積 (指数 ElGamal では平文の和) の暗号文になります
+func Mul(grp dlog.PrimeOrderGroup, a, b *Ciphertext) *Ciphertext {
+	return &Ciphertext{C1: grp.Op(a.C1, b.C1), C2: grp.Op(a.C2, b.C2)}
+}
+
+// encrypt は m が群の元であることを確かめずに暗号化する
+func encrypt(r io.Reader, pub *PublicKey, m dlog.Element) (*Ciphertext, error) {
+	grp := pub.Group
+	k, err := big.RandNonZeroInt(r, grp.Order())
+	if err != nil {
+		return nil, err
+	}
+	return &Ciphertext{
+		C1: grp.Exp(grp.Generator(), k),
+		C2: grp.Op(m, grp.Exp(pub.Y, k)),
+	}, nil
+}
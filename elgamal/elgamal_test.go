@@ -0,0 +1,188 @@
+package elgamal
+
+import (
+	"crypto/rand"
+	"errors"
+	"testing"
+
+	"github.com/convto/mycrypto/big"
+	"github.com/convto/mycrypto/dlog"
+	"github.com/convto/mycrypto/ec"
+)
+
+// testZpGroup は p が1024ビット、q が160ビットの FIPS 186-4 の DSA パラメータによる群です
+var testZpGroup = dlog.NewZpGroup(
+	new(big.Int).SetString("0x8f0e2cfd1ca6047c541b387aa36676e91c29aa6475dc61b601d4fc51cbae565817a223ed05cc28a8047dbe68516c9a04c3b688de6ed95ef4e7dd4d626721e2915ae20eda84e161b81f62c2067cf0349668516536150aba77d91c7a9e0d9f6715d8d2c10ff872bba84973dfc09414a183af6a319b7e4d9f6bc5383872c72ddf8b"),
+	new(big.Int).SetString("0xf82bf6fd6aa20c4706dd36e19202f835d333cb53"),
+	new(big.Int).SetString("0x390267fd31c85bfa709afb3eade45fc83db97a971daa3fc7df23817046288c8068ba2fca2604097d3d4b1f1ffe181773df52ca8132ee00baf8966fae831ed04ce653288a410cff1920036fa8502f90248fcb8ddda44ab5f2dddd268045b42904ab20c3670e60fdfb5be06d4f633bdeaee4909d1bbbbf2b8c42f9b0b26bd7ce1"),
+)
+
+// testGroups はテストに使う群で、Z_p* の部分群と楕円曲線の両方を含みます
+var testGroups = []struct {
+	name string
+	grp  dlog.PrimeOrderGroup
+}{
+	{name: "Zp 1024/160", grp: testZpGroup},
+	{name: "P-256", grp: dlog.NewCurveGroup(ec.P256())},
+	{name: "secp256k1", grp: dlog.NewCurveGroup(ec.Secp256k1())},
+}
+
+// randElement は群のランダムな元 g^k を返す
+func randElement(t *testing.T, grp dlog.PrimeOrderGroup) dlog.Element {
+	k, err := big.RandNonZeroInt(rand.Reader, grp.Order())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return grp.Exp(grp.Generator(), k)
+}
+
+func TestEncrypt(t *testing.T) {
+	for _, tt := range testGroups {
+		t.Run(tt.name, func(t *testing.T) {
+			grp := tt.grp
+			priv, err := GenerateKey(rand.Reader, grp)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, m := range []dlog.Element{randElement(t, grp), grp.Identity(), grp.Generator()} {
+				ct, err := Encrypt(rand.Reader, &priv.PublicKey, m)
+				if err != nil {
+					t.Fatalf("Encrypt() error = %v", err)
+				}
+				got, err := Decrypt(priv, ct)
+				if err != nil {
+					t.Fatalf("Decrypt() error = %v", err)
+				}
+				if !grp.Equal(got, m) {
+					t.Errorf("Decrypt() = %v, want %v", got, m)
+				}
+			}
+
+			// 別の鍵では復号できない
+			other, err := GenerateKey(rand.Reader, grp)
+			if err != nil {
+				t.Fatal(err)
+			}
+			m := randElement(t, grp)
+			ct, err := Encrypt(rand.Reader, &priv.PublicKey, m)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got, err := Decrypt(other, ct); err != nil || grp.Equal(got, m) {
+				t.Errorf("Decrypt() with other key = %v, %v", got, err)
+			}
+		})
+	}
+}
+
+func TestEncrypt_invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		grp  dlog.PrimeOrderGroup
+		m    dlog.Element
+	}{
+		{name: "Zp, outside subgroup", grp: testZpGroup, m: big.Sub(testZpGroup.P, big.NewInt(1))},
+		{name: "Zp, zero", grp: testZpGroup, m: big.NewInt(0)},
+		{name: "Zp, point", grp: testZpGroup, m: ec.P256().Generator()},
+		{name: "P-256, other curve", grp: dlog.NewCurveGroup(ec.P256()), m: ec.Secp256k1().Generator()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			priv, err := GenerateKey(rand.Reader, tt.grp)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := Encrypt(rand.Reader, &priv.PublicKey, tt.m); !errors.Is(err, ErrInvalidElement) {
+				t.Errorf("Encrypt() error = %v, want %v", err, ErrInvalidElement)
+			}
+		})
+	}
+}
+
+func TestDecrypt_invalid(t *testing.T) {
+	grp := testZpGroup
+	priv, err := GenerateKey(rand.Reader, grp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ct, err := Encrypt(rand.Reader, &priv.PublicKey, randElement(t, grp))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// p - 1 を掛けると位数 q の部分群から外れる
+	outside := big.Sub(grp.P, big.NewInt(1))
+	tests := []struct {
+		name string
+		ct   *Ciphertext
+	}{
+		{name: "c1 outside subgroup", ct: &Ciphertext{C1: grp.Op(ct.C1, outside), C2: ct.C2}},
+		{name: "c2 outside subgroup", ct: &Ciphertext{C1: ct.C1, C2: grp.Op(ct.C2, outside)}},
+		{name: "c1 = 0", ct: &Ciphertext{C1: big.NewInt(0), C2: ct.C2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Decrypt(priv, tt.ct); !errors.Is(err, ErrInvalidElement) {
+				t.Errorf("Decrypt() error = %v, want %v", err, ErrInvalidElement)
+			}
+		})
+	}
+}
+
+func TestRerandomize(t *testing.T) {
+	for _, tt := range testGroups {
+		t.Run(tt.name, func(t *testing.T) {
+			grp := tt.grp
+			priv, err := GenerateKey(rand.Reader, grp)
+			if err != nil {
+				t.Fatal(err)
+			}
+			m := randElement(t, grp)
+			ct, err := Encrypt(rand.Reader, &priv.PublicKey, m)
+			if err != nil {
+				t.Fatal(err)
+			}
+			re, err := Rerandomize(rand.Reader, &priv.PublicKey, ct)
+			if err != nil {
+				t.Fatalf("Rerandomize() error = %v", err)
+			}
+			if grp.Equal(re.C1, ct.C1) || grp.Equal(re.C2, ct.C2) {
+				t.Errorf("Rerandomize() did not change the ciphertext")
+			}
+			got, err := Decrypt(priv, re)
+			if err != nil {
+				t.Fatalf("Decrypt() error = %v", err)
+			}
+			if !grp.Equal(got, m) {
+				t.Errorf("Decrypt() = %v, want %v", got, m)
+			}
+		})
+	}
+}
+
+func TestMul(t *testing.T) {
+	for _, tt := range testGroups {
+		t.Run(tt.name, func(t *testing.T) {
+			grp := tt.grp
+			priv, err := GenerateKey(rand.Reader, grp)
+			if err != nil {
+				t.Fatal(err)
+			}
+			m1, m2 := randElement(t, grp), randElement(t, grp)
+			c1, err := Encrypt(rand.Reader, &priv.PublicKey, m1)
+			if err != nil {
+				t.Fatal(err)
+			}
+			c2, err := Encrypt(rand.Reader, &priv.PublicKey, m2)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := Decrypt(priv, Mul(grp, c1, c2))
+			if err != nil {
+				t.Fatalf("Decrypt() error = %v", err)
+			}
+			if want := grp.Op(m1, m2); !grp.Equal(got, want) {
+				t.Errorf("Decrypt(Mul()) = %v, want %v", got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package elgamal
+
+import (
+	"errors"
+	"io"
+
+	"github.com/convto/mycrypto/big"
+	"github.com/convto/mycrypto/dlog"
+)
+
+// EncryptExp は整数 m を g^m として暗号化する指数 ElGamal の暗号化です
+// 暗号文の積が平文の和の暗号文になり、復号には NewLogTable で作った表で小さな範囲の離散対数を求めます
+// m は 0 <= m < q の範囲で指定します
+func EncryptExp(r io.Reader, pub *PublicKey, m *big.Int) (*Ciphertext, error) {
+	grp := pub.Group
+	if big.Cmp(m, big.Zero) < 0 || big.Cmp(m, grp.Order()) >= 0 {
+		return nil, errors.New("elgamal: exponent out of range")
+	}
+	return encrypt(r, pub, grp.Exp(grp.Generator(), m))
+}
+
+// DecryptExp は指数 ElGamal の暗号文を復号し、g^m から t の範囲の m を求めます
+// m が範囲外のときは dlog.ErrNotFound を返します
+func DecryptExp(priv *PrivateKey, ct *Ciphertext, t *dlog.BSGSTable) (*big.Int, error) {
+	gm, err := Decrypt(priv, ct)
+	if err != nil {
+		return nil, err
+	}
+	return t.Log(gm)
+}
+
+// NewLogTable は群 grp で g^m から 0 <= m < max の m を求める baby-step giant-step 法の表を作ります
+// 表は鍵によらず群と範囲だけで決まるので、同じ群での復号に何度でも使えます
+func NewLogTable(grp dlog.PrimeOrderGroup, max *big.Int) (*dlog.BSGSTable, error) {
+	return dlog.NewBSGSTable(grp, grp.Generator(), max, grp.Order())
+}
@@ -0,0 +1,99 @@
+package elgamal
+
+import (
+	"crypto/rand"
+	"errors"
+	"testing"
+
+	"github.com/convto/mycrypto/big"
+	"github.com/convto/mycrypto/dlog"
+)
+
+// TestEncryptExp_tally は 0 か 1 の票を暗号文のまま集計し、合計だけを復号できることを確かめる
+func TestEncryptExp_tally(t *testing.T) {
+	votes := []int64{1, 0, 1, 1, 0, 1, 0, 0, 1, 1}
+	for _, tt := range testGroups {
+		t.Run(tt.name, func(t *testing.T) {
+			grp := tt.grp
+			priv, err := GenerateKey(rand.Reader, grp)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var sum *Ciphertext
+			var want int64
+			for _, v := range votes {
+				ct, err := EncryptExp(rand.Reader, &priv.PublicKey, big.NewInt(v))
+				if err != nil {
+					t.Fatalf("EncryptExp() error = %v", err)
+				}
+				if sum == nil {
+					sum = ct
+				} else {
+					sum = Mul(grp, sum, ct)
+				}
+				want += v
+			}
+			tbl, err := NewLogTable(grp, big.NewInt(int64(len(votes)+1)))
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := DecryptExp(priv, sum, tbl)
+			if err != nil {
+				t.Fatalf("DecryptExp() error = %v", err)
+			}
+			if big.Cmp(got, big.NewInt(want)) != 0 {
+				t.Errorf("DecryptExp() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestEncryptExp_invalid(t *testing.T) {
+	grp := testZpGroup
+	priv, err := GenerateKey(rand.Reader, grp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, m := range []*big.Int{big.NewInt(-1), grp.Q} {
+		if _, err := EncryptExp(rand.Reader, &priv.PublicKey, m); err == nil {
+			t.Errorf("EncryptExp(%v) error = nil, want error", m)
+		}
+	}
+}
+
+func TestLogTable(t *testing.T) {
+	for _, tt := range testGroups {
+		t.Run(tt.name, func(t *testing.T) {
+			grp := tt.grp
+			g := grp.Generator()
+			// max が平方数でないときも最後の giant step まで探す
+			tbl, err := NewLogTable(grp, big.NewInt(1000))
+			if err != nil {
+				t.Fatal(err)
+			}
+			tests := []struct {
+				x       int64
+				wantErr error
+			}{
+				{x: 0},
+				{x: 1},
+				{x: 31},
+				{x: 32},
+				{x: 500},
+				{x: 999},
+				{x: 1000, wantErr: dlog.ErrNotFound},
+				{x: 1023, wantErr: dlog.ErrNotFound},
+				{x: 5000, wantErr: dlog.ErrNotFound},
+			}
+			for _, tc := range tests {
+				got, err := tbl.Log(grp.Exp(g, big.NewInt(tc.x)))
+				if !errors.Is(err, tc.wantErr) {
+					t.Fatalf("Log(g^%v) error = %v, want %v", tc.x, err, tc.wantErr)
+				}
+				if err == nil && big.Cmp(got, big.NewInt(tc.x)) != 0 {
+					t.Errorf("Log(g^%v) = %v", tc.x, got)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,220 @@
+// Package field は法 n の剰余環 Z/nZ の演算を提供します
+// n が素数のときは有限体 GF(n) になり、逆元と平方根が常に扱えるようになります
+package field
+
+import (
+	"errors"
+	"io"
+
+	"github.com/convto/mycrypto/big"
+)
+
+// ErrInvalidEncoding は元のバイト表現が正規の形式でないことを表します
+var ErrInvalidEncoding = errors.New("field: invalid element encoding")
+
+// Field は法 n の剰余環です
+// n が 10 と互いに素なときは、元を Montgomery 表現で保持して乗算を高速に行います
+type Field struct {
+	n    *big.Int
+	mt   *big.Montgomery
+	size int // 元のバイト表現の長さ
+}
+
+// New は n を法とする剰余環を返します
+// n <= 1 のときpanicします
+func New(n *big.Int) *Field {
+	if big.Cmp(n, big.NewInt(1)) <= 0 {
+		panic("field: modulus must be greater than 1")
+	}
+	f := &Field{n: n, size: len(n.Bytes())}
+	if big.MontgomeryCompatible(n) {
+		f.mt = big.NewMontgomery(n)
+	}
+	return f
+}
+
+// Modulus は法 n を返します
+func (f *Field) Modulus() *big.Int {
+	return f.n
+}
+
+// ByteLen は元のバイト表現の長さを返します
+func (f *Field) ByteLen() int {
+	return f.size
+}
+
+// NewElement は x mod n を表す元を返します
+func (f *Field) NewElement(x *big.Int) *Element {
+	v := big.Mod(x, f.n)
+	if f.mt != nil {
+		v = f.mt.To(v)
+	}
+	return &Element{f: f, v: v}
+}
+
+// Zero は加法の単位元 0 を返します
+func (f *Field) Zero() *Element {
+	return &Element{f: f, v: big.NewInt(0)}
+}
+
+// One は乗法の単位元 1 を返します
+func (f *Field) One() *Element {
+	return f.NewElement(big.NewInt(1))
+}
+
+// SetBytes は ByteLen の長さのビッグエンディアンのバイト列から元を読み込みます
+// 長さが異なるときや値が n 以上のときは正規の表現でないので ErrInvalidEncoding を返します
+func (f *Field) SetBytes(b []byte) (*Element, error) {
+	if len(b) != f.size {
+		return nil, ErrInvalidEncoding
+	}
+	x := new(big.Int).SetBytes(b)
+	if big.Cmp(x, f.n) >= 0 {
+		return nil, ErrInvalidEncoding
+	}
+	return f.NewElement(x), nil
+}
+
+// Random は r から読み込んだ乱数で 0 <= x < n の一様な元を返します
+func (f *Field) Random(r io.Reader) (*Element, error) {
+	// 法の最上位バイトを超えないビットだけを残して、n 未満の値が出るまで読み込みを繰り返す
+	top := f.n.Bytes()[0]
+	mask := byte(0xff)
+	for mask>>1 >= top {
+		mask >>= 1
+	}
+	b := make([]byte, f.size)
+	for {
+		if _, err := io.ReadFull(r, b); err != nil {
+			return nil, err
+		}
+		b[0] &= mask
+		x := new(big.Int).SetBytes(b)
+		if big.Cmp(x, f.n) < 0 {
+			return f.NewElement(x), nil
+		}
+	}
+}
+
+// Element は剰余環の元です
+// 値は常に 0 <= v < n に正規化されています
+type Element struct {
+	f *Field
+	v *big.Int
+}
+
+// Field は x が属する剰余環を返します
+func (x *Element) Field() *Field {
+	return x.f
+}
+
+// Int は x を 0 <= x < n の整数で返します
+func (x *Element) Int() *big.Int {
+	if x.f.mt != nil {
+		return x.f.mt.From(x.v)
+	}
+	return x.v
+}
+
+// Bytes は x を ByteLen の長さのビッグエンディアンのバイト列で返します
+func (x *Element) Bytes() []byte {
+	return x.Int().FillBytes(make([]byte, x.f.size))
+}
+
+func (x *Element) String() string {
+	return x.Int().String()
+}
+
+// Add は x + y を返します
+func (x *Element) Add(y *Element) *Element {
+	x.check(y)
+	v := big.Add(x.v, y.v)
+	if big.Cmp(v, x.f.n) >= 0 {
+		v = big.Sub(v, x.f.n)
+	}
+	return &Element{f: x.f, v: v}
+}
+
+// Sub は x - y を返します
+func (x *Element) Sub(y *Element) *Element {
+	x.check(y)
+	v := big.Sub(x.v, y.v)
+	if big.Cmp(v, big.Zero) < 0 {
+		v = big.Add(v, x.f.n)
+	}
+	return &Element{f: x.f, v: v}
+}
+
+// Neg は -x を返します
+func (x *Element) Neg() *Element {
+	return x.f.Zero().Sub(x)
+}
+
+// Mul は x * y を返します
+func (x *Element) Mul(y *Element) *Element {
+	x.check(y)
+	if x.f.mt != nil {
+		return &Element{f: x.f, v: x.f.mt.Mul(x.v, y.v)}
+	}
+	return &Element{f: x.f, v: big.Mod(big.Mul(x.v, y.v), x.f.n)}
+}
+
+// Square は x^2 を返します
+func (x *Element) Square() *Element {
+	return x.Mul(x)
+}
+
+// Inv は x の乗法の逆元を返します
+// 逆元が存在しないときは nil を返します
+func (x *Element) Inv() *Element {
+	inv := big.ModInverse(x.Int(), x.f.n)
+	if inv == nil {
+		return nil
+	}
+	return x.f.NewElement(inv)
+}
+
+// Exp は x^k を返します
+// k < 0 のときは x の逆元について計算し、逆元が存在しなければ nil を返します
+func (x *Element) Exp(k *big.Int) *Element {
+	v := big.Exp(x.Int(), k, x.f.n)
+	if v == nil {
+		return nil
+	}
+	return x.f.NewElement(v)
+}
+
+// Sqrt は y^2 = x となる y を返します
+// 法が奇素数のときだけ使え、x が平方剰余でないときは ok = false を返します
+func (x *Element) Sqrt() (y *Element, ok bool) {
+	r := big.ModSqrt(x.Int(), x.f.n)
+	if r == nil {
+		return nil, false
+	}
+	return x.f.NewElement(r), true
+}
+
+// Equal は x と y が等しいかどうかを判定します
+func (x *Element) Equal(y *Element) bool {
+	x.check(y)
+	return big.Cmp(x.v, y.v) == 0
+}
+
+// Select は v == 1 のとき x を、v == 0 のとき y を返します
+// 内部の表現のまま全桁を読み込んで選ぶので、v によって処理が分岐しません
+func Select(v int, x, y *Element) *Element {
+	x.check(y)
+	return &Element{f: x.f, v: big.Select(v, x.v, y.v)}
+}
+
+// IsZero は x が0かどうかを判定します
+func (x *Element) IsZero() bool {
+	return big.Cmp(x.v, big.Zero) == 0
+}
+
+// check は x と y が同じ剰余環の元であることを確かめ、異なる場合はpanicする
+func (x *Element) check(y *Element) {
+	if x.f != y.f && big.Cmp(x.f.n, y.f.n) != 0 {
+		panic("field: elements from different fields")
+	}
+}
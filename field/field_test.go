@@ -0,0 +1,314 @@
+package field
+
+import (
+	"crypto/rand"
+	"reflect"
+	"testing"
+
+	"github.com/convto/mycrypto/big"
+)
+
+var (
+	// 10 と互いに素なので Montgomery 表現を使う
+	testPrime = big.NewInt(1000003)
+	// 偶数の法では Montgomery 表現を使わない
+	testEven = big.NewInt(1024)
+	p256     = new(big.Int).SetString("115792089210356248762697446949407573530086143415290314195533631308867097853951")
+)
+
+func TestElement_Add(t *testing.T) {
+	type args struct {
+		n *big.Int
+		x *big.Int
+		y *big.Int
+	}
+	tests := []struct {
+		name string
+		args args
+		want *big.Int
+	}{
+		{
+			name: "x + y",
+			args: args{n: testPrime, x: big.NewInt(12), y: big.NewInt(30)},
+			want: big.NewInt(42),
+		},
+		{
+			name: "x + y >= n",
+			args: args{n: testPrime, x: big.NewInt(1000000), y: big.NewInt(10)},
+			want: big.NewInt(7),
+		},
+		{
+			name: "even modulus",
+			args: args{n: testEven, x: big.NewInt(1000), y: big.NewInt(100)},
+			want: big.NewInt(76),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := New(tt.args.n)
+			got := f.NewElement(tt.args.x).Add(f.NewElement(tt.args.y))
+			if !reflect.DeepEqual(got.Int(), tt.want) {
+				t.Errorf("Add() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestElement_Sub(t *testing.T) {
+	type args struct {
+		n *big.Int
+		x *big.Int
+		y *big.Int
+	}
+	tests := []struct {
+		name string
+		args args
+		want *big.Int
+	}{
+		{
+			name: "x - y",
+			args: args{n: testPrime, x: big.NewInt(42), y: big.NewInt(30)},
+			want: big.NewInt(12),
+		},
+		{
+			name: "x - y < 0",
+			args: args{n: testPrime, x: big.NewInt(10), y: big.NewInt(20)},
+			want: big.NewInt(999993),
+		},
+		{
+			name: "even modulus",
+			args: args{n: testEven, x: big.NewInt(0), y: big.NewInt(1)},
+			want: big.NewInt(1023),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := New(tt.args.n)
+			got := f.NewElement(tt.args.x).Sub(f.NewElement(tt.args.y))
+			if !reflect.DeepEqual(got.Int(), tt.want) {
+				t.Errorf("Sub() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestElement_Mul(t *testing.T) {
+	type args struct {
+		n *big.Int
+		x *big.Int
+		y *big.Int
+	}
+	tests := []struct {
+		name string
+		args args
+		want *big.Int
+	}{
+		{
+			name: "x * y",
+			args: args{n: testPrime, x: big.NewInt(123456), y: big.NewInt(654321)},
+			want: big.NewInt(123456 * 654321 % 1000003),
+		},
+		{
+			name: "negative input is reduced",
+			args: args{n: testPrime, x: big.NewInt(-1), y: big.NewInt(2)},
+			want: big.NewInt(1000001),
+		},
+		{
+			name: "even modulus",
+			args: args{n: testEven, x: big.NewInt(1000), y: big.NewInt(1000)},
+			want: big.NewInt(1000 * 1000 % 1024),
+		},
+		{
+			name: "P-256 prime",
+			args: args{
+				n: p256,
+				x: new(big.Int).SetString("48439561293906451759052585252797914202762949526041747995844080717082404635286"),
+				y: new(big.Int).SetString("36134250956749795798585127919587881956611106672985015071877198253568414405109"),
+			},
+			want: new(big.Int).SetString("58908126177458906251578054527685290833723497900791240663493461173334367443134"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := New(tt.args.n)
+			got := f.NewElement(tt.args.x).Mul(f.NewElement(tt.args.y))
+			if !reflect.DeepEqual(got.Int(), tt.want) {
+				t.Errorf("Mul() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestElement_Inv(t *testing.T) {
+	type args struct {
+		n *big.Int
+		x *big.Int
+	}
+	tests := []struct {
+		name string
+		args args
+		want *big.Int
+	}{
+		{
+			name: "prime field",
+			args: args{n: testPrime, x: big.NewInt(3)},
+			want: big.NewInt(666669),
+		},
+		{
+			name: "even modulus",
+			args: args{n: testEven, x: big.NewInt(3)},
+			want: big.NewInt(683),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := New(tt.args.n)
+			got := f.NewElement(tt.args.x).Inv()
+			if !reflect.DeepEqual(got.Int(), tt.want) {
+				t.Errorf("Inv() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestElement_Inv_notInvertible(t *testing.T) {
+	f := New(testEven)
+	if got := f.NewElement(big.NewInt(2)).Inv(); got != nil {
+		t.Errorf("Inv() = %v, want nil", got)
+	}
+	f = New(testPrime)
+	if got := f.Zero().Inv(); got != nil {
+		t.Errorf("Inv() = %v, want nil", got)
+	}
+}
+
+func TestElement_Exp(t *testing.T) {
+	type args struct {
+		n *big.Int
+		x *big.Int
+		k *big.Int
+	}
+	tests := []struct {
+		name string
+		args args
+		want *big.Int
+	}{
+		{
+			name: "x^k",
+			args: args{n: testPrime, x: big.NewInt(123456), k: big.NewInt(1000001)},
+			want: big.NewInt(414894),
+		},
+		{
+			name: "x^(-1)",
+			args: args{n: testPrime, x: big.NewInt(3), k: big.NewInt(-1)},
+			want: big.NewInt(666669),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := New(tt.args.n)
+			got := f.NewElement(tt.args.x).Exp(tt.args.k)
+			if !reflect.DeepEqual(got.Int(), tt.want) {
+				t.Errorf("Exp() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestElement_Sqrt(t *testing.T) {
+	f := New(testPrime)
+	x := f.NewElement(big.NewInt(123456))
+	got, ok := x.Square().Sqrt()
+	if !ok {
+		t.Fatalf("Sqrt() ok = false")
+	}
+	if !got.Equal(x) && !got.Equal(x.Neg()) {
+		t.Errorf("Sqrt() = %v, want ±%v", got, x)
+	}
+
+	// 1000003 = 3 mod 4 なので -1 は平方非剰余
+	if _, ok := f.One().Neg().Sqrt(); ok {
+		t.Errorf("Sqrt() ok = true, want false")
+	}
+}
+
+func TestElement_Bytes(t *testing.T) {
+	f := New(p256)
+	x := f.NewElement(new(big.Int).SetString("48439561293906451759052585252797914202762949526041747995844080717082404635286"))
+	want := []byte{
+		0x6b, 0x17, 0xd1, 0xf2, 0xe1, 0x2c, 0x42, 0x47, 0xf8, 0xbc, 0xe6, 0xe5, 0x63, 0xa4, 0x40, 0xf2,
+		0x77, 0x03, 0x7d, 0x81, 0x2d, 0xeb, 0x33, 0xa0, 0xf4, 0xa1, 0x39, 0x45, 0xd8, 0x98, 0xc2, 0x96,
+	}
+	if got := x.Bytes(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Bytes() = %x, want %x", got, want)
+	}
+	got, err := f.SetBytes(want)
+	if err != nil {
+		t.Fatalf("SetBytes() error = %v", err)
+	}
+	if !got.Equal(x) {
+		t.Errorf("SetBytes() = %v, want %v", got, x)
+	}
+	if got := f.One().Bytes(); len(got) != 32 || got[31] != 1 {
+		t.Errorf("Bytes() = %x, want fixed length encoding of 1", got)
+	}
+}
+
+func TestField_SetBytes_invalid(t *testing.T) {
+	f := New(testPrime)
+	tests := []struct {
+		name string
+		b    []byte
+	}{
+		{name: "too short", b: []byte{0x01, 0x02}},
+		{name: "too long", b: []byte{0x00, 0x00, 0x00, 0x01}},
+		// 0x0f4243 = 1000003 は法と等しいので正規の表現ではない
+		{name: "not reduced", b: []byte{0x0f, 0x42, 0x43}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := f.SetBytes(tt.b); err != ErrInvalidEncoding {
+				t.Errorf("SetBytes() error = %v, want %v", err, ErrInvalidEncoding)
+			}
+		})
+	}
+}
+
+func TestSelect(t *testing.T) {
+	f := New(testPrime)
+	x := f.NewElement(big.NewInt(12345))
+	y := f.NewElement(big.NewInt(-1))
+	if got := Select(1, x, y); !got.Equal(x) {
+		t.Errorf("Select(1) = %v, want %v", got, x)
+	}
+	if got := Select(0, x, y); !got.Equal(y) {
+		t.Errorf("Select(0) = %v, want %v", got, y)
+	}
+}
+
+func TestElement_mismatchedFields(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Add() did not panic")
+		}
+	}()
+	New(testPrime).One().Add(New(testEven).One())
+}
+
+func TestField_Random(t *testing.T) {
+	f := New(testPrime)
+	seen := make(map[string]bool)
+	for i := 0; i < 100; i++ {
+		x, err := f.Random(rand.Reader)
+		if err != nil {
+			t.Fatalf("Random() error = %v", err)
+		}
+		if big.Cmp(x.Int(), testPrime) >= 0 {
+			t.Fatalf("Random() = %v, want < %v", x, testPrime)
+		}
+		seen[x.String()] = true
+	}
+	if len(seen) < 90 {
+		t.Errorf("Random() returned only %d distinct values out of 100", len(seen))
+	}
+}
@@ -0,0 +1,222 @@
+package frost
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/convto/mycrypto/big"
+	"github.com/convto/mycrypto/dlog"
+	"github.com/convto/mycrypto/sss"
+	"github.com/convto/mycrypto/vss"
+)
+
+// ErrInvalidProof は DKG の1ラウンド目の知識の証明が検証できないことを表します
+var ErrInvalidProof = errors.New("frost: invalid proof of knowledge")
+
+// DKGSecret は DKG の間に参加者が秘密に保持する状態です
+// 各参加者はディーラーとしてランダムな秘密を Feldman VSS で分散し、受け取った分け前の和が最終的な秘密鍵の分け前になります
+type DKGSecret struct {
+	id         int
+	n          int
+	shares     []*vss.Share
+	commitment *vss.FeldmanCommitment
+}
+
+// DKGRound1 は DKG の1ラウンド目に全員にブロードキャストするメッセージです
+type DKGRound1 struct {
+	ID *big.Int
+	// Commitment は多項式 f_i の係数へのコミットメント φ_ij = a_ij G です
+	Commitment []dlog.Element
+	// ProofR, ProofZ は a_i0 を知っていることを示す Schnorr の証明 (R_i, μ_i) です
+	// 他人のコミットメントを打ち消すように φ_i0 を選ぶ rogue-key 攻撃を防ぎます
+	ProofR dlog.Element
+	ProofZ *big.Int
+}
+
+// DKGRound2 は DKG の2ラウンド目に参加者 From から参加者 To に秘密の通信路で送る分け前 f_From(To) です
+type DKGRound2 struct {
+	From  *big.Int
+	To    *big.Int
+	Share *big.Int
+}
+
+// DKGPart1 は r から読み込んだ乱数で、n 人のうち k 人で署名する鍵の DKG の1ラウンド目を参加者 id (1 <= id <= n) として行います
+// 返した DKGSecret は秘密に保持し、DKGRound1 を他の全員に送ります
+func (cs *Ciphersuite) DKGPart1(r io.Reader, id, n, k int) (*DKGSecret, *DKGRound1, error) {
+	if id < 1 || id > n {
+		return nil, nil, ErrInvalidIdentifier
+	}
+	grp := cs.Group
+	q := grp.Order()
+	a0, err := big.RandNonZeroInt(r, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	shares, c, err := vss.SplitFeldman(r, grp, a0, n, k)
+	if err != nil {
+		return nil, nil, err
+	}
+	// μ_i = k + a_i0 c_i, c_i = HDKG(i || φ_i0 || R_i)
+	nonce, err := big.RandNonZeroInt(r, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	idInt := big.NewInt(int64(id))
+	proofR := grp.Exp(grp.Generator(), nonce)
+	ch, err := cs.dkgChallenge(idInt, c.PublicKey(), proofR)
+	if err != nil {
+		return nil, nil, err
+	}
+	mu := big.Mod(big.Add(nonce, big.Mul(a0, ch)), q)
+	secret := &DKGSecret{id: id, n: n, shares: shares, commitment: c}
+	return secret, &DKGRound1{ID: idInt, Commitment: c.C, ProofR: proofR, ProofZ: mu}, nil
+}
+
+// DKGPart2 は他の n-1 人の DKGRound1 を検証し、DKG の2ラウンド目にそれぞれに送る分け前を返します
+// 知識の証明が検証できない参加者がいるときは、その識別子を含む ErrInvalidProof を返します
+func (cs *Ciphersuite) DKGPart2(secret *DKGSecret, round1 []*DKGRound1) ([]*DKGRound2, error) {
+	if _, err := secret.round1ByID(round1); err != nil {
+		return nil, err
+	}
+	for _, m := range round1 {
+		if !cs.verifyDKGRound1(m, len(secret.commitment.C)) {
+			return nil, fmt.Errorf("%w: participant %v", ErrInvalidProof, m.ID)
+		}
+	}
+	var out []*DKGRound2
+	for _, s := range secret.shares {
+		if int(s.Index) == secret.id {
+			continue
+		}
+		out = append(out, &DKGRound2{
+			From:  big.NewInt(int64(secret.id)),
+			To:    big.NewInt(int64(s.Index)),
+			Share: new(big.Int).SetBytes(s.Value),
+		})
+	}
+	return out, nil
+}
+
+// DKGPart3 は他の n-1 人から受け取った分け前をそれぞれの DKGRound1 のコミットメントで検証し、鍵を返します
+// 秘密鍵の分け前は受け取った分け前と自分の分け前の和、公開鍵は全員の φ_i0 の和になります
+// 検証できない分け前があるときは送り主の識別子を含む ErrInvalidShare を返すので、参加者は vss.Complaint で送り主に申し立てます
+func (cs *Ciphersuite) DKGPart3(secret *DKGSecret, round1 []*DKGRound1, round2 []*DKGRound2) (*KeyPackage, *PublicKeyPackage, error) {
+	byID, err := secret.round1ByID(round1)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(round2) != len(round1) {
+		return nil, nil, ErrInvalidIdentifier
+	}
+	grp := cs.Group
+	q := grp.Order()
+	k := len(secret.commitment.C)
+	self := secret.shares[secret.id-1]
+	s := new(big.Int).SetBytes(self.Value)
+	received := make(map[int]bool)
+	for _, m := range round2 {
+		from, ok := secret.participant(m.From)
+		if !ok || received[from] || m.To == nil || big.Cmp(m.To, big.NewInt(int64(secret.id))) != 0 {
+			return nil, nil, ErrInvalidIdentifier
+		}
+		received[from] = true
+		r1 := byID[from]
+		c := &vss.FeldmanCommitment{Group: grp, C: r1.Commitment}
+		if m.Share == nil || big.Cmp(m.Share, big.Zero) < 0 || big.Cmp(m.Share, q) >= 0 {
+			return nil, nil, fmt.Errorf("%w: participant %v", ErrInvalidShare, m.From)
+		}
+		share := &vss.Share{Share: sss.Share{
+			Mode:      sss.ModePrime,
+			Threshold: byte(k),
+			Index:     byte(secret.id),
+			Value:     m.Share.FillBytes(make([]byte, (q.BitLen()+7)/8)),
+		}}
+		if !c.Verify(share) {
+			return nil, nil, fmt.Errorf("%w: participant %v", ErrInvalidShare, m.From)
+		}
+		s = big.Add(s, m.Share)
+	}
+
+	// 全員のコミットメントを係数ごとに足すと、分け前の和の多項式へのコミットメントになる
+	sum := append([]dlog.Element(nil), secret.commitment.C...)
+	for _, m := range round1 {
+		for j := range sum {
+			sum[j] = grp.Op(sum[j], m.Commitment[j])
+		}
+	}
+	c := &vss.FeldmanCommitment{Group: grp, C: sum}
+	s = big.Mod(s, q)
+	kp := &KeyPackage{
+		ID:             big.NewInt(int64(secret.id)),
+		SecretShare:    s,
+		VerifyingShare: grp.Exp(grp.Generator(), s),
+		GroupPublicKey: c.PublicKey(),
+		MinSigners:     k,
+	}
+	return kp, cs.NewPublicKeyPackage(c, secret.n), nil
+}
+
+// verifyDKGRound1 は m のコミットメントが k 個の群の元で、知識の証明 μ G = R + c φ_0 が成り立つかを判定する
+func (cs *Ciphersuite) verifyDKGRound1(m *DKGRound1, k int) bool {
+	grp := cs.Group
+	if len(m.Commitment) != k || m.ProofR == nil || m.ProofZ == nil || !grp.Contains(m.ProofR) {
+		return false
+	}
+	for _, e := range m.Commitment {
+		if e == nil || !grp.Contains(e) {
+			return false
+		}
+	}
+	if big.Cmp(m.ProofZ, big.Zero) < 0 || big.Cmp(m.ProofZ, grp.Order()) >= 0 {
+		return false
+	}
+	ch, err := cs.dkgChallenge(m.ID, m.Commitment[0], m.ProofR)
+	if err != nil {
+		return false
+	}
+	lhs := grp.Exp(grp.Generator(), m.ProofZ)
+	rhs := grp.Op(m.ProofR, grp.Exp(m.Commitment[0], ch))
+	return grp.Equal(lhs, rhs)
+}
+
+// dkgChallenge は知識の証明のチャレンジ HDKG(SerializeScalar(id) || SerializeElement(φ_0) || SerializeElement(R)) を返す
+// H1 などと同じく contextString にタグ "dkg" をつけてドメインを分ける
+func (cs *Ciphersuite) dkgChallenge(id *big.Int, phi0, r dlog.Element) (*big.Int, error) {
+	pb, err := cs.Group.SerializeElement(phi0)
+	if err != nil {
+		return nil, err
+	}
+	rb, err := cs.Group.SerializeElement(r)
+	if err != nil {
+		return nil, err
+	}
+	input := append(cs.Group.SerializeScalar(id), pb...)
+	return cs.hashToScalar("dkg", append(input, rb...)), nil
+}
+
+// round1ByID は自分以外の n-1 人の DKGRound1 がそろっていることを確かめ、識別子から引けるようにする
+func (s *DKGSecret) round1ByID(round1 []*DKGRound1) (map[int]*DKGRound1, error) {
+	if len(round1) != s.n-1 {
+		return nil, ErrInvalidIdentifier
+	}
+	byID := make(map[int]*DKGRound1)
+	for _, m := range round1 {
+		id, ok := s.participant(m.ID)
+		if !ok || byID[id] != nil {
+			return nil, ErrInvalidIdentifier
+		}
+		byID[id] = m
+	}
+	return byID, nil
+}
+
+// participant は id が自分以外の参加者 1, ..., n の識別子であればその値を返す
+// vss の制約で n <= 255 なので、識別子は1バイトに収まる
+func (s *DKGSecret) participant(id *big.Int) (int, bool) {
+	if id == nil || big.Cmp(id, big.NewInt(1)) < 0 || big.Cmp(id, big.NewInt(int64(s.n))) > 0 {
+		return 0, false
+	}
+	i := int(id.Bytes()[0])
+	return i, i != s.id
+}
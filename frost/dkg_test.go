@@ -0,0 +1,155 @@
+package frost
+
+import (
+	"crypto/rand"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/convto/mycrypto/big"
+	"github.com/convto/mycrypto/dlog"
+)
+
+// runDKG は n 人に閾値 k の DKG を行い、すべてのメッセージをバイト列で受け渡す
+// tamper が nil でなければ、送信する前の2ラウンド目のメッセージを書き換える
+func runDKG(t *testing.T, cs *Ciphersuite, n, k int, tamper func(m *DKGRound2)) ([]*KeyPackage, []*PublicKeyPackage, error) {
+	t.Helper()
+	secrets := make([]*DKGSecret, n)
+	round1 := make([]*DKGRound1, n)
+	for i := range secrets {
+		s, m, err := cs.DKGPart1(rand.Reader, i+1, n, k)
+		if err != nil {
+			t.Fatal(err)
+		}
+		secrets[i] = s
+		round1[i] = &DKGRound1{}
+		roundTrip(t, cs, m, round1[i])
+	}
+	others := func(i int) []*DKGRound1 {
+		var res []*DKGRound1
+		for j, m := range round1 {
+			if j != i {
+				res = append(res, m)
+			}
+		}
+		return res
+	}
+
+	inbox := make([][]*DKGRound2, n)
+	for i, s := range secrets {
+		out, err := cs.DKGPart2(s, others(i))
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, m := range out {
+			if tamper != nil {
+				tamper(m)
+			}
+			received := &DKGRound2{}
+			roundTrip(t, cs, m, received)
+			to := int(received.To.Bytes()[0]) - 1
+			inbox[to] = append(inbox[to], received)
+		}
+	}
+
+	kps := make([]*KeyPackage, n)
+	pkps := make([]*PublicKeyPackage, n)
+	for i, s := range secrets {
+		var err error
+		if kps[i], pkps[i], err = cs.DKGPart3(s, others(i), inbox[i]); err != nil {
+			return nil, nil, err
+		}
+	}
+	return kps, pkps, nil
+}
+
+func TestDKG(t *testing.T) {
+	for _, tt := range testSuites {
+		t.Run(tt.name, func(t *testing.T) {
+			cs := tt.cs
+			grp := cs.Group
+			kps, pkps, err := runDKG(t, cs, 3, 2, nil)
+			if err != nil {
+				t.Fatalf("DKG error = %v", err)
+			}
+			// 全員が同じ公開鍵と PK_i に合意し、PK_i は各自の分け前と対応する
+			for i, kp := range kps {
+				if !grp.Equal(kp.GroupPublicKey, pkps[0].GroupPublicKey) || !grp.Equal(pkps[i].GroupPublicKey, pkps[0].GroupPublicKey) {
+					t.Errorf("participant %v has a different group public key", kp.ID)
+				}
+				if !grp.Equal(pkps[0].verifyingShare(kp.ID), grp.Exp(grp.Generator(), kp.SecretShare)) {
+					t.Errorf("verifying share of participant %v does not match", kp.ID)
+				}
+			}
+			// 2人の分け前から復元した秘密鍵が公開鍵と対応する
+			q := grp.Order()
+			ids := []*big.Int{kps[0].ID, kps[2].ID}
+			secret := big.NewInt(0)
+			for _, kp := range []*KeyPackage{kps[0], kps[2]} {
+				l, err := cs.interpolatingValue(ids, kp.ID)
+				if err != nil {
+					t.Fatal(err)
+				}
+				secret = big.Mod(big.Add(secret, big.Mul(l, kp.SecretShare)), q)
+			}
+			if !grp.Equal(grp.Exp(grp.Generator(), secret), kps[0].GroupPublicKey) {
+				t.Errorf("reconstructed secret does not match the group public key")
+			}
+
+			msg := []byte("signed with a distributed key")
+			sig := sign(t, cs, kps, pkps[1], []int{1, 2}, msg)
+			if !cs.Verify(pkps[0].GroupPublicKey, msg, sig) {
+				t.Errorf("Verify() = false, want true")
+			}
+		})
+	}
+}
+
+// TestDKG_invalidShare は2ラウンド目で不正な分け前を送った参加者が特定されることを確かめる
+func TestDKG_invalidShare(t *testing.T) {
+	cs := Secp256k1()
+	q := cs.Group.Order()
+	_, _, err := runDKG(t, cs, 3, 2, func(m *DKGRound2) {
+		if big.Cmp(m.From, big.NewInt(2)) == 0 && big.Cmp(m.To, big.NewInt(3)) == 0 {
+			m.Share = big.Mod(big.Add(m.Share, big.NewInt(1)), q)
+		}
+	})
+	if !errors.Is(err, ErrInvalidShare) || !strings.Contains(err.Error(), "participant 2") {
+		t.Errorf("DKG error = %v, want %v for participant 2", err, ErrInvalidShare)
+	}
+}
+
+func TestDKGPart2_invalidProof(t *testing.T) {
+	cs := Secp256k1()
+	grp := cs.Group
+	s1, _, err := cs.DKGPart1(rand.Reader, 1, 3, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, m2, err := cs.DKGPart1(rand.Reader, 2, 3, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, m3, err := cs.DKGPart1(rand.Reader, 3, 3, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 参加者3が φ_0 を他人のものに差し替える (証明の対象の秘密を知らない)
+	forged := *m3
+	forged.Commitment = append([]dlog.Element{}, m3.Commitment...)
+	forged.Commitment[0] = grp.Op(m2.Commitment[0], grp.Generator())
+	if _, err := cs.DKGPart2(s1, []*DKGRound1{m2, &forged}); !errors.Is(err, ErrInvalidProof) || !strings.Contains(err.Error(), "participant 3") {
+		t.Errorf("DKGPart2() error = %v, want %v for participant 3", err, ErrInvalidProof)
+	}
+
+	// 証明を他の参加者の識別子で使い回すこともできない
+	replayed := *m3
+	replayed.ID = big.NewInt(2)
+	if _, err := cs.DKGPart2(s1, []*DKGRound1{m2, &replayed}); err == nil {
+		t.Errorf("DKGPart2(duplicate id) error = nil, want error")
+	}
+	if _, err := cs.DKGPart2(s1, []*DKGRound1{m2}); !errors.Is(err, ErrInvalidIdentifier) {
+		t.Errorf("DKGPart2(missing participant) error = %v, want %v", err, ErrInvalidIdentifier)
+	}
+}
@@ -0,0 +1,235 @@
+package frost
+
+import (
+	"encoding/binary"
+	"errors"
+
+	"github.com/convto/mycrypto/big"
+	"github.com/convto/mycrypto/dlog"
+)
+
+// ErrInvalidEncoding はメッセージのバイト表現が正しくないことを表します
+var ErrInvalidEncoding = errors.New("frost: invalid message encoding")
+
+// Message は参加者とコーディネーターの間で送受信するメッセージで、Ciphersuite の Marshal と Unmarshal でバイト列と相互に変換します
+// 群の元とスカラーは暗号スイートのシリアライズを使い、可変長の部分には2バイトのビッグエンディアンの個数を前置します
+//
+//	Commitment:     ID || D || E
+//	SigningPackage: count || Commitment... || message
+//	SignatureShare: ID || z
+//	Signature:      R || z
+//	DKGRound1:      ID || count || φ_0 ... φ_(k-1) || R || μ
+//	DKGRound2:      From || To || share
+type Message interface {
+	marshal(w *writer)
+	unmarshal(r *reader)
+}
+
+// Marshal は m をバイト列にします
+// 単位元など、シリアライズできない値を含むときはエラーを返します
+func (cs *Ciphersuite) Marshal(m Message) ([]byte, error) {
+	w := &writer{grp: cs.Group}
+	m.marshal(w)
+	if w.err != nil {
+		return nil, w.err
+	}
+	return w.b, nil
+}
+
+// Unmarshal はバイト列 b から m を読み込みます
+// 群の元やスカラーが正しくないときはそのエラーを、長さが合わないときは ErrInvalidEncoding を返します
+func (cs *Ciphersuite) Unmarshal(b []byte, m Message) error {
+	r := &reader{grp: cs.Group, b: b}
+	m.unmarshal(r)
+	if r.err == nil && len(r.b) != 0 {
+		r.err = ErrInvalidEncoding
+	}
+	return r.err
+}
+
+func (c *Commitment) marshal(w *writer) {
+	w.scalar(c.ID)
+	w.element(c.Hiding)
+	w.element(c.Binding)
+}
+
+func (c *Commitment) unmarshal(r *reader) {
+	c.ID = r.scalar()
+	c.Hiding = r.element()
+	c.Binding = r.element()
+}
+
+func (sp *SigningPackage) marshal(w *writer) {
+	w.count(len(sp.Commitments))
+	for _, c := range sp.Commitments {
+		c.marshal(w)
+	}
+	w.b = append(w.b, sp.Message...)
+}
+
+func (sp *SigningPackage) unmarshal(r *reader) {
+	n := r.count()
+	sp.Commitments = nil
+	for i := 0; i < n && r.err == nil; i++ {
+		c := &Commitment{}
+		c.unmarshal(r)
+		sp.Commitments = append(sp.Commitments, c)
+	}
+	sp.Message = append([]byte{}, r.b...)
+	r.b = nil
+}
+
+func (s *SignatureShare) marshal(w *writer) {
+	w.scalar(s.ID)
+	w.scalar(s.Z)
+}
+
+func (s *SignatureShare) unmarshal(r *reader) {
+	s.ID = r.scalar()
+	s.Z = r.scalar()
+}
+
+func (sig *Signature) marshal(w *writer) {
+	w.element(sig.R)
+	w.scalar(sig.Z)
+}
+
+func (sig *Signature) unmarshal(r *reader) {
+	sig.R = r.element()
+	sig.Z = r.scalar()
+}
+
+func (m *DKGRound1) marshal(w *writer) {
+	w.scalar(m.ID)
+	w.count(len(m.Commitment))
+	for _, e := range m.Commitment {
+		w.element(e)
+	}
+	w.element(m.ProofR)
+	w.scalar(m.ProofZ)
+}
+
+func (m *DKGRound1) unmarshal(r *reader) {
+	m.ID = r.scalar()
+	n := r.count()
+	m.Commitment = nil
+	for i := 0; i < n && r.err == nil; i++ {
+		m.Commitment = append(m.Commitment, r.element())
+	}
+	m.ProofR = r.element()
+	m.ProofZ = r.scalar()
+}
+
+func (m *DKGRound2) marshal(w *writer) {
+	w.scalar(m.From)
+	w.scalar(m.To)
+	w.scalar(m.Share)
+}
+
+func (m *DKGRound2) unmarshal(r *reader) {
+	m.From = r.scalar()
+	m.To = r.scalar()
+	m.Share = r.scalar()
+}
+
+// writer はメッセージを書き出す途中の状態で、最初に起きたエラーを保持する
+type writer struct {
+	grp Group
+	b   []byte
+	err error
+}
+
+func (w *writer) element(x dlog.Element) {
+	if w.err != nil {
+		return
+	}
+	if x == nil {
+		w.err = ErrInvalidElement
+		return
+	}
+	b, err := w.grp.SerializeElement(x)
+	if err != nil {
+		w.err = err
+		return
+	}
+	w.b = append(w.b, b...)
+}
+
+func (w *writer) scalar(s *big.Int) {
+	if w.err != nil {
+		return
+	}
+	if s == nil || big.Cmp(s, big.Zero) < 0 || big.Cmp(s, w.grp.Order()) >= 0 {
+		w.err = ErrInvalidScalar
+		return
+	}
+	w.b = append(w.b, w.grp.SerializeScalar(s)...)
+}
+
+func (w *writer) count(n int) {
+	if w.err != nil {
+		return
+	}
+	if n > 0xffff {
+		w.err = ErrInvalidEncoding
+		return
+	}
+	var b [2]byte
+	binary.BigEndian.PutUint16(b[:], uint16(n))
+	w.b = append(w.b, b[:]...)
+}
+
+// reader はメッセージを読み込む途中の状態で、最初に起きたエラーを保持する
+type reader struct {
+	grp Group
+	b   []byte
+	err error
+}
+
+// next は先頭から n バイトを取り出す
+func (r *reader) next(n int) []byte {
+	if r.err != nil {
+		return nil
+	}
+	if len(r.b) < n {
+		r.err = ErrInvalidEncoding
+		return nil
+	}
+	b := r.b[:n]
+	r.b = r.b[n:]
+	return b
+}
+
+func (r *reader) element() dlog.Element {
+	b := r.next(r.grp.ElementLen())
+	if r.err != nil {
+		return nil
+	}
+	x, err := r.grp.DeserializeElement(b)
+	if err != nil {
+		r.err = err
+		return nil
+	}
+	return x
+}
+
+func (r *reader) scalar() *big.Int {
+	b := r.next(r.grp.ScalarLen())
+	if r.err != nil {
+		return nil
+	}
+	s, err := r.grp.DeserializeScalar(b)
+	if err != nil {
+		r.err = err
+		return nil
+	}
+	return s
+}
+
+func (r *reader) count() int {
+	b := r.next(2)
+	if r.err != nil {
+		return 0
+	}
+	return int(binary.BigEndian.Uint16(b))
+}
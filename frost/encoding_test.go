@@ -0,0 +1,69 @@
+package frost
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/convto/mycrypto/big"
+)
+
+func TestMarshal_invalid(t *testing.T) {
+	cs := Secp256k1()
+	grp := cs.Group
+	tests := []struct {
+		name string
+		m    Message
+		want error
+	}{
+		{name: "identity element", m: &Signature{R: grp.Identity(), Z: big.NewInt(1)}, want: ErrInvalidElement},
+		{name: "nil element", m: &Commitment{ID: big.NewInt(1), Binding: grp.Generator()}, want: ErrInvalidElement},
+		{name: "scalar out of range", m: &SignatureShare{ID: big.NewInt(1), Z: grp.Order()}, want: ErrInvalidScalar},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := cs.Marshal(tt.m); !errors.Is(err, tt.want) {
+				t.Errorf("Marshal() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnmarshal_invalid(t *testing.T) {
+	cs := Secp256k1()
+	grp := cs.Group
+	b, err := cs.Marshal(&Signature{R: grp.Generator(), Z: big.NewInt(1)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	badScalar := append(append([]byte{}, b[:grp.ElementLen()]...), grp.Order().FillBytes(make([]byte, 32))...)
+	badElement := append([]byte{0x05}, b[1:]...)
+
+	tests := []struct {
+		name string
+		b    []byte
+		want error
+	}{
+		{name: "trailing bytes", b: append(append([]byte{}, b...), 0), want: ErrInvalidEncoding},
+		{name: "truncated", b: b[:len(b)-1], want: ErrInvalidEncoding},
+		{name: "empty", b: nil, want: ErrInvalidEncoding},
+		{name: "scalar out of range", b: badScalar, want: ErrInvalidScalar},
+		{name: "invalid element", b: badElement, want: ErrInvalidElement},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := cs.Unmarshal(tt.b, &Signature{}); !errors.Is(err, tt.want) {
+				t.Errorf("Unmarshal() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+
+	// SigningPackage の個数が実際の要素数より多い
+	sp, err := cs.Marshal(&SigningPackage{Message: []byte("m")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sp[1] = 1
+	if err := cs.Unmarshal(sp, &SigningPackage{}); !errors.Is(err, ErrInvalidEncoding) {
+		t.Errorf("Unmarshal(SigningPackage) error = %v, want %v", err, ErrInvalidEncoding)
+	}
+}
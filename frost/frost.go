@@ -0,0 +1,179 @@
+// Package frost は RFC 9591 の FROST (Flexible Round-Optimized Schnorr Threshold) 署名を提供します
+// n 人の参加者で分散した秘密鍵のうち k 人が2ラウンドのやり取りで Schnorr 署名を作り、署名は単一の鍵の署名と同じ方法で検証できます
+// 1ラウンド目で各参加者はナンスへのコミットメントを公開し、2ラウンド目でコミットメントの一覧とメッセージに束縛した署名の分け前を返します
+// 暗号スイートは FROST(Ed25519, SHA-512) と FROST(secp256k1, SHA-256) で、前者の署名は RFC 8032 の Ed25519 で検証できます
+// 鍵は信頼できるディーラーによる生成 (RFC 9591 Appendix C) と、ディーラーのいない Pedersen の DKG のどちらでも作れます
+package frost
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"errors"
+	"sort"
+
+	"github.com/convto/mycrypto/big"
+	"github.com/convto/mycrypto/dlog"
+	"github.com/convto/mycrypto/ec"
+	"github.com/convto/mycrypto/edwards25519"
+	"github.com/convto/mycrypto/h2c"
+)
+
+// ErrInvalidIdentifier は参加者の識別子が 1 <= id < q の範囲にないか、重複していることを表します
+var ErrInvalidIdentifier = errors.New("frost: invalid identifier")
+
+// Ciphersuite は RFC 9591 6 の暗号スイートで、群とハッシュ関数 H1 から H5 の組です
+type Ciphersuite struct {
+	// Group は署名に使う素数位数の群です
+	Group Group
+	// contextString は各ハッシュ関数の入力の先頭につける文字列です
+	contextString string
+	// hashToScalar は contextString || tag をドメインにして m をスカラーに写す
+	hashToScalar func(tag string, m []byte) *big.Int
+	// hash は contextString || tag || m のハッシュ値を返す
+	hash func(tag string, m []byte) []byte
+	// h2 はチャレンジを求める H2 で、Ed25519 では RFC 8032 と互換にするため contextString をつけない
+	h2 func(m []byte) *big.Int
+}
+
+// Ed25519 は RFC 9591 6.1 の FROST(Ed25519, SHA-512) を返します
+func Ed25519() *Ciphersuite {
+	const ctx = "FROST-ED25519-SHA512-v1"
+	l := edwards25519.L()
+	sum := func(msgs ...[]byte) []byte {
+		h := sha512.New()
+		for _, m := range msgs {
+			h.Write(m)
+		}
+		return h.Sum(nil)
+	}
+	return &Ciphersuite{
+		Group:         ed25519Group{},
+		contextString: ctx,
+		hashToScalar: func(tag string, m []byte) *big.Int {
+			return big.Mod(new(big.Int).SetBytesLE(sum([]byte(ctx+tag), m)), l)
+		},
+		hash: func(tag string, m []byte) []byte {
+			return sum([]byte(ctx+tag), m)
+		},
+		h2: func(m []byte) *big.Int {
+			return big.Mod(new(big.Int).SetBytesLE(sum(m)), l)
+		},
+	}
+}
+
+// Secp256k1 は RFC 9591 6.5 の FROST(secp256k1, SHA-256) を返します
+// スカラーへのハッシュには RFC 9380 の hash_to_field を expand_message_xmd と SHA-256 で使います
+func Secp256k1() *Ciphersuite {
+	const ctx = "FROST-secp256k1-SHA256-v1"
+	grp := secp256k1Group{dlog.NewCurveGroup(ec.Secp256k1())}
+	return &Ciphersuite{
+		Group:         grp,
+		contextString: ctx,
+		hashToScalar: func(tag string, m []byte) *big.Int {
+			return hashToScalar(h2c.XMD(sha256.New, []byte(ctx+tag)), m, grp.Order())
+		},
+		hash: func(tag string, m []byte) []byte {
+			h := sha256.New()
+			h.Write([]byte(ctx + tag))
+			h.Write(m)
+			return h.Sum(nil)
+		},
+		h2: func(m []byte) *big.Int {
+			return hashToScalar(h2c.XMD(sha256.New, []byte(ctx+"chal")), m, grp.Order())
+		},
+	}
+}
+
+// hashToScalar は RFC 9380 5.2 の hash_to_field で、count = 1 として msg を 0 <= e < q の整数に写す
+func hashToScalar(e h2c.Expander, msg []byte, q *big.Int) *big.Int {
+	u, err := h2c.HashToField(e, msg, q, 1, 1, 128)
+	if err != nil {
+		panic(err)
+	}
+	return u[0][0]
+}
+
+// Signature は FROST で作った Schnorr 署名 (R, z) です
+type Signature struct {
+	R dlog.Element
+	Z *big.Int
+}
+
+// Verify は sig が公開鍵 pk によるメッセージ msg の正しい署名かどうかを、RFC 9591 Appendix B の手順で判定します
+// c = H2(R || PK || msg) として zG = R + cPK を確かめます
+func (cs *Ciphersuite) Verify(pk dlog.Element, msg []byte, sig *Signature) bool {
+	grp := cs.Group
+	if pk == nil || sig.R == nil || sig.Z == nil || !grp.Contains(pk) || !grp.Contains(sig.R) {
+		return false
+	}
+	if big.Cmp(sig.Z, big.Zero) < 0 || big.Cmp(sig.Z, grp.Order()) >= 0 {
+		return false
+	}
+	c, err := cs.challenge(sig.R, pk, msg)
+	if err != nil {
+		return false
+	}
+	lhs := grp.Exp(grp.Generator(), sig.Z)
+	rhs := grp.Op(sig.R, grp.Exp(pk, c))
+	return grp.Equal(lhs, rhs)
+}
+
+// challenge は RFC 9591 4.6 の compute_challenge で、H2(SerializeElement(R) || SerializeElement(PK) || msg) を返します
+func (cs *Ciphersuite) challenge(r, pk dlog.Element, msg []byte) (*big.Int, error) {
+	rb, err := cs.Group.SerializeElement(r)
+	if err != nil {
+		return nil, err
+	}
+	pkb, err := cs.Group.SerializeElement(pk)
+	if err != nil {
+		return nil, err
+	}
+	return cs.h2(append(append(rb, pkb...), msg...)), nil
+}
+
+// interpolatingValue は RFC 9591 4.2 の derive_interpolating_value で、ids を通る多項式の x = 0 でのラグランジュ係数のうち x の分を返します
+// Π x_j / (x_j - x) (j != x) を求め、x が ids に含まれないときや ids が重複しているときは ErrInvalidIdentifier を返します
+func (cs *Ciphersuite) interpolatingValue(ids []*big.Int, x *big.Int) (*big.Int, error) {
+	q := cs.Group.Order()
+	num, den := big.NewInt(1), big.NewInt(1)
+	found := false
+	for _, xj := range ids {
+		if big.Cmp(xj, x) == 0 {
+			if found {
+				return nil, ErrInvalidIdentifier
+			}
+			found = true
+			continue
+		}
+		num = big.Mod(big.Mul(num, xj), q)
+		den = big.Mod(big.Mul(den, big.Sub(xj, x)), q)
+	}
+	if !found || big.Cmp(den, big.Zero) == 0 {
+		return nil, ErrInvalidIdentifier
+	}
+	return big.Mod(big.Mul(num, modInverse(den, q)), q), nil
+}
+
+// validIdentifier は 1 <= id < q かどうかを判定する
+func (cs *Ciphersuite) validIdentifier(id *big.Int) bool {
+	return id != nil && inRange(id, cs.Group.Order())
+}
+
+// sortByIdentifier はコミットメントを識別子の昇順に並べ替えた新しいスライスを返す
+func sortByIdentifier(comms []*Commitment) []*Commitment {
+	sorted := append([]*Commitment(nil), comms...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return big.Cmp(sorted[i].ID, sorted[j].ID) < 0
+	})
+	return sorted
+}
+
+// modInverse は素数 q を法とする x の逆元を Fermat の小定理 x^(q-2) で求める
+func modInverse(x, q *big.Int) *big.Int {
+	return big.Exp(x, big.Sub(q, big.NewInt(2)), q)
+}
+
+// inRange は 1 <= x < n かどうかを判定します
+func inRange(x, n *big.Int) bool {
+	return big.Cmp(x, big.NewInt(1)) >= 0 && big.Cmp(x, n) < 0
+}
@@ -0,0 +1,185 @@
+package frost
+
+import (
+	stded25519 "crypto/ed25519"
+	"crypto/rand"
+	"errors"
+	"testing"
+
+	"github.com/convto/mycrypto/big"
+	"github.com/convto/mycrypto/vss"
+)
+
+// testSuites はテストする暗号スイート
+var testSuites = []struct {
+	name string
+	cs   *Ciphersuite
+}{
+	{name: "Ed25519", cs: Ed25519()},
+	{name: "secp256k1", cs: Secp256k1()},
+}
+
+// dealerKeys は信頼できるディーラーで n 人に閾値 k の鍵を配る
+func dealerKeys(t *testing.T, cs *Ciphersuite, n, k int) ([]*KeyPackage, *PublicKeyPackage) {
+	t.Helper()
+	shares, c, err := cs.TrustedDealerKeygen(rand.Reader, nil, n, k)
+	if err != nil {
+		t.Fatal(err)
+	}
+	kps := make([]*KeyPackage, n)
+	for i, s := range shares {
+		if kps[i], err = cs.NewKeyPackage(s, c); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return kps, cs.NewPublicKeyPackage(c, n)
+}
+
+// roundTrip は m をバイト列にしてから out に読み込み、メッセージが送受信できることを確かめる
+func roundTrip(t *testing.T, cs *Ciphersuite, m, out Message) {
+	t.Helper()
+	b, err := cs.Marshal(m)
+	if err != nil {
+		t.Fatalf("Marshal(%T) error = %v", m, err)
+	}
+	if err := cs.Unmarshal(b, out); err != nil {
+		t.Fatalf("Unmarshal(%T) error = %v", m, err)
+	}
+}
+
+// sign は signers の参加者で msg に署名する2ラウンドを、すべてのメッセージをバイト列で受け渡して行う
+func sign(t *testing.T, cs *Ciphersuite, kps []*KeyPackage, pkp *PublicKeyPackage, signers []int, msg []byte) *Signature {
+	t.Helper()
+	// 1ラウンド目: 各署名者がコミットメントをコーディネーターに送る
+	nonces := make([]*Nonces, len(signers))
+	sp := &SigningPackage{Message: msg}
+	for i, j := range signers {
+		n, c, err := cs.Commit(rand.Reader, kps[j])
+		if err != nil {
+			t.Fatal(err)
+		}
+		nonces[i] = n
+		received := &Commitment{}
+		roundTrip(t, cs, c, received)
+		sp.Commitments = append(sp.Commitments, received)
+	}
+
+	// 2ラウンド目: コーディネーターが SigningPackage を配り、各署名者が分け前を返す
+	var shares []*SignatureShare
+	for i, j := range signers {
+		received := &SigningPackage{}
+		roundTrip(t, cs, sp, received)
+		share, err := cs.Sign(kps[j], nonces[i], received)
+		if err != nil {
+			t.Fatalf("Sign(participant %v) error = %v", kps[j].ID, err)
+		}
+		got := &SignatureShare{}
+		roundTrip(t, cs, share, got)
+		shares = append(shares, got)
+	}
+	sig, err := cs.Aggregate(pkp, sp, shares)
+	if err != nil {
+		t.Fatalf("Aggregate() error = %v", err)
+	}
+	got := &Signature{}
+	roundTrip(t, cs, sig, got)
+	return got
+}
+
+func TestSign(t *testing.T) {
+	msg := []byte("frost threshold signature")
+	for _, tt := range testSuites {
+		t.Run(tt.name, func(t *testing.T) {
+			cs := tt.cs
+			kps, pkp := dealerKeys(t, cs, 5, 3)
+			for _, signers := range [][]int{{4, 2, 0}, {0, 1, 2, 3}} {
+				sig := sign(t, cs, kps, pkp, signers, msg)
+				if !cs.Verify(pkp.GroupPublicKey, msg, sig) {
+					t.Errorf("Verify(signers %v) = false, want true", signers)
+				}
+				if cs.Verify(pkp.GroupPublicKey, []byte("other message"), sig) {
+					t.Errorf("Verify(other message) = true, want false")
+				}
+			}
+		})
+	}
+}
+
+// TestSign_ed25519 は FROST(Ed25519, SHA-512) の署名が RFC 8032 の Ed25519 署名として crypto/ed25519 で検証できることを確かめる
+func TestSign_ed25519(t *testing.T) {
+	cs := Ed25519()
+	kps, pkp := dealerKeys(t, cs, 3, 2)
+	msg := []byte("compatible with RFC 8032")
+	sig := sign(t, cs, kps, pkp, []int{0, 2}, msg)
+	b, err := cs.Marshal(sig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pk, err := cs.Group.SerializeElement(pkp.GroupPublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !stded25519.Verify(pk, msg, b) {
+		t.Errorf("crypto/ed25519.Verify() = false, want true")
+	}
+}
+
+// TestTrustedDealerKeygen_secret は指定した秘密鍵が公開鍵になり、分け前から sss で復元できることを確かめる
+func TestTrustedDealerKeygen_secret(t *testing.T) {
+	for _, tt := range testSuites {
+		t.Run(tt.name, func(t *testing.T) {
+			cs := tt.cs
+			secret := big.NewInt(123456789)
+			shares, c, err := cs.TrustedDealerKeygen(rand.Reader, secret, 3, 2)
+			if err != nil {
+				t.Fatal(err)
+			}
+			grp := cs.Group
+			if !grp.Equal(c.PublicKey(), grp.Exp(grp.Generator(), secret)) {
+				t.Errorf("PublicKey() != secret * G")
+			}
+			got, err := vss.Combine(grp, c, shares[1:])
+			if err != nil {
+				t.Fatal(err)
+			}
+			if big.Cmp(got, secret) != 0 {
+				t.Errorf("vss.Combine() = %v, want %v", got, secret)
+			}
+
+			// 改ざんした分け前は NewKeyPackage で検出する
+			bad := *shares[0]
+			bad.Value = append([]byte(nil), bad.Value...)
+			bad.Value[len(bad.Value)-1] ^= 1
+			if _, err := cs.NewKeyPackage(&bad, c); !errors.Is(err, ErrInvalidShare) {
+				t.Errorf("NewKeyPackage(tampered) error = %v, want %v", err, ErrInvalidShare)
+			}
+		})
+	}
+}
+
+func TestInterpolatingValue(t *testing.T) {
+	cs := Secp256k1()
+	ids := []*big.Int{big.NewInt(1), big.NewInt(3), big.NewInt(4)}
+	// f(x) = 7 + 2x + 5x^2 の f(1), f(3), f(4) から f(0) = Σ λ_i f(x_i) を求める
+	q := cs.Group.Order()
+	sum := big.NewInt(0)
+	for _, x := range ids {
+		l, err := cs.interpolatingValue(ids, x)
+		if err != nil {
+			t.Fatal(err)
+		}
+		fx := big.Add(big.Add(big.NewInt(7), big.Mul(big.NewInt(2), x)), big.Mul(big.NewInt(5), big.Mul(x, x)))
+		sum = big.Mod(big.Add(sum, big.Mul(l, fx)), q)
+	}
+	if big.Cmp(sum, big.NewInt(7)) != 0 {
+		t.Errorf("Σ λ_i f(x_i) = %v, want 7", sum)
+	}
+
+	if _, err := cs.interpolatingValue(ids, big.NewInt(2)); !errors.Is(err, ErrInvalidIdentifier) {
+		t.Errorf("interpolatingValue(not in list) error = %v, want %v", err, ErrInvalidIdentifier)
+	}
+	dup := []*big.Int{big.NewInt(1), big.NewInt(3), big.NewInt(3)}
+	if _, err := cs.interpolatingValue(dup, big.NewInt(3)); !errors.Is(err, ErrInvalidIdentifier) {
+		t.Errorf("interpolatingValue(duplicate) error = %v, want %v", err, ErrInvalidIdentifier)
+	}
+}
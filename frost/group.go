@@ -0,0 +1,155 @@
+package frost
+
+import (
+	"errors"
+
+	"github.com/convto/mycrypto/big"
+	"github.com/convto/mycrypto/dlog"
+	"github.com/convto/mycrypto/ec"
+	"github.com/convto/mycrypto/edwards25519"
+)
+
+var (
+	// ErrInvalidElement は群の元のバイト表現が正しくないか、単位元や素数位数の部分群の外の元であることを表します
+	ErrInvalidElement = errors.New("frost: invalid group element")
+	// ErrInvalidScalar はスカラーのバイト表現が正しくないか、群の位数以上であることを表します
+	ErrInvalidScalar = errors.New("frost: invalid scalar")
+)
+
+// Group は暗号スイートが使う素数位数の群で、dlog.PrimeOrderGroup に RFC 9591 3.1 の元とスカラーのシリアライズを加えたものです
+type Group interface {
+	dlog.PrimeOrderGroup
+	// ElementLen はシリアライズした元の長さ Ne を返します
+	ElementLen() int
+	// ScalarLen はシリアライズしたスカラーの長さ Ns を返します
+	ScalarLen() int
+	// SerializeElement は元をバイト列にします。単位元のときは ErrInvalidElement を返します
+	SerializeElement(x dlog.Element) ([]byte, error)
+	// DeserializeElement はバイト列から元を読み込み、単位元や部分群の外の元のときは ErrInvalidElement を返します
+	DeserializeElement(b []byte) (dlog.Element, error)
+	// SerializeScalar は 0 <= s < q のスカラーをバイト列にします
+	SerializeScalar(s *big.Int) []byte
+	// DeserializeScalar はバイト列からスカラーを読み込み、q 以上のときは ErrInvalidScalar を返します
+	DeserializeScalar(b []byte) (*big.Int, error)
+}
+
+// ed25519Group は edwards25519 の位数 l の部分群です
+// 元は RFC 8032 の32バイトの表現、スカラーは32バイトのリトルエンディアンでシリアライズします
+type ed25519Group struct{}
+
+func (ed25519Group) Identity() dlog.Element {
+	return edwards25519.NewIdentityPoint()
+}
+
+func (ed25519Group) Op(x, y dlog.Element) dlog.Element {
+	return x.(*edwards25519.Point).Add(y.(*edwards25519.Point))
+}
+
+func (ed25519Group) Exp(x dlog.Element, k *big.Int) dlog.Element {
+	return x.(*edwards25519.Point).ScalarMult(big.Mod(k, edwards25519.L()))
+}
+
+func (ed25519Group) Equal(x, y dlog.Element) bool {
+	return x.(*edwards25519.Point).Equal(y.(*edwards25519.Point))
+}
+
+func (ed25519Group) Generator() dlog.Element {
+	return edwards25519.NewGeneratorPoint()
+}
+
+func (ed25519Group) Order() *big.Int {
+	return edwards25519.L()
+}
+
+// Contains は x が位数 l の部分群の点かどうかを判定します
+func (ed25519Group) Contains(x dlog.Element) bool {
+	p, ok := x.(*edwards25519.Point)
+	return ok && p.IsTorsionFree()
+}
+
+func (ed25519Group) ElementLen() int {
+	return 32
+}
+
+func (ed25519Group) ScalarLen() int {
+	return 32
+}
+
+func (g ed25519Group) SerializeElement(x dlog.Element) ([]byte, error) {
+	p := x.(*edwards25519.Point)
+	if p.IsIdentity() {
+		return nil, ErrInvalidElement
+	}
+	return p.Bytes(), nil
+}
+
+func (g ed25519Group) DeserializeElement(b []byte) (dlog.Element, error) {
+	p, err := new(edwards25519.Point).SetBytes(b)
+	if err != nil || p.IsIdentity() || !p.IsTorsionFree() {
+		return nil, ErrInvalidElement
+	}
+	return p, nil
+}
+
+func (ed25519Group) SerializeScalar(s *big.Int) []byte {
+	return s.FillBytesLE(make([]byte, 32))
+}
+
+func (ed25519Group) DeserializeScalar(b []byte) (*big.Int, error) {
+	if len(b) != 32 {
+		return nil, ErrInvalidScalar
+	}
+	s := new(big.Int).SetBytesLE(b)
+	if big.Cmp(s, edwards25519.L()) >= 0 {
+		return nil, ErrInvalidScalar
+	}
+	return s, nil
+}
+
+// secp256k1Group は secp256k1 の点の群です
+// 元は SEC1 の圧縮形式の33バイト、スカラーは32バイトのビッグエンディアンでシリアライズします
+type secp256k1Group struct {
+	*dlog.CurveGroup
+}
+
+func (secp256k1Group) ElementLen() int {
+	return 33
+}
+
+func (secp256k1Group) ScalarLen() int {
+	return 32
+}
+
+func (g secp256k1Group) SerializeElement(x dlog.Element) ([]byte, error) {
+	p := x.(*ec.Point)
+	if p.IsInfinity() {
+		return nil, ErrInvalidElement
+	}
+	return p.BytesCompressed(), nil
+}
+
+func (g secp256k1Group) DeserializeElement(b []byte) (dlog.Element, error) {
+	if len(b) != 33 {
+		return nil, ErrInvalidElement
+	}
+	p, err := g.Curve.SetBytes(b)
+	if err != nil || p.IsInfinity() {
+		return nil, ErrInvalidElement
+	}
+	return p, nil
+}
+
+func (secp256k1Group) SerializeScalar(s *big.Int) []byte {
+	return s.FillBytes(make([]byte, 32))
+}
+
+func (g secp256k1Group) DeserializeScalar(b []byte) (*big.Int, error) {
+	if len(b) != 32 {
+		return nil, ErrInvalidScalar
+	}
+	s := new(big.Int).SetBytes(b)
+	if big.Cmp(s, g.Order()) >= 0 {
+		return nil, ErrInvalidScalar
+	}
+	return s, nil
+}
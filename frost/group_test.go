@@ -0,0 +1,88 @@
+package frost
+
+import (
+	"bytes"
+	"crypto/rand"
+	"errors"
+	"testing"
+
+	"github.com/convto/mycrypto/big"
+	"github.com/convto/mycrypto/edwards25519"
+)
+
+func TestGroup_serialize(t *testing.T) {
+	for _, tt := range testSuites {
+		t.Run(tt.name, func(t *testing.T) {
+			grp := tt.cs.Group
+			s, err := big.RandNonZeroInt(rand.Reader, grp.Order())
+			if err != nil {
+				t.Fatal(err)
+			}
+			x := grp.Exp(grp.Generator(), s)
+
+			b, err := grp.SerializeElement(x)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(b) != grp.ElementLen() {
+				t.Errorf("len(SerializeElement()) = %d, want %d", len(b), grp.ElementLen())
+			}
+			y, err := grp.DeserializeElement(b)
+			if err != nil || !grp.Equal(x, y) {
+				t.Errorf("DeserializeElement(SerializeElement(x)) = %v, %v, want x", y, err)
+			}
+			if _, err := grp.SerializeElement(grp.Identity()); !errors.Is(err, ErrInvalidElement) {
+				t.Errorf("SerializeElement(identity) error = %v, want %v", err, ErrInvalidElement)
+			}
+			if _, err := grp.DeserializeElement(b[1:]); !errors.Is(err, ErrInvalidElement) {
+				t.Errorf("DeserializeElement(short) error = %v, want %v", err, ErrInvalidElement)
+			}
+
+			sb := grp.SerializeScalar(s)
+			if len(sb) != grp.ScalarLen() {
+				t.Errorf("len(SerializeScalar()) = %d, want %d", len(sb), grp.ScalarLen())
+			}
+			if got, err := grp.DeserializeScalar(sb); err != nil || big.Cmp(got, s) != 0 {
+				t.Errorf("DeserializeScalar(SerializeScalar(s)) = %v, %v, want %v", got, err, s)
+			}
+			if _, err := grp.DeserializeScalar(grp.SerializeScalar(big.Sub(grp.Order(), big.NewInt(1)))); err != nil {
+				t.Errorf("DeserializeScalar(q-1) error = %v, want nil", err)
+			}
+			// q 自体は32バイトに収まるが範囲外
+			var q []byte
+			if tt.name == "Ed25519" {
+				q = grp.Order().FillBytesLE(make([]byte, 32))
+			} else {
+				q = grp.Order().FillBytes(make([]byte, 32))
+			}
+			if _, err := grp.DeserializeScalar(q); !errors.Is(err, ErrInvalidScalar) {
+				t.Errorf("DeserializeScalar(q) error = %v, want %v", err, ErrInvalidScalar)
+			}
+			if _, err := grp.DeserializeScalar(sb[1:]); !errors.Is(err, ErrInvalidScalar) {
+				t.Errorf("DeserializeScalar(short) error = %v, want %v", err, ErrInvalidScalar)
+			}
+		})
+	}
+}
+
+// 位数8の部分群に含まれる点は素数位数の部分群の元として受け付けない
+func TestEd25519_DeserializeElement_torsion(t *testing.T) {
+	// y = -1 の位数2の点
+	b := bytes.Repeat([]byte{0xff}, 32)
+	b[0] = 0xec
+	b[31] = 0x7f
+	grp := Ed25519().Group
+	if _, err := grp.DeserializeElement(b); !errors.Is(err, ErrInvalidElement) {
+		t.Errorf("DeserializeElement(order 2) error = %v, want %v", err, ErrInvalidElement)
+	}
+
+	// 素数位数の元に位数2の点を足したものも拒否する
+	t2, err := new(edwards25519.Point).SetBytes(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mixed := grp.Op(grp.Generator(), t2)
+	if _, err := grp.DeserializeElement(mixed.(*edwards25519.Point).Bytes()); !errors.Is(err, ErrInvalidElement) {
+		t.Errorf("DeserializeElement(mixed order) error = %v, want %v", err, ErrInvalidElement)
+	}
+}
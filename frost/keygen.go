@@ -0,0 +1,90 @@
+package frost
+
+import (
+	"errors"
+	"io"
+
+	"github.com/convto/mycrypto/big"
+	"github.com/convto/mycrypto/dlog"
+	"github.com/convto/mycrypto/vss"
+)
+
+// ErrInvalidShare は受け取った秘密鍵の分け前が VSS のコミットメントで検証できないことを表します
+var ErrInvalidShare = errors.New("frost: invalid secret share")
+
+// KeyPackage は署名に参加する1人の参加者の鍵です
+type KeyPackage struct {
+	// ID は参加者の識別子で、秘密鍵を分散した多項式を評価した点です
+	ID *big.Int
+	// SecretShare は秘密鍵の分け前 s_i = f(ID) です
+	SecretShare *big.Int
+	// VerifyingShare は分け前の公開鍵 PK_i = s_i G です
+	VerifyingShare dlog.Element
+	// GroupPublicKey は署名を検証する公開鍵 PK = sG です
+	GroupPublicKey dlog.Element
+	// MinSigners は署名に必要な参加者の数 k です
+	MinSigners int
+}
+
+// VerifyingShare は参加者 ID の分け前の公開鍵 PK_i です
+type VerifyingShare struct {
+	ID      *big.Int
+	Element dlog.Element
+}
+
+// PublicKeyPackage はコーディネーターが署名の分け前の検証に使う公開情報です
+type PublicKeyPackage struct {
+	GroupPublicKey  dlog.Element
+	VerifyingShares []*VerifyingShare
+}
+
+// verifyingShare は参加者 id の PK_i を返し、見つからないときは nil を返す
+func (p *PublicKeyPackage) verifyingShare(id *big.Int) dlog.Element {
+	for _, vs := range p.VerifyingShares {
+		if big.Cmp(vs.ID, id) == 0 {
+			return vs.Element
+		}
+	}
+	return nil
+}
+
+// TrustedDealerKeygen は RFC 9591 Appendix C の trusted_dealer_keygen で、r から読み込んだ乱数で秘密鍵 secret を閾値 k の n 個の分け前に分け、Feldman VSS のコミットメントとともに返します
+// secret が nil のときはランダムな秘密鍵を生成します
+// ディーラーは分け前 i を参加者 i に秘密の通信路で送り、コミットメントを全員に公開します
+func (cs *Ciphersuite) TrustedDealerKeygen(r io.Reader, secret *big.Int, n, k int) ([]*vss.Share, *vss.FeldmanCommitment, error) {
+	if secret == nil {
+		var err error
+		if secret, err = big.RandNonZeroInt(r, cs.Group.Order()); err != nil {
+			return nil, nil, err
+		}
+	}
+	return vss.SplitFeldman(r, cs.Group, secret, n, k)
+}
+
+// NewKeyPackage は分け前 share を RFC 9591 Appendix C.2 の vss_verify でコミットメント c と照合し、参加者の鍵を返します
+// 照合に失敗したときは ErrInvalidShare を返すので、参加者は vss.Complaint でディーラーに申し立てます
+func (cs *Ciphersuite) NewKeyPackage(share *vss.Share, c *vss.FeldmanCommitment) (*KeyPackage, error) {
+	if !c.Verify(share) {
+		return nil, ErrInvalidShare
+	}
+	s := new(big.Int).SetBytes(share.Value)
+	return &KeyPackage{
+		ID:             big.NewInt(int64(share.Index)),
+		SecretShare:    s,
+		VerifyingShare: cs.Group.Exp(cs.Group.Generator(), s),
+		GroupPublicKey: c.PublicKey(),
+		MinSigners:     c.Threshold(),
+	}, nil
+}
+
+// NewPublicKeyPackage は RFC 9591 Appendix C.2 の derive_group_info で、コミットメント c から公開鍵と参加者 1, ..., n の PK_i を求めます
+func (cs *Ciphersuite) NewPublicKeyPackage(c *vss.FeldmanCommitment, n int) *PublicKeyPackage {
+	p := &PublicKeyPackage{GroupPublicKey: c.PublicKey()}
+	for i := 1; i <= n; i++ {
+		p.VerifyingShares = append(p.VerifyingShares, &VerifyingShare{
+			ID:      big.NewInt(int64(i)),
+			Element: c.PublicShare(byte(i)),
+		})
+	}
+	return p
+}
@@ -0,0 +1,217 @@
+package frost
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/convto/mycrypto/big"
+	"github.com/convto/mycrypto/dlog"
+)
+
+// rfcVectors は RFC 9591 Appendix E のテストベクター (testdata/README.md を参照)
+type rfcVectors struct {
+	Inputs struct {
+		ParticipantList             []int    `json:"participant_list"`
+		GroupSecretKey              string   `json:"group_secret_key"`
+		GroupPublicKey              string   `json:"group_public_key"`
+		Message                     string   `json:"message"`
+		SharePolynomialCoefficients []string `json:"share_polynomial_coefficients"`
+		ParticipantShares           []struct {
+			Identifier       int    `json:"identifier"`
+			ParticipantShare string `json:"participant_share"`
+		} `json:"participant_shares"`
+	} `json:"inputs"`
+	RoundOneOutputs struct {
+		Outputs []struct {
+			Identifier             int    `json:"identifier"`
+			HidingNonceRandomness  string `json:"hiding_nonce_randomness"`
+			BindingNonceRandomness string `json:"binding_nonce_randomness"`
+			HidingNonce            string `json:"hiding_nonce"`
+			BindingNonce           string `json:"binding_nonce"`
+			HidingNonceCommitment  string `json:"hiding_nonce_commitment"`
+			BindingNonceCommitment string `json:"binding_nonce_commitment"`
+			BindingFactorInput     string `json:"binding_factor_input"`
+			BindingFactor          string `json:"binding_factor"`
+		} `json:"outputs"`
+	} `json:"round_one_outputs"`
+	RoundTwoOutputs struct {
+		Outputs []struct {
+			Identifier int    `json:"identifier"`
+			SigShare   string `json:"sig_share"`
+		} `json:"outputs"`
+	} `json:"round_two_outputs"`
+	FinalOutput struct {
+		Sig string `json:"sig"`
+	} `json:"final_output"`
+}
+
+func unhex(t *testing.T, s string) []byte {
+	t.Helper()
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func TestRFC9591(t *testing.T) {
+	tests := []struct {
+		name string
+		file string
+		cs   *Ciphersuite
+	}{
+		{name: "Ed25519", file: "testdata/frost_ed25519_sha512.json", cs: Ed25519()},
+		{name: "secp256k1", file: "testdata/frost_secp256k1_sha256.json", cs: Secp256k1()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := os.ReadFile(tt.file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var v rfcVectors
+			if err := json.Unmarshal(data, &v); err != nil {
+				t.Fatal(err)
+			}
+			n := len(v.Inputs.ParticipantList)
+			if len(v.RoundOneOutputs.Outputs) != n || len(v.RoundTwoOutputs.Outputs) != n || v.FinalOutput.Sig == "" {
+				t.Fatalf("%s does not contain the outputs of all %d participants", tt.file, n)
+			}
+			cs := tt.cs
+			grp := cs.Group
+			scalar := func(s string) *big.Int {
+				t.Helper()
+				x, err := grp.DeserializeScalar(unhex(t, s))
+				if err != nil {
+					t.Fatal(err)
+				}
+				return x
+			}
+			element := func(e dlog.Element) string {
+				t.Helper()
+				b, err := grp.SerializeElement(e)
+				if err != nil {
+					t.Fatal(err)
+				}
+				return hex.EncodeToString(b)
+			}
+
+			// 鍵生成: 多項式 f(x) = s + a_1 x + ... を各参加者の識別子で評価したものが分け前になる
+			secret := scalar(v.Inputs.GroupSecretKey)
+			pk := grp.Exp(grp.Generator(), secret)
+			if got := element(pk); got != v.Inputs.GroupPublicKey {
+				t.Errorf("group_public_key = %s, want %s", got, v.Inputs.GroupPublicKey)
+			}
+			q := grp.Order()
+			kps := make(map[int]*KeyPackage)
+			for _, ps := range v.Inputs.ParticipantShares {
+				id := big.NewInt(int64(ps.Identifier))
+				s, xi := secret, big.NewInt(1)
+				for _, a := range v.Inputs.SharePolynomialCoefficients {
+					xi = big.Mul(xi, id)
+					s = big.Mod(big.Add(s, big.Mul(scalar(a), xi)), q)
+				}
+				if got := hex.EncodeToString(grp.SerializeScalar(s)); got != ps.ParticipantShare {
+					t.Errorf("P%d participant_share = %s, want %s", ps.Identifier, got, ps.ParticipantShare)
+				}
+				kps[ps.Identifier] = &KeyPackage{
+					ID:             id,
+					SecretShare:    s,
+					VerifyingShare: grp.Exp(grp.Generator(), s),
+					GroupPublicKey: pk,
+					MinSigners:     len(v.Inputs.ParticipantList),
+				}
+			}
+
+			// 1ラウンド目: nonce_generate に固定の乱数を与えてナンスとコミットメントを求める
+			msg := unhex(t, v.Inputs.Message)
+			sp := &SigningPackage{Message: msg}
+			nonces := make(map[int]*Nonces)
+			for _, o := range v.RoundOneOutputs.Outputs {
+				r := bytes.NewReader(append(unhex(t, o.HidingNonceRandomness), unhex(t, o.BindingNonceRandomness)...))
+				n, c, err := cs.Commit(r, kps[o.Identifier])
+				if err != nil {
+					t.Fatal(err)
+				}
+				if got := hex.EncodeToString(grp.SerializeScalar(n.hiding)); got != o.HidingNonce {
+					t.Errorf("P%d hiding_nonce = %s, want %s", o.Identifier, got, o.HidingNonce)
+				}
+				if got := hex.EncodeToString(grp.SerializeScalar(n.binding)); got != o.BindingNonce {
+					t.Errorf("P%d binding_nonce = %s, want %s", o.Identifier, got, o.BindingNonce)
+				}
+				if got := element(c.Hiding); got != o.HidingNonceCommitment {
+					t.Errorf("P%d hiding_nonce_commitment = %s, want %s", o.Identifier, got, o.HidingNonceCommitment)
+				}
+				if got := element(c.Binding); got != o.BindingNonceCommitment {
+					t.Errorf("P%d binding_nonce_commitment = %s, want %s", o.Identifier, got, o.BindingNonceCommitment)
+				}
+				nonces[o.Identifier] = n
+				sp.Commitments = append(sp.Commitments, c)
+			}
+
+			// binding factor: input = PK || H4(msg) || H5(encode_group_commitment_list) || identifier
+			st, err := cs.newSigningState(pk, sp)
+			if err != nil {
+				t.Fatal(err)
+			}
+			encoded, err := cs.encodeGroupCommitmentList(st.comms)
+			if err != nil {
+				t.Fatal(err)
+			}
+			prefix := append(unhex(t, v.Inputs.GroupPublicKey), cs.hash("msg", msg)...)
+			prefix = append(prefix, cs.hash("com", encoded)...)
+			for _, o := range v.RoundOneOutputs.Outputs {
+				id := big.NewInt(int64(o.Identifier))
+				input := append(append([]byte{}, prefix...), grp.SerializeScalar(id)...)
+				if got := hex.EncodeToString(input); got != o.BindingFactorInput {
+					t.Errorf("P%d binding_factor_input = %s, want %s", o.Identifier, got, o.BindingFactorInput)
+				}
+				if got := hex.EncodeToString(grp.SerializeScalar(cs.hashToScalar("rho", input))); got != o.BindingFactor {
+					t.Errorf("P%d binding_factor (H1) = %s, want %s", o.Identifier, got, o.BindingFactor)
+				}
+				if got := hex.EncodeToString(grp.SerializeScalar(st.rho[st.index(id)])); got != o.BindingFactor {
+					t.Errorf("P%d binding_factor = %s, want %s", o.Identifier, got, o.BindingFactor)
+				}
+			}
+
+			// 2ラウンド目: 署名の分け前を求めて集約する
+			pkp := &PublicKeyPackage{GroupPublicKey: pk}
+			for _, kp := range kps {
+				pkp.VerifyingShares = append(pkp.VerifyingShares, &VerifyingShare{ID: kp.ID, Element: kp.VerifyingShare})
+			}
+			var shares []*SignatureShare
+			for _, o := range v.RoundTwoOutputs.Outputs {
+				share, err := cs.Sign(kps[o.Identifier], nonces[o.Identifier], sp)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if got := hex.EncodeToString(grp.SerializeScalar(share.Z)); got != o.SigShare {
+					t.Errorf("P%d sig_share = %s, want %s", o.Identifier, got, o.SigShare)
+				}
+				if !cs.VerifySignatureShare(pkp, share, sp) {
+					t.Errorf("P%d VerifySignatureShare() = false, want true", o.Identifier)
+				}
+				shares = append(shares, share)
+			}
+			sig, err := cs.Aggregate(pkp, sp, shares)
+			if err != nil {
+				t.Fatal(err)
+			}
+			// 署名の R は group commitment
+			want := unhex(t, v.FinalOutput.Sig)
+			if got := element(st.r); got != hex.EncodeToString(want[:grp.ElementLen()]) {
+				t.Errorf("group commitment = %s, want %x", got, want[:grp.ElementLen()])
+			}
+			got, err := cs.Marshal(sig)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(got, want) {
+				t.Errorf("sig = %x, want %x", got, want)
+			}
+		})
+	}
+}
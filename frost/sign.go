@@ -0,0 +1,254 @@
+package frost
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/convto/mycrypto/big"
+	"github.com/convto/mycrypto/dlog"
+)
+
+var (
+	// ErrNonceReused はすでに署名に使ったナンスでもう一度署名しようとしたことを表します
+	ErrNonceReused = errors.New("frost: nonces already used")
+	// ErrInvalidSigningPackage はコミットメントの一覧が署名に使えないことを表します
+	ErrInvalidSigningPackage = errors.New("frost: invalid signing package")
+	// ErrInvalidSignatureShare は署名の分け前が検証できないことを表します
+	ErrInvalidSignatureShare = errors.New("frost: invalid signature share")
+)
+
+// Nonces は1回の署名のために参加者が秘密に保持するナンスの組 (d_i, e_i) です
+// ナンスを2回使うと秘密鍵の分け前が漏れるので、Sign で一度使うと消去します
+type Nonces struct {
+	hiding     *big.Int
+	binding    *big.Int
+	commitment *Commitment
+}
+
+// Commitment は1ラウンド目に参加者がコーディネーターに送る、ナンスへのコミットメント (D_i, E_i) = (d_i G, e_i G) です
+type Commitment struct {
+	ID      *big.Int
+	Hiding  dlog.Element
+	Binding dlog.Element
+}
+
+// SigningPackage はコーディネーターが選んだ署名者のコミットメントの一覧と署名するメッセージで、2ラウンド目の始めに各署名者に送ります
+type SigningPackage struct {
+	Commitments []*Commitment
+	Message     []byte
+}
+
+// SignatureShare は2ラウンド目に署名者がコーディネーターに返す署名の分け前 z_i です
+type SignatureShare struct {
+	ID *big.Int
+	Z  *big.Int
+}
+
+// Commit は RFC 9591 5.1 の commit で、r から読み込んだ乱数と秘密鍵の分け前からナンスを作り、そのコミットメントを返します
+// ナンスは秘密に保持し、コミットメントをコーディネーターに送ります
+func (cs *Ciphersuite) Commit(r io.Reader, kp *KeyPackage) (*Nonces, *Commitment, error) {
+	hiding, err := cs.nonceGenerate(r, kp.SecretShare)
+	if err != nil {
+		return nil, nil, err
+	}
+	binding, err := cs.nonceGenerate(r, kp.SecretShare)
+	if err != nil {
+		return nil, nil, err
+	}
+	g := cs.Group.Generator()
+	c := &Commitment{
+		ID:      kp.ID,
+		Hiding:  cs.Group.Exp(g, hiding),
+		Binding: cs.Group.Exp(g, binding),
+	}
+	return &Nonces{hiding: hiding, binding: binding, commitment: c}, c, nil
+}
+
+// Sign は RFC 9591 5.2 の sign で、署名の分け前 z_i = d_i + e_i ρ_i + λ_i s_i c を返します
+// sp に自分のコミットメントが含まれていないときや、署名者が MinSigners 人に満たないときは ErrInvalidSigningPackage を返します
+// nonces は成功したかどうかによらず消去され、同じ nonces でもう一度呼ぶと ErrNonceReused を返します
+func (cs *Ciphersuite) Sign(kp *KeyPackage, nonces *Nonces, sp *SigningPackage) (*SignatureShare, error) {
+	if nonces.hiding == nil {
+		return nil, ErrNonceReused
+	}
+	hiding, binding, own := nonces.hiding, nonces.binding, nonces.commitment
+	nonces.hiding, nonces.binding = nil, nil
+
+	if len(sp.Commitments) < kp.MinSigners {
+		return nil, ErrInvalidSigningPackage
+	}
+	st, err := cs.newSigningState(kp.GroupPublicKey, sp)
+	if err != nil {
+		return nil, err
+	}
+	i := st.index(kp.ID)
+	if i < 0 || !cs.Group.Equal(st.comms[i].Hiding, own.Hiding) || !cs.Group.Equal(st.comms[i].Binding, own.Binding) {
+		return nil, ErrInvalidSigningPackage
+	}
+	lambda, err := cs.interpolatingValue(st.ids, kp.ID)
+	if err != nil {
+		return nil, err
+	}
+	q := cs.Group.Order()
+	z := big.Add(hiding, big.Mul(binding, st.rho[i]))
+	z = big.Add(z, big.Mul(big.Mul(lambda, kp.SecretShare), st.c))
+	return &SignatureShare{ID: kp.ID, Z: big.Mod(z, q)}, nil
+}
+
+// VerifySignatureShare は RFC 9591 5.4 の verify_signature_share で、z_i G = D_i + ρ_i E_i + λ_i c PK_i を確かめます
+// コーディネーターは集約した署名が検証できなかったときに、不正な分け前を送った参加者を特定するために使います
+func (cs *Ciphersuite) VerifySignatureShare(pkp *PublicKeyPackage, share *SignatureShare, sp *SigningPackage) bool {
+	pki := pkp.verifyingShare(share.ID)
+	if pki == nil || share.Z == nil || big.Cmp(share.Z, big.Zero) < 0 || big.Cmp(share.Z, cs.Group.Order()) >= 0 {
+		return false
+	}
+	st, err := cs.newSigningState(pkp.GroupPublicKey, sp)
+	if err != nil {
+		return false
+	}
+	i := st.index(share.ID)
+	if i < 0 {
+		return false
+	}
+	lambda, err := cs.interpolatingValue(st.ids, share.ID)
+	if err != nil {
+		return false
+	}
+	grp := cs.Group
+	comm := grp.Op(st.comms[i].Hiding, grp.Exp(st.comms[i].Binding, st.rho[i]))
+	rhs := grp.Op(comm, grp.Exp(pki, big.Mod(big.Mul(st.c, lambda), grp.Order())))
+	return grp.Equal(grp.Exp(grp.Generator(), share.Z), rhs)
+}
+
+// Aggregate は RFC 9591 5.3 の aggregate で、署名の分け前を足し合わせて署名 (R, Σ z_i) を作ります
+// 集約した署名が検証できないときは各分け前を検証し、不正な分け前を送った参加者の識別子を含む ErrInvalidSignatureShare を返します
+func (cs *Ciphersuite) Aggregate(pkp *PublicKeyPackage, sp *SigningPackage, shares []*SignatureShare) (*Signature, error) {
+	st, err := cs.newSigningState(pkp.GroupPublicKey, sp)
+	if err != nil {
+		return nil, err
+	}
+	if len(shares) != len(st.ids) {
+		return nil, ErrInvalidSigningPackage
+	}
+	q := cs.Group.Order()
+	seen := make([]bool, len(st.ids))
+	z := big.NewInt(0)
+	for _, s := range shares {
+		i := st.index(s.ID)
+		if i < 0 || seen[i] || s.Z == nil {
+			return nil, ErrInvalidSigningPackage
+		}
+		seen[i] = true
+		z = big.Add(z, s.Z)
+	}
+	sig := &Signature{R: st.r, Z: big.Mod(z, q)}
+	if !cs.Verify(pkp.GroupPublicKey, sp.Message, sig) {
+		for _, s := range shares {
+			if !cs.VerifySignatureShare(pkp, s, sp) {
+				return nil, fmt.Errorf("%w: participant %v", ErrInvalidSignatureShare, s.ID)
+			}
+		}
+		return nil, ErrInvalidSignatureShare
+	}
+	return sig, nil
+}
+
+// nonceGenerate は RFC 9591 4.1 の nonce_generate で、H3(random_bytes || SerializeScalar(secret)) を返す
+// 乱数生成器が弱くても秘密鍵の分け前を混ぜることでナンスが予測されにくくなる
+func (cs *Ciphersuite) nonceGenerate(r io.Reader, secret *big.Int) (*big.Int, error) {
+	buf := make([]byte, 32)
+	if _, err := io.ReadFull(r, buf); err != nil {
+		return nil, err
+	}
+	return cs.hashToScalar("nonce", append(buf, cs.Group.SerializeScalar(secret)...)), nil
+}
+
+// signingState は1回の署名で署名者とコーディネーターが共通に計算する値です
+type signingState struct {
+	// comms は識別子の昇順に並べたコミットメントで、ids と rho も同じ順に並ぶ
+	comms []*Commitment
+	ids   []*big.Int
+	// rho は RFC 9591 4.4 の各署名者の binding factor
+	rho []*big.Int
+	// r は RFC 9591 4.5 の group commitment R = Σ (D_i + ρ_i E_i)
+	r dlog.Element
+	// c はチャレンジ H2(R || PK || msg)
+	c *big.Int
+}
+
+// newSigningState は sp を検証して署名に使う値を求める
+func (cs *Ciphersuite) newSigningState(pk dlog.Element, sp *SigningPackage) (*signingState, error) {
+	grp := cs.Group
+	if len(sp.Commitments) == 0 {
+		return nil, ErrInvalidSigningPackage
+	}
+	comms := sortByIdentifier(sp.Commitments)
+	st := &signingState{comms: comms}
+	for i, c := range comms {
+		if !cs.validIdentifier(c.ID) || (i > 0 && big.Cmp(comms[i-1].ID, c.ID) == 0) {
+			return nil, ErrInvalidSigningPackage
+		}
+		if c.Hiding == nil || c.Binding == nil || !grp.Contains(c.Hiding) || !grp.Contains(c.Binding) {
+			return nil, ErrInvalidSigningPackage
+		}
+		st.ids = append(st.ids, c.ID)
+	}
+
+	// RFC 9591 4.4 の compute_binding_factors
+	encoded, err := cs.encodeGroupCommitmentList(comms)
+	if err != nil {
+		return nil, ErrInvalidSigningPackage
+	}
+	pkb, err := grp.SerializeElement(pk)
+	if err != nil {
+		return nil, err
+	}
+	prefix := append(pkb, cs.hash("msg", sp.Message)...)
+	prefix = append(prefix, cs.hash("com", encoded)...)
+	st.r = grp.Identity()
+	for _, c := range comms {
+		input := append(append([]byte{}, prefix...), grp.SerializeScalar(c.ID)...)
+		rho := cs.hashToScalar("rho", input)
+		st.rho = append(st.rho, rho)
+		st.r = grp.Op(st.r, grp.Op(c.Hiding, grp.Exp(c.Binding, rho)))
+	}
+
+	if st.c, err = cs.challenge(st.r, pk, sp.Message); err != nil {
+		return nil, ErrInvalidSigningPackage
+	}
+	return st, nil
+}
+
+// encodeGroupCommitmentList は RFC 9591 4.3 の encode_group_commitment_list で、識別子の昇順に並べた comms を連結する
+func (cs *Ciphersuite) encodeGroupCommitmentList(comms []*Commitment) ([]byte, error) {
+	grp := cs.Group
+	var encoded []byte
+	for _, c := range comms {
+		d, err := grp.SerializeElement(c.Hiding)
+		if err != nil {
+			return nil, err
+		}
+		e, err := grp.SerializeElement(c.Binding)
+		if err != nil {
+			return nil, err
+		}
+		encoded = append(encoded, grp.SerializeScalar(c.ID)...)
+		encoded = append(encoded, d...)
+		encoded = append(encoded, e...)
+	}
+	return encoded, nil
+}
+
+// index は識別子が id のコミットメントの位置を返し、見つからないときは -1 を返す
+func (st *signingState) index(id *big.Int) int {
+	if id == nil {
+		return -1
+	}
+	for i, x := range st.ids {
+		if big.Cmp(x, id) == 0 {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,112 @@
+package frost
+
+import (
+	"crypto/rand"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/convto/mycrypto/big"
+)
+
+// commitAll は signers の参加者のナンスとコミットメントを作り、SigningPackage にまとめる
+func commitAll(t *testing.T, cs *Ciphersuite, kps []*KeyPackage, signers []int, msg []byte) ([]*Nonces, *SigningPackage) {
+	t.Helper()
+	nonces := make([]*Nonces, len(signers))
+	sp := &SigningPackage{Message: msg}
+	for i, j := range signers {
+		n, c, err := cs.Commit(rand.Reader, kps[j])
+		if err != nil {
+			t.Fatal(err)
+		}
+		nonces[i] = n
+		sp.Commitments = append(sp.Commitments, c)
+	}
+	return nonces, sp
+}
+
+func TestSign_nonceReuse(t *testing.T) {
+	cs := Secp256k1()
+	kps, _ := dealerKeys(t, cs, 3, 2)
+	nonces, sp := commitAll(t, cs, kps, []int{0, 1}, []byte("message"))
+	if _, err := cs.Sign(kps[0], nonces[0], sp); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cs.Sign(kps[0], nonces[0], sp); !errors.Is(err, ErrNonceReused) {
+		t.Errorf("Sign() error = %v, want %v", err, ErrNonceReused)
+	}
+}
+
+func TestSign_invalidSigningPackage(t *testing.T) {
+	cs := Secp256k1()
+	kps, _ := dealerKeys(t, cs, 4, 3)
+	msg := []byte("message")
+	_, other := commitAll(t, cs, kps, []int{0, 1, 2}, msg)
+
+	tests := []struct {
+		name   string
+		modify func(sp *SigningPackage)
+	}{
+		{name: "too few signers", modify: func(sp *SigningPackage) { sp.Commitments = sp.Commitments[:2] }},
+		{name: "own commitment missing", modify: func(sp *SigningPackage) { sp.Commitments[0] = other.Commitments[0] }},
+		{name: "own commitment replaced", modify: func(sp *SigningPackage) {
+			c := *sp.Commitments[0]
+			c.Hiding = other.Commitments[0].Hiding
+			sp.Commitments[0] = &c
+		}},
+		{name: "duplicate identifier", modify: func(sp *SigningPackage) { sp.Commitments[2] = sp.Commitments[1] }},
+		{name: "identifier 0", modify: func(sp *SigningPackage) {
+			c := *sp.Commitments[1]
+			c.ID = big.NewInt(0)
+			sp.Commitments[1] = &c
+		}},
+		{name: "identity commitment", modify: func(sp *SigningPackage) {
+			c := *sp.Commitments[1]
+			c.Binding = cs.Group.Identity()
+			sp.Commitments[1] = &c
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nonces, sp := commitAll(t, cs, kps, []int{0, 1, 3}, msg)
+			tt.modify(sp)
+			if _, err := cs.Sign(kps[0], nonces[0], sp); !errors.Is(err, ErrInvalidSigningPackage) {
+				t.Errorf("Sign() error = %v, want %v", err, ErrInvalidSigningPackage)
+			}
+		})
+	}
+}
+
+// TestAggregate_cheater は不正な分け前を送った参加者を Aggregate が特定できることを確かめる
+func TestAggregate_cheater(t *testing.T) {
+	for _, tt := range testSuites {
+		t.Run(tt.name, func(t *testing.T) {
+			cs := tt.cs
+			kps, pkp := dealerKeys(t, cs, 3, 2)
+			nonces, sp := commitAll(t, cs, kps, []int{0, 2}, []byte("message"))
+			var shares []*SignatureShare
+			for i, j := range []int{0, 2} {
+				s, err := cs.Sign(kps[j], nonces[i], sp)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !cs.VerifySignatureShare(pkp, s, sp) {
+					t.Errorf("VerifySignatureShare(participant %v) = false, want true", s.ID)
+				}
+				shares = append(shares, s)
+			}
+			// 参加者3の分け前を改ざんする
+			bad := &SignatureShare{ID: shares[1].ID, Z: big.Mod(big.Add(shares[1].Z, big.NewInt(1)), cs.Group.Order())}
+			if cs.VerifySignatureShare(pkp, bad, sp) {
+				t.Errorf("VerifySignatureShare(tampered) = true, want false")
+			}
+			_, err := cs.Aggregate(pkp, sp, []*SignatureShare{shares[0], bad})
+			if !errors.Is(err, ErrInvalidSignatureShare) || !strings.Contains(err.Error(), "participant 3") {
+				t.Errorf("Aggregate() error = %v, want %v for participant 3", err, ErrInvalidSignatureShare)
+			}
+			if _, err := cs.Aggregate(pkp, sp, shares[:1]); !errors.Is(err, ErrInvalidSigningPackage) {
+				t.Errorf("Aggregate(missing share) error = %v, want %v", err, ErrInvalidSigningPackage)
+			}
+		})
+	}
+}
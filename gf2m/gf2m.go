@@ -0,0 +1,330 @@
+// Package gf2m は多項式基底による二元体 GF(2^m) の演算を提供します
+// GHASH で使う GF(2^128)、AES や Shamir の秘密分散で使う GF(2^8)、sect233k1 のような二元体上の楕円曲線を扱うために使います
+//
+// big.Int は10進数の桁で値を保持しておりビット演算を効率よく行えないため、元は64bitのワードの列で表現し、
+// big.Int とはバイト列を介して相互に変換します
+package gf2m
+
+import (
+	"encoding/hex"
+	"errors"
+	"math/bits"
+
+	"github.com/convto/mycrypto/big"
+)
+
+// ErrInvalidEncoding は元のバイト表現が正規の形式でないことを表します
+var ErrInvalidEncoding = errors.New("gf2m: invalid element encoding")
+
+// Field は既約多項式 f(x) を法とする二元体 GF(2^m) です
+type Field struct {
+	m     int
+	poly  []uint64 // 既約多項式 f(x) の係数 (x^i の係数が i ビット目)
+	words int      // 元を表すのに必要なワード数
+}
+
+// New は既約多項式 f(x) の0でない項の次数を exps に並べて GF(2^m) を作ります
+// 最大の次数が m になり、例えば AES の x^8 + x^4 + x^3 + x + 1 は New(8, 4, 3, 1, 0) で表します
+// f(x) が既約であることは呼び出し側が保証しなければなりません
+func New(exps ...int) *Field {
+	m := 0
+	for _, e := range exps {
+		if e < 0 {
+			panic("gf2m: negative exponent")
+		}
+		if e > m {
+			m = e
+		}
+	}
+	if m == 0 {
+		panic("gf2m: degree of polynomial must be positive")
+	}
+	poly := make([]uint64, m/64+1)
+	for _, e := range exps {
+		poly[e/64] ^= 1 << uint(e%64)
+	}
+	return &Field{m: m, poly: poly, words: (m + 63) / 64}
+}
+
+// Degree は拡大次数 m を返します
+func (f *Field) Degree() int {
+	return f.m
+}
+
+// ByteLen は元のバイト表現の長さを返します
+func (f *Field) ByteLen() int {
+	return (f.m + 7) / 8
+}
+
+// Zero は加法の単位元 0 を返します
+func (f *Field) Zero() *Element {
+	return &Element{f: f, w: make([]uint64, f.words)}
+}
+
+// One は乗法の単位元 1 を返します
+func (f *Field) One() *Element {
+	return f.NewElement(1)
+}
+
+// NewElement は x の各ビットを係数とする元 (x の i ビット目が x^i の係数) を f(x) で簡約して返します
+func (f *Field) NewElement(x uint64) *Element {
+	return &Element{f: f, w: f.reduce([]uint64{x})}
+}
+
+// NewElementFromInt は x の各ビットを係数とする元を f(x) で簡約して返します
+// x の符号は無視します
+func (f *Field) NewElementFromInt(x *big.Int) *Element {
+	return &Element{f: f, w: f.reduce(fromBytes(x.Bytes()))}
+}
+
+// SetBytes は ByteLen の長さのビッグエンディアンのバイト列から元を読み込みます
+// 長さが異なるときや次数が m 以上のときは正規の表現でないので ErrInvalidEncoding を返します
+func (f *Field) SetBytes(b []byte) (*Element, error) {
+	if len(b) != f.ByteLen() {
+		return nil, ErrInvalidEncoding
+	}
+	w := fromBytes(b)
+	if degree(w) >= f.m {
+		return nil, ErrInvalidEncoding
+	}
+	return &Element{f: f, w: f.reduce(w)}, nil
+}
+
+// Element は GF(2^m) の元です
+// 係数は常に m-1 次以下に簡約されています
+type Element struct {
+	f *Field
+	w []uint64
+}
+
+// Field は x が属する体を返します
+func (x *Element) Field() *Field {
+	return x.f
+}
+
+// Bytes は x を ByteLen の長さのビッグエンディアンのバイト列で返します
+func (x *Element) Bytes() []byte {
+	b := make([]byte, x.f.ByteLen())
+	for i := range b {
+		bit := 8 * (len(b) - 1 - i)
+		b[i] = byte(x.w[bit/64] >> uint(bit%64))
+	}
+	return b
+}
+
+// Int は x の各ビットを係数とみなした非負整数を返します
+func (x *Element) Int() *big.Int {
+	return new(big.Int).SetBytes(x.Bytes())
+}
+
+// String は x を16進数の文字列で返します
+func (x *Element) String() string {
+	return "0x" + hex.EncodeToString(x.Bytes())
+}
+
+// Add は x + y を返します
+// 標数2なので係数ごとの排他的論理和になり、減算も同じ演算になります
+func (x *Element) Add(y *Element) *Element {
+	x.check(y)
+	w := make([]uint64, len(x.w))
+	for i := range w {
+		w[i] = x.w[i] ^ y.w[i]
+	}
+	return &Element{f: x.f, w: w}
+}
+
+// Mul は x * y を返します
+func (x *Element) Mul(y *Element) *Element {
+	x.check(y)
+	return &Element{f: x.f, w: x.f.reduce(clmul(x.w, y.w))}
+}
+
+// Square は x^2 を返します
+func (x *Element) Square() *Element {
+	// 標数2では (Σ a_i x^i)^2 = Σ a_i x^(2i) なので、各ビットの間に0を挟むだけでよい
+	w := make([]uint64, 2*len(x.w))
+	for i, v := range x.w {
+		w[2*i] = spread(uint32(v))
+		w[2*i+1] = spread(uint32(v >> 32))
+	}
+	return &Element{f: x.f, w: x.f.reduce(w)}
+}
+
+// Exp は x^k を返します
+// k < 0 のときは x の逆元について計算します
+func (x *Element) Exp(k *big.Int) *Element {
+	if big.Cmp(k, big.Zero) < 0 {
+		x = x.Inv()
+		k = big.Sub(big.Zero, k)
+	}
+	// k をバイト列にして上位のビットから square-and-multiply を行う
+	r := x.f.One()
+	for _, b := range k.Bytes() {
+		for i := 7; i >= 0; i-- {
+			r = r.Square()
+			if b>>uint(i)&1 == 1 {
+				r = r.Mul(x)
+			}
+		}
+	}
+	return r
+}
+
+// Inv は x の乗法の逆元を返します
+// x == 0 のときpanicします
+func (x *Element) Inv() *Element {
+	if x.IsZero() {
+		panic("gf2m: inverse of zero")
+	}
+	// GF(2)[x] 上の拡張ユークリッドの互除法で g1 * x = u mod f(x) を保ちながら u を1まで小さくする
+	u := append([]uint64(nil), x.w...)
+	v := append([]uint64(nil), x.f.poly...)
+	g1 := make([]uint64, len(v))
+	g2 := make([]uint64, len(v))
+	g1[0] = 1
+	for degree(u) > 0 {
+		j := degree(u) - degree(v)
+		if j < 0 {
+			u, v = v, u
+			g1, g2 = g2, g1
+			j = -j
+		}
+		u = xorShifted(u, v, j)
+		g1 = xorShifted(g1, g2, j)
+	}
+	return &Element{f: x.f, w: x.f.reduce(g1)}
+}
+
+// Sqrt は y^2 = x となる y を返します
+// GF(2^m) ではすべての元がただ1つの平方根を持ち、それは x^(2^(m-1)) になります
+func (x *Element) Sqrt() *Element {
+	y := x
+	for i := 0; i < x.f.m-1; i++ {
+		y = y.Square()
+	}
+	return y
+}
+
+// Trace は x のトレース Tr(x) = x + x^2 + x^4 + ... + x^(2^(m-1)) を返します
+// 結果は常に0か1になります
+func (x *Element) Trace() uint {
+	t, y := x, x
+	for i := 1; i < x.f.m; i++ {
+		y = y.Square()
+		t = t.Add(y)
+	}
+	return uint(t.w[0] & 1)
+}
+
+// Equal は x と y が等しいかどうかを判定します
+func (x *Element) Equal(y *Element) bool {
+	x.check(y)
+	for i := range x.w {
+		if x.w[i] != y.w[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// IsZero は x が0かどうかを判定します
+func (x *Element) IsZero() bool {
+	for _, v := range x.w {
+		if v != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// check は x と y が同じ体の元であることを確かめ、異なる場合はpanicする
+func (x *Element) check(y *Element) {
+	if x.f == y.f {
+		return
+	}
+	if x.f.m != y.f.m || degree(xorShifted(x.f.poly, y.f.poly, 0)) >= 0 {
+		panic("gf2m: elements from different fields")
+	}
+}
+
+// reduce は w を f(x) で割ったあまりを words 個のワードで返す
+func (f *Field) reduce(w []uint64) []uint64 {
+	r := append([]uint64(nil), w...)
+	// 最高次の項から順に、次数 m 以上の項を f(x) をずらしたもので消していく
+	for d := degree(r); d >= f.m; d = degree(r) {
+		r = xorShifted(r, f.poly, d-f.m)
+	}
+	out := make([]uint64, f.words)
+	copy(out, r)
+	return out
+}
+
+// clmul は繰り上がりのない (GF(2)[x] 上の) 乗算を行う
+func clmul(x, y []uint64) []uint64 {
+	r := make([]uint64, len(x)+len(y))
+	for i, xw := range x {
+		for xw != 0 {
+			b := bits.TrailingZeros64(xw)
+			xw &= xw - 1
+			// y * x^(64i + b) を足し込む
+			shift := uint(b)
+			for j, yw := range y {
+				r[i+j] ^= yw << shift
+				if shift > 0 {
+					r[i+j+1] ^= yw >> (64 - shift)
+				}
+			}
+		}
+	}
+	return r
+}
+
+// xorShifted は x + y * x^s を返す
+func xorShifted(x, y []uint64, s int) []uint64 {
+	n := len(y) + s/64 + 1
+	if len(x) > n {
+		n = len(x)
+	}
+	r := make([]uint64, n)
+	copy(r, x)
+	ws, bs := s/64, uint(s%64)
+	for i, v := range y {
+		r[i+ws] ^= v << bs
+		if bs > 0 {
+			r[i+ws+1] ^= v >> (64 - bs)
+		}
+	}
+	return r
+}
+
+// degree は多項式の次数を返す
+// 0多項式のときは -1 を返す
+func degree(w []uint64) int {
+	for i := len(w) - 1; i >= 0; i-- {
+		if w[i] != 0 {
+			return 64*i + bits.Len64(w[i]) - 1
+		}
+	}
+	return -1
+}
+
+// spread は x の各ビットの間に0を挟んで64bitに広げる
+func spread(x uint32) uint64 {
+	v := uint64(x)
+	v = (v | v<<16) & 0x0000ffff0000ffff
+	v = (v | v<<8) & 0x00ff00ff00ff00ff
+	v = (v | v<<4) & 0x0f0f0f0f0f0f0f0f
+	v = (v | v<<2) & 0x3333333333333333
+	v = (v | v<<1) & 0x5555555555555555
+	return v
+}
+
+// fromBytes はビッグエンディアンのバイト列をワードの列に変換する
+func fromBytes(b []byte) []uint64 {
+	w := make([]uint64, (len(b)+7)/8+1)
+	for i, v := range b {
+		bit := 8 * (len(b) - 1 - i)
+		w[bit/64] |= uint64(v) << uint(bit%64)
+	}
+	return w
+}
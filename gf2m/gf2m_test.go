@@ -0,0 +1,249 @@
+package gf2m
+
+import (
+	"encoding/hex"
+	"reflect"
+	"testing"
+
+	"github.com/convto/mycrypto/big"
+)
+
+var (
+	// AES で使う GF(2^8): x^8 + x^4 + x^3 + x + 1
+	aesField = New(8, 4, 3, 1, 0)
+	// GHASH で使う GF(2^128): x^128 + x^7 + x^2 + x + 1
+	ghashField = New(128, 7, 2, 1, 0)
+	// sect233k1 の GF(2^233): x^233 + x^74 + 1
+	sect233Field = New(233, 74, 0)
+)
+
+// hexElement は16進数の文字列から元を作る
+func hexElement(f *Field, s string) *Element {
+	if len(s)%2 == 1 {
+		s = "0" + s
+	}
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		panic(err)
+	}
+	return f.NewElementFromInt(new(big.Int).SetBytes(b))
+}
+
+func TestElement_Add(t *testing.T) {
+	x, y := aesField.NewElement(0x57), aesField.NewElement(0x83)
+	if got, want := x.Add(y), aesField.NewElement(0xd4); !got.Equal(want) {
+		t.Errorf("Add() = %v, want %v", got, want)
+	}
+	if got := x.Add(x); !got.IsZero() {
+		t.Errorf("x + x = %v, want 0", got)
+	}
+}
+
+func TestElement_Mul(t *testing.T) {
+	tests := []struct {
+		name string
+		x    *Element
+		y    *Element
+		want *Element
+	}{
+		{
+			name: "GF(2^8) FIPS 197 example",
+			x:    aesField.NewElement(0x57),
+			y:    aesField.NewElement(0x83),
+			want: aesField.NewElement(0xc1),
+		},
+		{
+			name: "GF(2^8) x * 1",
+			x:    aesField.NewElement(0x57),
+			y:    aesField.One(),
+			want: aesField.NewElement(0x57),
+		},
+		{
+			name: "GF(2^128)",
+			x:    hexElement(ghashField, "66e94bd4ef8a2c3b884cfa59ca342b2e"),
+			y:    hexElement(ghashField, "0388dace60b6a392f328c2b971b2fe78"),
+			want: hexElement(ghashField, "519fa38ac731568e9c1eb21731167f1c"),
+		},
+		{
+			name: "GF(2^233)",
+			x:    hexElement(sect233Field, "17232ba853a7e731af129f22ff4149563a419c26bf50a4c9d6eefad6126"),
+			y:    hexElement(sect233Field, "1db537dece819b7f70f555a67c427a8cd9bf18aeb9b56e0c11056fae6a3"),
+			want: hexElement(sect233Field, "404c43af73958b87742ff9e35ec83a50fb77c1d266fa5b7e749ddd12ca"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.x.Mul(tt.y); !got.Equal(tt.want) {
+				t.Errorf("Mul() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestElement_Square(t *testing.T) {
+	for _, x := range []*Element{
+		aesField.NewElement(0x57),
+		hexElement(ghashField, "66e94bd4ef8a2c3b884cfa59ca342b2e"),
+		hexElement(sect233Field, "17232ba853a7e731af129f22ff4149563a419c26bf50a4c9d6eefad6126"),
+	} {
+		if got, want := x.Square(), x.Mul(x); !got.Equal(want) {
+			t.Errorf("Square() = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestElement_Inv(t *testing.T) {
+	tests := []struct {
+		name string
+		x    *Element
+		want *Element
+	}{
+		{
+			name: "GF(2^8) FIPS 197 example",
+			x:    aesField.NewElement(0x53),
+			want: aesField.NewElement(0xca),
+		},
+		{
+			name: "GF(2^128)",
+			x:    hexElement(ghashField, "66e94bd4ef8a2c3b884cfa59ca342b2e"),
+			want: hexElement(ghashField, "ee45999b33176c4646c7c7aa703ce7b8"),
+		},
+		{
+			name: "GF(2^233)",
+			x:    hexElement(sect233Field, "17232ba853a7e731af129f22ff4149563a419c26bf50a4c9d6eefad6126"),
+			want: hexElement(sect233Field, "1ecb92776d0fb3dec476585b9065724ef7e1966bf54a850e5cbddaa1be6"),
+		},
+		{
+			name: "one",
+			x:    sect233Field.One(),
+			want: sect233Field.One(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.x.Inv(); !got.Equal(tt.want) {
+				t.Errorf("Inv() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestElement_Exp(t *testing.T) {
+	x := hexElement(ghashField, "66e94bd4ef8a2c3b884cfa59ca342b2e")
+	// 乗法群の位数は 2^128 - 1 なので x^(2^128 - 2) = x^(-1)
+	k := new(big.Int).SetString("340282366920938463463374607431768211454")
+	if got, want := x.Exp(k), x.Inv(); !got.Equal(want) {
+		t.Errorf("Exp() = %v, want %v", got, want)
+	}
+	if got, want := x.Exp(big.NewInt(-1)), x.Inv(); !got.Equal(want) {
+		t.Errorf("Exp(-1) = %v, want %v", got, want)
+	}
+	if got, want := x.Exp(big.NewInt(3)), x.Mul(x).Mul(x); !got.Equal(want) {
+		t.Errorf("Exp(3) = %v, want %v", got, want)
+	}
+}
+
+func TestElement_Sqrt(t *testing.T) {
+	for _, x := range []*Element{
+		aesField.NewElement(0x57),
+		hexElement(ghashField, "66e94bd4ef8a2c3b884cfa59ca342b2e"),
+		hexElement(sect233Field, "17232ba853a7e731af129f22ff4149563a419c26bf50a4c9d6eefad6126"),
+	} {
+		if got := x.Sqrt().Square(); !got.Equal(x) {
+			t.Errorf("Sqrt()^2 = %v, want %v", got, x)
+		}
+	}
+}
+
+func TestElement_Trace(t *testing.T) {
+	tests := []struct {
+		name string
+		x    *Element
+		want uint
+	}{
+		{
+			name: "GF(2^8) one",
+			x:    aesField.One(),
+			want: 0,
+		},
+		{
+			name: "GF(2^8)",
+			x:    aesField.NewElement(0x57),
+			want: 0,
+		},
+		{
+			name: "GF(2^233) one",
+			x:    sect233Field.One(),
+			want: 1,
+		},
+		{
+			name: "GF(2^233) trace 0",
+			x:    hexElement(sect233Field, "17232ba853a7e731af129f22ff4149563a419c26bf50a4c9d6eefad6126"),
+			want: 0,
+		},
+		{
+			name: "GF(2^233) trace 1",
+			x:    hexElement(sect233Field, "1db537dece819b7f70f555a67c427a8cd9bf18aeb9b56e0c11056fae6a3"),
+			want: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.x.Trace(); got != tt.want {
+				t.Errorf("Trace() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestElement_Bytes(t *testing.T) {
+	x := hexElement(sect233Field, "17232ba853a7e731af129f22ff4149563a419c26bf50a4c9d6eefad6126")
+	b := x.Bytes()
+	if len(b) != 30 {
+		t.Fatalf("len(Bytes()) = %v, want 30", len(b))
+	}
+	got, err := sect233Field.SetBytes(b)
+	if err != nil {
+		t.Fatalf("SetBytes() error = %v", err)
+	}
+	if !got.Equal(x) {
+		t.Errorf("SetBytes() = %v, want %v", got, x)
+	}
+	if !reflect.DeepEqual(aesField.NewElement(0xc1).Bytes(), []byte{0xc1}) {
+		t.Errorf("Bytes() = %x, want c1", aesField.NewElement(0xc1).Bytes())
+	}
+	if got := x.Int().Bytes(); !reflect.DeepEqual(got, b) {
+		t.Errorf("Int().Bytes() = %x, want %x", got, b)
+	}
+}
+
+func TestField_SetBytes_invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		b    []byte
+	}{
+		{name: "too long", b: []byte{0x00, 0x01}},
+		// x^233 以上の項を含む
+		{name: "degree too large", b: append([]byte{0x02}, make([]byte, 29)...)},
+	}
+	for i, tt := range tests {
+		f := aesField
+		if i == 1 {
+			f = sect233Field
+		}
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := f.SetBytes(tt.b); err != ErrInvalidEncoding {
+				t.Errorf("SetBytes() error = %v, want %v", err, ErrInvalidEncoding)
+			}
+		})
+	}
+}
+
+func TestElement_mismatchedFields(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Mul() did not panic")
+		}
+	}()
+	aesField.One().Mul(New(8, 4, 3, 2, 0).One())
+}
@@ -0,0 +1,125 @@
+package h2c
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/convto/mycrypto/big"
+	"github.com/convto/mycrypto/ec"
+	"github.com/convto/mycrypto/pairing"
+	"github.com/convto/mycrypto/tower"
+)
+
+// suiteVectors は RFC 9380 Appendix J の JSON 形式のテストベクター
+// Fp2 の座標は "a0,a1" の形で書かれている
+type suiteVectors struct {
+	DST     string `json:"dst"`
+	Vectors []struct {
+		Msg string      `json:"msg"`
+		P   vectorPoint `json:"P"`
+		Q   vectorPoint `json:"Q"`
+		Q0  vectorPoint `json:"Q0"`
+		Q1  vectorPoint `json:"Q1"`
+		U   []string    `json:"u"`
+	} `json:"vectors"`
+}
+
+type vectorPoint struct {
+	X string `json:"x"`
+	Y string `json:"y"`
+}
+
+func readSuiteVectors(t *testing.T, name string) *suiteVectors {
+	t.Helper()
+	data, err := os.ReadFile("testdata/" + name + ".json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var v suiteVectors
+	if err := json.Unmarshal(data, &v); err != nil {
+		t.Fatal(err)
+	}
+	return &v
+}
+
+func ecPoint(t *testing.T, c *ec.Curve, v vectorPoint) *ec.Point {
+	t.Helper()
+	p, err := c.NewPoint(new(big.Int).SetString(v.X), new(big.Int).SetString(v.Y))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+func fp2(tw *tower.Tower, s string) *tower.Fp2 {
+	a := strings.Split(s, ",")
+	return tw.NewFp2(new(big.Int).SetString(a[0]), new(big.Int).SetString(a[1]))
+}
+
+func g2Point(t *testing.T, c *pairing.Curve, v vectorPoint) *pairing.G2 {
+	t.Helper()
+	q, err := c.NewG2(fp2(c.Tower(), v.X), fp2(c.Tower(), v.Y))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return q
+}
+
+func TestBLS12381G1(t *testing.T) {
+	testHasher(t, "BLS12381G1_XMD-SHA-256_SSWU", BLS12381G1)
+}
+
+func TestBLS12381G2(t *testing.T) {
+	for _, name := range []string{"BLS12381G2_XMD-SHA-256_SSWU_RO_", "BLS12381G2_XMD-SHA-256_SSWU_NU_"} {
+		t.Run(name, func(t *testing.T) {
+			v := readSuiteVectors(t, name)
+			h := BLS12381G2([]byte(v.DST))
+			ro := strings.HasSuffix(name, "_RO_")
+			for _, tt := range v.Vectors {
+				msg := []byte(tt.Msg)
+				u := h.hashToField(msg, len(tt.U))
+				qs := []vectorPoint{tt.Q}
+				if ro {
+					qs = []vectorPoint{tt.Q0, tt.Q1}
+				}
+				for i := range tt.U {
+					if !u[i].Equal(fp2(h.c.Tower(), tt.U[i])) {
+						t.Errorf("%q: u[%d] = %s, want %s", tt.Msg, i, u[i], tt.U[i])
+					}
+					if !h.mapToCurve(u[i]).Equal(g2Point(t, h.c, qs[i])) {
+						t.Errorf("%q: map_to_curve(u[%d]) mismatch", tt.Msg, i)
+					}
+				}
+
+				var got *pairing.G2
+				if ro {
+					got = h.HashToCurve(msg)
+				} else {
+					got = h.EncodeToCurve(msg)
+				}
+				if !got.Equal(g2Point(t, h.c, tt.P)) {
+					t.Errorf("%q: P = %s, want (%s, %s)", tt.Msg, got, tt.P.X, tt.P.Y)
+				}
+			}
+		})
+	}
+}
+
+// Budroni-Pintore の方法は h_eff 倍と一致する
+func TestClearCofactorG2(t *testing.T) {
+	hEff := new(big.Int).SetString("0x0bc69f08f2ee75b3584c6a0ea91b352888e2a8e9145ad7689986ff031508ffe1329c2f178731db956d82bf015d1212b02ec0ec69d7477c1ae954cbc06689f6a359894c0adebbf6b4e8020005aaa95551")
+	h := BLS12381G2([]byte("QUUX-V01-CS02-with-BLS12381G2_XMD:SHA-256_SSWU_RO_"))
+	q := h.mapToCurve(h.hashToField([]byte("abc"), 1)[0])
+	if h.c.InG2(q) {
+		t.Fatal("map_to_curve(u) is already in G2")
+	}
+	got := clearCofactorG2(q)
+	if !got.Equal(q.ScalarMult(hEff)) {
+		t.Errorf("clearCofactorG2(q) != [h_eff]q")
+	}
+	if !h.c.InG2(got) {
+		t.Errorf("clearCofactorG2(q) is not in G2")
+	}
+}
@@ -0,0 +1,159 @@
+package h2c
+
+import (
+	"crypto/sha512"
+
+	"github.com/convto/mycrypto/big"
+	"github.com/convto/mycrypto/edwards25519"
+	"github.com/convto/mycrypto/field"
+)
+
+// curve25519 と edwards25519 の suite の定数は RFC 9380 8.5 と 6.7.1, 6.8.2 による
+var (
+	fe25519 = field.New(edwards25519.P())
+	// Montgomery 曲線 curve25519: t^2 = s^3 + Js^2 + s (K = 1)
+	curve25519J = fe25519.NewElement(big.NewInt(486662))
+	curve25519Z = fe25519.NewElement(big.NewInt(2))
+	// curve25519C1 は有理写像で使う sqrt(-486664) で、sgn0 が0のもの
+	curve25519C1 = func() *field.Element {
+		c, ok := fe25519.NewElement(big.NewInt(-486664)).Sqrt()
+		if !ok {
+			panic("h2c: -486664 is not a square")
+		}
+		if sgn0(c) != 0 {
+			c = c.Neg()
+		}
+		return c
+	}()
+)
+
+// elligator2 は RFC 9380 6.7.1 の Elligator 2 で、u を curve25519 の点 (s, t) に写す
+func elligator2(u *field.Element) (s, t *field.Element) {
+	// x1 = -J / (1 + Z u^2)、分母が0のときは x1 = -J
+	x1 := curve25519J.Neg()
+	if den := fe25519.One().Add(curve25519Z.Mul(u.Square())); !den.IsZero() {
+		x1 = x1.Mul(den.Inv())
+	}
+	// g(x1) が平方剰余でなければ x2 = -x1 - J の g(x2) が平方剰余になる
+	// どちらを使ったかが t の符号に現れるように、x1 のときは sgn0(t) = 1、x2 のときは sgn0(t) = 0 にする
+	s = x1
+	t, ok := curve25519G(s).Sqrt()
+	sign := uint(1)
+	if !ok {
+		s = x1.Neg().Sub(curve25519J)
+		t, _ = curve25519G(s).Sqrt()
+		sign = 0
+	}
+	if sgn0(t) != sign {
+		t = t.Neg()
+	}
+	return s, t
+}
+
+// curve25519G は s^3 + Js^2 + s を返す
+func curve25519G(s *field.Element) *field.Element {
+	return s.Add(curve25519J).Mul(s).Add(fe25519.One()).Mul(s)
+}
+
+// edwardsPoint は RFC 9380 6.8.2 の有理写像 (x, y) = (c1 s / t, (s - 1) / (s + 1)) で curve25519 の点を edwards25519 の点に写す
+// t = 0 または s = -1 の例外点は単位元に写す
+func edwardsPoint(s, t *field.Element) *edwards25519.Point {
+	one := fe25519.One()
+	sp1 := s.Add(one)
+	if t.IsZero() || sp1.IsZero() {
+		return edwards25519.NewIdentityPoint()
+	}
+	x := curve25519C1.Mul(s).Mul(t.Inv())
+	y := s.Sub(one).Mul(sp1.Inv())
+	p, err := edwards25519.NewPoint(x.Int(), y.Int())
+	if err != nil {
+		panic("h2c: mapped point is not on the curve")
+	}
+	return p
+}
+
+// hashToField25519 は msg を GF(2^255 - 19) の count 個の元に写す
+func hashToField25519(e Expander, msg []byte, count int) []*field.Element {
+	u, err := HashToField(e, msg, fe25519.Modulus(), 1, count, 128)
+	if err != nil {
+		panic(err)
+	}
+	f := make([]*field.Element, count)
+	for i := range f {
+		f[i] = fe25519.NewElement(u[i][0])
+	}
+	return f
+}
+
+// Edwards25519Hasher は edwards25519 に対する RFC 9380 の suite です
+type Edwards25519Hasher struct {
+	e Expander
+}
+
+// Edwards25519 は edwards25519_XMD:SHA-512_ELL2_RO_ と edwards25519_XMD:SHA-512_ELL2_NU_ の suite を dst で返します
+// HashToCurve が前者、EncodeToCurve が後者にあたります
+func Edwards25519(dst []byte) *Edwards25519Hasher {
+	return &Edwards25519Hasher{e: XMD(sha512.New, dst)}
+}
+
+// HashToCurve は msg を位数 l の部分群の点に写します (_RO_ の suite)
+func (h *Edwards25519Hasher) HashToCurve(msg []byte) *edwards25519.Point {
+	u := hashToField25519(h.e, msg, 2)
+	q0 := h.mapToCurve(u[0])
+	q1 := h.mapToCurve(u[1])
+	return q0.Add(q1).MultByCofactor()
+}
+
+// EncodeToCurve は msg を位数 l の部分群の点に写します (_NU_ の suite)
+func (h *Edwards25519Hasher) EncodeToCurve(msg []byte) *edwards25519.Point {
+	u := hashToField25519(h.e, msg, 1)
+	return h.mapToCurve(u[0]).MultByCofactor()
+}
+
+// mapToCurve は u を Elligator 2 と有理写像で edwards25519 の点に写す
+func (h *Edwards25519Hasher) mapToCurve(u *field.Element) *edwards25519.Point {
+	return edwardsPoint(elligator2(u))
+}
+
+// Curve25519Hasher は curve25519 に対する RFC 9380 の suite です
+// 点の加算と余因子をかける計算は、有理写像で同型な edwards25519 に移して行います
+type Curve25519Hasher struct {
+	e Expander
+}
+
+// Curve25519 は curve25519_XMD:SHA-512_ELL2_RO_ と curve25519_XMD:SHA-512_ELL2_NU_ の suite を dst で返します
+// HashToCurve が前者、EncodeToCurve が後者にあたります
+func Curve25519(dst []byte) *Curve25519Hasher {
+	return &Curve25519Hasher{e: XMD(sha512.New, dst)}
+}
+
+// HashToCurve は msg を位数 l の部分群の点に写し、curve25519 のアフィン座標 (s, t) を返します (_RO_ の suite)
+// s をリトルエンディアンで32バイトに並べると X25519 の u 座標になります
+// 無限遠点になったときは s, t ともに nil を返しますが、その確率は無視できるほど小さいです
+func (h *Curve25519Hasher) HashToCurve(msg []byte) (s, t *big.Int) {
+	u := hashToField25519(h.e, msg, 2)
+	q0 := edwardsPoint(elligator2(u[0]))
+	q1 := edwardsPoint(elligator2(u[1]))
+	return montgomeryPoint(q0.Add(q1).MultByCofactor())
+}
+
+// EncodeToCurve は msg を位数 l の部分群の点に写し、curve25519 のアフィン座標 (s, t) を返します (_NU_ の suite)
+// 無限遠点になったときは s, t ともに nil を返します
+func (h *Curve25519Hasher) EncodeToCurve(msg []byte) (s, t *big.Int) {
+	u := hashToField25519(h.e, msg, 1)
+	return montgomeryPoint(edwardsPoint(elligator2(u[0])).MultByCofactor())
+}
+
+// montgomeryPoint は edwardsPoint の逆写像 (s, t) = ((1 + y) / (1 - y), c1 s / x) で p を curve25519 の点に写す
+// p は位数 l の部分群の点とし、単位元は無限遠点として nil, nil を返す
+func montgomeryPoint(p *edwards25519.Point) (s, t *big.Int) {
+	if p.IsIdentity() {
+		return nil, nil
+	}
+	xi, yi := p.Affine()
+	x, y := fe25519.NewElement(xi), fe25519.NewElement(yi)
+	one := fe25519.One()
+	ss := one.Add(y).Mul(one.Sub(y).Inv())
+	tt := curve25519C1.Mul(ss).Mul(x.Inv())
+	return ss.Int(), tt.Int()
+}
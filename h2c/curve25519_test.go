@@ -0,0 +1,113 @@
+package h2c
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/convto/mycrypto/big"
+	"github.com/convto/mycrypto/edwards25519"
+	"github.com/convto/mycrypto/field"
+)
+
+func edwardsPointOf(t *testing.T, v vectorPoint) *edwards25519.Point {
+	t.Helper()
+	p, err := edwards25519.NewPoint(new(big.Int).SetString(v.X), new(big.Int).SetString(v.Y))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+func TestEdwards25519(t *testing.T) {
+	for _, name := range []string{"edwards25519_XMD-SHA-512_ELL2_RO_", "edwards25519_XMD-SHA-512_ELL2_NU_"} {
+		t.Run(name, func(t *testing.T) {
+			v := readSuiteVectors(t, name)
+			h := Edwards25519([]byte(v.DST))
+			ro := strings.HasSuffix(name, "_RO_")
+			for _, tt := range v.Vectors {
+				msg := []byte(tt.Msg)
+				u := hashToField25519(h.e, msg, len(tt.U))
+				qs := []vectorPoint{tt.Q}
+				if ro {
+					qs = []vectorPoint{tt.Q0, tt.Q1}
+				}
+				for i := range tt.U {
+					if big.Cmp(u[i].Int(), new(big.Int).SetString(tt.U[i])) != 0 {
+						t.Errorf("%q: u[%d] = %s, want %s", tt.Msg, i, u[i], tt.U[i])
+					}
+					if !h.mapToCurve(u[i]).Equal(edwardsPointOf(t, qs[i])) {
+						t.Errorf("%q: map_to_curve(u[%d]) mismatch", tt.Msg, i)
+					}
+				}
+
+				var got *edwards25519.Point
+				if ro {
+					got = h.HashToCurve(msg)
+				} else {
+					got = h.EncodeToCurve(msg)
+				}
+				if !got.Equal(edwardsPointOf(t, tt.P)) {
+					t.Errorf("%q: P = %s, want (%s, %s)", tt.Msg, got, tt.P.X, tt.P.Y)
+				}
+			}
+		})
+	}
+}
+
+// montgomeryCheck は (s, t) がテストベクターの点 v と一致し、curve25519 の上にあることを確かめる
+func montgomeryCheck(t *testing.T, what string, s, tc *field.Element, v vectorPoint) {
+	t.Helper()
+	ws, wt := new(big.Int).SetString(v.X), new(big.Int).SetString(v.Y)
+	if big.Cmp(s.Int(), ws) != 0 || big.Cmp(tc.Int(), wt) != 0 {
+		t.Errorf("%s = (%s, %s), want (%s, %s)", what, s, tc, v.X, v.Y)
+	}
+	// t^2 = s^3 + Js^2 + s
+	if !tc.Square().Equal(curve25519G(s)) {
+		t.Errorf("%s = (%s, %s) is not on curve25519", what, s, tc)
+	}
+}
+
+func TestCurve25519(t *testing.T) {
+	for _, name := range []string{"curve25519_XMD-SHA-512_ELL2_RO_", "curve25519_XMD-SHA-512_ELL2_NU_"} {
+		t.Run(name, func(t *testing.T) {
+			v := readSuiteVectors(t, name)
+			h := Curve25519([]byte(v.DST))
+			ro := strings.HasSuffix(name, "_RO_")
+			for _, tt := range v.Vectors {
+				msg := []byte(tt.Msg)
+				u := hashToField25519(h.e, msg, len(tt.U))
+				qs := []vectorPoint{tt.Q}
+				if ro {
+					qs = []vectorPoint{tt.Q0, tt.Q1}
+				}
+				for i := range tt.U {
+					if big.Cmp(u[i].Int(), new(big.Int).SetString(tt.U[i])) != 0 {
+						t.Errorf("%q: u[%d] = %s, want %s", tt.Msg, i, u[i], tt.U[i])
+					}
+					s, tc := elligator2(u[i])
+					montgomeryCheck(t, fmt.Sprintf("%q: map_to_curve(u[%d])", tt.Msg, i), s, tc, qs[i])
+				}
+
+				var s, tc *big.Int
+				if ro {
+					s, tc = h.HashToCurve(msg)
+				} else {
+					s, tc = h.EncodeToCurve(msg)
+				}
+				montgomeryCheck(t, fmt.Sprintf("%q: P", tt.Msg), fe25519.NewElement(s), fe25519.NewElement(tc), tt.P)
+			}
+		})
+	}
+}
+
+// u = 0 は curve25519 の位数2の点 (0, 0) に写り、有理写像の例外点として edwards25519 の単位元になる
+func TestElligator2_exceptional(t *testing.T) {
+	s, tc := elligator2(fe25519.Zero())
+	if !s.IsZero() || !tc.IsZero() {
+		t.Errorf("elligator2(0) = (%s, %s), want (0, 0)", s, tc)
+	}
+	if p := edwardsPoint(s, tc); !p.IsIdentity() {
+		t.Errorf("edwardsPoint(0, 0) = %s, want identity", p)
+	}
+}
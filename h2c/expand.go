@@ -0,0 +1,119 @@
+package h2c
+
+import (
+	"encoding/binary"
+	"hash"
+	"io"
+)
+
+// Expander は RFC 9380 5.3 の expand_message で、msg から指定した長さの一様なバイト列を導出します
+// ドメイン分離タグ (DST) は Expander ごとに固定します
+type Expander interface {
+	Expand(msg []byte, lenInBytes int) ([]byte, error)
+}
+
+// xmd は Merkle-Damgård 型のハッシュ関数による expand_message_xmd です
+type xmd struct {
+	h   func() hash.Hash
+	dst []byte
+}
+
+// XMD は RFC 9380 5.3.1 の expand_message_xmd をハッシュ関数 h と dst で返します
+// dst が255バイトより長いときは 5.3.3 に従って H("H2C-OVERSIZE-DST-" || dst) に置き換えます
+func XMD(h func() hash.Hash, dst []byte) Expander {
+	if len(dst) > 255 {
+		hh := h()
+		hh.Write([]byte("H2C-OVERSIZE-DST-"))
+		hh.Write(dst)
+		dst = hh.Sum(nil)
+	}
+	return &xmd{h: h, dst: append([]byte{}, dst...)}
+}
+
+// Expand は msg から lenInBytes バイトの一様なバイト列を導出します
+// lenInBytes が65535を超えるか、ハッシュ値255個分より長いときは ErrInvalidLength を返します
+func (e *xmd) Expand(msg []byte, lenInBytes int) ([]byte, error) {
+	hh := e.h()
+	bLen := hh.Size()
+	ell := (lenInBytes + bLen - 1) / bLen
+	if ell > 255 || lenInBytes > 65535 || lenInBytes < 0 {
+		return nil, ErrInvalidLength
+	}
+	dstPrime := append(append([]byte{}, e.dst...), byte(len(e.dst)))
+	var lib [2]byte
+	binary.BigEndian.PutUint16(lib[:], uint16(lenInBytes))
+
+	// b_0 = H(Z_pad || msg || l_i_b_str || 0x00 || DST_prime)
+	hh.Write(make([]byte, hh.BlockSize()))
+	hh.Write(msg)
+	hh.Write(lib[:])
+	hh.Write([]byte{0})
+	hh.Write(dstPrime)
+	b0 := hh.Sum(nil)
+
+	// b_i = H((b_0 xor b_(i-1)) || i || DST_prime)
+	out := make([]byte, 0, ell*bLen)
+	prev := make([]byte, bLen)
+	for i := 1; i <= ell; i++ {
+		x := make([]byte, bLen)
+		for j := range x {
+			x[j] = b0[j] ^ prev[j]
+		}
+		hh.Reset()
+		hh.Write(x)
+		hh.Write([]byte{byte(i)})
+		hh.Write(dstPrime)
+		prev = hh.Sum(nil)
+		out = append(out, prev...)
+	}
+	return out[:lenInBytes], nil
+}
+
+// XOFHash は SHAKE128 のような可変長出力のハッシュ関数 (XOF) です
+// 入力を Write で書き込んでから、出力を Read で読み出します (sha3.Shake など)
+type XOFHash interface {
+	io.Writer
+	io.Reader
+}
+
+// xof は可変長出力のハッシュ関数による expand_message_xof です
+type xof struct {
+	h   func() XOFHash
+	dst []byte
+}
+
+// XOF は RFC 9380 5.3.2 の expand_message_xof を XOF の h と dst で返します
+// dst が255バイトより長いときは 5.3.3 に従って、安全性 k ビットに対して H("H2C-OVERSIZE-DST-" || dst) の先頭 ceil(2k/8) バイトに置き換えます
+func XOF(h func() XOFHash, dst []byte, k int) Expander {
+	if len(dst) > 255 {
+		hh := h()
+		hh.Write([]byte("H2C-OVERSIZE-DST-"))
+		hh.Write(dst)
+		d := make([]byte, (2*k+7)/8)
+		io.ReadFull(hh, d)
+		dst = d
+	}
+	return &xof{h: h, dst: append([]byte{}, dst...)}
+}
+
+// Expand は msg から lenInBytes バイトの一様なバイト列を導出します
+// lenInBytes が65535を超えるときは ErrInvalidLength を返します
+func (e *xof) Expand(msg []byte, lenInBytes int) ([]byte, error) {
+	if lenInBytes > 65535 || lenInBytes < 0 {
+		return nil, ErrInvalidLength
+	}
+	var lib [2]byte
+	binary.BigEndian.PutUint16(lib[:], uint16(lenInBytes))
+
+	// H(msg || l_i_b_str || DST_prime, len_in_bytes)
+	hh := e.h()
+	hh.Write(msg)
+	hh.Write(lib[:])
+	hh.Write(e.dst)
+	hh.Write([]byte{byte(len(e.dst))})
+	out := make([]byte, lenInBytes)
+	if _, err := io.ReadFull(hh, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
@@ -0,0 +1,144 @@
+package h2c
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/convto/mycrypto/sha3"
+)
+
+// RFC 9380 K.1 expand_message_xmd(SHA-256) のテストベクター
+func TestXMD(t *testing.T) {
+	e := XMD(sha256.New, []byte("QUUX-V01-CS02-with-expander-SHA256-128"))
+	tests := []struct {
+		msg  string
+		len  int
+		want string
+	}{
+		{msg: "", len: 0x20, want: "68a985b87eb6b46952128911f2a4412bbc302a9d759667f87f7a21d803f07235"},
+		{msg: "abc", len: 0x20, want: "d8ccab23b5985ccea865c6c97b6e5b8350e794e603b4b97902f53a8a0d605615"},
+		{msg: "abcdef0123456789", len: 0x20, want: "eff31487c770a893cfb36f912fbfcbff40d5661771ca4b2cb4eafe524333f5c1"},
+		{msg: "q128_" + strings.Repeat("q", 128), len: 0x20, want: "b23a1d2b4d97b2ef7785562a7e8bac7eed54ed6e97e29aa51bfe3f12ddad1ff9"},
+		{msg: "a512_" + strings.Repeat("a", 512), len: 0x20, want: "4623227bcc01293b8c130bf771da8c298dede7383243dc0993d2d94823958c4c"},
+		{msg: "abc", len: 0x80, want: "abba86a6129e366fc877aab32fc4ffc70120d8996c88aee2fe4b32d6c7b6437a647e6c3163d40b76a73cf6a5674ef1d890f95b664ee0afa5359a5c4e07985635bbecbac65d747d3d2da7ec2b8221b17b0ca9dc8a1ac1c07ea6a1e60583e2cb00058e77b7b72a298425cd1b941ad4ec65e8afc50303a22c0f99b0509b4c895f40"},
+	}
+	for _, tt := range tests {
+		got, err := e.Expand([]byte(tt.msg), tt.len)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want, _ := hex.DecodeString(tt.want)
+		if !bytes.Equal(got, want) {
+			t.Errorf("Expand(%.10q, %#x) = %x, want %x", tt.msg, tt.len, got, want)
+		}
+	}
+	if _, err := e.Expand(nil, 256*32); err != ErrInvalidLength {
+		t.Errorf("Expand(too long) error = %v, want %v", err, ErrInvalidLength)
+	}
+}
+
+// 255バイトより長い DST は H("H2C-OVERSIZE-DST-" || DST) に置き換わる
+func TestXMD_oversizeDST(t *testing.T) {
+	dst := []byte(strings.Repeat("x", 256))
+	h := sha256.Sum256(append([]byte("H2C-OVERSIZE-DST-"), dst...))
+	got, err := XMD(sha256.New, dst).Expand([]byte("abc"), 32)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := XMD(sha256.New, h[:]).Expand([]byte("abc"), 32)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("Expand with oversize DST = %x, want %x", got, want)
+	}
+}
+
+// expanderVectors は RFC 9380 Appendix K の JSON 形式のテストベクター
+type expanderVectors struct {
+	DST   string `json:"DST"`
+	K     int    `json:"k"`
+	Tests []struct {
+		Msg          string `json:"msg"`
+		LenInBytes   string `json:"len_in_bytes"`
+		UniformBytes string `json:"uniform_bytes"`
+	} `json:"tests"`
+}
+
+func testExpander(t *testing.T, name string, newExpander func(dst []byte, k int) Expander) {
+	t.Helper()
+	data, err := os.ReadFile("testdata/" + name + ".json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var v expanderVectors
+	if err := json.Unmarshal(data, &v); err != nil {
+		t.Fatal(err)
+	}
+	e := newExpander([]byte(v.DST), v.K)
+	for _, tt := range v.Tests {
+		l, err := strconv.ParseInt(tt.LenInBytes, 0, 32)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := e.Expand([]byte(tt.Msg), int(l))
+		if err != nil {
+			t.Fatal(err)
+		}
+		want, _ := hex.DecodeString(tt.UniformBytes)
+		if !bytes.Equal(got, want) {
+			t.Errorf("%s: Expand(%.10q, %s) = %x, want %x", name, tt.Msg, tt.LenInBytes, got, want)
+		}
+	}
+}
+
+func newShake128() XOFHash { return sha3.NewShake128() }
+func newShake256() XOFHash { return sha3.NewShake256() }
+
+// RFC 9380 K.3 expand_message_xmd(SHA-512) のテストベクター
+func TestXMD_SHA512(t *testing.T) {
+	testExpander(t, "expand_message_xmd_SHA512_38", func(dst []byte, _ int) Expander {
+		return XMD(sha512.New, dst)
+	})
+}
+
+// RFC 9380 K.4, K.6 expand_message_xof(SHAKE128, SHAKE256) のテストベクター
+func TestXOF(t *testing.T) {
+	testExpander(t, "expand_message_xof_SHAKE128_36", func(dst []byte, k int) Expander {
+		return XOF(newShake128, dst, k)
+	})
+	testExpander(t, "expand_message_xof_SHAKE256_36", func(dst []byte, k int) Expander {
+		return XOF(newShake256, dst, k)
+	})
+	if _, err := XOF(newShake128, []byte("dst"), 128).Expand(nil, 65536); err != ErrInvalidLength {
+		t.Errorf("Expand(too long) error = %v, want %v", err, ErrInvalidLength)
+	}
+}
+
+// 255バイトより長い DST は H("H2C-OVERSIZE-DST-" || DST) の先頭 ceil(2k/8) バイトに置き換わる
+func TestXOF_oversizeDST(t *testing.T) {
+	dst := []byte(strings.Repeat("x", 256))
+	h := sha3.NewShake128()
+	h.Write([]byte("H2C-OVERSIZE-DST-"))
+	h.Write(dst)
+	short := make([]byte, 32)
+	h.Read(short)
+	got, err := XOF(newShake128, dst, 128).Expand([]byte("abc"), 32)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := XOF(newShake128, short, 128).Expand([]byte("abc"), 32)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("Expand with oversize DST = %x, want %x", got, want)
+	}
+}
@@ -0,0 +1,25 @@
+package h2c
+
+import (
+	"github.com/convto/mycrypto/big"
+)
+
+// HashToField は RFC 9380 5.2 の hash_to_field で、msg を GF(p^m) の count 個の元に写します
+// 各元は m 個の係数 0 <= e < p の組で返します
+// 係数ごとに p のビット長に安全性の k ビットを足した L バイトを導出してから p で還元するので、偏りは 2^-k 程度に収まります
+func HashToField(e Expander, msg []byte, p *big.Int, m, count, k int) ([][]*big.Int, error) {
+	l := (p.BitLen() + k + 7) / 8
+	b, err := e.Expand(msg, count*m*l)
+	if err != nil {
+		return nil, err
+	}
+	u := make([][]*big.Int, count)
+	for i := range u {
+		u[i] = make([]*big.Int, m)
+		for j := range u[i] {
+			off := l * (j + i*m)
+			u[i][j] = big.Mod(new(big.Int).SetBytes(b[off:off+l]), p)
+		}
+	}
+	return u, nil
+}
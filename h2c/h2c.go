@@ -0,0 +1,19 @@
+// Package h2c は RFC 9380 の hash-to-curve で、任意のバイト列を楕円曲線の点に写します
+//
+// 写像は次の3段階からなります
+//   - hash_to_field: expand_message で一様なバイト列を導出し、体の元に還元する
+//   - map_to_curve: 体の元を曲線の点に写す (SSWU など)
+//   - clear_cofactor: 余因子をかけて素数位数の部分群に入れる
+//
+// HashToCurve は2つの体の元をそれぞれ写して足すので、結果はランダムオラクルとして扱えます (_RO_ の suite)
+// EncodeToCurve は1つの体の元だけを写すので速い代わりに、像が点全体の一部に偏ります (_NU_ の suite)
+//
+// suite は P-256, secp256k1, BLS12-381 の G1, G2 (SSWU) と curve25519, edwards25519 (Elligator 2) を提供します
+package h2c
+
+import (
+	"errors"
+)
+
+// ErrInvalidLength は expand_message に要求した長さが大きすぎることを表します
+var ErrInvalidLength = errors.New("h2c: invalid length")
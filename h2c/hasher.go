@@ -0,0 +1,61 @@
+package h2c
+
+import (
+	"github.com/convto/mycrypto/ec"
+	"github.com/convto/mycrypto/field"
+)
+
+// Hasher は素体上の曲線 (ec.Curve) に対する RFC 9380 の suite です
+type Hasher struct {
+	curve *ec.Curve
+	e     Expander
+	// k は suite の安全性のビット数
+	k int
+	// mapToCurve は体の元を曲線の点に写す
+	mapToCurve func(u *field.Element) *ec.Point
+	// clearCofactor は点に余因子 h_eff をかける
+	clearCofactor func(p *ec.Point) *ec.Point
+}
+
+// Curve は写した先の曲線を返します
+func (h *Hasher) Curve() *ec.Curve {
+	return h.curve
+}
+
+// HashToCurve は msg を素数位数の部分群の点に写します (_RO_ の suite)
+func (h *Hasher) HashToCurve(msg []byte) *ec.Point {
+	u := h.hashToField(msg, 2)
+	q0 := h.mapToCurve(u[0])
+	q1 := h.mapToCurve(u[1])
+	return h.clearCofactor(q0.Add(q1))
+}
+
+// EncodeToCurve は msg を素数位数の部分群の点に写します (_NU_ の suite)
+func (h *Hasher) EncodeToCurve(msg []byte) *ec.Point {
+	u := h.hashToField(msg, 1)
+	return h.clearCofactor(h.mapToCurve(u[0]))
+}
+
+// hashToField は msg を曲線の座標の体の count 個の元に写す
+func (h *Hasher) hashToField(msg []byte, count int) []*field.Element {
+	f := h.curve.Field()
+	u, err := HashToField(h.e, msg, f.Modulus(), 1, count, h.k)
+	if err != nil {
+		panic(err)
+	}
+	e := make([]*field.Element, count)
+	for i := range e {
+		e[i] = f.NewElement(u[i][0])
+	}
+	return e
+}
+
+// point は写像で求めたアフィン座標から曲線の点を作る
+// 座標は曲線上にあるはずなので、そうでなければpanicする
+func point(c *ec.Curve, x, y *field.Element) *ec.Point {
+	p, err := c.NewPoint(x.Int(), y.Int())
+	if err != nil {
+		panic("h2c: mapped point is not on the curve")
+	}
+	return p
+}
@@ -0,0 +1,47 @@
+package h2c
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/convto/mycrypto/big"
+	"github.com/convto/mycrypto/ec"
+)
+
+// testHasher は prefix + "_RO_" と prefix + "_NU_" のテストベクターで newHasher の suite を確かめる
+func testHasher(t *testing.T, prefix string, newHasher func(dst []byte) *Hasher) {
+	t.Helper()
+	for _, name := range []string{prefix + "_RO_", prefix + "_NU_"} {
+		t.Run(name, func(t *testing.T) {
+			v := readSuiteVectors(t, name)
+			h := newHasher([]byte(v.DST))
+			ro := strings.HasSuffix(name, "_RO_")
+			for _, tt := range v.Vectors {
+				msg := []byte(tt.Msg)
+				u := h.hashToField(msg, len(tt.U))
+				qs := []vectorPoint{tt.Q}
+				if ro {
+					qs = []vectorPoint{tt.Q0, tt.Q1}
+				}
+				for i := range tt.U {
+					if big.Cmp(u[i].Int(), new(big.Int).SetString(tt.U[i])) != 0 {
+						t.Errorf("%q: u[%d] = %s, want %s", tt.Msg, i, u[i], tt.U[i])
+					}
+					if !h.mapToCurve(u[i]).Equal(ecPoint(t, h.Curve(), qs[i])) {
+						t.Errorf("%q: map_to_curve(u[%d]) mismatch", tt.Msg, i)
+					}
+				}
+
+				var got *ec.Point
+				if ro {
+					got = h.HashToCurve(msg)
+				} else {
+					got = h.EncodeToCurve(msg)
+				}
+				if !got.Equal(ecPoint(t, h.Curve(), tt.P)) {
+					t.Errorf("%q: P = %s, want (%s, %s)", tt.Msg, got, tt.P.X, tt.P.Y)
+				}
+			}
+		})
+	}
+}
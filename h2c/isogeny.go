@@ -0,0 +1,99 @@
+package h2c
+
+import (
+	"github.com/convto/mycrypto/big"
+	"github.com/convto/mycrypto/field"
+	"github.com/convto/mycrypto/tower"
+)
+
+// isogeny は SSWU を使える補助曲線 E' から目的の曲線 E への同種写像
+// (x', y') を (xNum(x')/xDen(x'), y' * yNum(x')/yDen(x')) に写し、多項式の係数は次数の低い順に並べる
+type isogeny struct {
+	xNum, xDen, yNum, yDen []*field.Element
+}
+
+// newIsogeny は16進数の係数の列から isogeny を作る
+func newIsogeny(f *field.Field, xNum, xDen, yNum, yDen []string) *isogeny {
+	return &isogeny{
+		xNum: elements(f, xNum),
+		xDen: elements(f, xDen),
+		yNum: elements(f, yNum),
+		yDen: elements(f, yDen),
+	}
+}
+
+// apply は (x, y) の像を返す
+// 分母が0になるのは E' の例外点だけで、そのときは ok = false を返す
+func (iso *isogeny) apply(x, y *field.Element) (xx, yy *field.Element, ok bool) {
+	xDen := horner(iso.xDen, x)
+	yDen := horner(iso.yDen, x)
+	if xDen.IsZero() || yDen.IsZero() {
+		return nil, nil, false
+	}
+	xx = horner(iso.xNum, x).Mul(xDen.Inv())
+	yy = y.Mul(horner(iso.yNum, x)).Mul(yDen.Inv())
+	return xx, yy, true
+}
+
+// horner は係数 c の多項式の x での値を返す
+func horner(c []*field.Element, x *field.Element) *field.Element {
+	r := c[len(c)-1]
+	for i := len(c) - 2; i >= 0; i-- {
+		r = r.Mul(x).Add(c[i])
+	}
+	return r
+}
+
+// elements は16進数の列を体 f の元の列にする
+func elements(f *field.Field, s []string) []*field.Element {
+	e := make([]*field.Element, len(s))
+	for i := range s {
+		e[i] = f.NewElement(new(big.Int).SetString(s[i]))
+	}
+	return e
+}
+
+// isogenyFp2 は Fp2 上の isogeny
+type isogenyFp2 struct {
+	xNum, xDen, yNum, yDen []*tower.Fp2
+}
+
+// newIsogenyFp2 は a0, a1 の順に並んだ16進数の係数の列から isogenyFp2 を作る
+func newIsogenyFp2(t *tower.Tower, xNum, xDen, yNum, yDen [][2]string) *isogenyFp2 {
+	return &isogenyFp2{
+		xNum: elementsFp2(t, xNum),
+		xDen: elementsFp2(t, xDen),
+		yNum: elementsFp2(t, yNum),
+		yDen: elementsFp2(t, yDen),
+	}
+}
+
+// apply は (x, y) の像を返す
+func (iso *isogenyFp2) apply(x, y *tower.Fp2) (xx, yy *tower.Fp2, ok bool) {
+	xDen := hornerFp2(iso.xDen, x)
+	yDen := hornerFp2(iso.yDen, x)
+	if xDen.IsZero() || yDen.IsZero() {
+		return nil, nil, false
+	}
+	xx = hornerFp2(iso.xNum, x).Mul(xDen.Inv())
+	yy = y.Mul(hornerFp2(iso.yNum, x)).Mul(yDen.Inv())
+	return xx, yy, true
+}
+
+// hornerFp2 は係数 c の多項式の x での値を返す
+func hornerFp2(c []*tower.Fp2, x *tower.Fp2) *tower.Fp2 {
+	r := c[len(c)-1]
+	for i := len(c) - 2; i >= 0; i-- {
+		r = r.Mul(x).Add(c[i])
+	}
+	return r
+}
+
+// elementsFp2 は16進数の組の列を Fp2 の元の列にする
+func elementsFp2(t *tower.Tower, s [][2]string) []*tower.Fp2 {
+	e := make([]*tower.Fp2, len(s))
+	for i := range s {
+		e[i] = t.NewFp2(new(big.Int).SetString(s[i][0]), new(big.Int).SetString(s[i][1]))
+	}
+	return e
+}
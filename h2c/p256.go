@@ -0,0 +1,31 @@
+package h2c
+
+import (
+	"crypto/sha256"
+
+	"github.com/convto/mycrypto/big"
+	"github.com/convto/mycrypto/ec"
+	"github.com/convto/mycrypto/field"
+)
+
+// P256 は P256_XMD:SHA-256_SSWU_RO_ と P256_XMD:SHA-256_SSWU_NU_ の suite を dst で返します
+// HashToCurve が前者、EncodeToCurve が後者にあたります
+// P-256 は a, b がともに0でないので、RFC 9380 8.2 に従って曲線そのものに Z = -10 の SSWU を使います
+// 余因子は1なので clear_cofactor は何もしません
+func P256(dst []byte) *Hasher {
+	c := ec.P256()
+	f := c.Field()
+	a, b, z := f.NewElement(c.A()), f.NewElement(c.B()), f.NewElement(big.NewInt(-10))
+	return &Hasher{
+		curve: c,
+		e:     XMD(sha256.New, dst),
+		k:     128,
+		mapToCurve: func(u *field.Element) *ec.Point {
+			x, y := sswu(u, a, b, z)
+			return point(c, x, y)
+		},
+		clearCofactor: func(p *ec.Point) *ec.Point {
+			return p
+		},
+	}
+}
@@ -0,0 +1,9 @@
+package h2c
+
+import (
+	"testing"
+)
+
+func TestP256(t *testing.T) {
+	testHasher(t, "P256_XMD-SHA-256_SSWU", P256)
+}
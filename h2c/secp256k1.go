@@ -0,0 +1,66 @@
+package h2c
+
+import (
+	"crypto/sha256"
+
+	"github.com/convto/mycrypto/big"
+	"github.com/convto/mycrypto/ec"
+	"github.com/convto/mycrypto/field"
+)
+
+// secp256k1 の suite の定数は RFC 9380 8.7 と Appendix E.1 による
+var (
+	// a = 0 の secp256k1 には SSWU を直接使えないので、補助曲線 E': y^2 = x^3 + A'x + B' の上で SSWU を使い、3-isogeny で写す
+	secp256k1A   = ec.Secp256k1().Field().NewElement(new(big.Int).SetString("0x3f8731abdd661adca08a5558f0f5d272e953d363cb6f0e5d405447c01a444533"))
+	secp256k1B   = ec.Secp256k1().Field().NewElement(big.NewInt(1771))
+	secp256k1Z   = ec.Secp256k1().Field().NewElement(big.NewInt(-11))
+	secp256k1Iso = newIsogeny(
+		ec.Secp256k1().Field(),
+		[]string{
+			"0x8e38e38e38e38e38e38e38e38e38e38e38e38e38e38e38e38e38e38daaaaa8c7",
+			"0x07d3d4c80bc321d5b9f315cea7fd44c5d595d2fc0bf63b92dfff1044f17c6581",
+			"0x534c328d23f234e6e2a413deca25caece4506144037c40314ecbd0b53d9dd262",
+			"0x8e38e38e38e38e38e38e38e38e38e38e38e38e38e38e38e38e38e38daaaaa88c",
+		},
+		[]string{
+			"0xd35771193d94918a9ca34ccbb7b640dd86cd409542f8487d9fe6b745781eb49b",
+			"0xedadc6f64383dc1df7c4b2d51b54225406d36b641f5e41bbc52a56612a8c6d14",
+			"0x01",
+		},
+		[]string{
+			"0x4bda12f684bda12f684bda12f684bda12f684bda12f684bda12f684b8e38e23c",
+			"0xc75e0c32d5cb7c0fa9d0a54b12a0a6d5647ab046d686da6fdffc90fc201d71a3",
+			"0x29a6194691f91a73715209ef6512e576722830a201be2018a765e85a9ecee931",
+			"0x2f684bda12f684bda12f684bda12f684bda12f684bda12f684bda12f38e38d84",
+		},
+		[]string{
+			"0xfffffffffffffffffffffffffffffffffffffffffffffffffffffffefffff93b",
+			"0x7a06534bb8bdb49fd5e9e6632722c2989467c1bfc8e8d978dfb425d2685c2573",
+			"0x6484aa716545ca2cf3a70c3fa8fe337e0a3d21162f0d6299a7bf8192bfd2a76f",
+			"0x01",
+		},
+	)
+)
+
+// Secp256k1 は secp256k1_XMD:SHA-256_SSWU_RO_ と secp256k1_XMD:SHA-256_SSWU_NU_ の suite を dst で返します
+// HashToCurve が前者、EncodeToCurve が後者にあたります
+// 余因子は1なので clear_cofactor は何もしません
+func Secp256k1(dst []byte) *Hasher {
+	c := ec.Secp256k1()
+	return &Hasher{
+		curve: c,
+		e:     XMD(sha256.New, dst),
+		k:     128,
+		mapToCurve: func(u *field.Element) *ec.Point {
+			x, y := sswu(u, secp256k1A, secp256k1B, secp256k1Z)
+			x, y, ok := secp256k1Iso.apply(x, y)
+			if !ok {
+				return c.Infinity()
+			}
+			return point(c, x, y)
+		},
+		clearCofactor: func(p *ec.Point) *ec.Point {
+			return p
+		},
+	}
+}
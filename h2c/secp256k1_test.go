@@ -0,0 +1,9 @@
+package h2c
+
+import (
+	"testing"
+)
+
+func TestSecp256k1(t *testing.T) {
+	testHasher(t, "secp256k1_XMD-SHA-256_SSWU", Secp256k1)
+}
@@ -0,0 +1,68 @@
+package h2c
+
+import (
+	"github.com/convto/mycrypto/field"
+	"github.com/convto/mycrypto/tower"
+)
+
+// sswu は RFC 9380 6.6.2 の Simplified SWU で、u を曲線 y^2 = x^3 + ax + b の点 (x, y) に写す
+// a, b は0でなく、z は 6.6.2 の条件を満たす非平方剰余とする
+func sswu(u, a, b, z *field.Element) (x, y *field.Element) {
+	one := u.Field().One()
+	// tv1 = Z u^2, tv2 = Z^2 u^4 + Z u^2
+	tv1 := z.Mul(u.Square())
+	tv2 := tv1.Square().Add(tv1)
+	// x1 = (-b/a) (1 + 1/tv2)、tv2 = 0 のときは x1 = b/(Z a)
+	var x1 *field.Element
+	if tv2.IsZero() {
+		x1 = b.Mul(z.Mul(a).Inv())
+	} else {
+		x1 = b.Neg().Mul(a.Inv()).Mul(one.Add(tv2.Inv()))
+	}
+	// g(x1) が平方剰余でなければ g(Z u^2 x1) が平方剰余になる
+	x = x1
+	y, ok := x.Square().Add(a).Mul(x).Add(b).Sqrt()
+	if !ok {
+		x = tv1.Mul(x1)
+		y, _ = x.Square().Add(a).Mul(x).Add(b).Sqrt()
+	}
+	if sgn0(u) != sgn0(y) {
+		y = y.Neg()
+	}
+	return x, y
+}
+
+// sswuFp2 は塔 t の Fp2 上の曲線に対する sswu
+func sswuFp2(t *tower.Tower, u, a, b, z *tower.Fp2) (x, y *tower.Fp2) {
+	tv1 := z.Mul(u.Square())
+	tv2 := tv1.Square().Add(tv1)
+	var x1 *tower.Fp2
+	if tv2.IsZero() {
+		x1 = b.Mul(z.Mul(a).Inv())
+	} else {
+		x1 = b.Neg().Mul(a.Inv()).Mul(t.OneFp2().Add(tv2.Inv()))
+	}
+	x = x1
+	y, ok := x.Square().Add(a).Mul(x).Add(b).Sqrt()
+	if !ok {
+		x = tv1.Mul(x1)
+		y, _ = x.Square().Add(a).Mul(x).Add(b).Sqrt()
+	}
+	if sgn0Fp2(u) != sgn0Fp2(y) {
+		y = y.Neg()
+	}
+	return x, y
+}
+
+// sgn0 は RFC 9380 4.1 の sgn0 で、x の偶奇を返す
+func sgn0(x *field.Element) uint {
+	return x.Int().Bit(0)
+}
+
+// sgn0Fp2 は x = x0 + x1*u の sgn0 で、x0 が0でなければ x0 の、0なら x1 の偶奇を返す
+func sgn0Fp2(x *tower.Fp2) uint {
+	if x.A0().IsZero() {
+		return sgn0(x.A1())
+	}
+	return sgn0(x.A0())
+}
@@ -0,0 +1,208 @@
+// Package paillier は Paillier 暗号を提供します
+// 暗号文の積が平文の和の暗号文になる加法準同型性を持ち、暗号化したままの集計に使えます
+// 生成元には g = n + 1 を使い、g^m = 1 + mn mod n^2 なので暗号化の累乗は r^n の1回で済みます
+package paillier
+
+import (
+	"errors"
+	"io"
+
+	"github.com/convto/mycrypto/big"
+)
+
+var (
+	// ErrMessageOutOfRange は平文が 0 <= m < n の範囲にないことを表します
+	ErrMessageOutOfRange = errors.New("paillier: message out of range")
+	// ErrInvalidCiphertext は暗号文が Z_{n^2}* の元でないことを表します
+	ErrInvalidCiphertext = errors.New("paillier: invalid ciphertext")
+	// ErrInvalidKey は素数 p, q から鍵を作れないことを表します
+	ErrInvalidKey = errors.New("paillier: invalid private key")
+)
+
+// PublicKey は Paillier の公開鍵 n = pq です
+type PublicKey struct {
+	N *big.Int
+}
+
+// G は生成元 g = n + 1 を返します
+func (pub *PublicKey) G() *big.Int {
+	return big.Add(pub.N, big.NewInt(1))
+}
+
+// nsquare は n^2 を返す
+func (pub *PublicKey) nsquare() *big.Int {
+	return big.Mul(pub.N, pub.N)
+}
+
+// PrivateKey は Paillier の秘密鍵で、n の素因数 p, q です
+type PrivateKey struct {
+	PublicKey
+	P *big.Int
+	Q *big.Int
+	// Precomputed は Precompute で計算する CRT のパラメータで、NewPrivateKey と GenerateKey は計算済みの鍵を返します
+	Precomputed PrecomputedValues
+}
+
+// PrecomputedValues は CRT による復号に使う値です
+type PrecomputedValues struct {
+	// PP = p^2, QQ = q^2
+	PP, QQ *big.Int
+	// Hp = L_p(g^(p-1) mod p^2)^-1 mod p, Hq = L_q(g^(q-1) mod q^2)^-1 mod q
+	Hp, Hq *big.Int
+	// Qinv = q^-1 mod p
+	Qinv *big.Int
+}
+
+// GenerateKey は r から読み込んだ乱数で、法 n のビット長が bits の鍵を生成します
+func GenerateKey(r io.Reader, bits int) (*PrivateKey, error) {
+	if bits < 32 {
+		return nil, errors.New("paillier: GenerateKey: too few bits")
+	}
+	for {
+		p, err := big.Prime(r, bits/2)
+		if err != nil {
+			return nil, err
+		}
+		q, err := big.Prime(r, bits-bits/2)
+		if err != nil {
+			return nil, err
+		}
+		priv, err := NewPrivateKey(p, q)
+		if err == ErrInvalidKey {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if priv.N.BitLen() != bits {
+			continue
+		}
+		return priv, nil
+	}
+}
+
+// NewPrivateKey は素数 p, q から秘密鍵を作ります
+// p = q のときや gcd(pq, (p-1)(q-1)) != 1 のときは ErrInvalidKey を返します
+// (p, q が同じビット長なら後者の条件は常に満たされます)
+func NewPrivateKey(p, q *big.Int) (*PrivateKey, error) {
+	one := big.NewInt(1)
+	if big.Cmp(p, q) == 0 {
+		return nil, ErrInvalidKey
+	}
+	n := big.Mul(p, q)
+	phi := big.Mul(big.Sub(p, one), big.Sub(q, one))
+	if d, _, _ := big.GCD(n, phi); big.Cmp(d, one) != 0 {
+		return nil, ErrInvalidKey
+	}
+	priv := &PrivateKey{PublicKey: PublicKey{N: n}, P: p, Q: q}
+	priv.Precompute()
+	return priv, nil
+}
+
+// Precompute は CRT による復号に使う値を計算します
+func (priv *PrivateKey) Precompute() {
+	p, q := priv.P, priv.Q
+	pre := &priv.Precomputed
+	pre.PP = big.Mul(p, p)
+	pre.QQ = big.Mul(q, q)
+	g := priv.G()
+	pre.Hp = big.ModInverse(lFunc(big.Exp(g, big.Sub(p, big.NewInt(1)), pre.PP), p), p)
+	pre.Hq = big.ModInverse(lFunc(big.Exp(g, big.Sub(q, big.NewInt(1)), pre.QQ), q), q)
+	pre.Qinv = big.ModInverse(q, p)
+}
+
+// Encrypt は r から読み込んだ乱数 r' ∈ Z_n* で平文 m を c = g^m * r'^n mod n^2 に暗号化します
+// m が 0 <= m < n の範囲にないときは ErrMessageOutOfRange を返します
+func Encrypt(r io.Reader, pub *PublicKey, m *big.Int) (*big.Int, error) {
+	c, _, err := encrypt(r, pub, m)
+	return c, err
+}
+
+// Decrypt は暗号文 c を復号します
+// p と q それぞれについて m_p = L_p(c^(p-1) mod p^2) * h_p mod p を求め、CRT で m mod n に戻します
+// c が Z_{n^2}* の元でないときは ErrInvalidCiphertext を返します
+func Decrypt(priv *PrivateKey, c *big.Int) (*big.Int, error) {
+	if !isUnit(c, priv.N, priv.nsquare()) {
+		return nil, ErrInvalidCiphertext
+	}
+	p, q := priv.P, priv.Q
+	pre := &priv.Precomputed
+	one := big.NewInt(1)
+	mp := big.Mod(big.Mul(lFunc(big.Exp(big.Mod(c, pre.PP), big.Sub(p, one), pre.PP), p), pre.Hp), p)
+	mq := big.Mod(big.Mul(lFunc(big.Exp(big.Mod(c, pre.QQ), big.Sub(q, one), pre.QQ), q), pre.Hq), q)
+	// m = mq + q * (qinv * (mp - mq) mod p)
+	h := big.Mod(big.Mul(pre.Qinv, big.Sub(mp, mq)), p)
+	return big.Add(mq, big.Mul(q, h)), nil
+}
+
+// Add は2つの暗号文の積 c1 * c2 mod n^2 を返します
+// 結果は平文の和 m1 + m2 mod n の暗号文になります
+func Add(pub *PublicKey, c1, c2 *big.Int) *big.Int {
+	return big.Mod(big.Mul(c1, c2), pub.nsquare())
+}
+
+// ScalarMul は暗号文の k 乗 c^k mod n^2 を返します
+// 結果は平文の k 倍 k * m mod n の暗号文になり、k が負のときは k mod n として扱います
+func ScalarMul(pub *PublicKey, c, k *big.Int) *big.Int {
+	return big.Exp(c, big.Mod(k, pub.N), pub.nsquare())
+}
+
+// Rerandomize は r から読み込んだ乱数 r' ∈ Z_n* で c * r'^n mod n^2 を返します
+// 0 の新しい暗号文を掛けるので、同じ平文の暗号文のまま元の暗号文と結びつけられなくなります
+func Rerandomize(r io.Reader, pub *PublicKey, c *big.Int) (*big.Int, error) {
+	zero, _, err := encrypt(r, pub, big.Zero)
+	if err != nil {
+		return nil, err
+	}
+	return Add(pub, c, zero), nil
+}
+
+// encrypt は m を暗号化し、使った乱数 r' も返す
+func encrypt(r io.Reader, pub *PublicKey, m *big.Int) (c, nonce *big.Int, err error) {
+	n := pub.N
+	if big.Cmp(m, big.Zero) < 0 || big.Cmp(m, n) >= 0 {
+		return nil, nil, ErrMessageOutOfRange
+	}
+	nonce, err = randUnit(r, n)
+	if err != nil {
+		return nil, nil, err
+	}
+	return encryptWithNonce(pub, m, nonce), nonce, nil
+}
+
+// encryptWithNonce は乱数 nonce で c = (1 + mn) * nonce^n mod n^2 を求める
+func encryptWithNonce(pub *PublicKey, m, nonce *big.Int) *big.Int {
+	n := pub.N
+	nn := pub.nsquare()
+	gm := big.Add(big.Mul(m, n), big.NewInt(1))
+	return big.Mod(big.Mul(gm, big.Exp(nonce, n, nn)), nn)
+}
+
+// lFunc は L(x) = (x - 1) / d を返す
+func lFunc(x, d *big.Int) *big.Int {
+	l, _ := big.Div(big.Sub(x, big.NewInt(1)), d)
+	return l
+}
+
+// randUnit は r から読み込んだ乱数で、n と互いに素な 1 <= x < n の一様な整数を返す
+func randUnit(r io.Reader, n *big.Int) (*big.Int, error) {
+	for {
+		x, err := big.RandNonZeroInt(r, n)
+		if err != nil {
+			return nil, err
+		}
+		if isUnit(x, n, n) {
+			return x, nil
+		}
+	}
+}
+
+// isUnit は 0 < x < m かつ gcd(x, n) = 1 かどうかを判定する
+// m が n の累乗のときは、x が Z_m* の元かどうかの判定になる
+func isUnit(x, n, m *big.Int) bool {
+	if big.Cmp(x, big.Zero) <= 0 || big.Cmp(x, m) >= 0 {
+		return false
+	}
+	d, _, _ := big.GCD(x, n)
+	return big.Cmp(d, big.NewInt(1)) == 0
+}
@@ -0,0 +1,217 @@
+package paillier
+
+import (
+	"crypto/rand"
+	"errors"
+	stdbig "math/big"
+	"testing"
+
+	"github.com/convto/mycrypto/big"
+)
+
+// testKey は 1024 ビットの法の鍵を生成する
+func testKey(t *testing.T) *PrivateKey {
+	priv, err := GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return priv
+}
+
+func TestGenerateKey(t *testing.T) {
+	priv := testKey(t)
+	if got := priv.N.BitLen(); got != 1024 {
+		t.Errorf("N.BitLen() = %v, want 1024", got)
+	}
+	if big.Cmp(big.Mul(priv.P, priv.Q), priv.N) != 0 {
+		t.Errorf("P * Q != N")
+	}
+}
+
+func TestNewPrivateKey_invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		p, q int64
+	}{
+		{name: "p = q", p: 1009, q: 1009},
+		// q - 1 = 2 * 3 * 5 * 7 なので p = 7 は (p-1)(q-1) を割り切る
+		{name: "p divides q - 1", p: 7, q: 211},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewPrivateKey(big.NewInt(tt.p), big.NewInt(tt.q)); !errors.Is(err, ErrInvalidKey) {
+				t.Errorf("NewPrivateKey() error = %v, want %v", err, ErrInvalidKey)
+			}
+		})
+	}
+}
+
+func TestEncrypt(t *testing.T) {
+	priv := testKey(t)
+	n := priv.N
+	tests := []struct {
+		name    string
+		m       *big.Int
+		wantErr error
+	}{
+		{name: "0", m: big.NewInt(0)},
+		{name: "1", m: big.NewInt(1)},
+		{name: "n - 1", m: big.Sub(n, big.NewInt(1))},
+		{name: "n", m: n, wantErr: ErrMessageOutOfRange},
+		{name: "-1", m: big.NewInt(-1), wantErr: ErrMessageOutOfRange},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := Encrypt(rand.Reader, &priv.PublicKey, tt.m)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Encrypt() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			got, err := Decrypt(priv, c)
+			if err != nil {
+				t.Fatalf("Decrypt() error = %v", err)
+			}
+			if big.Cmp(got, tt.m) != 0 {
+				t.Errorf("Decrypt() = %v, want %v", got, tt.m)
+			}
+		})
+	}
+}
+
+// TestDecrypt_textbook は CRT を使った復号が m = L(c^λ mod n^2) * μ mod n と一致することを math/big で確かめる
+func TestDecrypt_textbook(t *testing.T) {
+	priv := testKey(t)
+	m, err := big.RandInt(rand.Reader, priv.N)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := Encrypt(rand.Reader, &priv.PublicKey, m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := Decrypt(priv, c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	one := stdbig.NewInt(1)
+	n, p, q := toStd(priv.N), toStd(priv.P), toStd(priv.Q)
+	nn := new(stdbig.Int).Mul(n, n)
+	pm1, qm1 := new(stdbig.Int).Sub(p, one), new(stdbig.Int).Sub(q, one)
+	gcd := new(stdbig.Int).GCD(nil, nil, pm1, qm1)
+	lambda := new(stdbig.Int).Div(new(stdbig.Int).Mul(pm1, qm1), gcd)
+	l := func(x *stdbig.Int) *stdbig.Int {
+		return new(stdbig.Int).Div(new(stdbig.Int).Sub(x, one), n)
+	}
+	g := new(stdbig.Int).Add(n, one)
+	mu := new(stdbig.Int).ModInverse(l(new(stdbig.Int).Exp(g, lambda, nn)), n)
+	want := new(stdbig.Int).Mod(new(stdbig.Int).Mul(l(new(stdbig.Int).Exp(toStd(c), lambda, nn)), mu), n)
+	if toStd(got).Cmp(want) != 0 {
+		t.Errorf("Decrypt() = %v, want %v", got, want)
+	}
+}
+
+func TestDecrypt_invalid(t *testing.T) {
+	priv := testKey(t)
+	nn := big.Mul(priv.N, priv.N)
+	tests := []struct {
+		name string
+		c    *big.Int
+	}{
+		{name: "0", c: big.NewInt(0)},
+		{name: "n^2", c: nn},
+		{name: "multiple of p", c: big.Mul(priv.P, big.NewInt(12345))},
+		{name: "negative", c: big.NewInt(-1)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Decrypt(priv, tt.c); !errors.Is(err, ErrInvalidCiphertext) {
+				t.Errorf("Decrypt() error = %v, want %v", err, ErrInvalidCiphertext)
+			}
+		})
+	}
+}
+
+func TestAdd(t *testing.T) {
+	priv := testKey(t)
+	pub := &priv.PublicKey
+	n := priv.N
+	tests := []struct {
+		name   string
+		m1, m2 *big.Int
+	}{
+		{name: "small", m1: big.NewInt(12345), m2: big.NewInt(67890)},
+		// 和は n を法として折り返す
+		{name: "wrap around", m1: big.Sub(n, big.NewInt(1)), m2: big.NewInt(2)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c1, err := Encrypt(rand.Reader, pub, tt.m1)
+			if err != nil {
+				t.Fatal(err)
+			}
+			c2, err := Encrypt(rand.Reader, pub, tt.m2)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := Decrypt(priv, Add(pub, c1, c2))
+			if err != nil {
+				t.Fatalf("Decrypt() error = %v", err)
+			}
+			if want := big.Mod(big.Add(tt.m1, tt.m2), n); big.Cmp(got, want) != 0 {
+				t.Errorf("Decrypt(Add()) = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestScalarMul(t *testing.T) {
+	priv := testKey(t)
+	pub := &priv.PublicKey
+	n := priv.N
+	m := big.NewInt(1000)
+	c, err := Encrypt(rand.Reader, pub, m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, k := range []*big.Int{big.NewInt(0), big.NewInt(1), big.NewInt(37), big.NewInt(-1), n} {
+		got, err := Decrypt(priv, ScalarMul(pub, c, k))
+		if err != nil {
+			t.Fatalf("Decrypt() error = %v", err)
+		}
+		if want := big.Mod(big.Mul(m, k), n); big.Cmp(got, want) != 0 {
+			t.Errorf("Decrypt(ScalarMul(%v)) = %v, want %v", k, got, want)
+		}
+	}
+}
+
+func TestRerandomize(t *testing.T) {
+	priv := testKey(t)
+	pub := &priv.PublicKey
+	m := big.NewInt(42)
+	c, err := Encrypt(rand.Reader, pub, m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	re, err := Rerandomize(rand.Reader, pub, c)
+	if err != nil {
+		t.Fatalf("Rerandomize() error = %v", err)
+	}
+	if big.Cmp(re, c) == 0 {
+		t.Errorf("Rerandomize() did not change the ciphertext")
+	}
+	got, err := Decrypt(priv, re)
+	if err != nil {
+		t.Fatalf("Decrypt() error = %v", err)
+	}
+	if big.Cmp(got, m) != 0 {
+		t.Errorf("Decrypt() = %v, want %v", got, m)
+	}
+}
+
+// toStd は math/big の整数に変換する
+func toStd(x *big.Int) *stdbig.Int {
+	return new(stdbig.Int).SetBytes(x.Bytes())
+}
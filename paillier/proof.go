@@ -0,0 +1,126 @@
+package paillier
+
+import (
+	"crypto/sha256"
+	"errors"
+	"io"
+
+	"github.com/convto/mycrypto/big"
+)
+
+// challengeBits はゼロ知識証明のチャレンジのビット長で、SHA-256 の出力をそのまま使います
+// 健全性のためにはチャレンジが n の素因数より小さくなければならないので、n は 512 ビットより大きい鍵で使います
+const challengeBits = 256
+
+// bitProofDomain は Fiat-Shamir 変換でハッシュする値の先頭につける文字列です
+var bitProofDomain = []byte("paillier bit proof")
+
+// BitProof は暗号文 c が 0 か 1 を暗号化していることの非対話ゼロ知識証明です
+// j = 0, 1 のそれぞれについて u_j = c / g^j が n 乗数であることを示す Σ プロトコルを OR で組み合わせ、Fiat-Shamir 変換したものです
+// 証明者は平文に対応する側だけを正直に、もう一方はチャレンジを先に決めてシミュレートするので、どちらの平文かは漏れません
+type BitProof struct {
+	// A はコミットメント a_j で、Z_{n^2}* の元です
+	A [2]*big.Int
+	// E はチャレンジ e_j で、e_0 + e_1 = H(n, c, a_0, a_1) mod 2^256 を満たします
+	E [2]*big.Int
+	// Z はレスポンス z_j で、z_j^n = a_j * u_j^(e_j) mod n^2 を満たします
+	Z [2]*big.Int
+}
+
+// EncryptBit は r から読み込んだ乱数で 0 か 1 の b を暗号化し、暗号文と、それが 0 か 1 の暗号文であることの証明を返します
+func EncryptBit(r io.Reader, pub *PublicKey, b int) (*big.Int, *BitProof, error) {
+	if b != 0 && b != 1 {
+		return nil, nil, errors.New("paillier: EncryptBit: b must be 0 or 1")
+	}
+	c, nonce, err := encrypt(r, pub, big.NewInt(int64(b)))
+	if err != nil {
+		return nil, nil, err
+	}
+	proof, err := proveBit(r, pub, c, b, nonce)
+	if err != nil {
+		return nil, nil, err
+	}
+	return c, proof, nil
+}
+
+// VerifyBit は proof が c が 0 か 1 の暗号文であることの正しい証明かどうかを判定します
+func VerifyBit(pub *PublicKey, c *big.Int, proof *BitProof) bool {
+	n := pub.N
+	nn := pub.nsquare()
+	if !isUnit(c, n, nn) {
+		return false
+	}
+	bound := big.Exp(big.NewInt(2), big.NewInt(challengeBits), nil)
+	u := bitStatements(pub, c)
+	for j := 0; j < 2; j++ {
+		a, e, z := proof.A[j], proof.E[j], proof.Z[j]
+		if a == nil || e == nil || z == nil {
+			return false
+		}
+		if !isUnit(a, n, nn) || !isUnit(z, n, n) || big.Cmp(e, big.Zero) < 0 || big.Cmp(e, bound) >= 0 {
+			return false
+		}
+		// z_j^n = a_j * u_j^(e_j) mod n^2
+		lhs := big.Exp(z, n, nn)
+		rhs := big.Mod(big.Mul(a, big.Exp(u[j], e, nn)), nn)
+		if big.Cmp(lhs, rhs) != 0 {
+			return false
+		}
+	}
+	e := bitChallenge(pub, c, proof.A[0], proof.A[1])
+	return big.Cmp(big.Mod(big.Add(proof.E[0], proof.E[1]), bound), e) == 0
+}
+
+// proveBit は c = g^b * nonce^n mod n^2 が 0 か 1 の暗号文であることを証明する
+func proveBit(r io.Reader, pub *PublicKey, c *big.Int, b int, nonce *big.Int) (*BitProof, error) {
+	n := pub.N
+	nn := pub.nsquare()
+	bound := big.Exp(big.NewInt(2), big.NewInt(challengeBits), nil)
+	u := bitStatements(pub, c)
+	proof := &BitProof{}
+
+	// 平文でない側 (1 - b) はチャレンジとレスポンスを先に選び、a = z^n * u^(-e) とする
+	sim := 1 - b
+	e, err := big.RandInt(r, bound)
+	if err != nil {
+		return nil, err
+	}
+	z, err := randUnit(r, n)
+	if err != nil {
+		return nil, err
+	}
+	uinv := big.ModInverse(u[sim], nn)
+	proof.A[sim] = big.Mod(big.Mul(big.Exp(z, n, nn), big.Exp(uinv, e, nn)), nn)
+	proof.E[sim], proof.Z[sim] = e, z
+
+	// 平文の側 b は a = rho^n をコミットし、残りのチャレンジ e_b = e - e_sim に z = rho * nonce^(e_b) で応答する
+	rho, err := randUnit(r, n)
+	if err != nil {
+		return nil, err
+	}
+	proof.A[b] = big.Exp(rho, n, nn)
+	ch := bitChallenge(pub, c, proof.A[0], proof.A[1])
+	proof.E[b] = big.Mod(big.Sub(ch, e), bound)
+	proof.Z[b] = big.Mod(big.Mul(rho, big.Exp(nonce, proof.E[b], n)), n)
+	return proof, nil
+}
+
+// bitStatements は n 乗数であることを示す対象 u_0 = c, u_1 = c / g mod n^2 を返す
+// g = 1 + n の逆元は 1 - n mod n^2 になる
+func bitStatements(pub *PublicKey, c *big.Int) [2]*big.Int {
+	nn := pub.nsquare()
+	ginv := big.Sub(big.Add(nn, big.NewInt(1)), pub.N)
+	return [2]*big.Int{c, big.Mod(big.Mul(c, ginv), nn)}
+}
+
+// bitChallenge は H(domain || n || c || a_0 || a_1) を整数として返す
+// 各値は n^2 のバイト長に揃えてから連結する
+func bitChallenge(pub *PublicKey, c, a0, a1 *big.Int) *big.Int {
+	l := (pub.nsquare().BitLen() + 7) / 8
+	h := sha256.New()
+	h.Write(bitProofDomain)
+	for _, v := range []*big.Int{pub.N, c, a0, a1} {
+		h.Write(v.FillBytes(make([]byte, l)))
+	}
+	return new(big.Int).SetBytes(h.Sum(nil))
+}
@@ -0,0 +1,102 @@
+package paillier
+
+import (
+	"crypto/rand"
+	"testing"
+
+	"github.com/convto/mycrypto/big"
+)
+
+// TestEncryptBit は 0 か 1 の票を証明つきで暗号化し、暗号文のまま集計した合計を復号できることを確かめる
+func TestEncryptBit(t *testing.T) {
+	priv := testKey(t)
+	pub := &priv.PublicKey
+	votes := []int{1, 0, 0, 1, 1}
+	var sum *big.Int
+	want := 0
+	for _, b := range votes {
+		c, proof, err := EncryptBit(rand.Reader, pub, b)
+		if err != nil {
+			t.Fatalf("EncryptBit() error = %v", err)
+		}
+		if !VerifyBit(pub, c, proof) {
+			t.Errorf("VerifyBit() = false for b = %v, want true", b)
+		}
+		if sum == nil {
+			sum = c
+		} else {
+			sum = Add(pub, sum, c)
+		}
+		want += b
+	}
+	got, err := Decrypt(priv, sum)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if big.Cmp(got, big.NewInt(int64(want))) != 0 {
+		t.Errorf("Decrypt() = %v, want %v", got, want)
+	}
+
+	if _, _, err := EncryptBit(rand.Reader, pub, 2); err == nil {
+		t.Errorf("EncryptBit(2) error = nil, want error")
+	}
+}
+
+func TestVerifyBit_invalid(t *testing.T) {
+	priv := testKey(t)
+	pub := &priv.PublicKey
+	c, proof, err := EncryptBit(rand.Reader, pub, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, _, err := EncryptBit(rand.Reader, pub, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 2 の暗号文について、平文が 1 だと偽って証明を作る
+	two, nonce, err := encrypt(rand.Reader, pub, big.NewInt(2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	forged, err := proveBit(rand.Reader, pub, two, 1, nonce)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	modify := func(f func(p *BitProof)) *BitProof {
+		p := *proof
+		f(&p)
+		return &p
+	}
+	one := big.NewInt(1)
+	tests := []struct {
+		name  string
+		c     *big.Int
+		proof *BitProof
+	}{
+		{name: "other ciphertext", c: other, proof: proof},
+		{name: "encrypts 2", c: two, proof: forged},
+		{name: "swapped branches", c: c, proof: modify(func(p *BitProof) {
+			p.A[0], p.A[1] = p.A[1], p.A[0]
+			p.E[0], p.E[1] = p.E[1], p.E[0]
+			p.Z[0], p.Z[1] = p.Z[1], p.Z[0]
+		})},
+		{name: "tampered challenge", c: c, proof: modify(func(p *BitProof) { p.E[0] = big.Add(p.E[0], one) })},
+		{name: "tampered response", c: c, proof: modify(func(p *BitProof) { p.Z[1] = big.Add(p.Z[1], one) })},
+		{name: "tampered commitment", c: c, proof: modify(func(p *BitProof) { p.A[1] = big.Add(p.A[1], one) })},
+		{name: "challenge out of range", c: c, proof: modify(func(p *BitProof) {
+			// e_0 に 2^256 を足すと和は同じだが範囲外になる
+			p.E[0] = big.Add(p.E[0], big.Exp(big.NewInt(2), big.NewInt(challengeBits), nil))
+		})},
+		{name: "missing response", c: c, proof: modify(func(p *BitProof) { p.Z[0] = nil })},
+		{name: "ciphertext not a unit", c: big.Mul(priv.P, big.NewInt(3)), proof: proof},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if VerifyBit(pub, tt.c, tt.proof) {
+				t.Errorf("VerifyBit() = true, want false")
+			}
+		})
+	}
+}
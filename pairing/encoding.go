@@ -0,0 +1,257 @@
+package pairing
+
+import (
+	"errors"
+
+	"github.com/convto/mycrypto/big"
+	"github.com/convto/mycrypto/ec"
+	"github.com/convto/mycrypto/field"
+	"github.com/convto/mycrypto/tower"
+)
+
+var (
+	// ErrInvalidEncoding は点のバイト表現が ZCash の形式でないことを表します
+	ErrInvalidEncoding = errors.New("pairing: invalid point encoding")
+	// ErrNotInSubgroup は点が位数 r の部分群に含まれないことを表します
+	ErrNotInSubgroup = errors.New("pairing: point is not in the subgroup")
+)
+
+// ZCash の形式で先頭バイトの上位3ビットに置くフラグ
+const (
+	flagCompressed = 0x80
+	flagInfinity   = 0x40
+	// flagLargest は y が -y より辞書式順序で大きいことを表す
+	flagLargest = 0x20
+	flagMask    = flagCompressed | flagInfinity | flagLargest
+)
+
+// G1Bytes は G1 の点 p を ZCash の非圧縮形式 x || y で返します
+// ZCash の形式は BLS12-381 のためのもので、座標の最上位に3ビットの空きがない曲線ではpanicします
+func (c *Curve) G1Bytes(p *ec.Point) []byte {
+	l := c.byteLen()
+	buf := make([]byte, 2*l)
+	if p.IsInfinity() {
+		buf[0] = flagInfinity
+		return buf
+	}
+	x, y := p.Affine()
+	x.FillBytes(buf[:l])
+	y.FillBytes(buf[l:])
+	return buf
+}
+
+// G1BytesCompressed は G1 の点 p を ZCash の圧縮形式で返します
+// x の先頭バイトに圧縮のフラグと y の符号を表すフラグを重ねます
+func (c *Curve) G1BytesCompressed(p *ec.Point) []byte {
+	l := c.byteLen()
+	buf := make([]byte, l)
+	if p.IsInfinity() {
+		buf[0] = flagCompressed | flagInfinity
+		return buf
+	}
+	x, y := p.Affine()
+	x.FillBytes(buf)
+	buf[0] |= flagCompressed
+	if c.largest(y) {
+		buf[0] |= flagLargest
+	}
+	return buf
+}
+
+// G1SetBytes は ZCash の圧縮形式または非圧縮形式のバイト列から G1 の点を読み込みます
+// 形式が正しくないときは ErrInvalidEncoding を、曲線上にないときは ErrNotOnCurve を、
+// G1 に含まれないときは ErrNotInSubgroup を返します
+func (c *Curve) G1SetBytes(b []byte) (*ec.Point, error) {
+	l := c.byteLen()
+	flags, b, err := c.splitFlags(b, l, 2*l)
+	if err != nil {
+		return nil, err
+	}
+	if flags&flagInfinity != 0 {
+		return c.g1.Infinity(), nil
+	}
+
+	x, err := c.coordinate(b[:l])
+	if err != nil {
+		return nil, err
+	}
+	var p *ec.Point
+	if flags&flagCompressed != 0 {
+		y, ok := x.Square().Mul(x).Add(c.t.Fp().NewElement(c.g1.B())).Sqrt()
+		if !ok {
+			return nil, ErrNotOnCurve
+		}
+		if c.largest(y.Int()) != (flags&flagLargest != 0) {
+			y = y.Neg()
+		}
+		p, err = c.g1.NewPoint(x.Int(), y.Int())
+	} else {
+		var y *field.Element
+		if y, err = c.coordinate(b[l:]); err != nil {
+			return nil, err
+		}
+		p, err = c.g1.NewPoint(x.Int(), y.Int())
+	}
+	if err != nil {
+		return nil, ErrNotOnCurve
+	}
+	if !c.InG1(p) {
+		return nil, ErrNotInSubgroup
+	}
+	return p, nil
+}
+
+// Bytes は q を ZCash の非圧縮形式 x1 || x0 || y1 || y0 で返します
+// Fp2 の元 a0 + a1*u は u の係数 a1 を先に書きます
+func (q *G2) Bytes() []byte {
+	c := q.c
+	l := c.byteLen()
+	buf := make([]byte, 4*l)
+	if q.IsInfinity() {
+		buf[0] = flagInfinity
+		return buf
+	}
+	x, y := q.Affine()
+	putFp2(buf[:2*l], x, l)
+	putFp2(buf[2*l:], y, l)
+	return buf
+}
+
+// BytesCompressed は q を ZCash の圧縮形式 x1 || x0 で返します
+func (q *G2) BytesCompressed() []byte {
+	c := q.c
+	l := c.byteLen()
+	buf := make([]byte, 2*l)
+	if q.IsInfinity() {
+		buf[0] = flagCompressed | flagInfinity
+		return buf
+	}
+	x, y := q.Affine()
+	putFp2(buf, x, l)
+	buf[0] |= flagCompressed
+	if c.largestFp2(y) {
+		buf[0] |= flagLargest
+	}
+	return buf
+}
+
+// G2SetBytes は ZCash の圧縮形式または非圧縮形式のバイト列から G2 の点を読み込みます
+// 形式が正しくないときは ErrInvalidEncoding を、捻り曲線上にないときは ErrNotOnCurve を、
+// G2 に含まれないときは ErrNotInSubgroup を返します
+func (c *Curve) G2SetBytes(b []byte) (*G2, error) {
+	l := c.byteLen()
+	flags, b, err := c.splitFlags(b, 2*l, 4*l)
+	if err != nil {
+		return nil, err
+	}
+	if flags&flagInfinity != 0 {
+		return c.G2Infinity(), nil
+	}
+
+	x, err := c.coordinateFp2(b[:2*l])
+	if err != nil {
+		return nil, err
+	}
+	var y *tower.Fp2
+	if flags&flagCompressed != 0 {
+		var ok bool
+		if y, ok = c.rhs2(x).Sqrt(); !ok {
+			return nil, ErrNotOnCurve
+		}
+		if c.largestFp2(y) != (flags&flagLargest != 0) {
+			y = y.Neg()
+		}
+	} else if y, err = c.coordinateFp2(b[2*l:]); err != nil {
+		return nil, err
+	}
+	q, err := c.NewG2(x, y)
+	if err != nil {
+		return nil, err
+	}
+	if !c.InG2(q) {
+		return nil, ErrNotInSubgroup
+	}
+	return q, nil
+}
+
+// byteLen は座標のバイト表現の長さを返す
+func (c *Curve) byteLen() int {
+	p := c.t.Fp().Modulus()
+	l := c.t.Fp().ByteLen()
+	if p.BitLen() > 8*l-3 {
+		panic("pairing: ZCash encoding needs three spare bits in the field element")
+	}
+	return l
+}
+
+// splitFlags は先頭バイトのフラグを取り除いたバイト列を返す
+// 長さが圧縮形式の compressedLen か非圧縮形式の uncompressedLen でフラグと矛盾しないことを確かめる
+func (c *Curve) splitFlags(b []byte, compressedLen, uncompressedLen int) (byte, []byte, error) {
+	if len(b) == 0 {
+		return 0, nil, ErrInvalidEncoding
+	}
+	flags := b[0] & flagMask
+	compressed := flags&flagCompressed != 0
+	if compressed && len(b) != compressedLen || !compressed && len(b) != uncompressedLen {
+		return 0, nil, ErrInvalidEncoding
+	}
+	b = append([]byte{b[0] &^ flagMask}, b[1:]...)
+
+	if flags&flagInfinity != 0 {
+		// 無限遠点では符号のフラグを含めて残りがすべて0でなければならない
+		if flags&flagLargest != 0 {
+			return 0, nil, ErrInvalidEncoding
+		}
+		for _, v := range b {
+			if v != 0 {
+				return 0, nil, ErrInvalidEncoding
+			}
+		}
+	} else if !compressed && flags&flagLargest != 0 {
+		return 0, nil, ErrInvalidEncoding
+	}
+	return flags, b, nil
+}
+
+// coordinate は p 未満のビッグエンディアンの整数を Fp の元として読み込む
+func (c *Curve) coordinate(b []byte) (*field.Element, error) {
+	x, err := c.t.Fp().SetBytes(b)
+	if err != nil {
+		return nil, ErrInvalidEncoding
+	}
+	return x, nil
+}
+
+// coordinateFp2 は a1 || a0 の順に並んだ Fp2 の元を読み込む
+func (c *Curve) coordinateFp2(b []byte) (*tower.Fp2, error) {
+	l := len(b) / 2
+	a1, err := c.coordinate(b[:l])
+	if err != nil {
+		return nil, err
+	}
+	a0, err := c.coordinate(b[l:])
+	if err != nil {
+		return nil, err
+	}
+	return c.t.Fp2FromElements(a0, a1), nil
+}
+
+// putFp2 は x を a1 || a0 の順に buf に書き込む
+func putFp2(buf []byte, x *tower.Fp2, l int) {
+	x.A1().Int().FillBytes(buf[:l])
+	x.A0().Int().FillBytes(buf[l : 2*l])
+}
+
+// largest は y > (p - 1)/2、すなわち y が -y より大きいかどうかを判定する
+func (c *Curve) largest(y *big.Int) bool {
+	half, _ := big.Div(c.t.Fp().Modulus(), big.NewInt(2))
+	return big.Cmp(y, half) > 0
+}
+
+// largestFp2 は y = y0 + y1*u を (y1, y0) の辞書式順序で -y と比べる
+func (c *Curve) largestFp2(y *tower.Fp2) bool {
+	if !y.A1().IsZero() {
+		return c.largest(y.A1().Int())
+	}
+	return c.largest(y.A0().Int())
+}
@@ -0,0 +1,198 @@
+package pairing
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/convto/mycrypto/big"
+)
+
+// ZCash の形式のテストベクターは i = 0, 1, ..., 999 について iG をつなげたもの
+// すべて読むと G2 の平方根の計算に時間がかかるので、先頭の一部だけを使う
+const serializationVectors = 50
+
+func readVectors(t *testing.T, name string, size int) [][]byte {
+	t.Helper()
+	data, err := os.ReadFile("testdata/" + name + "_valid_test_vectors.dat")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(data) != 1000*size {
+		t.Fatalf("len(%s) = %d, want %d", name, len(data), 1000*size)
+	}
+	var res [][]byte
+	for i := 0; i < serializationVectors; i++ {
+		res = append(res, data[i*size:(i+1)*size])
+	}
+	return res
+}
+
+func TestG1Bytes_vectors(t *testing.T) {
+	c := BLS12381()
+	tests := []struct {
+		name       string
+		size       int
+		compressed bool
+	}{
+		{name: "g1_uncompressed", size: 96},
+		{name: "g1_compressed", size: 48, compressed: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := c.G1().Infinity()
+			for i, b := range readVectors(t, tt.name, tt.size) {
+				var got []byte
+				if tt.compressed {
+					got = c.G1BytesCompressed(p)
+				} else {
+					got = c.G1Bytes(p)
+				}
+				if !bytes.Equal(got, b) {
+					t.Errorf("%d: encoding = %x, want %x", i, got, b)
+				}
+				q, err := c.G1SetBytes(b)
+				if err != nil || !q.Equal(p) {
+					t.Errorf("%d: G1SetBytes() = %v, %v, want %v", i, q, err, p)
+				}
+				p = p.Add(c.G1().Generator())
+			}
+		})
+	}
+}
+
+func TestG2Bytes_vectors(t *testing.T) {
+	c := BLS12381()
+	tests := []struct {
+		name       string
+		size       int
+		compressed bool
+	}{
+		{name: "g2_uncompressed", size: 192},
+		{name: "g2_compressed", size: 96, compressed: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := c.G2Infinity()
+			for i, b := range readVectors(t, tt.name, tt.size) {
+				var got []byte
+				if tt.compressed {
+					got = p.BytesCompressed()
+				} else {
+					got = p.Bytes()
+				}
+				if !bytes.Equal(got, b) {
+					t.Errorf("%d: encoding = %x, want %x", i, got, b)
+				}
+				q, err := c.G2SetBytes(b)
+				if err != nil || !q.Equal(p) {
+					t.Errorf("%d: G2SetBytes() = %v, %v, want %v", i, q, err, p)
+				}
+				p = p.Add(c.G2Generator())
+			}
+		})
+	}
+}
+
+func TestG1SetBytes_invalid(t *testing.T) {
+	c := BLS12381()
+	g := c.G1().Generator()
+	compressed := c.G1BytesCompressed(g)
+	uncompressed := c.G1Bytes(g)
+	modify := func(b []byte, f func(b []byte)) []byte {
+		b = append([]byte{}, b...)
+		f(b)
+		return b
+	}
+
+	// x = 0 は y^2 = 4 なので曲線上の点だが、位数 r の部分群には含まれない
+	p0, err := c.G1().NewPoint(big.Zero, big.NewInt(2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name string
+		b    []byte
+		want error
+	}{
+		{name: "empty", b: nil, want: ErrInvalidEncoding},
+		{name: "compressed flag with uncompressed length", b: modify(uncompressed, func(b []byte) { b[0] |= flagCompressed }), want: ErrInvalidEncoding},
+		{name: "uncompressed with compressed length", b: modify(compressed, func(b []byte) { b[0] &^= flagCompressed }), want: ErrInvalidEncoding},
+		{name: "sort flag in uncompressed form", b: modify(uncompressed, func(b []byte) { b[0] |= flagLargest }), want: ErrInvalidEncoding},
+		{name: "infinity with sort flag", b: modify(c.G1BytesCompressed(c.G1().Infinity()), func(b []byte) { b[0] |= flagLargest }), want: ErrInvalidEncoding},
+		{name: "infinity with non-zero x", b: modify(c.G1BytesCompressed(c.G1().Infinity()), func(b []byte) { b[47] = 1 }), want: ErrInvalidEncoding},
+		{name: "x >= p", b: modify(compressed, func(b []byte) { b[0] |= 0x1f }), want: ErrInvalidEncoding},
+		{name: "not on curve", b: modify(uncompressed, func(b []byte) { b[95] ^= 1 }), want: ErrNotOnCurve},
+		{name: "not in subgroup", b: c.G1BytesCompressed(p0), want: ErrNotInSubgroup},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := c.G1SetBytes(tt.b); !errors.Is(err, tt.want) {
+				t.Errorf("G1SetBytes() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+
+	// 符号のフラグを反転すると -G になる
+	p, err := c.G1SetBytes(modify(compressed, func(b []byte) { b[0] ^= flagLargest }))
+	if err != nil || !p.Equal(g.Neg()) {
+		t.Errorf("G1SetBytes(flipped sign) = %v, %v, want -G", p, err)
+	}
+}
+
+func TestG2SetBytes_invalid(t *testing.T) {
+	c := BLS12381()
+	g := c.G2Generator()
+	compressed := g.BytesCompressed()
+	uncompressed := g.Bytes()
+	modify := func(b []byte, f func(b []byte)) []byte {
+		b = append([]byte{}, b...)
+		f(b)
+		return b
+	}
+
+	var outside *G2
+	for outside == nil {
+		if q := randomTwistPoint(t, c); !c.InG2(q) {
+			outside = q
+		}
+	}
+	tests := []struct {
+		name string
+		b    []byte
+		want error
+	}{
+		{name: "wrong length", b: compressed[:95], want: ErrInvalidEncoding},
+		{name: "G1 length", b: c.G1BytesCompressed(c.G1().Generator()), want: ErrInvalidEncoding},
+		{name: "sort flag in uncompressed form", b: modify(uncompressed, func(b []byte) { b[0] |= flagLargest }), want: ErrInvalidEncoding},
+		{name: "infinity with non-zero x", b: modify(c.G2Infinity().Bytes(), func(b []byte) { b[100] = 1 }), want: ErrInvalidEncoding},
+		{name: "x1 >= p", b: modify(compressed, func(b []byte) { b[0] |= 0x1f }), want: ErrInvalidEncoding},
+		{name: "x0 >= p", b: modify(compressed, func(b []byte) { b[48] |= 0xff }), want: ErrInvalidEncoding},
+		{name: "not on curve", b: modify(uncompressed, func(b []byte) { b[191] ^= 1 }), want: ErrNotOnCurve},
+		{name: "not in subgroup", b: outside.BytesCompressed(), want: ErrNotInSubgroup},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := c.G2SetBytes(tt.b); !errors.Is(err, tt.want) {
+				t.Errorf("G2SetBytes() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+
+	q, err := c.G2SetBytes(modify(compressed, func(b []byte) { b[0] ^= flagLargest }))
+	if err != nil || !q.Equal(g.Neg()) {
+		t.Errorf("G2SetBytes(flipped sign) = %v, %v, want -G", q, err)
+	}
+}
+
+// BN254 の p は254ビットで、フラグを置く3ビットの空きがない
+func TestG1Bytes_BN254(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("G1Bytes() did not panic")
+		}
+	}()
+	c := BN254()
+	c.G1Bytes(c.G1().Generator())
+}
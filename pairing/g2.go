@@ -0,0 +1,199 @@
+package pairing
+
+import (
+	"github.com/convto/mycrypto/big"
+	"github.com/convto/mycrypto/tower"
+)
+
+// G2 は捻り曲線 E': y^2 = x^3 + b' 上の Fp2 の点で、Jacobian 座標 (X, Y, Z) で保持します
+// Z = 0 のときは無限遠点を表します
+type G2 struct {
+	c *Curve
+	x *tower.Fp2
+	y *tower.Fp2
+	z *tower.Fp2
+}
+
+// G2Generator は G2 の生成元を返します
+func (c *Curve) G2Generator() *G2 {
+	return c.g2
+}
+
+// G2Infinity は G2 の無限遠点を返します
+func (c *Curve) G2Infinity() *G2 {
+	return &G2{c: c, x: c.t.OneFp2(), y: c.t.OneFp2(), z: c.t.ZeroFp2()}
+}
+
+// NewG2 は座標 (x, y) の捻り曲線上の点を返します
+// 捻り曲線上にないときは ErrNotOnCurve を返します
+// 位数 r の部分群に含まれるかどうかは InG2 で確かめます
+func (c *Curve) NewG2(x, y *tower.Fp2) (*G2, error) {
+	if !y.Square().Equal(c.rhs2(x)) {
+		return nil, ErrNotOnCurve
+	}
+	return &G2{c: c, x: x, y: y, z: c.t.OneFp2()}, nil
+}
+
+// InG2 は q が位数 r の部分群 G2 に含まれるかどうかを判定します
+// r 倍の代わりに、Scott の方法で BLS12 曲線では ψ(q) = [x]q、BN 曲線では ψ(q) = [6x^2]q かどうかを調べます
+func (c *Curve) InG2(q *G2) bool {
+	k := c.x
+	if c.family == familyBN {
+		k = big.Mul(big.NewInt(6), big.Mul(c.x, c.x))
+	}
+	return q.Psi().Equal(q.ScalarMult(k))
+}
+
+// rhs2 は x^3 + b' を返します
+func (c *Curve) rhs2(x *tower.Fp2) *tower.Fp2 {
+	return x.Square().Mul(x).Add(c.b2)
+}
+
+// Curve は q が属する曲線を返します
+func (q *G2) Curve() *Curve {
+	return q.c
+}
+
+// IsInfinity は q が無限遠点かどうかを判定します
+func (q *G2) IsInfinity() bool {
+	return q.z.IsZero()
+}
+
+// Affine は q のアフィン座標 (x, y) を返します
+// 無限遠点のときは nil, nil を返します
+func (q *G2) Affine() (x, y *tower.Fp2) {
+	if q.IsInfinity() {
+		return nil, nil
+	}
+	zinv := q.z.Inv()
+	zinv2 := zinv.Square()
+	return q.x.Mul(zinv2), q.y.Mul(zinv2).Mul(zinv)
+}
+
+func (q *G2) String() string {
+	if q.IsInfinity() {
+		return "infinity"
+	}
+	x, y := q.Affine()
+	return "(" + x.String() + ", " + y.String() + ")"
+}
+
+// Equal は q と r が同じ点かどうかを判定します
+func (q *G2) Equal(r *G2) bool {
+	q.check(r)
+	if q.IsInfinity() || r.IsInfinity() {
+		return q.IsInfinity() && r.IsInfinity()
+	}
+	z1z1 := q.z.Square()
+	z2z2 := r.z.Square()
+	if !q.x.Mul(z2z2).Equal(r.x.Mul(z1z1)) {
+		return false
+	}
+	return q.y.Mul(z2z2).Mul(r.z).Equal(r.y.Mul(z1z1).Mul(q.z))
+}
+
+// Neg は -q を返します
+func (q *G2) Neg() *G2 {
+	return &G2{c: q.c, x: q.x, y: q.y.Neg(), z: q.z}
+}
+
+// Add は q + r を返します
+func (q *G2) Add(r *G2) *G2 {
+	q.check(r)
+	if q.IsInfinity() {
+		return r
+	}
+	if r.IsInfinity() {
+		return q
+	}
+	// add-2007-bl
+	z1z1 := q.z.Square()
+	z2z2 := r.z.Square()
+	u1 := q.x.Mul(z2z2)
+	u2 := r.x.Mul(z1z1)
+	s1 := q.y.Mul(r.z).Mul(z2z2)
+	s2 := r.y.Mul(q.z).Mul(z1z1)
+	h := u2.Sub(u1)
+	rr := s2.Sub(s1)
+	if h.IsZero() {
+		if rr.IsZero() {
+			return q.Double()
+		}
+		return q.c.G2Infinity()
+	}
+	rr = rr.Double()
+	i := h.Double().Square()
+	j := h.Mul(i)
+	v := u1.Mul(i)
+	x3 := rr.Square().Sub(j).Sub(v).Sub(v)
+	s1j := s1.Mul(j)
+	y3 := rr.Mul(v.Sub(x3)).Sub(s1j).Sub(s1j)
+	z3 := q.z.Add(r.z).Square().Sub(z1z1).Sub(z2z2).Mul(h)
+	return &G2{c: q.c, x: x3, y: y3, z: z3}
+}
+
+// Sub は q - r を返します
+func (q *G2) Sub(r *G2) *G2 {
+	return q.Add(r.Neg())
+}
+
+// Double は 2q を返します
+func (q *G2) Double() *G2 {
+	if q.IsInfinity() || q.y.IsZero() {
+		return q.c.G2Infinity()
+	}
+	// a = 0 の dbl-2009-l
+	a := q.x.Square()
+	b := q.y.Square()
+	c := b.Square()
+	d := q.x.Add(b).Square().Sub(a).Sub(c).Double()
+	e := a.Double().Add(a)
+	x3 := e.Square().Sub(d).Sub(d)
+	c8 := c.Double().Double().Double()
+	y3 := e.Mul(d.Sub(x3)).Sub(c8)
+	z3 := q.y.Mul(q.z).Double()
+	return &G2{c: q.c, x: x3, y: y3, z: z3}
+}
+
+// ScalarMult は kq を返します
+// Montgomery ladder で k のビットによらず毎回同じ加算と2倍算を行います
+// k は r で還元しないので、r 倍や余因子倍にも使えます
+func (q *G2) ScalarMult(k *big.Int) *G2 {
+	if big.Cmp(k, big.Zero) < 0 {
+		q = q.Neg()
+		k = big.Sub(big.Zero, k)
+	}
+	r0, r1 := q.c.G2Infinity(), q
+	kb := k.Bits(k.BitLen())
+	for i := len(kb) - 1; i >= 0; i-- {
+		if kb[i] == 0 {
+			r1 = r0.Add(r1)
+			r0 = r0.Double()
+		} else {
+			r0 = r0.Add(r1)
+			r1 = r1.Double()
+		}
+	}
+	return r0
+}
+
+// Psi は E に写してから p 乗 Frobenius 写像をかけ、捻り曲線に戻した点 ψ(q) を返します
+// G2 の上では ψ(q) = [p]q で、部分群の判定や余因子の除去の高速化に使えます
+//
+// D 型の捻りでは (x, y) は E の (x*w^2, y*w^3) に対応し、w^(2p) = γ2*w^2, w^(3p) = γ3*w^3 なので
+// ψ(x, y) = (conj(x)*γ2, conj(y)*γ3) になります (M 型では w の冪の符号が逆なので γ2, γ3 の逆元をかけます)
+func (q *G2) Psi() *G2 {
+	return &G2{
+		c: q.c,
+		x: q.x.Conjugate().Mul(q.c.gamma2),
+		y: q.y.Conjugate().Mul(q.c.gamma3),
+		z: q.z.Conjugate(),
+	}
+}
+
+// check は q と r が同じ曲線上の点であることを確かめ、異なる場合はpanicする
+func (q *G2) check(r *G2) {
+	if q.c != r.c {
+		panic("pairing: points on different curves")
+	}
+}
@@ -0,0 +1,112 @@
+package pairing
+
+import (
+	"crypto/rand"
+	"errors"
+	"testing"
+
+	"github.com/convto/mycrypto/big"
+)
+
+// randomTwistPoint は捻り曲線上のランダムな点を返します
+// 捻り曲線の位数は r の他に大きな素因数を持つので、ほとんどの場合 G2 には含まれません
+func randomTwistPoint(t *testing.T, c *Curve) *G2 {
+	t.Helper()
+	for {
+		x, err := c.t.RandomFp2(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if y, ok := c.rhs2(x).Sqrt(); ok {
+			q, err := c.NewG2(x, y)
+			if err != nil {
+				t.Fatal(err)
+			}
+			return q
+		}
+	}
+}
+
+func TestG2_arithmetic(t *testing.T) {
+	for _, c := range testCurves {
+		t.Run(c.Name(), func(t *testing.T) {
+			g := c.G2Generator()
+			inf := c.G2Infinity()
+			g2 := g.Double()
+			g3 := g2.Add(g)
+			if !g.Add(g).Equal(g2) {
+				t.Errorf("G + G != 2G")
+			}
+			if !g3.Sub(g).Equal(g2) {
+				t.Errorf("3G - G != 2G")
+			}
+			if !g.Add(g.Neg()).IsInfinity() || !g.Sub(g).IsInfinity() {
+				t.Errorf("G - G != O")
+			}
+			if !g.Add(inf).Equal(g) || !inf.Add(g).Equal(g) || !inf.Double().IsInfinity() {
+				t.Errorf("G + O != G")
+			}
+			if !g.ScalarMult(big.NewInt(3)).Equal(g3) || !g.ScalarMult(big.NewInt(-3)).Equal(g3.Neg()) {
+				t.Errorf("ScalarMult(±3) != ±3G")
+			}
+			if !g.ScalarMult(big.Zero).IsInfinity() {
+				t.Errorf("ScalarMult(0) != O")
+			}
+			if !g.ScalarMult(c.r).IsInfinity() {
+				t.Errorf("ScalarMult(r) != O")
+			}
+			a, b := randScalar(t, c), randScalar(t, c)
+			if !g.ScalarMult(a).Add(g.ScalarMult(b)).Equal(g.ScalarMult(big.Add(a, b))) {
+				t.Errorf("aG + bG != (a + b)G")
+			}
+			if x, y := g3.Affine(); !y.Square().Equal(c.rhs2(x)) {
+				t.Errorf("3G is not on the twist")
+			}
+		})
+	}
+}
+
+// ψ は G2 の上で p 倍として働く
+func TestG2_Psi(t *testing.T) {
+	for _, c := range testCurves {
+		t.Run(c.Name(), func(t *testing.T) {
+			q := c.G2Generator().ScalarMult(randScalar(t, c))
+			p := big.Mod(c.t.Fp().Modulus(), c.r)
+			if !q.Psi().Equal(q.ScalarMult(p)) {
+				t.Errorf("ψ(Q) != [p]Q")
+			}
+		})
+	}
+}
+
+func TestInG2(t *testing.T) {
+	for _, c := range testCurves {
+		t.Run(c.Name(), func(t *testing.T) {
+			if !c.InG2(c.G2Infinity()) {
+				t.Errorf("InG2(infinity) = false, want true")
+			}
+			if q := c.G2Generator().ScalarMult(randScalar(t, c)); !c.InG2(q) {
+				t.Errorf("InG2(kG) = false, want true")
+			}
+			for i := 0; i < 3; i++ {
+				q := randomTwistPoint(t, c)
+				// r 倍して無限遠点になるかどうかと一致する
+				if got, want := c.InG2(q), q.ScalarMult(c.r).IsInfinity(); got != want {
+					t.Errorf("InG2(%v) = %v, want %v", q, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestNewG2_notOnCurve(t *testing.T) {
+	for _, c := range testCurves {
+		x, y := c.G2Generator().Affine()
+		if _, err := c.NewG2(x, y.Add(c.t.OneFp2())); !errors.Is(err, ErrNotOnCurve) {
+			t.Errorf("NewG2() error = %v, want %v", err, ErrNotOnCurve)
+		}
+		if _, err := c.NewG2(x, y.Neg()); err != nil {
+			t.Errorf("NewG2(x, -y) error = %v, want nil", err)
+		}
+	}
+}
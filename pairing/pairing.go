@@ -0,0 +1,318 @@
+// Package pairing は BN254 と BLS12-381 の上の最適 ate ペアリング e: G1 × G2 → GT を提供します
+//
+// G1 は素体 Fp 上の曲線 E: y^2 = x^3 + b の位数 r の部分群で、点は ec.Point で表します
+// G2 は Fp2 上の6次の捻り曲線 E': y^2 = x^3 + b' の位数 r の部分群で、点は G2 で表します
+// GT は Fp12 の1の r 乗根の群で、元は tower.Fp12 で表します
+//
+// ペアリングは Miller ループで f を求めてから最終冪 f^((p^12 - 1)/r) をとった値で、
+// 最終冪は他の実装のように定数倍 (3乗など) をかけず、そのままの指数で計算します
+package pairing
+
+import (
+	"errors"
+
+	"github.com/convto/mycrypto/big"
+	"github.com/convto/mycrypto/ec"
+	"github.com/convto/mycrypto/field"
+	"github.com/convto/mycrypto/tower"
+)
+
+// ErrNotOnCurve は点が曲線上にないことを表します
+var ErrNotOnCurve = errors.New("pairing: point is not on the curve")
+
+// family はペアリングに適した曲線の族です
+type family int
+
+const (
+	// familyBN は p = 36x^4 + 36x^3 + 24x^2 + 6x + 1 の Barreto-Naehrig 曲線
+	familyBN family = iota
+	// familyBLS12 は p = (x - 1)^2 (x^4 - x^2 + 1)/3 + x の Barreto-Lynn-Scott 曲線
+	familyBLS12
+)
+
+// Curve は埋め込み次数12のペアリングに適した曲線と、その捻り曲線の組です
+type Curve struct {
+	name   string
+	family family
+	// x は曲線の族のパラメータ
+	x  *big.Int
+	r  *big.Int
+	t  *tower.Tower
+	g1 *ec.Curve
+	// b2 は捻り曲線の係数 b'
+	b2 *tower.Fp2
+	g2 *G2
+	// mTwist は捻りが M 型 (b' = b*ξ) かどうかで、false なら D 型 (b' = b/ξ)
+	mTwist bool
+	// gamma2, gamma3 は捻り曲線上の Frobenius 写像の係数
+	gamma2 *tower.Fp2
+	gamma3 *tower.Fp2
+}
+
+// newCurve は族 fam とパラメータ x の曲線 y^2 = x^3 + b と、G2 の生成元 g2xy = (x0 + x1*u, y0 + y1*u) から Curve を作ります
+// Fp2, Fp6 は β = -1 と ξ = xi0 + u で作ります
+func newCurve(name string, fam family, x, b, xi0 *big.Int, g1x, g1y, h1 *big.Int, mTwist bool, g2xy [4]*big.Int) *Curve {
+	c := &Curve{name: name, family: fam, x: x, mTwist: mTwist}
+	var p *big.Int
+	switch fam {
+	case familyBN:
+		// p = 36x^4 + 36x^3 + 24x^2 + 6x + 1, r = 36x^4 + 36x^3 + 18x^2 + 6x + 1
+		x2 := big.Mul(x, x)
+		x3 := big.Mul(x2, x)
+		x4 := big.Mul(x3, x)
+		base := big.Add(big.Add(big.Mul(big.NewInt(36), x4), big.Mul(big.NewInt(36), x3)), big.Add(big.Mul(big.NewInt(6), x), big.NewInt(1)))
+		p = big.Add(base, big.Mul(big.NewInt(24), x2))
+		c.r = big.Add(base, big.Mul(big.NewInt(18), x2))
+	case familyBLS12:
+		// r = x^4 - x^2 + 1, p = (x - 1)^2 r/3 + x
+		x2 := big.Mul(x, x)
+		c.r = big.Add(big.Sub(big.Mul(x2, x2), x2), big.NewInt(1))
+		xm1 := big.Sub(x, big.NewInt(1))
+		p, _ = big.Div(big.Mul(big.Mul(xm1, xm1), c.r), big.NewInt(3))
+		p = big.Add(p, x)
+	}
+
+	c.t = tower.New(p, big.NewInt(-1), xi0, big.NewInt(1))
+	c.g1 = ec.NewCurve(name+" G1", p, big.Zero, b, g1x, g1y, c.r, h1)
+
+	fb := c.t.NewFp2(b, big.Zero)
+	if mTwist {
+		c.b2 = fb.Mul(c.t.Xi())
+	} else {
+		c.b2 = fb.Mul(c.t.Xi().Inv())
+	}
+
+	// γ2 = ξ^((p-1)/3), γ3 = ξ^((p-1)/2)
+	e, _ := big.Div(big.Sub(p, big.NewInt(1)), big.NewInt(6))
+	gamma1 := c.t.Xi().Exp(e)
+	c.gamma2 = gamma1.Square()
+	c.gamma3 = c.gamma2.Mul(gamma1)
+	if mTwist {
+		c.gamma2 = c.gamma2.Inv()
+		c.gamma3 = c.gamma3.Inv()
+	}
+
+	g2, err := c.NewG2(c.t.NewFp2(g2xy[0], g2xy[1]), c.t.NewFp2(g2xy[2], g2xy[3]))
+	if err != nil {
+		panic("pairing: G2 generator is not on the twist")
+	}
+	c.g2 = g2
+	return c
+}
+
+// Name は曲線の名前を返します
+func (c *Curve) Name() string {
+	return c.name
+}
+
+// X は曲線の族のパラメータ x を返します
+func (c *Curve) X() *big.Int {
+	return c.x
+}
+
+// Order は G1, G2, GT の位数 r を返します
+func (c *Curve) Order() *big.Int {
+	return c.r
+}
+
+// Tower は GT の元の属する拡大体の塔を返します
+func (c *Curve) Tower() *tower.Tower {
+	return c.t
+}
+
+// G1 は G1 の元を点に持つ Fp 上の曲線を返します
+func (c *Curve) G1() *ec.Curve {
+	return c.g1
+}
+
+// InG1 は曲線上の点 p が位数 r の部分群 G1 に含まれるかどうかを判定します
+func (c *Curve) InG1(p *ec.Point) bool {
+	if big.Cmp(c.g1.H(), big.NewInt(1)) == 0 {
+		return true
+	}
+	return ec.WNAF{W: 5}.ScalarMult(p, c.r).IsInfinity()
+}
+
+// Pair はペアリング e(p, q) を返します
+// p は G1 の、q は G2 の元である必要があり、どちらかが無限遠点なら1を返します
+func (c *Curve) Pair(p *ec.Point, q *G2) *tower.Fp12 {
+	return c.MultiPair([]*ec.Point{p}, []*G2{q})
+}
+
+// MultiPair はペアリングの積 e(ps[0], qs[0]) * ... * e(ps[n-1], qs[n-1]) を返します
+// Miller ループの二乗と最終冪を共有するので、ペアリングを1つずつ計算してかけるより速くなります
+// ps と qs の長さが異なるときはpanicします
+func (c *Curve) MultiPair(ps []*ec.Point, qs []*G2) *tower.Fp12 {
+	if len(ps) != len(qs) {
+		panic("pairing: mismatched number of points")
+	}
+	return c.finalExp(c.millerLoop(ps, qs))
+}
+
+// PairingCheck は e(ps[0], qs[0]) * ... * e(ps[n-1], qs[n-1]) = 1 かどうかを判定します
+func (c *Curve) PairingCheck(ps []*ec.Point, qs []*G2) bool {
+	return c.MultiPair(ps, qs).Equal(c.t.OneFp12())
+}
+
+// millerPair は Miller ループの途中の状態で、P のアフィン座標と Jacobian 座標の T、アフィン座標の Q を持つ
+type millerPair struct {
+	xp, yp *field.Element
+	t      *G2
+	qx, qy *tower.Fp2
+}
+
+// millerLoop は各組について f_{s,Q}(P) を求めて、その積を返します
+// s は BN 曲線では 6x + 2、BLS12 曲線では x で、BN 曲線ではさらに ψ(Q), -ψ^2(Q) を足す直線をかけます
+func (c *Curve) millerLoop(ps []*ec.Point, qs []*G2) *tower.Fp12 {
+	var pairs []*millerPair
+	for i := range ps {
+		if ps[i].IsInfinity() || qs[i].IsInfinity() {
+			continue
+		}
+		x, y := ps[i].Affine()
+		qx, qy := qs[i].Affine()
+		fp := c.t.Fp()
+		pairs = append(pairs, &millerPair{
+			xp: fp.NewElement(x),
+			yp: fp.NewElement(y),
+			t:  &G2{c: c, x: qx, y: qy, z: c.t.OneFp2()},
+			qx: qx,
+			qy: qy,
+		})
+	}
+
+	s := c.x
+	if c.family == familyBN {
+		s = big.Add(big.Mul(big.NewInt(6), s), big.NewInt(2))
+	}
+	neg := big.Cmp(s, big.Zero) < 0
+	if neg {
+		s = big.Sub(big.Zero, s)
+	}
+
+	f := c.t.OneFp12()
+	sb := s.Bits(s.BitLen())
+	for i := len(sb) - 2; i >= 0; i-- {
+		f = f.Square()
+		for _, m := range pairs {
+			f = f.Mul(c.double(m))
+		}
+		if sb[i] == 1 {
+			for _, m := range pairs {
+				f = f.Mul(c.add(m, m.qx, m.qy))
+			}
+		}
+	}
+	if neg {
+		// f_{-s,Q} と 1/f_{s,Q} は最終冪をとると等しく、円分部分群では逆元は共役になる
+		f = f.Conjugate()
+		for _, m := range pairs {
+			m.t = m.t.Neg()
+		}
+	}
+
+	if c.family == familyBN {
+		for _, m := range pairs {
+			q := &G2{c: c, x: m.qx, y: m.qy, z: c.t.OneFp2()}
+			q1 := q.Psi()
+			q2 := q1.Psi().Neg()
+			f = f.Mul(c.add(m, q1.x, q1.y))
+			f = f.Mul(c.add(m, q2.x, q2.y))
+		}
+	}
+	return f
+}
+
+// double は T を 2T に更新し、T での接線の P での値を返します
+func (c *Curve) double(m *millerPair) *tower.Fp12 {
+	// 傾き λ = 3x^2/2y = 3X^2/2YZ の直線を 2YZ^3 倍して分母を払う
+	t := m.t
+	xx := t.x.Square()
+	zz := t.z.Square()
+	xx3 := xx.Double().Add(xx)
+	lambda := xx3.Mul(zz)
+	b := xx3.Mul(t.x).Sub(t.y.Square().Double())
+	scale := t.y.Mul(zz).Mul(t.z).Double()
+	m.t = t.Double()
+	return c.line(m, lambda, b, scale)
+}
+
+// add は T を T + (x, y) に更新し、T と (x, y) を通る直線の P での値を返します
+// T = -(x, y) のときの直線は垂直線で、最終冪で消えるので1を返します
+func (c *Curve) add(m *millerPair, x, y *tower.Fp2) *tower.Fp12 {
+	// 傾き λ = (y - yT)/(x - xT) = N/D, N = yZ^3 - Y, D = Z(xZ^2 - X) の直線を D 倍して分母を払う
+	t := m.t
+	zz := t.z.Square()
+	n := y.Mul(zz).Mul(t.z).Sub(t.y)
+	d := t.z.Mul(x.Mul(zz).Sub(t.x))
+	m.t = t.Add(&G2{c: c, x: x, y: y, z: c.t.OneFp2()})
+	if d.IsZero() {
+		return c.t.OneFp12()
+	}
+	// 直線は (x, y) も通るので、定数項は λx - y を D 倍した Nx - yD になる
+	return c.line(m, n, n.Mul(x).Sub(y.Mul(d)), d)
+}
+
+// line は捻り曲線上の直線 s*y = λ*x - b を E に写して P で評価した値を返します
+// D 型では s*yP - λ*xP*w + b*w^3 になり、M 型では同じ直線を w^3 倍した b - λ*xP*w^2 + s*yP*w^3 を返します (Fp4 の元の倍は最終冪で消える)
+func (c *Curve) line(m *millerPair, lambda, b, s *tower.Fp2) *tower.Fp12 {
+	t := c.t
+	zero := t.ZeroFp2()
+	a := lambda.MulElement(m.xp).Neg()
+	yp := s.MulElement(m.yp)
+	if c.mTwist {
+		return t.NewFp12(t.NewFp6(b, a, zero), t.NewFp6(zero, yp, zero))
+	}
+	return t.NewFp12(t.NewFp6(yp, zero, zero), t.NewFp6(a, b, zero))
+}
+
+// finalExp は f^((p^12 - 1)/r) を返します
+func (c *Curve) finalExp(f *tower.Fp12) *tower.Fp12 {
+	// easy part: f^((p^6 - 1)(p^2 + 1)) で円分部分群に移す
+	f = f.Conjugate().Mul(f.Inv())
+	f = f.Frobenius(2).Mul(f)
+
+	// hard part: f^((p^4 - p^2 + 1)/r)
+	switch c.family {
+	case familyBN:
+		return c.hardPartBN(f)
+	default:
+		return c.hardPartBLS12(f)
+	}
+}
+
+// hardPartBN は Scott らの方法で (p^4 - p^2 + 1)/r = λ0 + λ1*p + λ2*p^2 + λ3*p^3 と x の多項式に分解して冪をとります
+func (c *Curve) hardPartBN(f *tower.Fp12) *tower.Fp12 {
+	fx := f.CyclotomicExp(c.x)
+	fx2 := fx.CyclotomicExp(c.x)
+	fx3 := fx2.CyclotomicExp(c.x)
+
+	y0 := f.Frobenius(1).Mul(f.Frobenius(2)).Mul(f.Frobenius(3))
+	y1 := f.Conjugate()
+	y2 := fx2.Frobenius(2)
+	y3 := fx.Frobenius(1).Conjugate()
+	y4 := fx.Mul(fx2.Frobenius(1)).Conjugate()
+	y5 := fx2.Conjugate()
+	y6 := fx3.Mul(fx3.Frobenius(1)).Conjugate()
+
+	// y0 * y1^2 * y2^6 * y3^12 * y4^18 * y5^30 * y6^36 を加算鎖で計算する
+	t0 := y6.CyclotomicSquare().Mul(y4).Mul(y5)
+	t1 := y3.Mul(y5).Mul(t0)
+	t0 = t0.Mul(y2)
+	t1 = t1.CyclotomicSquare().Mul(t0).CyclotomicSquare()
+	t0 = t1.Mul(y1)
+	t1 = t1.Mul(y0)
+	return t0.CyclotomicSquare().Mul(t1)
+}
+
+// hardPartBLS12 は (p^4 - p^2 + 1)/r = (x - 1)^2/3 * (x + p)(x^2 + p^2 - 1) + 1 を使って冪をとります
+func (c *Curve) hardPartBLS12(f *tower.Fp12) *tower.Fp12 {
+	xm1 := big.Sub(c.x, big.NewInt(1))
+	e, _ := big.Div(big.Mul(xm1, xm1), big.NewInt(3))
+	a := f.CyclotomicExp(e)
+	// a^(x + p)
+	b := a.CyclotomicExp(c.x).Mul(a.Frobenius(1))
+	// b^(x^2 + p^2 - 1)
+	d := b.CyclotomicExp(c.x).CyclotomicExp(c.x).Mul(b.Frobenius(2)).Mul(b.Conjugate())
+	return d.Mul(f)
+}
@@ -0,0 +1,307 @@
+package pairing
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"testing"
+
+	"github.com/convto/mycrypto/big"
+	"github.com/convto/mycrypto/ec"
+	"github.com/convto/mycrypto/tower"
+)
+
+var testCurves = []*Curve{BN254(), BLS12381()}
+
+func randScalar(t *testing.T, c *Curve) *big.Int {
+	t.Helper()
+	k, err := c.t.Fp().Random(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return big.Mod(k.Int(), c.r)
+}
+
+func TestCurve_params(t *testing.T) {
+	tests := []struct {
+		c    *Curve
+		p    string
+		r    string
+		bits int
+	}{
+		{
+			c:    BN254(),
+			p:    "21888242871839275222246405745257275088696311157297823662689037894645226208583",
+			r:    "21888242871839275222246405745257275088548364400416034343698204186575808495617",
+			bits: 254,
+		},
+		{
+			c:    BLS12381(),
+			p:    "0x1a0111ea397fe69a4b1ba7b6434bacd764774b84f38512bf6730d2a0f6b0f6241eabfffeb153ffffb9feffffffffaaab",
+			r:    "0x73eda753299d7d483339d80809a1d80553bda402fffe5bfeffffffff00000001",
+			bits: 381,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.c.Name(), func(t *testing.T) {
+			c := tt.c
+			if got, want := c.t.Fp().Modulus(), new(big.Int).SetString(tt.p); big.Cmp(got, want) != 0 {
+				t.Errorf("p = %v, want %v", got, want)
+			}
+			if got, want := c.Order(), new(big.Int).SetString(tt.r); big.Cmp(got, want) != 0 {
+				t.Errorf("r = %v, want %v", got, want)
+			}
+			if got := c.t.Fp().Modulus().BitLen(); got != tt.bits {
+				t.Errorf("p.BitLen() = %d, want %d", got, tt.bits)
+			}
+			if !c.InG1(c.G1().Generator()) {
+				t.Errorf("InG1(generator) = false, want true")
+			}
+			if !c.InG2(c.G2Generator()) {
+				t.Errorf("InG2(generator) = false, want true")
+			}
+		})
+	}
+}
+
+func TestPair_bilinear(t *testing.T) {
+	for _, c := range testCurves {
+		t.Run(c.Name(), func(t *testing.T) {
+			p, q := c.G1().Generator(), c.G2Generator()
+			a, b := randScalar(t, c), randScalar(t, c)
+			e := c.Pair(p, q)
+			one := c.t.OneFp12()
+			if e.Equal(one) {
+				t.Fatalf("Pair(G1, G2) = 1, want non-degenerate")
+			}
+			if !e.CyclotomicExp(c.r).Equal(one) {
+				t.Errorf("Pair(G1, G2)^r != 1")
+			}
+
+			// e(aP, bQ) = e(P, Q)^(ab) = e(abP, Q) = e(P, abQ)
+			ab := big.Mod(big.Mul(a, b), c.r)
+			want := e.CyclotomicExp(ab)
+			if got := c.Pair(p.ScalarMult(a), q.ScalarMult(b)); !got.Equal(want) {
+				t.Errorf("Pair(aP, bQ) != Pair(P, Q)^(ab)")
+			}
+			if got := c.Pair(p.ScalarMult(ab), q); !got.Equal(want) {
+				t.Errorf("Pair(abP, Q) != Pair(P, Q)^(ab)")
+			}
+			if got := c.Pair(p, q.ScalarMult(ab)); !got.Equal(want) {
+				t.Errorf("Pair(P, abQ) != Pair(P, Q)^(ab)")
+			}
+		})
+	}
+}
+
+func TestMultiPair(t *testing.T) {
+	for _, c := range testCurves {
+		t.Run(c.Name(), func(t *testing.T) {
+			p, q := c.G1().Generator(), c.G2Generator()
+			a := randScalar(t, c)
+			ap := p.ScalarMult(a)
+
+			// e(aP, Q) * e(P, Q) = e((a+1)P, Q)
+			got := c.MultiPair([]*ec.Point{ap, p}, []*G2{q, q})
+			if want := c.Pair(ap.Add(p), q); !got.Equal(want) {
+				t.Errorf("MultiPair() = %v, want %v", got, want)
+			}
+			// e(aP, Q) * e(-P, aQ) = 1
+			if !c.PairingCheck([]*ec.Point{ap, p.Neg()}, []*G2{q, q.ScalarMult(a)}) {
+				t.Errorf("PairingCheck(e(aP, Q) * e(-P, aQ)) = false, want true")
+			}
+			if c.PairingCheck([]*ec.Point{ap, p}, []*G2{q, q.ScalarMult(a)}) {
+				t.Errorf("PairingCheck(e(aP, Q) * e(P, aQ)) = true, want false")
+			}
+			// 無限遠点との組は1になり、積に影響しない
+			if !c.Pair(c.G1().Infinity(), q).Equal(c.t.OneFp12()) || !c.Pair(p, c.G2Infinity()).Equal(c.t.OneFp12()) {
+				t.Errorf("Pair() with infinity != 1")
+			}
+			if !c.PairingCheck(nil, nil) {
+				t.Errorf("PairingCheck(empty) = false, want true")
+			}
+		})
+	}
+}
+
+// BLS12-381 の e(G1, G2) を他の実装と比べる
+// cloudflare/circl (ecc/bls12381) などは最終冪の hard part を3倍した指数で計算するので、その出力は e(G1, G2)^3 になる
+func TestPair_BLS12381(t *testing.T) {
+	// circl の Pair(G1Generator(), G2Generator()) の出力で、w^i (i = 0, ..., 5) の係数を a0, a1 の順に並べたもの
+	want := [12]string{
+		"1250ebd871fc0a92a7b2d83168d0d727272d441befa15c503dd8e90ce98db3e7b6d194f60839c508a84305aaca1789b6",
+		"089a1c5b46e5110b86750ec6a532348868a84045483c92b7af5af689452eafabf1a8943e50439f1d59882a98eaa0170f",
+		"19f26337d205fb469cd6bd15c3d5a04dc88784fbb3d0b2dbdea54d43b2b73f2cbb12d58386a8703e0f948226e47ee89d",
+		"06fba23eb7c5af0d9f80940ca771b6ffd5857baaf222eb95a7d2809d61bfe02e1bfd1b68ff02f0b8102ae1c2d5d5ab1a",
+		"1368bb445c7c2d209703f239689ce34c0378a68e72a6b3b216da0e22a5031b54ddff57309396b38c881c4c849ec23e87",
+		"193502b86edb8857c273fa075a50512937e0794e1e65a7617c90d8bd66065b1fffe51d7a579973b1315021ec3c19934f",
+		"11b8b424cd48bf38fcef68083b0b0ec5c81a93b330ee1a677d0d15ff7b984e8978ef48881e32fac91b93b47333e2ba57",
+		"03350f55a7aefcd3c31b4fcb6ce5771cc6a0e9786ab5973320c806ad360829107ba810c5a09ffdd9be2291a0c25a99a2",
+		"01b2f522473d171391125ba84dc4007cfbf2f8da752f7c74185203fcca589ac719c34dffbbaad8431dad1c1fb597aaa5",
+		"018107154f25a764bd3c79937a45b84546da634b8f6be14a8061e55cceba478b23f7dacaa35c8ca78beae9624045b4b6",
+		"04c581234d086a9902249b64728ffd21a189e87935a954051c7cdba7b3872629a4fafc05066245cb9108f0242d0fe3ef",
+		"0f41e58663bf08cf068672cbd01a7ec73baca4d72ca93544deff686bfd6df543d48eaa24afe47e1efde449383b676631",
+	}
+	c := BLS12381()
+	e := c.Pair(c.G1().Generator(), c.G2Generator())
+	e3 := e.Square().Mul(e)
+	coeffs := []*tower.Fp2{e3.C0().C0(), e3.C1().C0(), e3.C0().C1(), e3.C1().C1(), e3.C0().C2(), e3.C1().C2()}
+	for i, x := range coeffs {
+		for j, a := range []interface{ Bytes() []byte }{x.A0(), x.A1()} {
+			if got := hex.EncodeToString(a.Bytes()); got != want[2*i+j] {
+				t.Errorf("coefficient %d = %v, want %v", 2*i+j, got, want[2*i+j])
+			}
+		}
+	}
+}
+
+// eip197Generator は EIP-197 で G2 の生成元として定められた点を、プリコンパイルの入力と同じ (x1, x0, y1, y0) の順に並べたもの
+// x = x0 + x1 i, y = y0 + y1 i
+const eip197Generator = "198e9393920d483a7260bfb731fb5d25f1aa493335a9e71297e485b7aef312c2" +
+	"1800deef121f1e76426a00665e5c4479674322d4f75edadd46debd5cd992f6ed" +
+	"090689d0585ff075ec9e99ad690c3395bc4b313370b38ef355acdadcd122975b" +
+	"12c85ea5db8c6deb4aab71808dcb408fe3d1e7690c43d37b4ce6cc0166fa7daa"
+
+// eip197Encode は EIP-197 のペアリング検査のプリコンパイルの入力で、(x, y, x1, x0, y1, y0) の組を32バイトずつ並べる
+// 無限遠点は座標をすべて0にする
+func eip197Encode(ps []*ec.Point, qs []*G2) []byte {
+	var b []byte
+	word := func(x *big.Int) {
+		b = append(b, x.FillBytes(make([]byte, 32))...)
+	}
+	for i, p := range ps {
+		if p.IsInfinity() {
+			b = append(b, make([]byte, 64)...)
+		} else {
+			x, y := p.Affine()
+			word(x)
+			word(y)
+		}
+		if qs[i].IsInfinity() {
+			b = append(b, make([]byte, 128)...)
+		} else {
+			x, y := qs[i].Affine()
+			word(x.A1().Int())
+			word(x.A0().Int())
+			word(y.A1().Int())
+			word(y.A0().Int())
+		}
+	}
+	return b
+}
+
+// eip197Decode は eip197Encode の逆で、入力を G1 と G2 の点の列に戻す
+func eip197Decode(t *testing.T, c *Curve, input []byte) ([]*ec.Point, []*G2) {
+	t.Helper()
+	if len(input)%192 != 0 {
+		t.Fatalf("invalid input length %d", len(input))
+	}
+	var ps []*ec.Point
+	var qs []*G2
+	for ; len(input) > 0; input = input[192:] {
+		// w[0], w[1] が G1 の点、w[2:] が G2 の点の座標で、どちらも座標がすべて0なら無限遠点
+		w := make([]*big.Int, 6)
+		for i := range w {
+			w[i] = new(big.Int).SetBytes(input[32*i : 32*(i+1)])
+		}
+		isZero := func(ws []*big.Int) bool {
+			for _, x := range ws {
+				if big.Cmp(x, big.Zero) != 0 {
+					return false
+				}
+			}
+			return true
+		}
+		p := c.G1().Infinity()
+		if !isZero(w[:2]) {
+			var err error
+			if p, err = c.G1().NewPoint(w[0], w[1]); err != nil {
+				t.Fatal(err)
+			}
+		}
+		q := c.G2Infinity()
+		if !isZero(w[2:]) {
+			var err error
+			if q, err = c.NewG2(c.t.NewFp2(w[3], w[2]), c.t.NewFp2(w[5], w[4])); err != nil {
+				t.Fatal(err)
+			}
+			if !c.InG2(q) {
+				t.Fatalf("InG2() = false, want true")
+			}
+		}
+		ps = append(ps, p)
+		qs = append(qs, q)
+	}
+	return ps, qs
+}
+
+// TestPairingCheck_EIP197 は EIP-197 のペアリング検査のプリコンパイルに相当する入力を仕様から組み立て、Π e(P_i, Q_i) = 1 の判定を確かめる
+func TestPairingCheck_EIP197(t *testing.T) {
+	c := BN254()
+	g1, g2 := c.G1().Generator(), c.G2Generator()
+
+	// 仕様の生成元は G1 が (1, 2)、G2 が eip197Generator
+	if x, y := g1.Affine(); big.Cmp(x, big.NewInt(1)) != 0 || big.Cmp(y, big.NewInt(2)) != 0 {
+		t.Errorf("G1 generator = %s, want (1, 2)", g1)
+	}
+	want, err := hex.DecodeString("0000000000000000000000000000000000000000000000000000000000000001" +
+		"0000000000000000000000000000000000000000000000000000000000000002" + eip197Generator)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, qs := eip197Decode(t, c, want); !qs[0].Equal(g2) {
+		t.Errorf("G2 generator = %s, want EIP-197 generator", g2)
+	}
+
+	a, b := big.NewInt(0x1234567), new(big.Int).SetString("0xfedcba9876543210fedcba9876543210")
+	ab := big.Mod(big.Mul(a, b), c.r)
+	// pairs は e(a_i G1, b_i G2) の組を作る
+	pairs := func(k ...*big.Int) ([]*ec.Point, []*G2) {
+		var ps []*ec.Point
+		var qs []*G2
+		for i := 0; i < len(k); i += 2 {
+			ps = append(ps, g1.ScalarMult(k[i]))
+			qs = append(qs, g2.ScalarMult(k[i+1]))
+		}
+		return ps, qs
+	}
+	neg := func(k *big.Int) *big.Int {
+		return big.Mod(big.Sub(big.Zero, k), c.r)
+	}
+	one := big.NewInt(1)
+	// ten は Σ a_i b_i = 0 となる10組
+	var ten []*big.Int
+	sum := big.NewInt(0)
+	for i := int64(1); i < 10; i++ {
+		ai, bi := big.Mul(a, big.NewInt(i)), big.Add(b, big.NewInt(i))
+		ten = append(ten, ai, bi)
+		sum = big.Add(sum, big.Mul(ai, bi))
+	}
+	ten = append(ten, neg(sum), one)
+
+	tests := []struct {
+		name string
+		k    []*big.Int
+		want bool
+	}{
+		{name: "empty", k: nil, want: true},
+		{name: "one point", k: []*big.Int{one, one}, want: false},
+		{name: "two points", k: []*big.Int{one, one, neg(one), one}, want: true},
+		{name: "bilinear", k: []*big.Int{a, b, neg(ab), one}, want: true},
+		{name: "bilinear G2 side", k: []*big.Int{a, b, one, neg(ab)}, want: true},
+		{name: "not bilinear", k: []*big.Int{a, b, ab, one}, want: false},
+		{name: "infinity in G1", k: []*big.Int{big.Zero, one}, want: true},
+		{name: "infinity in G2", k: []*big.Int{one, big.Zero}, want: true},
+		{name: "infinity and bilinear", k: []*big.Int{big.Zero, one, a, b, neg(ab), one}, want: true},
+		{name: "ten points", k: ten, want: true},
+		{name: "ten points mismatch", k: append(append([]*big.Int{}, ten[:19]...), big.NewInt(2)), want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := eip197Encode(pairs(tt.k...))
+			ps, qs := eip197Decode(t, c, input)
+			if got := c.PairingCheck(ps, qs); got != tt.want {
+				t.Errorf("PairingCheck() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
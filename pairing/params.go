@@ -0,0 +1,54 @@
+package pairing
+
+import (
+	"github.com/convto/mycrypto/big"
+)
+
+var (
+	// bn254 は EIP-196, EIP-197 で使われる alt_bn128 で、捻りは D 型
+	bn254 = newCurve(
+		"BN254",
+		familyBN,
+		new(big.Int).SetString("4965661367192848881"),
+		big.NewInt(3),
+		big.NewInt(9),
+		big.NewInt(1),
+		big.NewInt(2),
+		big.NewInt(1),
+		false,
+		[4]*big.Int{
+			new(big.Int).SetString("10857046999023057135944570762232829481370756359578518086990519993285655852781"),
+			new(big.Int).SetString("11559732032986387107991004021392285783925812861821192530917403151452391805634"),
+			new(big.Int).SetString("8495653923123431417604973247489272438418190587263600148770280649306958101930"),
+			new(big.Int).SetString("4082367875863433681332203403145435568316851327593401208105741076214120093531"),
+		},
+	)
+	// bls12381 は Zcash や Ethereum で使われる BLS12-381 で、捻りは M 型
+	bls12381 = newCurve(
+		"BLS12-381",
+		familyBLS12,
+		new(big.Int).SetString("-0xd201000000010000"),
+		big.NewInt(4),
+		big.NewInt(1),
+		new(big.Int).SetString("0x17f1d3a73197d7942695638c4fa9ac0fc3688c4f9774b905a14e3a3f171bac586c55e83ff97a1aeffb3af00adb22c6bb"),
+		new(big.Int).SetString("0x08b3f481e3aaa0f1a09e30ed741d8ae4fcf5e095d5d00af600db18cb2c04b3edd03cc744a2888ae40caa232946c5e7e1"),
+		new(big.Int).SetString("0x396c8c005555e1568c00aaab0000aaab"),
+		true,
+		[4]*big.Int{
+			new(big.Int).SetString("0x024aa2b2f08f0a91260805272dc51051c6e47ad4fa403b02b4510b647ae3d1770bac0326a805bbefd48056c8c121bdb8"),
+			new(big.Int).SetString("0x13e02b6052719f607dacd3a088274f65596bd0d09920b61ab5da61bbdc7f5049334cf11213945d57e5ac7d055d042b7e"),
+			new(big.Int).SetString("0x0ce5d527727d6e118cc9cdc6da2e351aadfd9baa8cbdd3a76d429a695160d12c923ac9cc3baca289e193548608b82801"),
+			new(big.Int).SetString("0x0606c4a02ea734cc32acd2b02bc28b99cb3e287e85a763af267492ab572e99ab3f370d275cec1da1aaa9075ff05f79be"),
+		},
+	)
+)
+
+// BN254 は BN 曲線 BN254 (alt_bn128) を返します
+func BN254() *Curve {
+	return bn254
+}
+
+// BLS12381 は BLS12 曲線 BLS12-381 を返します
+func BLS12381() *Curve {
+	return bls12381
+}
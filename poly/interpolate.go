@@ -0,0 +1,83 @@
+package poly
+
+import (
+	"errors"
+
+	"github.com/convto/mycrypto/big"
+	"github.com/convto/mycrypto/field"
+)
+
+// ErrDuplicatePoints は補間に使う点の x 座標が重複していることを表します
+var ErrDuplicatePoints = errors.New("poly: duplicate x coordinates")
+
+// Interpolate は (xs[i], ys[i]) をすべて通る次数 len(xs)-1 以下の多項式をラグランジュ補間で求めます
+// len(xs) != len(ys) のときpanicします
+func Interpolate(f *field.Field, xs, ys []*field.Element) (*Poly, error) {
+	if len(xs) != len(ys) {
+		panic("poly: mismatched number of points")
+	}
+	// M(x) = (x - x0)(x - x1)...(x - x(n-1))
+	m := New(f, big.NewInt(1))
+	for _, x := range xs {
+		m = m.Mul(linear(f, x))
+	}
+
+	// L_i(x) = M(x) / (x - x_i) / Π_(j!=i) (x_i - x_j) として Σ y_i * L_i(x) を求める
+	p := &Poly{f: f}
+	for i, x := range xs {
+		num, _ := m.DivMod(linear(f, x))
+		den := num.Eval(x)
+		if den.IsZero() {
+			return nil, ErrDuplicatePoints
+		}
+		p = p.Add(num.Scale(ys[i].Mul(den.Inv())))
+	}
+	return p, nil
+}
+
+// EvalMulti は xs のそれぞれの点における p の値を返します
+// (x - x_i) の積を節点に持つ product tree を作り、根から順に剰余をとっていくことで1点ずつ Eval するより少ない計算量で求めます
+func (p *Poly) EvalMulti(xs []*field.Element) []*field.Element {
+	ys := make([]*field.Element, len(xs))
+	if len(xs) == 0 {
+		return ys
+	}
+	evalTree(p, newProductTree(p.f, xs, 0), ys)
+	return ys
+}
+
+// productTree は葉が (x - x_i) で、それ以外の節点が2つの子の積になっている二分木
+type productTree struct {
+	p           *Poly
+	index       int // 葉のときに対応する点の添字
+	left, right *productTree
+}
+
+// newProductTree は xs の各点について product tree を作る
+// offset は xs の先頭の点の元の添字
+func newProductTree(f *field.Field, xs []*field.Element, offset int) *productTree {
+	if len(xs) == 1 {
+		return &productTree{p: linear(f, xs[0]), index: offset}
+	}
+	h := len(xs) / 2
+	l := newProductTree(f, xs[:h], offset)
+	r := newProductTree(f, xs[h:], offset+h)
+	return &productTree{p: l.p.Mul(r.p), left: l, right: r}
+}
+
+// evalTree は p を節点の多項式で割ったあまりを子に渡していき、葉での値を ys に書き込む
+func evalTree(p *Poly, t *productTree, ys []*field.Element) {
+	_, r := p.DivMod(t.p)
+	if t.left == nil {
+		// 葉では (x - x_i) で割ったあまりが p(x_i) になる
+		ys[t.index] = r.Coeff(0)
+		return
+	}
+	evalTree(r, t.left, ys)
+	evalTree(r, t.right, ys)
+}
+
+// linear は x - a を返す
+func linear(f *field.Field, a *field.Element) *Poly {
+	return FromElements(f, []*field.Element{a.Neg(), f.One()})
+}
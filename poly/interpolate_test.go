@@ -0,0 +1,74 @@
+package poly
+
+import (
+	"testing"
+
+	"github.com/convto/mycrypto/big"
+	"github.com/convto/mycrypto/field"
+)
+
+// elements は int64 の値から testField の元の列を作る
+func elements(xs ...int64) []*field.Element {
+	es := make([]*field.Element, len(xs))
+	for i, x := range xs {
+		es[i] = testField.NewElement(big.NewInt(x))
+	}
+	return es
+}
+
+func TestInterpolate(t *testing.T) {
+	tests := []struct {
+		name string
+		xs   []*field.Element
+		ys   []*field.Element
+		want *Poly
+	}{
+		{
+			name: "1 + 2x + 3x^2",
+			xs:   elements(1, 2, 3),
+			ys:   elements(6, 17, 34),
+			want: newPoly(1, 2, 3),
+		},
+		{
+			name: "constant",
+			xs:   elements(5),
+			ys:   elements(42),
+			want: newPoly(42),
+		},
+		{
+			name: "degree lower than number of points",
+			xs:   elements(10, 20, 30, 40),
+			ys:   elements(7, 7, 7, 7),
+			want: newPoly(7),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Interpolate(testField, tt.xs, tt.ys)
+			if err != nil {
+				t.Fatalf("Interpolate() error = %v", err)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("Interpolate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInterpolate_duplicatePoints(t *testing.T) {
+	_, err := Interpolate(testField, elements(1, 2, 1), elements(3, 4, 5))
+	if err != ErrDuplicatePoints {
+		t.Errorf("Interpolate() error = %v, want %v", err, ErrDuplicatePoints)
+	}
+}
+
+func TestPoly_EvalMulti(t *testing.T) {
+	p := newPoly(3, 1, 4, 1, 5, 9, 2, 6, 5, 3, 5)
+	xs := elements(0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 1000002)
+	got := p.EvalMulti(xs)
+	for i, x := range xs {
+		if want := p.Eval(x); !got[i].Equal(want) {
+			t.Errorf("EvalMulti()[%d] = %v, want %v", i, got[i], want)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package poly
+
+import "github.com/convto/mycrypto/field"
+
+// karatsuba法は定数倍が大きいので、係数が16個以上の乗算について適用させるようにする
+const karatsubaThreshold = 16
+
+// mulCoeffs は係数の列どうしの積を求める
+// 結果の長さは len(x) + len(y) - 1 になる
+func mulCoeffs(f *field.Field, x, y []*field.Element) []*field.Element {
+	m, n := len(x), len(y)
+	if m < karatsubaThreshold || n < karatsubaThreshold {
+		return basicMul(f, x, y)
+	}
+	// big の karatsuba と同じく、長さを揃えて0で埋めてから分割する
+	l := m
+	if n > l {
+		l = n
+	}
+	px, py := pad(f, x, l), pad(f, y, l)
+	return karatsuba(f, px, py)[:m+n-1]
+}
+
+// basicMul は各項どうしを掛け合わせて乗算を行う
+func basicMul(f *field.Field, x, y []*field.Element) []*field.Element {
+	c := make([]*field.Element, len(x)+len(y)-1)
+	for i := range c {
+		c[i] = f.Zero()
+	}
+	for i := range x {
+		if x[i].IsZero() {
+			continue
+		}
+		for j := range y {
+			c[i+j] = c[i+j].Add(x[i].Mul(y[j]))
+		}
+	}
+	return c
+}
+
+// karatsuba は karatsuba's algorithm で乗算を行う
+// 呼び出し側は len(x) == len(y) を保証すること
+// 結果の長さは 2*len(x) - 1 になる
+func karatsuba(f *field.Field, x, y []*field.Element) []*field.Element {
+	m := len(x)
+	if m < karatsubaThreshold {
+		return basicMul(f, x, y)
+	}
+	// x = x0 + x1*t^h, y = y0 + y1*t^h (t は変数) と分割する
+	h := m / 2
+	x0, x1 := x[:h], x[h:]
+	y0, y1 := y[:h], y[h:]
+
+	// 長さを揃えるため x0, y0 は上位を0で埋めて x1, y1 と同じ長さにする
+	x0p, y0p := pad(f, x0, m-h), pad(f, y0, m-h)
+	z0 := karatsuba(f, x0, y0)
+	z2 := karatsuba(f, x1, y1)
+	// 多項式の係数には繰り上がりがないので、(x0+x1)(y0+y1) - z0 - z2 で中間の項を求める
+	z1 := karatsuba(f, addCoeffs(x0p, x1), addCoeffs(y0p, y1))
+	z1 = subCoeffs(subCoeffs(z1, z0), z2)
+
+	// z0 + z1*t^h + z2*t^(2h)
+	c := make([]*field.Element, 2*m-1)
+	for i := range c {
+		c[i] = f.Zero()
+	}
+	for i, v := range z0 {
+		c[i] = c[i].Add(v)
+	}
+	for i, v := range z1 {
+		c[i+h] = c[i+h].Add(v)
+	}
+	for i, v := range z2 {
+		c[i+2*h] = c[i+2*h].Add(v)
+	}
+	return c
+}
+
+// pad は係数の列の長さが n になるように上位の次数を0で埋める
+func pad(f *field.Field, x []*field.Element, n int) []*field.Element {
+	c := make([]*field.Element, n)
+	copy(c, x)
+	for i := len(x); i < n; i++ {
+		c[i] = f.Zero()
+	}
+	return c
+}
@@ -0,0 +1,228 @@
+// Package poly は素数 p を法とする有限体 GF(p) 上の1変数多項式の演算を提供します
+// Shamir の秘密分散や Reed-Solomon 符号、KZG コミットメントなどの基礎として使います
+package poly
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/convto/mycrypto/big"
+	"github.com/convto/mycrypto/field"
+)
+
+// Poly は c[0] + c[1]x + c[2]x^2 + ... の形の多項式です
+// 係数は低い次数から順に並べ、最高次の係数は常に0でないように正規化されています (0多項式は係数を持ちません)
+type Poly struct {
+	f *field.Field
+	c []*field.Element
+}
+
+// New は低い次数から順に係数を並べた多項式を返します
+func New(f *field.Field, coeffs ...*big.Int) *Poly {
+	c := make([]*field.Element, len(coeffs))
+	for i, x := range coeffs {
+		c[i] = f.NewElement(x)
+	}
+	return FromElements(f, c)
+}
+
+// FromElements は低い次数から順に並べた f の元を係数とする多項式を返します
+func FromElements(f *field.Field, coeffs []*field.Element) *Poly {
+	return norm(&Poly{f: f, c: coeffs})
+}
+
+// Field は係数の属する体を返します
+func (p *Poly) Field() *field.Field {
+	return p.f
+}
+
+// Degree は多項式の次数を返します
+// 0多項式の次数は -1 とします
+func (p *Poly) Degree() int {
+	return len(p.c) - 1
+}
+
+// Coeff は i 次の係数を返します
+func (p *Poly) Coeff(i int) *field.Element {
+	if i < 0 || i >= len(p.c) {
+		return p.f.Zero()
+	}
+	return p.c[i]
+}
+
+// Coeffs は低い次数から順に並べた係数を返します
+func (p *Poly) Coeffs() []*field.Element {
+	return p.c
+}
+
+// IsZero は0多項式かどうかを判定します
+func (p *Poly) IsZero() bool {
+	return len(p.c) == 0
+}
+
+// Equal は p と q が等しいかどうかを判定します
+func (p *Poly) Equal(q *Poly) bool {
+	if len(p.c) != len(q.c) {
+		return false
+	}
+	for i := range p.c {
+		if !p.c[i].Equal(q.c[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func (p *Poly) String() string {
+	if len(p.c) == 0 {
+		return "0"
+	}
+	terms := make([]string, len(p.c))
+	for i, c := range p.c {
+		terms[i] = c.String()
+		switch {
+		case i == 1:
+			terms[i] += "x"
+		case i > 1:
+			terms[i] += "x^" + strconv.Itoa(i)
+		}
+	}
+	return strings.Join(terms, " + ")
+}
+
+// Add は p + q を返します
+func (p *Poly) Add(q *Poly) *Poly {
+	return norm(&Poly{f: p.f, c: addCoeffs(p.c, q.c)})
+}
+
+// Sub は p - q を返します
+func (p *Poly) Sub(q *Poly) *Poly {
+	return norm(&Poly{f: p.f, c: subCoeffs(p.c, q.c)})
+}
+
+// Scale は p の各係数を k 倍した多項式を返します
+func (p *Poly) Scale(k *field.Element) *Poly {
+	c := make([]*field.Element, len(p.c))
+	for i := range p.c {
+		c[i] = p.c[i].Mul(k)
+	}
+	return norm(&Poly{f: p.f, c: c})
+}
+
+// Mul は p * q を返します
+func (p *Poly) Mul(q *Poly) *Poly {
+	if len(p.c) == 0 || len(q.c) == 0 {
+		return &Poly{f: p.f}
+	}
+	return norm(&Poly{f: p.f, c: mulCoeffs(p.f, p.c, q.c)})
+}
+
+// DivMod は p = q*quo + rem, deg(rem) < deg(q) となる quo, rem を返します
+// q が0多項式のときpanicします
+func (p *Poly) DivMod(q *Poly) (quo, rem *Poly) {
+	if len(q.c) == 0 {
+		panic("division by zero polynomial")
+	}
+	if len(p.c) < len(q.c) {
+		return &Poly{f: p.f}, p
+	}
+	// 筆算と同じように、最高次の項から順に q の定数倍を引いていく
+	r := append([]*field.Element(nil), p.c...)
+	qc := make([]*field.Element, len(p.c)-len(q.c)+1)
+	lead := q.c[len(q.c)-1].Inv()
+	for i := len(qc) - 1; i >= 0; i-- {
+		k := r[i+len(q.c)-1].Mul(lead)
+		qc[i] = k
+		for j := range q.c {
+			r[i+j] = r[i+j].Sub(k.Mul(q.c[j]))
+		}
+	}
+	return norm(&Poly{f: p.f, c: qc}), norm(&Poly{f: p.f, c: r[:len(q.c)-1]})
+}
+
+// Monic は最高次の係数が1になるように p を定数倍した多項式を返します
+func (p *Poly) Monic() *Poly {
+	if len(p.c) == 0 {
+		return p
+	}
+	return p.Scale(p.c[len(p.c)-1].Inv())
+}
+
+// GCD は p と q の最大公約多項式をモニックな多項式で返します
+// p と q がともに0多項式のときは0多項式を返します
+func (p *Poly) GCD(q *Poly) *Poly {
+	a, b := p, q
+	for !b.IsZero() {
+		_, r := a.DivMod(b)
+		a, b = b, r
+	}
+	return a.Monic()
+}
+
+// Eval は x における p の値を返します
+func (p *Poly) Eval(x *field.Element) *field.Element {
+	// ホーナー法で最高次の係数から順に x を掛けて足していく
+	y := p.f.Zero()
+	for i := len(p.c) - 1; i >= 0; i-- {
+		y = y.Mul(x).Add(p.c[i])
+	}
+	return y
+}
+
+// Derivative は p の形式的な微分を返します
+func (p *Poly) Derivative() *Poly {
+	if len(p.c) <= 1 {
+		return &Poly{f: p.f}
+	}
+	c := make([]*field.Element, len(p.c)-1)
+	for i := range c {
+		c[i] = p.c[i+1].Mul(p.f.NewElement(big.NewInt(int64(i + 1))))
+	}
+	return norm(&Poly{f: p.f, c: c})
+}
+
+// norm は最高次から連続して0になっている係数を取り除く
+func norm(p *Poly) *Poly {
+	i := len(p.c)
+	for i > 0 && p.c[i-1].IsZero() {
+		i--
+	}
+	p.c = p.c[:i]
+	return p
+}
+
+// addCoeffs は係数の列どうしを次数ごとに足し合わせる
+func addCoeffs(x, y []*field.Element) []*field.Element {
+	if len(x) < len(y) {
+		x, y = y, x
+	}
+	c := make([]*field.Element, len(x))
+	for i := range x {
+		if i < len(y) {
+			c[i] = x[i].Add(y[i])
+		} else {
+			c[i] = x[i]
+		}
+	}
+	return c
+}
+
+// subCoeffs は係数の列どうしを次数ごとに引く
+func subCoeffs(x, y []*field.Element) []*field.Element {
+	n := len(x)
+	if len(y) > n {
+		n = len(y)
+	}
+	c := make([]*field.Element, n)
+	for i := range c {
+		switch {
+		case i < len(x) && i < len(y):
+			c[i] = x[i].Sub(y[i])
+		case i < len(x):
+			c[i] = x[i]
+		default:
+			c[i] = y[i].Neg()
+		}
+	}
+	return c
+}
@@ -0,0 +1,271 @@
+package poly
+
+import (
+	"testing"
+
+	"github.com/convto/mycrypto/big"
+	"github.com/convto/mycrypto/field"
+)
+
+var testField = field.New(big.NewInt(1000003))
+
+// newPoly は int64 の係数から testField 上の多項式を作る
+func newPoly(coeffs ...int64) *Poly {
+	c := make([]*big.Int, len(coeffs))
+	for i, x := range coeffs {
+		c[i] = big.NewInt(x)
+	}
+	return New(testField, c...)
+}
+
+func TestNew(t *testing.T) {
+	p := newPoly(1, 2, 0, 0)
+	if got := p.Degree(); got != 1 {
+		t.Errorf("Degree() = %v, want 1", got)
+	}
+	if got := newPoly(0, 0).Degree(); got != -1 {
+		t.Errorf("Degree() = %v, want -1", got)
+	}
+	if got := newPoly(-1, 0, 3).String(); got != "1000002 + 0x + 3x^2" {
+		t.Errorf("String() = %v", got)
+	}
+}
+
+func TestPoly_Add(t *testing.T) {
+	tests := []struct {
+		name string
+		p    *Poly
+		q    *Poly
+		want *Poly
+	}{
+		{
+			name: "p + q",
+			p:    newPoly(1, 2, 3),
+			q:    newPoly(5, -2),
+			want: newPoly(6, 0, 3),
+		},
+		{
+			name: "cancel leading term",
+			p:    newPoly(1, 2, 3),
+			q:    newPoly(0, 0, -3),
+			want: newPoly(1, 2),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.p.Add(tt.q); !got.Equal(tt.want) {
+				t.Errorf("Add() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPoly_Sub(t *testing.T) {
+	tests := []struct {
+		name string
+		p    *Poly
+		q    *Poly
+		want *Poly
+	}{
+		{
+			name: "p - q",
+			p:    newPoly(1, 2),
+			q:    newPoly(5, 2, 7),
+			want: newPoly(-4, 0, -7),
+		},
+		{
+			name: "p - p = 0",
+			p:    newPoly(1, 2, 3),
+			q:    newPoly(1, 2, 3),
+			want: newPoly(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.p.Sub(tt.q); !got.Equal(tt.want) {
+				t.Errorf("Sub() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPoly_Mul(t *testing.T) {
+	tests := []struct {
+		name string
+		p    *Poly
+		q    *Poly
+		want *Poly
+	}{
+		{
+			name: "(x + 1)(x - 1)",
+			p:    newPoly(1, 1),
+			q:    newPoly(-1, 1),
+			want: newPoly(-1, 0, 1),
+		},
+		{
+			name: "(1 + 2x + 3x^2)(4 + 5x)",
+			p:    newPoly(1, 2, 3),
+			q:    newPoly(4, 5),
+			want: newPoly(4, 13, 22, 15),
+		},
+		{
+			name: "p * 0",
+			p:    newPoly(1, 2, 3),
+			q:    newPoly(),
+			want: newPoly(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.p.Mul(tt.q); !got.Equal(tt.want) {
+				t.Errorf("Mul() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPoly_Mul_karatsuba(t *testing.T) {
+	// karatsubaThreshold を超える長さで basicMul の結果と一致することを確かめる
+	for _, n := range []int{karatsubaThreshold, 37, 64} {
+		x := make([]int64, n)
+		y := make([]int64, n+3)
+		for i := range x {
+			x[i] = int64(i*i + 1)
+		}
+		for i := range y {
+			y[i] = int64(1000000 - 7*i)
+		}
+		p, q := newPoly(x...), newPoly(y...)
+		want := FromElements(testField, basicMul(testField, p.c, q.c))
+		if got := p.Mul(q); !got.Equal(want) {
+			t.Errorf("Mul() with %d coefficients = %v, want %v", n, got, want)
+		}
+	}
+}
+
+func TestPoly_DivMod(t *testing.T) {
+	tests := []struct {
+		name    string
+		p       *Poly
+		q       *Poly
+		wantQuo *Poly
+		wantRem *Poly
+	}{
+		{
+			name:    "(x^3 - 1) / (x - 1)",
+			p:       newPoly(-1, 0, 0, 1),
+			q:       newPoly(-1, 1),
+			wantQuo: newPoly(1, 1, 1),
+			wantRem: newPoly(),
+		},
+		{
+			name:    "exact division",
+			p:       newPoly(4, 13, 22, 15),
+			q:       newPoly(1, 2, 3),
+			wantQuo: newPoly(4, 5),
+			wantRem: newPoly(),
+		},
+		{
+			name:    "(x^2 + 1) / (2x)",
+			p:       newPoly(1, 0, 1),
+			q:       newPoly(0, 2),
+			wantQuo: newPoly(0, 500002),
+			wantRem: newPoly(1),
+		},
+		{
+			name:    "deg(p) < deg(q)",
+			p:       newPoly(1, 2),
+			q:       newPoly(1, 2, 3),
+			wantQuo: newPoly(),
+			wantRem: newPoly(1, 2),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			quo, rem := tt.p.DivMod(tt.q)
+			if !quo.Equal(tt.wantQuo) {
+				t.Errorf("DivMod() quo = %v, want %v", quo, tt.wantQuo)
+			}
+			if !rem.Equal(tt.wantRem) {
+				t.Errorf("DivMod() rem = %v, want %v", rem, tt.wantRem)
+			}
+		})
+	}
+}
+
+func TestPoly_GCD(t *testing.T) {
+	tests := []struct {
+		name string
+		p    *Poly
+		q    *Poly
+		want *Poly
+	}{
+		{
+			name: "common factor",
+			p:    newPoly(-1, 1).Mul(newPoly(-2, 1)),
+			q:    newPoly(-2, 1).Mul(newPoly(-3, 1)).Scale(testField.NewElement(big.NewInt(5))),
+			want: newPoly(-2, 1),
+		},
+		{
+			name: "coprime",
+			p:    newPoly(-1, 1),
+			q:    newPoly(1, 0, 1),
+			want: newPoly(1),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.p.GCD(tt.q); !got.Equal(tt.want) {
+				t.Errorf("GCD() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPoly_Eval(t *testing.T) {
+	tests := []struct {
+		name string
+		p    *Poly
+		x    int64
+		want int64
+	}{
+		{
+			name: "1 + 2x + 3x^2 at 2",
+			p:    newPoly(1, 2, 3),
+			x:    2,
+			want: 17,
+		},
+		{
+			name: "zero polynomial",
+			p:    newPoly(),
+			x:    2,
+			want: 0,
+		},
+		{
+			name: "reduced by modulus",
+			p:    newPoly(0, 0, 1),
+			x:    1000,
+			want: 1000000,
+		},
+		{
+			name: "wrap around",
+			p:    newPoly(0, 0, 1),
+			x:    1001,
+			want: 1001*1001 - 1000003,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.p.Eval(testField.NewElement(big.NewInt(tt.x)))
+			if big.Cmp(got.Int(), big.NewInt(tt.want)) != 0 {
+				t.Errorf("Eval() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPoly_Derivative(t *testing.T) {
+	if got, want := newPoly(1, 2, 3, 4).Derivative(), newPoly(2, 6, 12); !got.Equal(want) {
+		t.Errorf("Derivative() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,93 @@
+package poly
+
+import (
+	"errors"
+	"io"
+
+	"github.com/convto/mycrypto/big"
+	"github.com/convto/mycrypto/field"
+)
+
+// ErrZeroPolynomial は0多項式に対して根を求めようとしたことを表します
+// 0多項式はすべての元を根に持つので列挙できません
+var ErrZeroPolynomial = errors.New("poly: roots of zero polynomial")
+
+// Roots は p(x) = 0 となる x を重複を除いてすべて返します
+// Cantor-Zassenhaus のアルゴリズムで1次式の積に分解するため、乱数を r から読み込みます
+// 係数の体は素数 q を法としていなければなりません
+func (p *Poly) Roots(r io.Reader) ([]*field.Element, error) {
+	if p.IsZero() {
+		return nil, ErrZeroPolynomial
+	}
+	f := p.f
+	q := f.Modulus()
+	if big.Cmp(q, big.NewInt(2)) == 0 {
+		// GF(2) では0と1を試すだけでよい
+		var roots []*field.Element
+		for _, x := range []*field.Element{f.Zero(), f.One()} {
+			if p.Eval(x).IsZero() {
+				roots = append(roots, x)
+			}
+		}
+		return roots, nil
+	}
+
+	// GF(q) のすべての元は x^q - x の根なので、gcd(p, x^q - x) は p の相異なる根についての1次式の積になる
+	x := New(f, big.NewInt(0), big.NewInt(1))
+	g := p.GCD(powMod(x, q, p).Sub(x))
+	return split(g, r)
+}
+
+// split は相異なる1次式の積 g を分解して根を返す
+func split(g *Poly, r io.Reader) ([]*field.Element, error) {
+	f := g.f
+	switch g.Degree() {
+	case 0:
+		return nil, nil
+	case 1:
+		// g(x) = c1*x + c0 の根は -c0/c1
+		return []*field.Element{g.c[0].Neg().Mul(g.c[1].Inv())}, nil
+	}
+
+	// ランダムな a について (x + a)^((q-1)/2) - 1 は、x + a が平方剰余となる x を根に持つ
+	// g との gcd をとるとおよそ半分の根を持つ因数が得られるので、うまく分かれるまで a を変えて試す
+	e, _ := big.Div(big.Sub(f.Modulus(), big.NewInt(1)), big.NewInt(2))
+	one := New(f, big.NewInt(1))
+	for {
+		a, err := f.Random(r)
+		if err != nil {
+			return nil, err
+		}
+		xa := FromElements(f, []*field.Element{a, f.One()})
+		h := g.GCD(powMod(xa, e, g).Sub(one))
+		if h.Degree() <= 0 || h.Degree() >= g.Degree() {
+			continue
+		}
+		q, _ := g.DivMod(h)
+		left, err := split(h, r)
+		if err != nil {
+			return nil, err
+		}
+		right, err := split(q, r)
+		if err != nil {
+			return nil, err
+		}
+		return append(left, right...), nil
+	}
+}
+
+// powMod は base^e mod m を求める
+func powMod(base *Poly, e *big.Int, m *Poly) *Poly {
+	// e をバイト列にして上位のビットから square-and-multiply を行う
+	result := New(base.f, big.NewInt(1))
+	_, base = base.DivMod(m)
+	for _, b := range e.Bytes() {
+		for i := 7; i >= 0; i-- {
+			_, result = result.Mul(result).DivMod(m)
+			if b>>uint(i)&1 == 1 {
+				_, result = result.Mul(base).DivMod(m)
+			}
+		}
+	}
+	return result
+}
@@ -0,0 +1,83 @@
+package poly
+
+import (
+	"crypto/rand"
+	"sort"
+	"testing"
+
+	"github.com/convto/mycrypto/big"
+	"github.com/convto/mycrypto/field"
+)
+
+func TestPoly_Roots(t *testing.T) {
+	tests := []struct {
+		name string
+		p    *Poly
+		want []*field.Element
+	}{
+		{
+			name: "distinct roots with irreducible factor",
+			// 1000003 = 3 mod 4 なので x^2 + 1 は既約
+			p:    newPoly(-3, 1).Mul(newPoly(-5, 1)).Mul(newPoly(-7, 1)).Mul(newPoly(1, 0, 1)),
+			want: elements(3, 5, 7),
+		},
+		{
+			name: "repeated root",
+			p:    newPoly(-2, 1).Mul(newPoly(-2, 1)).Mul(newPoly(-9, 1)),
+			want: elements(2, 9),
+		},
+		{
+			name: "no roots",
+			p:    newPoly(1, 0, 1),
+			want: nil,
+		},
+		{
+			name: "many roots",
+			p: newPoly(-11, 1).Mul(newPoly(-22, 1)).Mul(newPoly(-33, 1)).Mul(newPoly(-44, 1)).
+				Mul(newPoly(-55, 1)).Mul(newPoly(-66, 1)).Mul(newPoly(-77, 1)).Mul(newPoly(-88, 1)),
+			want: elements(11, 22, 33, 44, 55, 66, 77, 88),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.p.Roots(rand.Reader)
+			if err != nil {
+				t.Fatalf("Roots() error = %v", err)
+			}
+			sort.Slice(got, func(i, j int) bool { return big.Cmp(got[i].Int(), got[j].Int()) < 0 })
+			if len(got) != len(tt.want) {
+				t.Fatalf("Roots() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if !got[i].Equal(tt.want[i]) {
+					t.Errorf("Roots() = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestPoly_Roots_largeField(t *testing.T) {
+	f := field.New(new(big.Int).SetString("115792089210356248762697446949407573530086143415290314195533631308867097853951"))
+	r1 := new(big.Int).SetString("48439561293906451759052585252797914202762949526041747995844080717082404635286")
+	r2 := big.NewInt(12345)
+	p := New(f, big.Sub(big.Zero, r1), big.NewInt(1)).Mul(New(f, big.Sub(big.Zero, r2), big.NewInt(1)))
+	got, err := p.Roots(rand.Reader)
+	if err != nil {
+		t.Fatalf("Roots() error = %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("Roots() = %v, want [%v %v]", got, r1, r2)
+	}
+	for _, x := range got {
+		if !p.Eval(x).IsZero() {
+			t.Errorf("p(%v) != 0", x)
+		}
+	}
+}
+
+func TestPoly_Roots_zero(t *testing.T) {
+	if _, err := newPoly().Roots(rand.Reader); err != ErrZeroPolynomial {
+		t.Errorf("Roots() error = %v, want %v", err, ErrZeroPolynomial)
+	}
+}
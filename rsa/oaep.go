@@ -0,0 +1,122 @@
+package rsa
+
+import (
+	"crypto"
+	"crypto/subtle"
+	"encoding/binary"
+	"errors"
+	"io"
+)
+
+// OAEPOptions は RSAES-OAEP のパラメータです
+type OAEPOptions struct {
+	// Hash はラベルのハッシュに使うハッシュ関数です
+	Hash crypto.Hash
+	// MGFHash は MGF1 に使うハッシュ関数で、0 のときは Hash を使います
+	MGFHash crypto.Hash
+	// Label は暗号文に結びつけるラベルで、暗号化と復号で同じものを使わなければなりません
+	Label []byte
+}
+
+// hashes は使うハッシュ関数の組を確かめて返す
+func (opts *OAEPOptions) hashes() (hash, mgfHash crypto.Hash, err error) {
+	hash, mgfHash = opts.Hash, opts.MGFHash
+	if mgfHash == 0 {
+		mgfHash = hash
+	}
+	if !hash.Available() || !mgfHash.Available() {
+		return 0, 0, ErrUnsupportedHash
+	}
+	return hash, mgfHash, nil
+}
+
+// EncryptOAEP は RFC 8017 7.1.1 の RSAES-OAEP で msg を暗号化します
+// msg は k - 2hLen - 2 バイト (k は法のバイト長、hLen は opts.Hash の出力長) 以下でなければならず、
+// 長すぎるときは ErrMessageTooLong を返します
+func EncryptOAEP(random io.Reader, pub *PublicKey, msg []byte, opts *OAEPOptions) ([]byte, error) {
+	hash, mgfHash, err := opts.hashes()
+	if err != nil {
+		return nil, err
+	}
+	k := pub.Size()
+	hLen := hash.Size()
+	if len(msg) > k-2*hLen-2 {
+		return nil, ErrMessageTooLong
+	}
+
+	// EM = 0x00 || maskedSeed || maskedDB で、DB = lHash || PS || 0x01 || M
+	em := make([]byte, k)
+	seed := em[1 : 1+hLen]
+	db := em[1+hLen:]
+	h := hash.New()
+	h.Write(opts.Label)
+	h.Sum(db[:0])
+	db[len(db)-len(msg)-1] = 1
+	copy(db[len(db)-len(msg):], msg)
+	if _, err := io.ReadFull(random, seed); err != nil {
+		return nil, err
+	}
+	mgf1XOR(db, mgfHash, seed)
+	mgf1XOR(seed, mgfHash, db)
+	return encrypt(pub, em)
+}
+
+// DecryptOAEP は RFC 8017 7.1.2 の RSAES-OAEP で ct を復号します
+// random が nil でなければ Decrypt と同じく blinding を行います
+// Manger の攻撃の手がかりにならないよう、先頭のバイト、lHash、区切りの 0x01 の検査は分岐せずにまとめて行い、
+// どれが誤っていても同じ ErrDecryption を返します
+func DecryptOAEP(random io.Reader, priv *PrivateKey, ct []byte, opts *OAEPOptions) ([]byte, error) {
+	hash, mgfHash, err := opts.hashes()
+	if err != nil {
+		return nil, err
+	}
+	k := priv.Size()
+	hLen := hash.Size()
+	if len(ct) != k || k < 2*hLen+2 {
+		return nil, ErrDecryption
+	}
+	em, err := decrypt(random, priv, ct)
+	if errors.Is(err, ErrMessageOutOfRange) {
+		return nil, ErrDecryption
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	h := hash.New()
+	h.Write(opts.Label)
+	lHash := h.Sum(nil)
+
+	// EM = 0x00 || maskedSeed || maskedDB で、DB = lHash || PS || 0x01 || M (PS は0の並び)
+	seed := em[1 : 1+hLen]
+	db := em[1+hLen:]
+	mgf1XOR(seed, mgfHash, db)
+	mgf1XOR(db, mgfHash, seed)
+	valid := subtle.ConstantTimeByteEq(em[0], 0)
+	valid &= subtle.ConstantTimeCompare(lHash, db[:hLen])
+	rest := db[hLen:]
+	index, found, ps := ctIndexByte(rest, 1)
+	valid &= found & subtle.ConstantTimeByteEq(ps, 0)
+	if valid != 1 {
+		return nil, ErrDecryption
+	}
+	return rest[index+1:], nil
+}
+
+// mgf1XOR は RFC 8017 B.2.1 の MGF1 で seed から作ったマスクを out に XOR する
+func mgf1XOR(out []byte, hash crypto.Hash, seed []byte) {
+	h := hash.New()
+	var counter [4]byte
+	var digest []byte
+	for done := 0; done < len(out); {
+		h.Reset()
+		h.Write(seed)
+		h.Write(counter[:])
+		digest = h.Sum(digest[:0])
+		for i := 0; i < len(digest) && done < len(out); i++ {
+			out[done] ^= digest[i]
+			done++
+		}
+		binary.BigEndian.PutUint32(counter[:], binary.BigEndian.Uint32(counter[:])+1)
+	}
+}
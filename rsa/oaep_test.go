@@ -0,0 +1,141 @@
+package rsa
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rand"
+	stdrsa "crypto/rsa"
+	_ "crypto/sha1"
+	"crypto/sha256"
+	"errors"
+	"testing"
+)
+
+func TestEncryptOAEP_vectors(t *testing.T) {
+	// RSA Laboratories の oaep-vect.txt の Example 1 (SHA-1, ラベルは空)
+	tests := []struct {
+		name string
+		msg  string
+		seed string
+		ct   string
+	}{
+		{
+			name: "Example 1.1",
+			msg:  "6628194e12073db03ba94cda9ef9532397d50dba79b987004afefe34",
+			seed: "18b776ea21069d69776a33e96bad48e1dda0a5ef",
+			ct:   "354fe67b4a126d5d35fe36c777791a3f7ba13def484e2d3908aff722fad468fb21696de95d0be911c2d3174f8afcc201035f7b6d8e69402de5451618c21a535fa9d7bfc5b8dd9fc243f8cf927db31322d6e881eaa91a996170e657a05a266426d98c88003f8477c1227094a0d9fa1e8c4024309ce1ecccb5210035d47ac72e8a",
+		},
+		{
+			name: "Example 1.2",
+			msg:  "750c4047f547e8e41411856523298ac9bae245efaf1397fbe56f9dd5",
+			seed: "0cc742ce4a9b7f32f951bcb251efd925fe4fe35f",
+			ct:   "640db1acc58e0568fe5407e5f9b701dff8c3c91e716c536fc7fcec6cb5b71c1165988d4a279e1577d730fc7a29932e3f00c81515236d8d8e31017a7a09df4352d904cdeb79aa583adcc31ea698a4c05283daba9089be5491f67c1a4ee48dc74bbbe6643aef846679b4cb395a352d5ed115912df696ffe0702932946d71492b44",
+		},
+		{
+			name: "Example 1.3",
+			msg:  "d94ae0832e6445ce42331cb06d531a82b1db4baad30f746dc916df24d4e3c2451fff59a6423eb0e1d02d4fe646cf699dfd818c6e97b051",
+			seed: "2514df4695755a67b288eaf4905c36eec66fd2fd",
+			ct:   "423736ed035f6026af276c35c0b3741b365e5f76ca091b4e8c29e2f0befee603595aa8322d602d2e625e95eb81b2f1c9724e822eca76db8618cf09c5343503a4360835b5903bc637e3879fb05e0ef32685d5aec5067cd7cc96fe4b2670b6eac3066b1fcf5686b68589aafb7d629b02d8f8625ca3833624d4800fb081b1cf94eb",
+		},
+	}
+	priv := testKey1024()
+	opts := &OAEPOptions{Hash: crypto.SHA1}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg, want := mustHex(tt.msg), mustHex(tt.ct)
+			// seed を乱数の代わりに読ませる
+			ct, err := EncryptOAEP(bytes.NewReader(mustHex(tt.seed)), &priv.PublicKey, msg, opts)
+			if err != nil {
+				t.Fatalf("EncryptOAEP() error = %v", err)
+			}
+			if !bytes.Equal(ct, want) {
+				t.Errorf("EncryptOAEP() = %x, want %x", ct, want)
+			}
+			got, err := DecryptOAEP(nil, priv, want, opts)
+			if err != nil {
+				t.Fatalf("DecryptOAEP() error = %v", err)
+			}
+			if !bytes.Equal(got, msg) {
+				t.Errorf("DecryptOAEP() = %x, want %x", got, msg)
+			}
+		})
+	}
+}
+
+func TestEncryptOAEP(t *testing.T) {
+	priv := testKey1024()
+	k := priv.Size()
+	tests := []struct {
+		name    string
+		opts    *OAEPOptions
+		msg     []byte
+		wantErr error
+	}{
+		{name: "SHA-256", opts: &OAEPOptions{Hash: crypto.SHA256}, msg: []byte("mycrypto")},
+		{name: "label", opts: &OAEPOptions{Hash: crypto.SHA256, Label: []byte("label")}, msg: []byte("mycrypto")},
+		{name: "MGF1 with SHA-1", opts: &OAEPOptions{Hash: crypto.SHA256, MGFHash: crypto.SHA1}, msg: []byte("mycrypto")},
+		{name: "empty", opts: &OAEPOptions{Hash: crypto.SHA256}, msg: []byte{}},
+		{name: "max length", opts: &OAEPOptions{Hash: crypto.SHA256}, msg: bytes.Repeat([]byte{1}, k-2*32-2)},
+		{name: "too long", opts: &OAEPOptions{Hash: crypto.SHA256}, msg: bytes.Repeat([]byte{1}, k-2*32-1), wantErr: ErrMessageTooLong},
+		{name: "unavailable hash", opts: &OAEPOptions{Hash: crypto.BLAKE2b_256}, msg: []byte("mycrypto"), wantErr: ErrUnsupportedHash},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ct, err := EncryptOAEP(rand.Reader, &priv.PublicKey, tt.msg, tt.opts)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("EncryptOAEP() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			got, err := DecryptOAEP(nil, priv, ct, tt.opts)
+			if err != nil {
+				t.Fatalf("DecryptOAEP() error = %v", err)
+			}
+			if !bytes.Equal(got, tt.msg) {
+				t.Errorf("DecryptOAEP() = %x, want %x", got, tt.msg)
+			}
+			// 標準ライブラリでも復号できる
+			std, err := toStd(priv).Decrypt(nil, ct, &stdrsa.OAEPOptions{Hash: tt.opts.Hash, MGFHash: tt.opts.MGFHash, Label: tt.opts.Label})
+			if err != nil {
+				t.Fatalf("crypto/rsa.PrivateKey.Decrypt() error = %v", err)
+			}
+			if !bytes.Equal(std, tt.msg) {
+				t.Errorf("crypto/rsa.PrivateKey.Decrypt() = %x, want %x", std, tt.msg)
+			}
+		})
+	}
+}
+
+func TestDecryptOAEP_invalid(t *testing.T) {
+	priv := testKey1024()
+	opts := &OAEPOptions{Hash: crypto.SHA256, Label: []byte("label")}
+	ct, err := stdrsa.EncryptOAEP(sha256.New(), rand.Reader, &toStd(priv).PublicKey, []byte("mycrypto"), opts.Label)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := DecryptOAEP(rand.Reader, priv, ct, opts); err != nil {
+		t.Fatalf("DecryptOAEP() error = %v", err)
+	}
+
+	tampered := append([]byte(nil), ct...)
+	tampered[len(tampered)-1] ^= 1
+	tests := []struct {
+		name string
+		ct   []byte
+		opts *OAEPOptions
+	}{
+		{name: "wrong label", ct: ct, opts: &OAEPOptions{Hash: crypto.SHA256, Label: []byte("other")}},
+		{name: "wrong hash", ct: ct, opts: &OAEPOptions{Hash: crypto.SHA1, Label: opts.Label}},
+		{name: "tampered", ct: tampered, opts: opts},
+		{name: "wrong length", ct: ct[1:], opts: opts},
+		{name: "ct >= n", ct: bytes.Repeat([]byte{0xff}, len(ct)), opts: opts},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := DecryptOAEP(nil, priv, tt.ct, tt.opts); !errors.Is(err, ErrDecryption) {
+				t.Errorf("DecryptOAEP() error = %v, want %v", err, ErrDecryption)
+			}
+		})
+	}
+}
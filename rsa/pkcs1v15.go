@@ -0,0 +1,189 @@
+package rsa
+
+import (
+	"crypto"
+	"crypto/subtle"
+	"errors"
+	"io"
+)
+
+// hashPrefixes は RSASSA-PKCS1-v1_5 の DigestInfo のうち、ハッシュ値の前に付く DER エンコードされた部分です (RFC 8017 9.2 の注1)
+var hashPrefixes = map[crypto.Hash][]byte{
+	crypto.MD5:        {0x30, 0x20, 0x30, 0x0c, 0x06, 0x08, 0x2a, 0x86, 0x48, 0x86, 0xf7, 0x0d, 0x02, 0x05, 0x05, 0x00, 0x04, 0x10},
+	crypto.SHA1:       {0x30, 0x21, 0x30, 0x09, 0x06, 0x05, 0x2b, 0x0e, 0x03, 0x02, 0x1a, 0x05, 0x00, 0x04, 0x14},
+	crypto.SHA224:     {0x30, 0x2d, 0x30, 0x0d, 0x06, 0x09, 0x60, 0x86, 0x48, 0x01, 0x65, 0x03, 0x04, 0x02, 0x04, 0x05, 0x00, 0x04, 0x1c},
+	crypto.SHA256:     {0x30, 0x31, 0x30, 0x0d, 0x06, 0x09, 0x60, 0x86, 0x48, 0x01, 0x65, 0x03, 0x04, 0x02, 0x01, 0x05, 0x00, 0x04, 0x20},
+	crypto.SHA384:     {0x30, 0x41, 0x30, 0x0d, 0x06, 0x09, 0x60, 0x86, 0x48, 0x01, 0x65, 0x03, 0x04, 0x02, 0x02, 0x05, 0x00, 0x04, 0x30},
+	crypto.SHA512:     {0x30, 0x51, 0x30, 0x0d, 0x06, 0x09, 0x60, 0x86, 0x48, 0x01, 0x65, 0x03, 0x04, 0x02, 0x03, 0x05, 0x00, 0x04, 0x40},
+	crypto.SHA512_224: {0x30, 0x2d, 0x30, 0x0d, 0x06, 0x09, 0x60, 0x86, 0x48, 0x01, 0x65, 0x03, 0x04, 0x02, 0x05, 0x05, 0x00, 0x04, 0x1c},
+	crypto.SHA512_256: {0x30, 0x31, 0x30, 0x0d, 0x06, 0x09, 0x60, 0x86, 0x48, 0x01, 0x65, 0x03, 0x04, 0x02, 0x06, 0x05, 0x00, 0x04, 0x20},
+	crypto.SHA3_224:   {0x30, 0x2d, 0x30, 0x0d, 0x06, 0x09, 0x60, 0x86, 0x48, 0x01, 0x65, 0x03, 0x04, 0x02, 0x07, 0x05, 0x00, 0x04, 0x1c},
+	crypto.SHA3_256:   {0x30, 0x31, 0x30, 0x0d, 0x06, 0x09, 0x60, 0x86, 0x48, 0x01, 0x65, 0x03, 0x04, 0x02, 0x08, 0x05, 0x00, 0x04, 0x20},
+	crypto.SHA3_384:   {0x30, 0x41, 0x30, 0x0d, 0x06, 0x09, 0x60, 0x86, 0x48, 0x01, 0x65, 0x03, 0x04, 0x02, 0x09, 0x05, 0x00, 0x04, 0x30},
+	crypto.SHA3_512:   {0x30, 0x51, 0x30, 0x0d, 0x06, 0x09, 0x60, 0x86, 0x48, 0x01, 0x65, 0x03, 0x04, 0x02, 0x0a, 0x05, 0x00, 0x04, 0x40},
+}
+
+// EncryptPKCS1v15 は RFC 8017 7.2.1 の RSAES-PKCS1-v1_5 で msg を暗号化します
+// msg は k - 11 バイト (k は法のバイト長) 以下でなければならず、長すぎるときは ErrMessageTooLong を返します
+// 新しく使うなら Bleichenbacher の攻撃に強い OAEP を使うべきです
+func EncryptPKCS1v15(random io.Reader, pub *PublicKey, msg []byte) ([]byte, error) {
+	k := pub.Size()
+	if len(msg) > k-11 {
+		return nil, ErrMessageTooLong
+	}
+	// EM = 0x00 || 0x02 || PS || 0x00 || M で、PS は0を含まない乱数
+	em := make([]byte, k)
+	em[1] = 2
+	ps := em[2 : k-len(msg)-1]
+	if err := nonZeroRandomBytes(random, ps); err != nil {
+		return nil, err
+	}
+	copy(em[k-len(msg):], msg)
+	return encrypt(pub, em)
+}
+
+// DecryptPKCS1v15 は RFC 8017 7.2.2 の RSAES-PKCS1-v1_5 で ct を復号します
+// random が nil でなければ Decrypt と同じく blinding を行います
+// パディングの検査は分岐せずに行いますが、失敗したことは ErrDecryption で呼び出し側に伝わるので、
+// 失敗を攻撃者に観測させてはいけない用途では DecryptPKCS1v15SessionKey を使います
+func DecryptPKCS1v15(random io.Reader, priv *PrivateKey, ct []byte) ([]byte, error) {
+	valid, em, index, err := decryptPKCS1v15(random, priv, ct)
+	if err != nil {
+		return nil, err
+	}
+	if valid == 0 {
+		return nil, ErrDecryption
+	}
+	return em[index:], nil
+}
+
+// DecryptPKCS1v15SessionKey は ct を復号して得た鍵を key にコピーします
+// パディングが不正か長さが len(key) と異なるときは、エラーを返さずに key を書き換えません
+// 呼び出し側はあらかじめ key に乱数を入れておき、パディングの正否によらず同じように処理を続けることで、
+// Bleichenbacher の攻撃の手がかりになる応答の違いをなくせます (RFC 5246 7.4.7.1)
+// 暗号文の長さが法のバイト長と異なるなど、公開されている情報だけで決まる誤りのときはエラーを返します
+func DecryptPKCS1v15SessionKey(random io.Reader, priv *PrivateKey, ct []byte, key []byte) error {
+	k := priv.Size()
+	if k-(len(key)+3+8) < 0 {
+		return ErrDecryption
+	}
+	valid, em, index, err := decryptPKCS1v15(random, priv, ct)
+	if err != nil {
+		return err
+	}
+	valid &= subtle.ConstantTimeEq(int32(len(em)-index), int32(len(key)))
+	subtle.ConstantTimeCopy(valid, key, em[len(em)-len(key):])
+	return nil
+}
+
+// decryptPKCS1v15 は ct を復号し、パディングが正しいかどうか (valid は 1 か 0) とメッセージの開始位置を返す
+// パディングの検査は EM の内容によらず同じ処理を行う
+func decryptPKCS1v15(random io.Reader, priv *PrivateKey, ct []byte) (valid int, em []byte, index int, err error) {
+	k := priv.Size()
+	if k < 11 || len(ct) != k {
+		return 0, nil, 0, ErrDecryption
+	}
+	em, err = decrypt(random, priv, ct)
+	if errors.Is(err, ErrMessageOutOfRange) {
+		return 0, nil, 0, ErrDecryption
+	}
+	if err != nil {
+		return 0, nil, 0, err
+	}
+
+	// EM = 0x00 || 0x02 || PS || 0x00 || M で、PS は8バイト以上
+	sep, found, _ := ctIndexByte(em[2:], 0)
+	valid = subtle.ConstantTimeByteEq(em[0], 0) & subtle.ConstantTimeByteEq(em[1], 2)
+	valid &= found & subtle.ConstantTimeLessOrEq(8, sep)
+	return valid, em, subtle.ConstantTimeSelect(valid, sep+3, 0), nil
+}
+
+// ctIndexByte は b の中で最初に c が現れる位置 i を、b の内容によらず同じ処理で探す
+// c が見つかれば found = 1、見つからなければ found = 0 と i = 0 を返す
+// prefix は最初の c より前にあるバイトをすべて OR したもの
+func ctIndexByte(b []byte, c byte) (i, found int, prefix byte) {
+	for j, x := range b {
+		eq := subtle.ConstantTimeByteEq(x, c)
+		i = subtle.ConstantTimeSelect(eq&^found, j, i)
+		found |= eq
+		// found が0の間だけ mask は 0xff になる
+		mask := byte(found - 1)
+		prefix |= x & mask
+	}
+	return i, found, prefix
+}
+
+// nonZeroRandomBytes は random から読み込んだ0を含まない乱数で b を埋める
+func nonZeroRandomBytes(random io.Reader, b []byte) error {
+	if _, err := io.ReadFull(random, b); err != nil {
+		return err
+	}
+	for i := range b {
+		for b[i] == 0 {
+			if _, err := io.ReadFull(random, b[i:i+1]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// SignPKCS1v15 は RFC 8017 8.2.1 の RSASSA-PKCS1-v1_5 で、hash でハッシュした値 hashed に署名します
+// hash が 0 のときは DigestInfo を付けずに hashed そのものに署名します
+// random が nil でなければ Decrypt と同じく blinding を行います
+func SignPKCS1v15(random io.Reader, priv *PrivateKey, hash crypto.Hash, hashed []byte) ([]byte, error) {
+	em, err := pkcs1v15EncodeSignature(hash, hashed, priv.Size())
+	if err != nil {
+		return nil, err
+	}
+	return decrypt(random, priv, em)
+}
+
+// VerifyPKCS1v15 は RFC 8017 8.2.2 の RSASSA-PKCS1-v1_5 で、hashed に対する署名 sig を検証します
+// 署名が正しくなければ ErrInvalidSignature を返します
+func VerifyPKCS1v15(pub *PublicKey, hash crypto.Hash, hashed []byte, sig []byte) error {
+	k := pub.Size()
+	want, err := pkcs1v15EncodeSignature(hash, hashed, k)
+	if err != nil {
+		return err
+	}
+	if len(sig) != k {
+		return ErrInvalidSignature
+	}
+	// EM を読み解かずに、期待する EM を組み立てて比べる (RFC 8017 8.2.2 の手順3, 4)
+	em, err := encrypt(pub, sig)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+	if subtle.ConstantTimeCompare(em, want) != 1 {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// pkcs1v15EncodeSignature は RFC 8017 9.2 の EMSA-PKCS1-v1_5 で長さ k の EM を組み立てる
+// EM = 0x00 || 0x01 || PS || 0x00 || T で、PS は 0xff の並び、T は DigestInfo
+func pkcs1v15EncodeSignature(hash crypto.Hash, hashed []byte, k int) ([]byte, error) {
+	var prefix []byte
+	if hash != 0 {
+		var ok bool
+		if prefix, ok = hashPrefixes[hash]; !ok {
+			return nil, ErrUnsupportedHash
+		}
+		if len(hashed) != hash.Size() {
+			return nil, errors.New("rsa: input must be hashed message")
+		}
+	}
+	tLen := len(prefix) + len(hashed)
+	if k < tLen+11 {
+		return nil, ErrMessageTooLong
+	}
+	em := make([]byte, k)
+	em[1] = 1
+	for i := 2; i < k-tLen-1; i++ {
+		em[i] = 0xff
+	}
+	copy(em[k-tLen:], prefix)
+	copy(em[k-len(hashed):], hashed)
+	return em, nil
+}
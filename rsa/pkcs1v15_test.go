@@ -0,0 +1,264 @@
+package rsa
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rand"
+	stdrsa "crypto/rsa"
+	"crypto/sha1"
+	"crypto/sha256"
+	"errors"
+	"testing"
+)
+
+func TestEncryptPKCS1v15(t *testing.T) {
+	priv := testKey1024()
+	k := priv.Size()
+	tests := []struct {
+		name    string
+		msg     []byte
+		wantErr error
+	}{
+		{name: "empty", msg: []byte{}},
+		{name: "short", msg: []byte("mycrypto")},
+		{name: "max length", msg: bytes.Repeat([]byte{0xab}, k-11)},
+		{name: "too long", msg: bytes.Repeat([]byte{0xab}, k-10), wantErr: ErrMessageTooLong},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ct, err := EncryptPKCS1v15(rand.Reader, &priv.PublicKey, tt.msg)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("EncryptPKCS1v15() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if len(ct) != k {
+				t.Errorf("len(ct) = %v, want %v", len(ct), k)
+			}
+			got, err := DecryptPKCS1v15(nil, priv, ct)
+			if err != nil {
+				t.Fatalf("DecryptPKCS1v15() error = %v", err)
+			}
+			if !bytes.Equal(got, tt.msg) {
+				t.Errorf("DecryptPKCS1v15() = %x, want %x", got, tt.msg)
+			}
+			// 標準ライブラリでも復号できる
+			std, err := stdrsa.DecryptPKCS1v15(nil, toStd(priv), ct)
+			if err != nil {
+				t.Fatalf("crypto/rsa.DecryptPKCS1v15() error = %v", err)
+			}
+			if !bytes.Equal(std, tt.msg) {
+				t.Errorf("crypto/rsa.DecryptPKCS1v15() = %x, want %x", std, tt.msg)
+			}
+		})
+	}
+}
+
+func TestDecryptPKCS1v15(t *testing.T) {
+	priv := testKey1024()
+	msg := []byte("mycrypto")
+	ct, err := stdrsa.EncryptPKCS1v15(rand.Reader, &toStd(priv).PublicKey, msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, random := range []bool{false, true} {
+		var got []byte
+		if random {
+			got, err = DecryptPKCS1v15(rand.Reader, priv, ct)
+		} else {
+			got, err = DecryptPKCS1v15(nil, priv, ct)
+		}
+		if err != nil {
+			t.Fatalf("DecryptPKCS1v15() error = %v", err)
+		}
+		if !bytes.Equal(got, msg) {
+			t.Errorf("DecryptPKCS1v15() = %x, want %x (blinding: %v)", got, msg, random)
+		}
+	}
+}
+
+// pkcs1v15EM は EM = 0x00 || 0x02 || PS || 0x00 || msg を長さ k で組み立て、modify で書き換える
+func pkcs1v15EM(k int, msg []byte, modify func(em []byte)) []byte {
+	em := make([]byte, k)
+	em[1] = 2
+	for i := 2; i < k-len(msg)-1; i++ {
+		em[i] = 0x55
+	}
+	copy(em[k-len(msg):], msg)
+	modify(em)
+	return em
+}
+
+func TestDecryptPKCS1v15_invalid(t *testing.T) {
+	priv := testKey1024()
+	k := priv.Size()
+	msg := []byte("mycrypto")
+	tests := []struct {
+		name   string
+		modify func(em []byte)
+	}{
+		{name: "first byte", modify: func(em []byte) { em[0] = 1 }},
+		{name: "block type 1", modify: func(em []byte) { em[1] = 1 }},
+		{name: "no separator", modify: func(em []byte) { em[k-len(msg)-1] = 0x55 }},
+		{name: "PS too short", modify: func(em []byte) { em[9] = 0 }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ct, err := encrypt(&priv.PublicKey, pkcs1v15EM(k, msg, tt.modify))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := DecryptPKCS1v15(nil, priv, ct); !errors.Is(err, ErrDecryption) {
+				t.Errorf("DecryptPKCS1v15() error = %v, want %v", err, ErrDecryption)
+			}
+		})
+	}
+
+	t.Run("PS of 8 bytes", func(t *testing.T) {
+		// PS がちょうど8バイトなら受け入れる
+		long := bytes.Repeat([]byte{0xab}, k-11)
+		ct, err := encrypt(&priv.PublicKey, pkcs1v15EM(k, long, func([]byte) {}))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, err := DecryptPKCS1v15(nil, priv, ct); err != nil || !bytes.Equal(got, long) {
+			t.Errorf("DecryptPKCS1v15() = %x, %v, want %x", got, err, long)
+		}
+	})
+	t.Run("wrong length", func(t *testing.T) {
+		if _, err := DecryptPKCS1v15(nil, priv, make([]byte, k-1)); !errors.Is(err, ErrDecryption) {
+			t.Errorf("DecryptPKCS1v15() error = %v, want %v", err, ErrDecryption)
+		}
+	})
+	t.Run("ct >= n", func(t *testing.T) {
+		if _, err := DecryptPKCS1v15(nil, priv, bytes.Repeat([]byte{0xff}, k)); !errors.Is(err, ErrDecryption) {
+			t.Errorf("DecryptPKCS1v15() error = %v, want %v", err, ErrDecryption)
+		}
+	})
+}
+
+func TestDecryptPKCS1v15SessionKey(t *testing.T) {
+	priv := testKey1024()
+	k := priv.Size()
+	sessionKey := bytes.Repeat([]byte{0x42}, 16)
+	valid, err := encrypt(&priv.PublicKey, pkcs1v15EM(k, sessionKey, func([]byte) {}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	invalid, err := encrypt(&priv.PublicKey, pkcs1v15EM(k, sessionKey, func(em []byte) { em[1] = 1 }))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name   string
+		ct     []byte
+		keyLen int
+		want   bool
+	}{
+		{name: "valid", ct: valid, keyLen: 16, want: true},
+		{name: "wrong key length", ct: valid, keyLen: 24, want: false},
+		{name: "invalid padding", ct: invalid, keyLen: 16, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// 失敗したときは key に入れておいた値がそのまま残る
+			key := bytes.Repeat([]byte{0x99}, tt.keyLen)
+			if err := DecryptPKCS1v15SessionKey(nil, priv, tt.ct, key); err != nil {
+				t.Fatalf("DecryptPKCS1v15SessionKey() error = %v", err)
+			}
+			want := bytes.Repeat([]byte{0x99}, tt.keyLen)
+			if tt.want {
+				want = sessionKey
+			}
+			if !bytes.Equal(key, want) {
+				t.Errorf("key = %x, want %x", key, want)
+			}
+		})
+	}
+}
+
+func TestCtIndexByte(t *testing.T) {
+	tests := []struct {
+		name       string
+		b          []byte
+		wantI      int
+		wantFound  int
+		wantPrefix byte
+	}{
+		{name: "first", b: []byte{1, 2, 1}, wantI: 0, wantFound: 1, wantPrefix: 0},
+		{name: "middle", b: []byte{0, 4, 1, 8, 1}, wantI: 2, wantFound: 1, wantPrefix: 4},
+		{name: "last", b: []byte{2, 0, 1}, wantI: 2, wantFound: 1, wantPrefix: 2},
+		{name: "not found", b: []byte{0, 2, 4}, wantI: 0, wantFound: 0, wantPrefix: 6},
+		{name: "empty", b: nil, wantI: 0, wantFound: 0, wantPrefix: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			i, found, prefix := ctIndexByte(tt.b, 1)
+			if i != tt.wantI || found != tt.wantFound || prefix != tt.wantPrefix {
+				t.Errorf("ctIndexByte() = (%d, %d, %#x), want (%d, %d, %#x)", i, found, prefix, tt.wantI, tt.wantFound, tt.wantPrefix)
+			}
+		})
+	}
+}
+
+func TestSignPKCS1v15(t *testing.T) {
+	priv := testKey1024()
+	sha1Digest := sha1.Sum([]byte("mycrypto"))
+	sha256Digest := sha256.Sum256([]byte("mycrypto"))
+	tests := []struct {
+		name   string
+		hash   crypto.Hash
+		hashed []byte
+	}{
+		{name: "SHA-1", hash: crypto.SHA1, hashed: sha1Digest[:]},
+		{name: "SHA-256", hash: crypto.SHA256, hashed: sha256Digest[:]},
+		{name: "raw", hash: 0, hashed: []byte("already encoded")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sig, err := SignPKCS1v15(rand.Reader, priv, tt.hash, tt.hashed)
+			if err != nil {
+				t.Fatalf("SignPKCS1v15() error = %v", err)
+			}
+			// 署名は決定的なので標準ライブラリと一致する
+			want, err := stdrsa.SignPKCS1v15(nil, toStd(priv), tt.hash, tt.hashed)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(sig, want) {
+				t.Errorf("SignPKCS1v15() = %x, want %x", sig, want)
+			}
+			if err := VerifyPKCS1v15(&priv.PublicKey, tt.hash, tt.hashed, sig); err != nil {
+				t.Errorf("VerifyPKCS1v15() error = %v", err)
+			}
+			sig[len(sig)-1] ^= 1
+			if err := VerifyPKCS1v15(&priv.PublicKey, tt.hash, tt.hashed, sig); !errors.Is(err, ErrInvalidSignature) {
+				t.Errorf("VerifyPKCS1v15() error = %v, want %v", err, ErrInvalidSignature)
+			}
+		})
+	}
+}
+
+func TestSignPKCS1v15_invalid(t *testing.T) {
+	priv := testKey1024()
+	digest := sha256.Sum256([]byte("mycrypto"))
+	tests := []struct {
+		name   string
+		hash   crypto.Hash
+		hashed []byte
+		want   error
+	}{
+		{name: "unsupported hash", hash: crypto.MD5SHA1, hashed: make([]byte, 36), want: ErrUnsupportedHash},
+		{name: "wrong digest length", hash: crypto.SHA256, hashed: digest[:20]},
+		{name: "too long", hash: 0, hashed: make([]byte, priv.Size()-10), want: ErrMessageTooLong},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := SignPKCS1v15(nil, priv, tt.hash, tt.hashed)
+			if err == nil || tt.want != nil && !errors.Is(err, tt.want) {
+				t.Errorf("SignPKCS1v15() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
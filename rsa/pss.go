@@ -0,0 +1,174 @@
+package rsa
+
+import (
+	"bytes"
+	"crypto"
+	"errors"
+	"io"
+)
+
+const (
+	// PSSSaltLengthAuto は署名では取りうる最大の長さのソルトを使い、検証ではソルトの長さを EM から読み取ることを表します
+	PSSSaltLengthAuto = 0
+	// PSSSaltLengthEqualsHash はソルトの長さをハッシュ関数の出力長と同じにすることを表します
+	PSSSaltLengthEqualsHash = -1
+)
+
+// PSSOptions は RSASSA-PSS のパラメータです
+// MGF1 には署名するハッシュ値と同じハッシュ関数を使います
+type PSSOptions struct {
+	// SaltLength はソルトのバイト長か、PSSSaltLengthAuto, PSSSaltLengthEqualsHash のどちらかです
+	SaltLength int
+}
+
+// saltLength は opts が nil のときも含めて SaltLength を返す
+func (opts *PSSOptions) saltLength() int {
+	if opts == nil {
+		return PSSSaltLengthAuto
+	}
+	return opts.SaltLength
+}
+
+// SignPSS は RFC 8017 8.1.1 の RSASSA-PSS で、hash でハッシュした値 digest に署名します
+// ソルトは random から読み込み、blinding にも random を使います
+// opts が nil のときは PSSSaltLengthAuto として扱います
+func SignPSS(random io.Reader, priv *PrivateKey, hash crypto.Hash, digest []byte, opts *PSSOptions) ([]byte, error) {
+	if !hash.Available() {
+		return nil, ErrUnsupportedHash
+	}
+	// EM のビット長は法より1ビット短い
+	emBits := priv.N.BitLen() - 1
+	emLen := (emBits + 7) / 8
+	sLen := opts.saltLength()
+	switch {
+	case sLen == PSSSaltLengthAuto:
+		sLen = emLen - hash.Size() - 2
+	case sLen == PSSSaltLengthEqualsHash:
+		sLen = hash.Size()
+	case sLen < 0:
+		return nil, errors.New("rsa: invalid PSS salt length")
+	}
+	if sLen < 0 {
+		return nil, ErrMessageTooLong
+	}
+	salt := make([]byte, sLen)
+	if _, err := io.ReadFull(random, salt); err != nil {
+		return nil, err
+	}
+	em, err := emsaPSSEncode(digest, emBits, salt, hash)
+	if err != nil {
+		return nil, err
+	}
+	return decrypt(random, priv, em)
+}
+
+// VerifyPSS は RFC 8017 8.1.2 の RSASSA-PSS で、digest に対する署名 sig を検証します
+// opts が nil のときや SaltLength が PSSSaltLengthAuto のときは、ソルトの長さを EM から読み取ります
+// 署名が正しくなければ ErrInvalidSignature を返します
+func VerifyPSS(pub *PublicKey, hash crypto.Hash, digest []byte, sig []byte, opts *PSSOptions) error {
+	if !hash.Available() {
+		return ErrUnsupportedHash
+	}
+	if len(sig) != pub.Size() {
+		return ErrInvalidSignature
+	}
+	em, err := encrypt(pub, sig)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+	emBits := pub.N.BitLen() - 1
+	emLen := (emBits + 7) / 8
+	// 法のビット長が8の倍数に1を足した値のときは EM が k - 1 バイトになり、先頭のバイトは0でなければならない
+	if emLen < len(em) {
+		if em[0] != 0 {
+			return ErrInvalidSignature
+		}
+		em = em[1:]
+	}
+	return emsaPSSVerify(digest, em, emBits, opts.saltLength(), hash)
+}
+
+// emsaPSSEncode は RFC 8017 9.1.1 の EMSA-PSS-ENCODE で、ビット長が emBits 以下の EM を組み立てる
+// EM = maskedDB || H || 0xbc で、H = Hash(0x00 * 8 || mHash || salt), DB = PS || 0x01 || salt
+func emsaPSSEncode(mHash []byte, emBits int, salt []byte, hash crypto.Hash) ([]byte, error) {
+	hLen := hash.Size()
+	sLen := len(salt)
+	emLen := (emBits + 7) / 8
+	if len(mHash) != hLen {
+		return nil, errors.New("rsa: input must be hashed message")
+	}
+	if emLen < hLen+sLen+2 {
+		return nil, ErrMessageTooLong
+	}
+
+	em := make([]byte, emLen)
+	psLen := emLen - sLen - hLen - 2
+	db := em[:psLen+1+sLen]
+	h := em[psLen+1+sLen : emLen-1]
+
+	hh := hash.New()
+	hh.Write(make([]byte, 8))
+	hh.Write(mHash)
+	hh.Write(salt)
+	hh.Sum(h[:0])
+
+	db[psLen] = 1
+	copy(db[psLen+1:], salt)
+	mgf1XOR(db, hash, h)
+	// emBits を超える上位のビットを落とす
+	db[0] &= 0xff >> uint(8*emLen-emBits)
+	em[emLen-1] = 0xbc
+	return em, nil
+}
+
+// emsaPSSVerify は RFC 8017 9.1.2 の EMSA-PSS-VERIFY で、EM が mHash に対する正しい符号化かを確かめる
+// sLen が PSSSaltLengthAuto のときは、DB の中の 0x01 の位置からソルトの長さを決める
+func emsaPSSVerify(mHash, em []byte, emBits, sLen int, hash crypto.Hash) error {
+	hLen := hash.Size()
+	if sLen == PSSSaltLengthEqualsHash {
+		sLen = hLen
+	}
+	emLen := (emBits + 7) / 8
+	if sLen < 0 || len(em) != emLen || len(mHash) != hLen {
+		return ErrInvalidSignature
+	}
+	if emLen < hLen+sLen+2 || em[emLen-1] != 0xbc {
+		return ErrInvalidSignature
+	}
+
+	db := em[:emLen-hLen-1]
+	h := em[emLen-hLen-1 : emLen-1]
+	bitMask := byte(0xff >> uint(8*emLen-emBits))
+	if em[0]&^bitMask != 0 {
+		return ErrInvalidSignature
+	}
+	mgf1XOR(db, hash, h)
+	db[0] &= bitMask
+
+	if sLen == PSSSaltLengthAuto {
+		psLen := bytes.IndexByte(db, 1)
+		if psLen < 0 {
+			return ErrInvalidSignature
+		}
+		sLen = len(db) - psLen - 1
+	}
+	psLen := emLen - hLen - sLen - 2
+	for _, b := range db[:psLen] {
+		if b != 0 {
+			return ErrInvalidSignature
+		}
+	}
+	if db[psLen] != 1 {
+		return ErrInvalidSignature
+	}
+	salt := db[len(db)-sLen:]
+
+	hh := hash.New()
+	hh.Write(make([]byte, 8))
+	hh.Write(mHash)
+	hh.Write(salt)
+	if !bytes.Equal(hh.Sum(nil), h) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
@@ -0,0 +1,182 @@
+package rsa
+
+import (
+	"bufio"
+	"bytes"
+	"crypto"
+	"crypto/rand"
+	stdrsa "crypto/rsa"
+	"crypto/sha1"
+	"crypto/sha256"
+	"errors"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/convto/mycrypto/big"
+)
+
+// pssVector は pss-vect.txt の1つの署名の例
+type pssVector struct {
+	name           string
+	priv           *PrivateKey
+	msg, salt, sig []byte
+}
+
+// readPSSVectors は pss-vect.txt を読み込む
+// 値は "# <名前>:" の行に続く16進数の行で、空行で終わる
+func readPSSVectors(t *testing.T) []pssVector {
+	f, err := os.Open("testdata/pss-vect.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	var vs []pssVector
+	var priv *PrivateKey
+	var v pssVector
+	var name, label, value string
+	flush := func() {
+		if value == "" {
+			return
+		}
+		b := mustHex(value)
+		switch label {
+		case "RSA modulus n":
+			priv = &PrivateKey{PublicKey: PublicKey{N: new(big.Int).SetBytes(b)}}
+		case "RSA public exponent e":
+			priv.E = bytesToInt(b)
+		case "RSA private exponent d":
+			priv.D = new(big.Int).SetBytes(b)
+		case "Prime p", "Prime q":
+			priv.Primes = append(priv.Primes, new(big.Int).SetBytes(b))
+		case "Message to be signed":
+			v = pssVector{name: name, priv: priv, msg: b}
+		case "Salt":
+			v.salt = b
+		case "Signature":
+			v.sig = b
+			vs = append(vs, v)
+		}
+		value = ""
+	}
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		line := strings.TrimSpace(s.Text())
+		switch {
+		case line == "":
+			flush()
+		case strings.HasPrefix(line, "# RSASSA-PSS Signature Example"):
+			name = strings.TrimPrefix(line, "# RSASSA-PSS Signature Example ")
+		case strings.HasPrefix(line, "#") && strings.HasSuffix(line, ":"):
+			label = strings.TrimSpace(strings.TrimSuffix(strings.TrimPrefix(line, "#"), ":"))
+		case strings.HasPrefix(line, "#"):
+		default:
+			value += strings.ReplaceAll(line, " ", "")
+		}
+	}
+	flush()
+	if err := s.Err(); err != nil {
+		t.Fatal(err)
+	}
+	return vs
+}
+
+func TestSignPSS_vectors(t *testing.T) {
+	// RSA Laboratories の pss-vect.txt (SHA-1, ソルトは20バイト)
+	vs := readPSSVectors(t)
+	if len(vs) != 60 {
+		t.Fatalf("read %d vectors, want 60", len(vs))
+	}
+	for _, v := range vs {
+		t.Run(v.name, func(t *testing.T) {
+			digest := sha1.Sum(v.msg)
+			// ソルトを読ませた後は blinding のための乱数を読ませる
+			random := io.MultiReader(bytes.NewReader(v.salt), rand.Reader)
+			opts := &PSSOptions{SaltLength: len(v.salt)}
+			sig, err := SignPSS(random, v.priv, crypto.SHA1, digest[:], opts)
+			if err != nil {
+				t.Fatalf("SignPSS() error = %v", err)
+			}
+			if !bytes.Equal(sig, v.sig) {
+				t.Errorf("SignPSS() = %x, want %x", sig, v.sig)
+			}
+			for _, opts := range []*PSSOptions{opts, {SaltLength: PSSSaltLengthEqualsHash}, nil} {
+				if err := VerifyPSS(&v.priv.PublicKey, crypto.SHA1, digest[:], v.sig, opts); err != nil {
+					t.Errorf("VerifyPSS(%v) error = %v", opts, err)
+				}
+			}
+		})
+	}
+}
+
+func TestSignPSS(t *testing.T) {
+	priv := testKey1024()
+	digest := sha256.Sum256([]byte("mycrypto"))
+	// k = 128, hLen = 32 なので、取りうる最大のソルトの長さは 128 - 32 - 2 = 94 バイト
+	tests := []struct {
+		name       string
+		signLen    int
+		verifyLen  int
+		wantSalt   int
+		wantVerify error
+	}{
+		{name: "auto", signLen: PSSSaltLengthAuto, verifyLen: PSSSaltLengthAuto, wantSalt: 94},
+		{name: "equals hash", signLen: PSSSaltLengthEqualsHash, verifyLen: PSSSaltLengthEqualsHash, wantSalt: 32},
+		{name: "equals hash, verify auto", signLen: PSSSaltLengthEqualsHash, verifyLen: PSSSaltLengthAuto, wantSalt: 32},
+		{name: "10 bytes", signLen: 10, verifyLen: 10, wantSalt: 10},
+		{name: "salt length mismatch", signLen: 10, verifyLen: 11, wantSalt: 10, wantVerify: ErrInvalidSignature},
+		{name: "auto, verify equals hash", signLen: PSSSaltLengthAuto, verifyLen: PSSSaltLengthEqualsHash, wantSalt: 94, wantVerify: ErrInvalidSignature},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sig, err := SignPSS(rand.Reader, priv, crypto.SHA256, digest[:], &PSSOptions{SaltLength: tt.signLen})
+			if err != nil {
+				t.Fatalf("SignPSS() error = %v", err)
+			}
+			err = VerifyPSS(&priv.PublicKey, crypto.SHA256, digest[:], sig, &PSSOptions{SaltLength: tt.verifyLen})
+			if !errors.Is(err, tt.wantVerify) {
+				t.Errorf("VerifyPSS() error = %v, want %v", err, tt.wantVerify)
+			}
+			// 標準ライブラリでソルトの長さを指定して検証できる
+			std := &toStd(priv).PublicKey
+			if err := stdrsa.VerifyPSS(std, crypto.SHA256, digest[:], sig, &stdrsa.PSSOptions{SaltLength: tt.wantSalt}); err != nil {
+				t.Errorf("crypto/rsa.VerifyPSS() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestVerifyPSS(t *testing.T) {
+	priv := testKey1024()
+	digest := sha256.Sum256([]byte("mycrypto"))
+	sig, err := stdrsa.SignPSS(rand.Reader, toStd(priv), crypto.SHA256, digest[:], nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	other := sha256.Sum256([]byte("other"))
+	tampered := append([]byte(nil), sig...)
+	tampered[0] ^= 1
+	tests := []struct {
+		name   string
+		hash   crypto.Hash
+		digest []byte
+		sig    []byte
+		want   error
+	}{
+		{name: "valid", hash: crypto.SHA256, digest: digest[:], sig: sig},
+		{name: "other message", hash: crypto.SHA256, digest: other[:], sig: sig, want: ErrInvalidSignature},
+		{name: "tampered", hash: crypto.SHA256, digest: digest[:], sig: tampered, want: ErrInvalidSignature},
+		{name: "wrong length", hash: crypto.SHA256, digest: digest[:], sig: sig[1:], want: ErrInvalidSignature},
+		{name: "sig >= n", hash: crypto.SHA256, digest: digest[:], sig: bytes.Repeat([]byte{0xff}, len(sig)), want: ErrInvalidSignature},
+		{name: "wrong hash", hash: crypto.SHA1, digest: digest[:20], sig: sig, want: ErrInvalidSignature},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := VerifyPSS(&priv.PublicKey, tt.hash, tt.digest, tt.sig, nil); !errors.Is(err, tt.want) {
+				t.Errorf("VerifyPSS() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
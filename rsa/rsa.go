@@ -0,0 +1,349 @@
+// Package rsa は RSA の鍵生成と暗号化・復号のプリミティブ、RFC 8017 (PKCS #1 v2.2) のパディング方式を提供します
+// 秘密鍵の演算は CRT (中国剰余定理) で高速化し、3つ以上の素数からなる multi-prime RSA にも対応します
+// パディング方式として RSAES-PKCS1-v1_5, RSAES-OAEP による暗号化と RSASSA-PKCS1-v1_5, RSASSA-PSS による署名があります
+package rsa
+
+import (
+	"errors"
+	"io"
+
+	"github.com/convto/mycrypto/big"
+)
+
+var (
+	// ErrMessageOutOfRange はメッセージや暗号文が 0 <= m < n の範囲にないことを表します
+	ErrMessageOutOfRange = errors.New("rsa: message out of range")
+	// ErrInvalidPublicExponent は公開指数が3以上の奇数でないことを表します
+	ErrInvalidPublicExponent = errors.New("rsa: invalid public exponent")
+	// ErrInvalidKey は秘密鍵の値の組が整合していないことを表します
+	ErrInvalidKey = errors.New("rsa: invalid private key")
+	// ErrVerification は CRT で復号した結果を公開鍵で戻すと元に戻らなかった (計算の誤りを検出した) ことを表します
+	ErrVerification = errors.New("rsa: CRT result failed verification")
+	// ErrMessageTooLong はメッセージが鍵の大きさに対して長すぎることを表します
+	ErrMessageTooLong = errors.New("rsa: message too long for RSA key size")
+	// ErrDecryption は暗号文の復号やパディングの除去に失敗したことを表します
+	// Bleichenbacher や Manger の攻撃の手がかりにならないよう、失敗の理由は区別しません
+	ErrDecryption = errors.New("rsa: decryption error")
+	// ErrInvalidSignature は署名の検証に失敗したことを表します
+	ErrInvalidSignature = errors.New("rsa: invalid signature")
+	// ErrUnsupportedHash はハッシュ関数がリンクされていないか、パディング方式で使えないことを表します
+	ErrUnsupportedHash = errors.New("rsa: unsupported hash function")
+)
+
+// PublicKey は RSA の公開鍵 (n, e) です
+type PublicKey struct {
+	N *big.Int
+	E int
+}
+
+// Size は法 n のバイト長を返します
+func (pub *PublicKey) Size() int {
+	return (pub.N.BitLen() + 7) / 8
+}
+
+// PrivateKey は RSA の秘密鍵です
+type PrivateKey struct {
+	PublicKey
+	D *big.Int
+	// Primes は n の素因数で、2つ以上あります
+	Primes []*big.Int
+	// Precomputed は Precompute で計算する CRT のパラメータです
+	Precomputed PrecomputedValues
+}
+
+// PrecomputedValues は CRT による秘密鍵の演算に使う値です
+type PrecomputedValues struct {
+	// Dp = d mod (p-1), Dq = d mod (q-1), Qinv = q^-1 mod p (p, q は Primes[0], Primes[1])
+	Dp, Dq, Qinv *big.Int
+	// CRTValues は3つ目以降の素数についての値です
+	CRTValues []CRTValue
+}
+
+// CRTValue は multi-prime RSA の3つ目以降の素数 r_i についての CRT のパラメータです
+type CRTValue struct {
+	// Exp = d mod (r_i - 1)
+	Exp *big.Int
+	// Coeff = R^-1 mod r_i
+	Coeff *big.Int
+	// R は r_i より前の素数の積
+	R *big.Int
+}
+
+// GenerateKey は r から読み込んだ乱数で、法のビット長が bits で公開指数が e の鍵を生成します
+func GenerateKey(r io.Reader, bits, e int) (*PrivateKey, error) {
+	return GenerateMultiPrimeKey(r, 2, bits, e)
+}
+
+// GenerateMultiPrimeKey は nprimes 個の素数からなる、法のビット長が bits で公開指数が e の鍵を生成します
+func GenerateMultiPrimeKey(r io.Reader, nprimes, bits, e int) (*PrivateKey, error) {
+	if e < 3 || e%2 == 0 {
+		return nil, ErrInvalidPublicExponent
+	}
+	if nprimes < 2 {
+		return nil, errors.New("rsa: GenerateMultiPrimeKey: nprimes must be >= 2")
+	}
+	if bits/nprimes < 16 {
+		return nil, errors.New("rsa: GenerateMultiPrimeKey: too few bits per prime")
+	}
+	bigE := big.NewInt(int64(e))
+	for {
+		primes, err := randomPrimes(r, nprimes, bits)
+		if err != nil {
+			return nil, err
+		}
+		n, lambda, ok := modulus(primes, bigE)
+		// 素数を3つ以上にすると上位ビットを立てても積が bits に届かないことがある
+		if !ok || n.BitLen() != bits {
+			continue
+		}
+		priv := &PrivateKey{
+			PublicKey: PublicKey{N: n, E: e},
+			D:         big.ModInverse(bigE, lambda),
+			Primes:    primes,
+		}
+		priv.Precompute()
+		return priv, nil
+	}
+}
+
+// randomPrimes は r から読み込んだ乱数で、ビット長の合計が bits になる nprimes 個の素数を生成する
+func randomPrimes(r io.Reader, nprimes, bits int) ([]*big.Int, error) {
+	primes := make([]*big.Int, nprimes)
+	for i := range primes {
+		// 残りのビットを残りの素数で分ける
+		size := bits / (nprimes - i)
+		p, err := big.Prime(r, size)
+		if err != nil {
+			return nil, err
+		}
+		primes[i] = p
+		bits -= size
+	}
+	return primes, nil
+}
+
+// modulus は素数の積 n と λ(n) を返す
+// 素数に重複があるか、p-1 と e が互いに素でない素数 p があれば ok = false を返す
+func modulus(primes []*big.Int, e *big.Int) (n, lambda *big.Int, ok bool) {
+	one := big.NewInt(1)
+	n, lambda = big.NewInt(1), big.NewInt(1)
+	for i, p := range primes {
+		for _, q := range primes[:i] {
+			if big.Cmp(p, q) == 0 {
+				return nil, nil, false
+			}
+		}
+		pm1 := big.Sub(p, one)
+		if g, _, _ := big.GCD(e, pm1); big.Cmp(g, one) != 0 {
+			return nil, nil, false
+		}
+		n = big.Mul(n, p)
+		lambda = lcm(lambda, pm1)
+	}
+	return n, lambda, true
+}
+
+// Validate は秘密鍵の値の組が整合しているかを確かめます
+// 素数の積が n であること、素数がすべて異なること、d * e = 1 mod λ(n) であることを確かめます
+func (priv *PrivateKey) Validate() error {
+	if priv.E < 3 || priv.E%2 == 0 {
+		return ErrInvalidPublicExponent
+	}
+	if len(priv.Primes) < 2 {
+		return ErrInvalidKey
+	}
+	one := big.NewInt(1)
+	n := big.NewInt(1)
+	lambda := big.NewInt(1)
+	for i, p := range priv.Primes {
+		if big.Cmp(p, one) <= 0 {
+			return ErrInvalidKey
+		}
+		for _, q := range priv.Primes[:i] {
+			if big.Cmp(p, q) == 0 {
+				return ErrInvalidKey
+			}
+		}
+		n = big.Mul(n, p)
+		lambda = lcm(lambda, big.Sub(p, one))
+	}
+	if big.Cmp(n, priv.N) != 0 {
+		return ErrInvalidKey
+	}
+	de := big.Mul(priv.D, big.NewInt(int64(priv.E)))
+	if big.Cmp(big.Mod(de, lambda), one) != 0 {
+		return ErrInvalidKey
+	}
+	return nil
+}
+
+// Precompute は CRT による秘密鍵の演算に使う値を計算して Precomputed に保存します
+// GenerateKey で生成した鍵は計算済みです
+// 鍵を書き換えるので、複数の goroutine で共有する前に呼んでください
+func (priv *PrivateKey) Precompute() {
+	if priv.Precomputed.Dp != nil {
+		return
+	}
+	priv.Precomputed = priv.precompute()
+}
+
+// precomputed は Precomputed が計算済みならそれを、そうでなければ鍵を書き換えずにその場で計算した値を返す
+func (priv *PrivateKey) precomputed() *PrecomputedValues {
+	pre := &priv.Precomputed
+	if pre.Dp != nil && pre.Dq != nil && pre.Qinv != nil && len(pre.CRTValues) == len(priv.Primes)-2 {
+		return pre
+	}
+	v := priv.precompute()
+	return &v
+}
+
+// precompute は CRT のパラメータを計算する
+func (priv *PrivateKey) precompute() PrecomputedValues {
+	one := big.NewInt(1)
+	p, q := priv.Primes[0], priv.Primes[1]
+	pre := PrecomputedValues{
+		Dp:        big.Mod(priv.D, big.Sub(p, one)),
+		Dq:        big.Mod(priv.D, big.Sub(q, one)),
+		Qinv:      modInverse(q, p),
+		CRTValues: make([]CRTValue, len(priv.Primes)-2),
+	}
+	r := big.Mul(p, q)
+	for i, prime := range priv.Primes[2:] {
+		pre.CRTValues[i] = CRTValue{
+			Exp:   big.Mod(priv.D, big.Sub(prime, one)),
+			Coeff: modInverse(r, prime),
+			R:     r,
+		}
+		r = big.Mul(r, prime)
+	}
+	return pre
+}
+
+// Encrypt は m^e mod n を返します
+// m が 0 <= m < n の範囲にないときは ErrMessageOutOfRange を返します
+func Encrypt(pub *PublicKey, m *big.Int) (*big.Int, error) {
+	if !inRange(m, pub.N) {
+		return nil, ErrMessageOutOfRange
+	}
+	return big.Exp(m, big.NewInt(int64(pub.E)), pub.N), nil
+}
+
+// Decrypt は c^d mod n を CRT で計算して返します
+//
+// random が nil でなければ、そこから読み込んだ乱数 r で c * r^e を復号してから r で割り戻し (base blinding)、
+// 復号にかかる時間と暗号文の関係を隠します
+// 故障攻撃で誤った CRT の結果から素因数が漏れないように、結果を公開鍵で戻して c と一致するかを確かめ、
+// 一致しなければ ErrVerification を返します
+// 鍵は書き換えないので、同じ鍵で複数の goroutine から呼べます
+// Precompute していない鍵では、呼び出しごとに CRT のパラメータを計算します
+func Decrypt(random io.Reader, priv *PrivateKey, c *big.Int) (*big.Int, error) {
+	n := priv.N
+	if !inRange(c, n) {
+		return nil, ErrMessageOutOfRange
+	}
+	pre := priv.precomputed()
+	e := big.NewInt(int64(priv.E))
+
+	var ir *big.Int
+	cc := c
+	if random != nil {
+		// n と互いに素な r を選び、c' = c * r^e mod n を復号すると m * r が得られる
+		var r *big.Int
+		for ir == nil {
+			var err error
+			r, err = big.RandNonZeroInt(random, n)
+			if err != nil {
+				return nil, err
+			}
+			ir = priv.inverse(pre, r)
+		}
+		cc = big.Mod(big.Mul(c, big.Exp(r, e, n)), n)
+	}
+
+	m := priv.decryptCRT(pre, cc)
+	if big.Cmp(big.Exp(m, e, n), cc) != 0 {
+		return nil, ErrVerification
+	}
+	if ir != nil {
+		m = big.Mod(big.Mul(m, ir), n)
+	}
+	return m, nil
+}
+
+// decryptCRT は各素数を法とした c^(d mod (p_i - 1)) から c^d mod n を組み立てる
+func (priv *PrivateKey) decryptCRT(pre *PrecomputedValues, c *big.Int) *big.Int {
+	exps := []*big.Int{pre.Dp, pre.Dq}
+	for _, v := range pre.CRTValues {
+		exps = append(exps, v.Exp)
+	}
+	ms := make([]*big.Int, len(priv.Primes))
+	for i, p := range priv.Primes {
+		ms[i] = big.Exp(big.Mod(c, p), exps[i], p)
+	}
+	return priv.crt(pre, ms)
+}
+
+// inverse は r^-1 mod n を、各素数を法とした逆元から組み立てる
+// r と n が互いに素でないときは nil を返す
+func (priv *PrivateKey) inverse(pre *PrecomputedValues, r *big.Int) *big.Int {
+	ms := make([]*big.Int, len(priv.Primes))
+	for i, p := range priv.Primes {
+		ms[i] = modInverse(r, p)
+		if big.Cmp(ms[i], big.Zero) == 0 {
+			return nil
+		}
+	}
+	return priv.crt(pre, ms)
+}
+
+// crt は各素数 p_i を法とした剰余 ms[i] から、Garner の方法で法 n の値を組み立てる
+func (priv *PrivateKey) crt(pre *PrecomputedValues, ms []*big.Int) *big.Int {
+	p, q := priv.Primes[0], priv.Primes[1]
+	// m = m2 + q * (qinv * (m1 - m2) mod p)
+	h := big.Mod(big.Mul(pre.Qinv, big.Sub(ms[0], ms[1])), p)
+	m := big.Add(ms[1], big.Mul(q, h))
+
+	// 3つ目以降の素数 r_i について m = m + R * (coeff * (m_i - m) mod r_i)
+	for i, v := range pre.CRTValues {
+		prime := priv.Primes[2+i]
+		h := big.Mod(big.Mul(v.Coeff, big.Sub(ms[2+i], m)), prime)
+		m = big.Add(m, big.Mul(v.R, h))
+	}
+	return m
+}
+
+// encrypt はビッグエンディアンのバイト列 m を整数として Encrypt し、結果を法のバイト長で返す
+func encrypt(pub *PublicKey, m []byte) ([]byte, error) {
+	c, err := Encrypt(pub, new(big.Int).SetBytes(m))
+	if err != nil {
+		return nil, err
+	}
+	return c.FillBytes(make([]byte, pub.Size())), nil
+}
+
+// decrypt はビッグエンディアンのバイト列 c を整数として Decrypt し、結果を法のバイト長で返す
+func decrypt(random io.Reader, priv *PrivateKey, c []byte) ([]byte, error) {
+	m, err := Decrypt(random, priv, new(big.Int).SetBytes(c))
+	if err != nil {
+		return nil, err
+	}
+	return m.FillBytes(make([]byte, priv.Size())), nil
+}
+
+// modInverse は素数 p を法とした x の逆元を Fermat の小定理により x^(p-2) mod p で求める
+// 法が大きいと拡張ユークリッドの互除法による big.ModInverse よりずっと速い
+func modInverse(x, p *big.Int) *big.Int {
+	return big.Exp(big.Mod(x, p), big.Sub(p, big.NewInt(2)), p)
+}
+
+// lcm は a と b の最小公倍数を返す
+func lcm(a, b *big.Int) *big.Int {
+	g, _, _ := big.GCD(a, b)
+	q, _ := big.Div(big.Mul(a, b), g)
+	return q
+}
+
+// inRange は 0 <= x < n かどうかを判定する
+func inRange(x, n *big.Int) bool {
+	return big.Cmp(x, big.Zero) >= 0 && big.Cmp(x, n) < 0
+}
@@ -0,0 +1,240 @@
+package rsa
+
+import (
+	"crypto/rand"
+	stdrsa "crypto/rsa"
+	"errors"
+	stdbig "math/big"
+	"testing"
+
+	"github.com/convto/mycrypto/big"
+)
+
+// testKey は p = 61, q = 53, e = 17 の小さな鍵
+// λ(n) = lcm(60, 52) = 780 なので d = 17^-1 mod 780 = 413
+func testKey() *PrivateKey {
+	priv := &PrivateKey{
+		PublicKey: PublicKey{N: big.NewInt(3233), E: 17},
+		D:         big.NewInt(413),
+		Primes:    []*big.Int{big.NewInt(61), big.NewInt(53)},
+	}
+	priv.Precompute()
+	return priv
+}
+
+// testKey1024 は RSA Laboratories の OAEP のテストベクトル (oaep-vect.txt) の Example 1 の 1024 ビットの鍵
+func testKey1024() *PrivateKey {
+	priv := &PrivateKey{
+		PublicKey: PublicKey{
+			N: hexInt("a8b3b284af8eb50b387034a860f146c4919f318763cd6c5598c8ae4811a1e0abc4c7e0b082d693a5e7fced675cf4668512772c0cbc64a742c6c630f533c8cc72f62ae833c40bf25842e984bb78bdbf97c0107d55bdb662f5c4e0fab9845cb5148ef7392dd3aaff93ae1e6b667bb3d4247616d4f5ba10d4cfd226de88d39f16fb"),
+			E: 65537,
+		},
+		D: hexInt("53339cfdb79fc8466a655c7316aca85c55fd8f6dd898fdaf119517ef4f52e8fd8e258df93fee180fa0e4ab29693cd83b152a553d4ac4d1812b8b9fa5af0e7f55fe7304df41570926f3311f15c4d65a732c483116ee3d3d2d0af3549ad9bf7cbfb78ad884f84d5beb04724dc7369b31def37d0cf539e9cfcdd3de653729ead5d1"),
+		Primes: []*big.Int{
+			hexInt("d32737e7267ffe1341b2d5c0d150a81b586fb3132bed2f8d5262864a9cb9f30af38be448598d413a172efb802c21acf1c11c520c2f26a471dcad212eac7ca39d"),
+			hexInt("cc8853d1d54da630fac004f471f281c7b8982d8224a490edbeb33d3e3d5cc93c4765703d1dd791642f1f116a0dd852be2419b2af72bfe9a030e860b0288b5d77"),
+		},
+	}
+	priv.Precompute()
+	return priv
+}
+
+// toStd は標準ライブラリの鍵に変換し、結果を突き合わせるのに使う
+func toStd(priv *PrivateKey) *stdrsa.PrivateKey {
+	std := &stdrsa.PrivateKey{
+		PublicKey: stdrsa.PublicKey{N: new(stdbig.Int).SetBytes(priv.N.Bytes()), E: priv.E},
+		D:         new(stdbig.Int).SetBytes(priv.D.Bytes()),
+	}
+	for _, p := range priv.Primes {
+		std.Primes = append(std.Primes, new(stdbig.Int).SetBytes(p.Bytes()))
+	}
+	std.Precompute()
+	return std
+}
+
+func TestEncrypt(t *testing.T) {
+	tests := []struct {
+		name    string
+		m       *big.Int
+		want    *big.Int
+		wantErr error
+	}{
+		{name: "65", m: big.NewInt(65), want: big.NewInt(2790)},
+		{name: "zero", m: big.NewInt(0), want: big.NewInt(0)},
+		{name: "n - 1", m: big.NewInt(3232), want: big.NewInt(3232)},
+		{name: "n", m: big.NewInt(3233), wantErr: ErrMessageOutOfRange},
+		{name: "negative", m: big.NewInt(-1), wantErr: ErrMessageOutOfRange},
+	}
+	priv := testKey()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Encrypt(&priv.PublicKey, tt.m)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Encrypt() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && big.Cmp(got, tt.want) != 0 {
+				t.Errorf("Encrypt() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecrypt(t *testing.T) {
+	priv := testKey()
+	for _, random := range []bool{false, true} {
+		for m := int64(0); m < 3233; m += 97 {
+			c, err := Encrypt(&priv.PublicKey, big.NewInt(m))
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got *big.Int
+			if random {
+				got, err = Decrypt(rand.Reader, priv, c)
+			} else {
+				got, err = Decrypt(nil, priv, c)
+			}
+			if err != nil {
+				t.Fatalf("Decrypt() error = %v", err)
+			}
+			if big.Cmp(got, big.NewInt(m)) != 0 {
+				t.Errorf("Decrypt(%v) = %v, want %v (blinding: %v)", c, got, m, random)
+			}
+		}
+	}
+}
+
+func TestDecrypt_fault(t *testing.T) {
+	// CRT の途中の値が壊れていれば、公開鍵での検算で検出する
+	priv := testKey()
+	priv.Precomputed.Dp = big.Add(priv.Precomputed.Dp, big.NewInt(1))
+	if _, err := Decrypt(nil, priv, big.NewInt(2790)); !errors.Is(err, ErrVerification) {
+		t.Errorf("Decrypt() error = %v, want %v", err, ErrVerification)
+	}
+}
+
+// Precompute していない鍵でも Decrypt は鍵を書き換えないので、複数の goroutine から同時に呼べる
+func TestDecrypt_notPrecomputed(t *testing.T) {
+	priv := testKey()
+	priv.Precomputed = PrecomputedValues{}
+	errc := make(chan error, 8)
+	for i := 0; i < cap(errc); i++ {
+		go func() {
+			got, err := Decrypt(rand.Reader, priv, big.NewInt(2790))
+			if err == nil && big.Cmp(got, big.NewInt(65)) != 0 {
+				err = errors.New("Decrypt() = " + got.String() + ", want 65")
+			}
+			errc <- err
+		}()
+	}
+	for i := 0; i < cap(errc); i++ {
+		if err := <-errc; err != nil {
+			t.Error(err)
+		}
+	}
+	if priv.Precomputed.Dp != nil {
+		t.Errorf("Decrypt() modified priv.Precomputed")
+	}
+}
+
+func TestGenerateKey(t *testing.T) {
+	tests := []struct {
+		name    string
+		nprimes int
+		bits    int
+		e       int
+	}{
+		{name: "2 primes 1024 bits", nprimes: 2, bits: 1024, e: 65537},
+		{name: "2 primes e = 3", nprimes: 2, bits: 256, e: 3},
+		{name: "3 primes 384 bits", nprimes: 3, bits: 384, e: 65537},
+		{name: "4 primes 257 bits", nprimes: 4, bits: 257, e: 65537},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			priv, err := GenerateMultiPrimeKey(rand.Reader, tt.nprimes, tt.bits, tt.e)
+			if err != nil {
+				t.Fatalf("GenerateMultiPrimeKey() error = %v", err)
+			}
+			if got := priv.N.BitLen(); got != tt.bits {
+				t.Errorf("N.BitLen() = %v, want %v", got, tt.bits)
+			}
+			if len(priv.Primes) != tt.nprimes {
+				t.Errorf("len(Primes) = %v, want %v", len(priv.Primes), tt.nprimes)
+			}
+			for _, p := range priv.Primes {
+				if !new(stdbig.Int).SetBytes(p.Bytes()).ProbablyPrime(20) {
+					t.Errorf("%v is not prime", p)
+				}
+			}
+			if err := priv.Validate(); err != nil {
+				t.Errorf("Validate() error = %v", err)
+			}
+			m, err := big.RandNonZeroInt(rand.Reader, priv.N)
+			if err != nil {
+				t.Fatal(err)
+			}
+			c, err := Encrypt(&priv.PublicKey, m)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := Decrypt(rand.Reader, priv, c)
+			if err != nil {
+				t.Fatalf("Decrypt() error = %v", err)
+			}
+			if big.Cmp(got, m) != 0 {
+				t.Errorf("Decrypt() = %v, want %v", got, m)
+			}
+		})
+	}
+}
+
+func TestGenerateKey_invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		nprimes int
+		bits    int
+		e       int
+	}{
+		{name: "even e", nprimes: 2, bits: 256, e: 65536},
+		{name: "e = 1", nprimes: 2, bits: 256, e: 1},
+		{name: "one prime", nprimes: 1, bits: 256, e: 65537},
+		{name: "too small", nprimes: 4, bits: 32, e: 65537},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := GenerateMultiPrimeKey(rand.Reader, tt.nprimes, tt.bits, tt.e); err == nil {
+				t.Errorf("GenerateMultiPrimeKey() error = nil")
+			}
+		})
+	}
+}
+
+func TestPrivateKey_Validate(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(priv *PrivateKey)
+		want   error
+	}{
+		{name: "valid", modify: func(*PrivateKey) {}, want: nil},
+		{name: "d = φ(n) based inverse", modify: func(priv *PrivateKey) { priv.D = big.NewInt(2753) }, want: nil},
+		{name: "wrong d", modify: func(priv *PrivateKey) { priv.D = big.NewInt(414) }, want: ErrInvalidKey},
+		{name: "wrong n", modify: func(priv *PrivateKey) { priv.N = big.NewInt(3127) }, want: ErrInvalidKey},
+		{
+			name: "p = q",
+			modify: func(priv *PrivateKey) {
+				priv.N = big.NewInt(3721)
+				priv.Primes = []*big.Int{big.NewInt(61), big.NewInt(61)}
+			},
+			want: ErrInvalidKey,
+		},
+		{name: "even e", modify: func(priv *PrivateKey) { priv.E = 16 }, want: ErrInvalidPublicExponent},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			priv := testKey()
+			tt.modify(priv)
+			if err := priv.Validate(); !errors.Is(err, tt.want) {
+				t.Errorf("Validate() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
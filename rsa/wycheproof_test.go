@@ -0,0 +1,158 @@
+package rsa
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"strconv"
+	"testing"
+
+	"github.com/convto/mycrypto/big"
+)
+
+// wycheproofKey は Wycheproof の鍵で、公開鍵には modulus と publicExponent だけがある
+type wycheproofKey struct {
+	Modulus         string `json:"modulus"`
+	PublicExponent  string `json:"publicExponent"`
+	PrivateExponent string `json:"privateExponent"`
+	Prime1          string `json:"prime1"`
+	Prime2          string `json:"prime2"`
+}
+
+func (k *wycheproofKey) publicKey() *PublicKey {
+	return &PublicKey{N: hexInt(k.Modulus), E: bytesToInt(mustHex(k.PublicExponent))}
+}
+
+func (k *wycheproofKey) privateKey() *PrivateKey {
+	priv := &PrivateKey{
+		PublicKey: *k.publicKey(),
+		D:         hexInt(k.PrivateExponent),
+		Primes:    []*big.Int{hexInt(k.Prime1), hexInt(k.Prime2)},
+	}
+	priv.Precompute()
+	return priv
+}
+
+// wycheproofGroup は Wycheproof の RSA のベクトルのテストグループです
+type wycheproofGroup struct {
+	PrivateKey wycheproofKey    `json:"privateKey"`
+	PublicKey  wycheproofKey    `json:"publicKey"`
+	Sha        string           `json:"sha"`
+	MgfSha     string           `json:"mgfSha"`
+	SLen       int              `json:"sLen"`
+	Tests      []wycheproofTest `json:"tests"`
+}
+
+// wycheproofTest は Wycheproof の RSA のベクトルの1つのテストです
+type wycheproofTest struct {
+	TcID    int      `json:"tcId"`
+	Comment string   `json:"comment"`
+	Msg     string   `json:"msg"`
+	Ct      string   `json:"ct"`
+	Sig     string   `json:"sig"`
+	Label   string   `json:"label"`
+	Result  string   `json:"result"`
+	Flags   []string `json:"flags"`
+}
+
+// testWycheproof は testdata のベクトルを読み込み、check の結果 (受け入れたかどうか) を期待する結果と比べる
+// acceptable のテストはどちらの結果でもよいので飛ばす
+func testWycheproof(t *testing.T, file string, check func(g *wycheproofGroup, tt *wycheproofTest) bool) {
+	data, err := os.ReadFile("testdata/" + file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var f struct {
+		TestGroups []*wycheproofGroup `json:"testGroups"`
+	}
+	if err := json.Unmarshal(data, &f); err != nil {
+		t.Fatal(err)
+	}
+	for _, g := range f.TestGroups {
+		if g.Sha != "" && g.Sha != "SHA-256" || g.MgfSha != "" && g.MgfSha != "SHA-256" {
+			t.Fatalf("unsupported group: %s, %s", g.Sha, g.MgfSha)
+		}
+		for _, tt := range g.Tests {
+			if tt.Result == "acceptable" {
+				continue
+			}
+			g, tt := g, tt
+			t.Run(strconv.Itoa(tt.TcID), func(t *testing.T) {
+				if got, want := check(g, &tt), tt.Result == "valid"; got != want {
+					t.Errorf("%s (%v): got %v, want %v", tt.Comment, tt.Flags, got, tt.Result)
+				}
+			})
+		}
+	}
+}
+
+func TestWycheproof_PKCS1v15Decrypt(t *testing.T) {
+	keys := make(map[*wycheproofGroup]*PrivateKey)
+	testWycheproof(t, "rsa_pkcs1_2048_test.json", func(g *wycheproofGroup, tt *wycheproofTest) bool {
+		priv, ok := keys[g]
+		if !ok {
+			priv = g.PrivateKey.privateKey()
+			keys[g] = priv
+		}
+		msg, err := DecryptPKCS1v15(nil, priv, mustHex(tt.Ct))
+		return err == nil && bytes.Equal(msg, mustHex(tt.Msg))
+	})
+}
+
+func TestWycheproof_OAEPDecrypt(t *testing.T) {
+	keys := make(map[*wycheproofGroup]*PrivateKey)
+	testWycheproof(t, "rsa_oaep_2048_sha256_mgf1sha256_test.json", func(g *wycheproofGroup, tt *wycheproofTest) bool {
+		priv, ok := keys[g]
+		if !ok {
+			priv = g.PrivateKey.privateKey()
+			keys[g] = priv
+		}
+		opts := &OAEPOptions{Hash: crypto.SHA256, MGFHash: crypto.SHA256, Label: mustHex(tt.Label)}
+		msg, err := DecryptOAEP(nil, priv, mustHex(tt.Ct), opts)
+		return err == nil && bytes.Equal(msg, mustHex(tt.Msg))
+	})
+}
+
+func TestWycheproof_PKCS1v15Verify(t *testing.T) {
+	testWycheproof(t, "rsa_signature_2048_sha256_test.json", func(g *wycheproofGroup, tt *wycheproofTest) bool {
+		digest := sha256.Sum256(mustHex(tt.Msg))
+		return VerifyPKCS1v15(g.PublicKey.publicKey(), crypto.SHA256, digest[:], mustHex(tt.Sig)) == nil
+	})
+}
+
+func TestWycheproof_PSSVerify(t *testing.T) {
+	testWycheproof(t, "rsa_pss_2048_sha256_mgf1_32_test.json", func(g *wycheproofGroup, tt *wycheproofTest) bool {
+		digest := sha256.Sum256(mustHex(tt.Msg))
+		opts := &PSSOptions{SaltLength: g.SLen}
+		return VerifyPSS(g.PublicKey.publicKey(), crypto.SHA256, digest[:], mustHex(tt.Sig), opts) == nil
+	})
+}
+
+// hexInt は16進数の文字列を整数にします
+func hexInt(s string) *big.Int {
+	return new(big.Int).SetBytes(mustHex(s))
+}
+
+// mustHex は16進数の文字列をバイト列にします
+func mustHex(s string) []byte {
+	if len(s)%2 == 1 {
+		s = "0" + s
+	}
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// bytesToInt はビッグエンディアンのバイト列を int にします
+func bytesToInt(b []byte) int {
+	x := 0
+	for _, c := range b {
+		x = x<<8 | int(c)
+	}
+	return x
+}
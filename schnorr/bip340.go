@@ -0,0 +1,207 @@
+package schnorr
+
+import (
+	"crypto/sha256"
+	"errors"
+	"io"
+
+	"github.com/convto/mycrypto/big"
+	"github.com/convto/mycrypto/ec"
+)
+
+// BIP-340 の鍵と署名の長さ
+const (
+	// PrivateKeySizeBIP340 は秘密鍵の長さです
+	PrivateKeySizeBIP340 = 32
+	// PublicKeySizeBIP340 は x 座標だけの公開鍵の長さです
+	PublicKeySizeBIP340 = 32
+	// SignatureSizeBIP340 は署名 bytes(R) || bytes(s) の長さです
+	SignatureSizeBIP340 = 64
+)
+
+// secp256k1 は BIP-340 で使う曲線です
+var secp256k1 = ec.Secp256k1()
+
+// taggedHash は BIP-340 のタグつきハッシュ SHA256(SHA256(tag) || SHA256(tag) || x) を返します
+func taggedHash(tag string, msgs ...[]byte) []byte {
+	t := sha256.Sum256([]byte(tag))
+	h := sha256.New()
+	h.Write(t[:])
+	h.Write(t[:])
+	for _, m := range msgs {
+		h.Write(m)
+	}
+	return h.Sum(nil)
+}
+
+// PublicKeyBIP340 は32バイトの秘密鍵 sk に対する、x 座標だけの32バイトの公開鍵を返します
+// sk が 1 <= d < n の範囲にないときは ErrInvalidPrivateKey を返します
+func PublicKeyBIP340(sk []byte) ([]byte, error) {
+	_, p, err := bip340Key(sk)
+	if err != nil {
+		return nil, err
+	}
+	return bytesX(p), nil
+}
+
+// SignBIP340 は秘密鍵 sk でメッセージ msg に BIP-340 の署名をします
+// auxRand は32バイトの補助乱数で、ナンスの導出に混ぜて故障攻撃やサイドチャネルへの耐性を高めます
+// BIP-340 の推奨どおり、作った署名を検証してから返します
+func SignBIP340(sk, msg, auxRand []byte) ([]byte, error) {
+	if len(auxRand) != 32 {
+		return nil, errors.New("schnorr: SignBIP340: auxRand must be 32 bytes")
+	}
+	d, p, err := bip340Key(sk)
+	if err != nil {
+		return nil, err
+	}
+	n := secp256k1.N()
+	pk := bytesX(p)
+
+	// t = bytes(d) xor hash_aux(a), k' = hash_nonce(t || bytes(P) || m) mod n
+	t := d.FillBytes(make([]byte, 32))
+	aux := taggedHash("BIP0340/aux", auxRand)
+	for i := range t {
+		t[i] ^= aux[i]
+	}
+	k := big.Mod(new(big.Int).SetBytes(taggedHash("BIP0340/nonce", t, pk, msg)), n)
+	if big.Cmp(k, big.Zero) == 0 {
+		return nil, errors.New("schnorr: SignBIP340: nonce is zero")
+	}
+	r := secp256k1.ScalarBaseMult(k)
+	if !hasEvenY(r) {
+		k = big.Sub(n, k)
+	}
+	rx := bytesX(r)
+	e := bip340Challenge(rx, pk, msg)
+	s := big.Mod(big.Add(k, big.Mul(e, d)), n)
+
+	sig := append(rx, s.FillBytes(make([]byte, 32))...)
+	if !VerifyBIP340(pk, msg, sig) {
+		return nil, errors.New("schnorr: SignBIP340: generated signature does not verify")
+	}
+	return sig, nil
+}
+
+// VerifyBIP340 は sig が x 座標だけの公開鍵 pk によるメッセージ msg の正しい BIP-340 署名かどうかを判定します
+func VerifyBIP340(pk, msg, sig []byte) bool {
+	p, r, s, ok := parseBIP340(pk, sig)
+	if !ok {
+		return false
+	}
+	// R = sG - eP の y 座標が偶数で x 座標が r と一致するかを確かめる
+	// スカラーは公開された値なので P の倍算には wNAF を使う
+	e := bip340Challenge(sig[:32], pk, msg)
+	rr := secp256k1.ScalarBaseMult(s).Sub(ec.WNAF{W: 5}.ScalarMult(p, e))
+	if rr.IsInfinity() || !hasEvenY(rr) {
+		return false
+	}
+	x, _ := rr.Affine()
+	return big.Cmp(x, r) == 0
+}
+
+// BatchVerifyBIP340 は i 番目の署名 sigs[i] が公開鍵 pks[i] によるメッセージ msgs[i] の署名であることを、まとめて検証します
+// r から読み込んだ乱数 a_i (a_0 = 1) で (Σ a_i s_i)G = Σ a_i R_i + Σ a_i e_i P_i を確かめ、右辺はマルチスカラー倍算で求めます
+// 1つでも不正な署名があれば、乱数の選び方によらずほぼ確実に false を返しますが、どれが不正かはわかりません
+// 引数の長さが揃っていないときや、乱数の読み込みに失敗したときも false を返します
+func BatchVerifyBIP340(r io.Reader, pks, msgs, sigs [][]byte) bool {
+	if len(pks) != len(msgs) || len(pks) != len(sigs) {
+		return false
+	}
+	if len(pks) == 0 {
+		return true
+	}
+	n := secp256k1.N()
+	points := []*ec.Point{secp256k1.Generator()}
+	scalars := []*big.Int{nil}
+	sum := big.NewInt(0)
+	for i := range pks {
+		p, rx, s, ok := parseBIP340(pks[i], sigs[i])
+		if !ok {
+			return false
+		}
+		rr, ok := liftX(rx)
+		if !ok {
+			return false
+		}
+		a := big.NewInt(1)
+		if i > 0 {
+			var err error
+			if a, err = big.RandNonZeroInt(r, n); err != nil {
+				return false
+			}
+		}
+		e := bip340Challenge(sigs[i][:32], pks[i], msgs[i])
+		sum = big.Add(sum, big.Mul(a, s))
+		points = append(points, rr, p)
+		scalars = append(scalars, a, big.Mod(big.Mul(a, e), n))
+	}
+	// -(Σ a_i s_i)G + Σ a_i R_i + Σ a_i e_i P_i が無限遠点になるかを確かめる
+	scalars[0] = big.Sub(n, big.Mod(sum, n))
+	return ec.MultiScalarMult(points, scalars).IsInfinity()
+}
+
+// bip340Key は秘密鍵 sk を読み込み、P = d'G の y 座標が偶数になるように符号を調整した d と P を返す
+func bip340Key(sk []byte) (*big.Int, *ec.Point, error) {
+	if len(sk) != PrivateKeySizeBIP340 {
+		return nil, nil, ErrInvalidPrivateKey
+	}
+	d := new(big.Int).SetBytes(sk)
+	n := secp256k1.N()
+	if !inRange(d, n) {
+		return nil, nil, ErrInvalidPrivateKey
+	}
+	p := secp256k1.ScalarBaseMult(d)
+	if !hasEvenY(p) {
+		d = big.Sub(n, d)
+	}
+	return d, p, nil
+}
+
+// parseBIP340 は公開鍵 P と署名の r, s を読み込む
+// P が lift_x できないとき、r >= p のとき、s >= n のときは ok = false を返す
+func parseBIP340(pk, sig []byte) (p *ec.Point, r, s *big.Int, ok bool) {
+	if len(pk) != PublicKeySizeBIP340 || len(sig) != SignatureSizeBIP340 {
+		return nil, nil, nil, false
+	}
+	p, ok = liftX(new(big.Int).SetBytes(pk))
+	if !ok {
+		return nil, nil, nil, false
+	}
+	r = new(big.Int).SetBytes(sig[:32])
+	s = new(big.Int).SetBytes(sig[32:])
+	if big.Cmp(r, secp256k1.P()) >= 0 || big.Cmp(s, secp256k1.N()) >= 0 {
+		return nil, nil, nil, false
+	}
+	return p, r, s, true
+}
+
+// bip340Challenge は e = hash_challenge(bytes(R) || bytes(P) || m) mod n を返す
+func bip340Challenge(rx, pk, msg []byte) *big.Int {
+	return big.Mod(new(big.Int).SetBytes(taggedHash("BIP0340/challenge", rx, pk, msg)), secp256k1.N())
+}
+
+// liftX は x 座標が x で y 座標が偶数の点を返す
+// x >= p のときや、x 座標が x の点が存在しないときは ok = false を返す
+func liftX(x *big.Int) (*ec.Point, bool) {
+	if big.Cmp(x, secp256k1.P()) >= 0 {
+		return nil, false
+	}
+	p, err := secp256k1.SetBytes(append([]byte{0x02}, x.FillBytes(make([]byte, 32))...))
+	if err != nil {
+		return nil, false
+	}
+	return p, true
+}
+
+// bytesX は p の x 座標を32バイトで返す
+func bytesX(p *ec.Point) []byte {
+	x, _ := p.Affine()
+	return x.FillBytes(make([]byte, 32))
+}
+
+// hasEvenY は p の y 座標が偶数かどうかを判定する
+func hasEvenY(p *ec.Point) bool {
+	_, y := p.Affine()
+	return y.Bit(0) == 0
+}
@@ -0,0 +1,172 @@
+package schnorr
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/csv"
+	"encoding/hex"
+	"errors"
+	"os"
+	"testing"
+)
+
+// bip340Vector は BIP-340 の test-vectors.csv の1行です
+type bip340Vector struct {
+	index   string
+	sk      []byte
+	pk      []byte
+	auxRand []byte
+	msg     []byte
+	sig     []byte
+	result  bool
+	comment string
+}
+
+func readBIP340Vectors(t *testing.T) []bip340Vector {
+	t.Helper()
+	f, err := os.Open("testdata/bip340-test-vectors.csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	records, err := csv.NewReader(f).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var vectors []bip340Vector
+	for _, rec := range records[1:] {
+		vectors = append(vectors, bip340Vector{
+			index:   rec[0],
+			sk:      mustHex(t, rec[1]),
+			pk:      mustHex(t, rec[2]),
+			auxRand: mustHex(t, rec[3]),
+			msg:     mustHex(t, rec[4]),
+			sig:     mustHex(t, rec[5]),
+			result:  rec[6] == "TRUE",
+			comment: rec[7],
+		})
+	}
+	return vectors
+}
+
+func TestBIP340Vectors(t *testing.T) {
+	for _, v := range readBIP340Vectors(t) {
+		t.Run(v.index, func(t *testing.T) {
+			if len(v.sk) > 0 {
+				pk, err := PublicKeyBIP340(v.sk)
+				if err != nil {
+					t.Fatalf("PublicKeyBIP340() error = %v", err)
+				}
+				if !bytes.Equal(pk, v.pk) {
+					t.Errorf("PublicKeyBIP340() = %x, want %x", pk, v.pk)
+				}
+				sig, err := SignBIP340(v.sk, v.msg, v.auxRand)
+				if err != nil {
+					t.Fatalf("SignBIP340() error = %v", err)
+				}
+				if !bytes.Equal(sig, v.sig) {
+					t.Errorf("SignBIP340() = %x, want %x", sig, v.sig)
+				}
+			}
+			if got := VerifyBIP340(v.pk, v.msg, v.sig); got != v.result {
+				t.Errorf("VerifyBIP340() = %v, want %v (%s)", got, v.result, v.comment)
+			}
+		})
+	}
+}
+
+func TestBatchVerifyBIP340(t *testing.T) {
+	var valid, invalid [][3][]byte
+	for _, v := range readBIP340Vectors(t) {
+		if v.result {
+			valid = append(valid, [3][]byte{v.pk, v.msg, v.sig})
+		} else {
+			invalid = append(invalid, [3][]byte{v.pk, v.msg, v.sig})
+		}
+	}
+	// 鍵と署名を生成して、同じ公開鍵の署名を含むバッチにする
+	sk := make([]byte, 32)
+	if _, err := rand.Read(sk); err != nil {
+		t.Fatal(err)
+	}
+	pk, err := PublicKeyBIP340(sk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, m := range []string{"a", "b", "c"} {
+		sig, err := SignBIP340(sk, []byte(m), make([]byte, 32))
+		if err != nil {
+			t.Fatal(err)
+		}
+		valid = append(valid, [3][]byte{pk, []byte(m), sig})
+	}
+
+	batch := func(entries [][3][]byte) bool {
+		var pks, msgs, sigs [][]byte
+		for _, e := range entries {
+			pks = append(pks, e[0])
+			msgs = append(msgs, e[1])
+			sigs = append(sigs, e[2])
+		}
+		return BatchVerifyBIP340(rand.Reader, pks, msgs, sigs)
+	}
+	if !batch(valid) {
+		t.Errorf("BatchVerifyBIP340(valid) = false, want true")
+	}
+	if !batch(nil) {
+		t.Errorf("BatchVerifyBIP340(empty) = false, want true")
+	}
+	for i, e := range invalid {
+		// 不正な署名を1つ混ぜると、先頭でも途中でも検出できる
+		entries := append(append([][3][]byte{}, valid[:i%len(valid)]...), e)
+		entries = append(entries, valid[i%len(valid):]...)
+		if batch(entries) {
+			t.Errorf("BatchVerifyBIP340(with invalid %v) = true, want false", i)
+		}
+	}
+
+	// 2つの署名の s を入れ替えると個別には不正だが、a_i をすべて1にした検証式では和が変わらないので見逃してしまう
+	swapped := [][3][]byte{valid[0], valid[1]}
+	swapped[0][2] = append(append([]byte{}, valid[0][2][:32]...), valid[1][2][32:]...)
+	swapped[1][2] = append(append([]byte{}, valid[1][2][:32]...), valid[0][2][32:]...)
+	if batch(swapped) {
+		t.Errorf("BatchVerifyBIP340(swapped s) = true, want false")
+	}
+
+	if BatchVerifyBIP340(rand.Reader, [][]byte{pk}, nil, nil) {
+		t.Errorf("BatchVerifyBIP340(mismatched lengths) = true, want false")
+	}
+}
+
+func TestSignBIP340_invalid(t *testing.T) {
+	n := mustHex(t, "FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFEBAAEDCE6AF48A03BBFD25E8CD0364141")
+	tests := []struct {
+		name string
+		sk   []byte
+	}{
+		{name: "zero", sk: make([]byte, 32)},
+		{name: "n", sk: n},
+		{name: "short", sk: make([]byte, 31)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := SignBIP340(tt.sk, nil, make([]byte, 32)); !errors.Is(err, ErrInvalidPrivateKey) {
+				t.Errorf("SignBIP340() error = %v, want %v", err, ErrInvalidPrivateKey)
+			}
+		})
+	}
+	sk := make([]byte, 32)
+	sk[31] = 1
+	if _, err := SignBIP340(sk, nil, nil); err == nil {
+		t.Errorf("SignBIP340(auxRand = nil) error = nil, want error")
+	}
+}
+
+func mustHex(t *testing.T, s string) []byte {
+	t.Helper()
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
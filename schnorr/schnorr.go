@@ -0,0 +1,116 @@
+// Package schnorr は素数位数の群の上の Schnorr 署名と、secp256k1 上の BIP-340 署名を提供します
+// Schnorr 署名はナンスへのコミットメント R = g^k と、チャレンジ e = H(R, Y, m) への応答 s = k + ex mod q の組です
+// 検証式 g^s = R Y^e が線形なので、BIP-340 では複数の署名をまとめて検証するバッチ検証ができます
+package schnorr
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"io"
+
+	"github.com/convto/mycrypto/big"
+	"github.com/convto/mycrypto/dlog"
+)
+
+// ErrInvalidPrivateKey は秘密鍵が 1 <= x < q の範囲にないことを表します
+var ErrInvalidPrivateKey = errors.New("schnorr: private key out of range")
+
+// challengeDomain はチャレンジのハッシュの先頭につける文字列です
+var challengeDomain = []byte("schnorr challenge")
+
+// PublicKey は Schnorr 署名の公開鍵 Y = g^x です
+type PublicKey struct {
+	Group dlog.PrimeOrderGroup
+	Y     dlog.Element
+}
+
+// PrivateKey は Schnorr 署名の秘密鍵 x です
+type PrivateKey struct {
+	PublicKey
+	X *big.Int
+}
+
+// Signature は Schnorr 署名 (R, s) です
+type Signature struct {
+	R dlog.Element
+	S *big.Int
+}
+
+// NewPrivateKey は 1 <= x < q の x から秘密鍵を返します
+// 範囲外のときは ErrInvalidPrivateKey を返します
+func NewPrivateKey(grp dlog.PrimeOrderGroup, x *big.Int) (*PrivateKey, error) {
+	if !inRange(x, grp.Order()) {
+		return nil, ErrInvalidPrivateKey
+	}
+	return &PrivateKey{PublicKey: PublicKey{Group: grp, Y: grp.Exp(grp.Generator(), x)}, X: x}, nil
+}
+
+// GenerateKey は r から読み込んだ乱数で群 grp の鍵ペアを生成します
+func GenerateKey(r io.Reader, grp dlog.PrimeOrderGroup) (*PrivateKey, error) {
+	x, err := big.RandNonZeroInt(r, grp.Order())
+	if err != nil {
+		return nil, err
+	}
+	return NewPrivateKey(grp, x)
+}
+
+// Sign は r から読み込んだ乱数をナンスにしてメッセージ msg に署名します
+func Sign(r io.Reader, priv *PrivateKey, msg []byte) (*Signature, error) {
+	grp := priv.Group
+	q := grp.Order()
+	k, err := big.RandNonZeroInt(r, q)
+	if err != nil {
+		return nil, err
+	}
+	// s = k + ex mod q
+	rr := grp.Exp(grp.Generator(), k)
+	e := challenge(grp, rr, priv.Y, msg)
+	s := big.Mod(big.Add(k, big.Mul(e, priv.X)), q)
+	return &Signature{R: rr, S: s}, nil
+}
+
+// Verify は sig が公開鍵 pub によるメッセージ msg の正しい署名かどうかを判定します
+func Verify(pub *PublicKey, msg []byte, sig *Signature) bool {
+	grp := pub.Group
+	q := grp.Order()
+	if sig.R == nil || sig.S == nil || !grp.Contains(sig.R) || !grp.Contains(pub.Y) {
+		return false
+	}
+	if big.Cmp(sig.S, big.Zero) < 0 || big.Cmp(sig.S, q) >= 0 {
+		return false
+	}
+	// g^s = R Y^e
+	e := challenge(grp, sig.R, pub.Y, msg)
+	lhs := grp.Exp(grp.Generator(), sig.S)
+	rhs := grp.Op(sig.R, grp.Exp(pub.Y, e))
+	return grp.Equal(lhs, rhs)
+}
+
+// challenge は e = H(domain || R || Y || m) mod q を返します
+// 群の元は長さを前置して連結するので、可変長の表現でも区切りが曖昧になりません
+func challenge(grp dlog.PrimeOrderGroup, r, y dlog.Element, msg []byte) *big.Int {
+	h := sha256.New()
+	h.Write(challengeDomain)
+	for _, b := range [][]byte{encodeElement(r), encodeElement(y), msg} {
+		var l [8]byte
+		binary.BigEndian.PutUint64(l[:], uint64(len(b)))
+		h.Write(l[:])
+		h.Write(b)
+	}
+	return big.Mod(new(big.Int).SetBytes(h.Sum(nil)), grp.Order())
+}
+
+// encodeElement は群の元のバイト表現を返します
+// *big.Int や *ec.Point のように Bytes を持つ元はそれを使い、持たない元は dlog.Element の文字列表現を使います
+func encodeElement(x dlog.Element) []byte {
+	if b, ok := x.(interface{ Bytes() []byte }); ok {
+		return b.Bytes()
+	}
+	return []byte(x.String())
+}
+
+// inRange は 1 <= x < n かどうかを判定します
+func inRange(x, n *big.Int) bool {
+	return big.Cmp(x, big.NewInt(1)) >= 0 && big.Cmp(x, n) < 0
+}
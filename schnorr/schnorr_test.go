@@ -0,0 +1,101 @@
+package schnorr
+
+import (
+	"crypto/rand"
+	"errors"
+	"testing"
+
+	"github.com/convto/mycrypto/big"
+	"github.com/convto/mycrypto/dlog"
+	"github.com/convto/mycrypto/ec"
+)
+
+// testZpGroup は p が1024ビット、q が160ビットの FIPS 186-4 の DSA パラメータによる群です
+var testZpGroup = dlog.NewZpGroup(
+	new(big.Int).SetString("0x8f0e2cfd1ca6047c541b387aa36676e91c29aa6475dc61b601d4fc51cbae565817a223ed05cc28a8047dbe68516c9a04c3b688de6ed95ef4e7dd4d626721e2915ae20eda84e161b81f62c2067cf0349668516536150aba77d91c7a9e0d9f6715d8d2c10ff872bba84973dfc09414a183af6a319b7e4d9f6bc5383872c72ddf8b"),
+	new(big.Int).SetString("0xf82bf6fd6aa20c4706dd36e19202f835d333cb53"),
+	new(big.Int).SetString("0x390267fd31c85bfa709afb3eade45fc83db97a971daa3fc7df23817046288c8068ba2fca2604097d3d4b1f1ffe181773df52ca8132ee00baf8966fae831ed04ce653288a410cff1920036fa8502f90248fcb8ddda44ab5f2dddd268045b42904ab20c3670e60fdfb5be06d4f633bdeaee4909d1bbbbf2b8c42f9b0b26bd7ce1"),
+)
+
+// testGroups はテストに使う群で、Z_p* の部分群と楕円曲線の両方を含みます
+var testGroups = []struct {
+	name string
+	grp  dlog.PrimeOrderGroup
+}{
+	{name: "Zp 1024/160", grp: testZpGroup},
+	{name: "P-256", grp: dlog.NewCurveGroup(ec.P256())},
+	{name: "secp256k1", grp: dlog.NewCurveGroup(ec.Secp256k1())},
+}
+
+func TestSign(t *testing.T) {
+	msg := []byte("hello, schnorr")
+	for _, tt := range testGroups {
+		t.Run(tt.name, func(t *testing.T) {
+			grp := tt.grp
+			priv, err := GenerateKey(rand.Reader, grp)
+			if err != nil {
+				t.Fatal(err)
+			}
+			sig, err := Sign(rand.Reader, priv, msg)
+			if err != nil {
+				t.Fatalf("Sign() error = %v", err)
+			}
+			if !Verify(&priv.PublicKey, msg, sig) {
+				t.Fatalf("Verify() = false, want true")
+			}
+
+			other, err := GenerateKey(rand.Reader, grp)
+			if err != nil {
+				t.Fatal(err)
+			}
+			one := big.NewInt(1)
+			tests := []struct {
+				name string
+				pub  *PublicKey
+				msg  []byte
+				sig  *Signature
+			}{
+				{name: "other message", pub: &priv.PublicKey, msg: []byte("hello, schnorr!"), sig: sig},
+				{name: "other key", pub: &other.PublicKey, msg: msg, sig: sig},
+				{name: "tampered s", pub: &priv.PublicKey, msg: msg, sig: &Signature{R: sig.R, S: big.Mod(big.Add(sig.S, one), grp.Order())}},
+				{name: "tampered R", pub: &priv.PublicKey, msg: msg, sig: &Signature{R: grp.Op(sig.R, grp.Generator()), S: sig.S}},
+				{name: "s + q", pub: &priv.PublicKey, msg: msg, sig: &Signature{R: sig.R, S: big.Add(sig.S, grp.Order())}},
+				{name: "missing R", pub: &priv.PublicKey, msg: msg, sig: &Signature{S: sig.S}},
+				{name: "R = identity", pub: &priv.PublicKey, msg: msg, sig: &Signature{R: grp.Identity(), S: sig.S}},
+			}
+			for _, tc := range tests {
+				if Verify(tc.pub, tc.msg, tc.sig) {
+					t.Errorf("Verify(%v) = true, want false", tc.name)
+				}
+			}
+		})
+	}
+}
+
+// TestVerify_notInGroup は位数 q の部分群に含まれない R を使った署名を受け付けないことを確かめる
+func TestVerify_notInGroup(t *testing.T) {
+	grp := testZpGroup
+	priv, err := GenerateKey(rand.Reader, grp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg := []byte("message")
+	sig, err := Sign(rand.Reader, priv, msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// -R は位数 2q の元になる
+	neg := big.Sub(grp.P, sig.R.(*big.Int))
+	if Verify(&priv.PublicKey, msg, &Signature{R: neg, S: sig.S}) {
+		t.Errorf("Verify(-R) = true, want false")
+	}
+}
+
+func TestNewPrivateKey(t *testing.T) {
+	grp := testZpGroup
+	for _, x := range []*big.Int{big.NewInt(0), grp.Q, big.NewInt(-1)} {
+		if _, err := NewPrivateKey(grp, x); !errors.Is(err, ErrInvalidPrivateKey) {
+			t.Errorf("NewPrivateKey(%v) error = %v, want %v", x, err, ErrInvalidPrivateKey)
+		}
+	}
+}
@@ -0,0 +1,172 @@
+// Package sha3 は FIPS 202 の Keccak-f[1600] によるスポンジ構造と、可変長出力のハッシュ関数 (XOF) の SHAKE128, SHAKE256 を提供します
+// RFC 9380 の expand_message_xof のように、任意の長さの一様なバイト列を導出するのに使います
+package sha3
+
+import (
+	"encoding/binary"
+	"math/bits"
+)
+
+// rounds は Keccak-f[1600] のラウンド数
+const rounds = 24
+
+var (
+	// rotations[x+5y] は ρ でレーン (x, y) を回転するビット数
+	rotations = func() [25]int {
+		var r [25]int
+		x, y := 1, 0
+		for t := 0; t < 24; t++ {
+			r[x+5*y] = (t + 1) * (t + 2) / 2 % 64
+			x, y = y, (2*x+3*y)%5
+		}
+		return r
+	}()
+
+	// roundConstants[i] は i ラウンド目の ι でレーン (0, 0) に足す定数
+	// 2^j - 1 ビット目 (j = 0, ..., 6) が LFSR の出力 rc(j + 7i) になる
+	roundConstants = func() [rounds]uint64 {
+		var rc [rounds]uint64
+		for i := range rc {
+			for j := 0; j < 7; j++ {
+				rc[i] |= uint64(lfsr(j+7*i)) << (1<<j - 1)
+			}
+		}
+		return rc
+	}()
+)
+
+// lfsr は FIPS 202 Algorithm 5 の rc(t) で、x^8 + x^6 + x^5 + x^4 + 1 の LFSR の t 番目の出力を返す
+func lfsr(t int) uint {
+	r := uint(1)
+	for i := 0; i < t%255; i++ {
+		r <<= 1
+		if r&0x100 != 0 {
+			r ^= 0x171
+		}
+	}
+	return r & 1
+}
+
+// keccakF1600 は25個の64bitのレーンからなる状態 a に Keccak-f[1600] を適用する
+// レーン (x, y) は a[x+5y] に置く
+func keccakF1600(a *[25]uint64) {
+	var c [5]uint64
+	var b [25]uint64
+	for _, rc := range roundConstants {
+		// θ: 各列のパリティを隣の列に足す
+		for x := 0; x < 5; x++ {
+			c[x] = a[x] ^ a[x+5] ^ a[x+10] ^ a[x+15] ^ a[x+20]
+		}
+		for x := 0; x < 5; x++ {
+			d := c[(x+4)%5] ^ bits.RotateLeft64(c[(x+1)%5], 1)
+			for y := 0; y < 25; y += 5 {
+				a[x+y] ^= d
+			}
+		}
+		// ρ と π: レーンを回転し、(x, y) を (y, 2x + 3y) に移す
+		for x := 0; x < 5; x++ {
+			for y := 0; y < 5; y++ {
+				b[y+5*((2*x+3*y)%5)] = bits.RotateLeft64(a[x+5*y], rotations[x+5*y])
+			}
+		}
+		// χ: 行ごとの非線形変換
+		for y := 0; y < 25; y += 5 {
+			for x := 0; x < 5; x++ {
+				a[x+y] = b[x+y] ^ (^b[(x+1)%5+y] & b[(x+2)%5+y])
+			}
+		}
+		// ι
+		a[0] ^= rc
+	}
+}
+
+// Shake は SHAKE128 または SHAKE256 のスポンジです
+// Write で入力を吸収し、最初の Read でパディングしてから任意の長さの出力を絞り出します
+type Shake struct {
+	a    [25]uint64
+	rate int
+	// buf は吸収中は rate に満たない入力、絞り出し中はまだ読まれていない出力
+	buf       []byte
+	squeezing bool
+}
+
+// NewShake128 は SHAKE128 (rate 168 バイト、安全性 128 ビット) を返します
+func NewShake128() *Shake {
+	return &Shake{rate: 168}
+}
+
+// NewShake256 は SHAKE256 (rate 136 バイト、安全性 256 ビット) を返します
+func NewShake256() *Shake {
+	return &Shake{rate: 136}
+}
+
+// Write は p を吸収します
+// Read を呼んだ後に書き込むとpanicします
+func (s *Shake) Write(p []byte) (int, error) {
+	if s.squeezing {
+		panic("sha3: write after read")
+	}
+	n := len(p)
+	for len(p) > 0 {
+		k := s.rate - len(s.buf)
+		if k > len(p) {
+			k = len(p)
+		}
+		s.buf = append(s.buf, p[:k]...)
+		p = p[k:]
+		if len(s.buf) == s.rate {
+			s.absorb(s.buf)
+			s.buf = s.buf[:0]
+		}
+	}
+	return n, nil
+}
+
+// Read は出力の続きを len(p) バイト読み出します
+func (s *Shake) Read(p []byte) (int, error) {
+	if !s.squeezing {
+		// SHAKE のドメイン分離のビット 1111 と pad10*1 を合わせたパディング
+		block := make([]byte, s.rate)
+		copy(block, s.buf)
+		block[len(s.buf)] ^= 0x1f
+		block[s.rate-1] ^= 0x80
+		s.absorb(block)
+		s.buf = s.squeeze()
+		s.squeezing = true
+	}
+	n := len(p)
+	for len(p) > 0 {
+		if len(s.buf) == 0 {
+			keccakF1600(&s.a)
+			s.buf = s.squeeze()
+		}
+		k := copy(p, s.buf)
+		s.buf = s.buf[k:]
+		p = p[k:]
+	}
+	return n, nil
+}
+
+// Reset は入力を吸収する前の状態に戻します
+func (s *Shake) Reset() {
+	s.a = [25]uint64{}
+	s.buf = nil
+	s.squeezing = false
+}
+
+// absorb は rate バイトのブロックを状態に足して置換する
+func (s *Shake) absorb(block []byte) {
+	for i := 0; i < s.rate/8; i++ {
+		s.a[i] ^= binary.LittleEndian.Uint64(block[8*i:])
+	}
+	keccakF1600(&s.a)
+}
+
+// squeeze は状態の先頭 rate バイトを返す
+func (s *Shake) squeeze() []byte {
+	out := make([]byte, s.rate)
+	for i := 0; i < s.rate/8; i++ {
+		binary.LittleEndian.PutUint64(out[8*i:], s.a[i])
+	}
+	return out
+}
@@ -0,0 +1,72 @@
+package sha3
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+)
+
+// NIST の SHAKE128, SHAKE256 の例 (空のメッセージと 0xa3 を200バイト並べたメッセージ)
+func TestShake(t *testing.T) {
+	msg1600 := bytes.Repeat([]byte{0xa3}, 200)
+	tests := []struct {
+		name string
+		new  func() *Shake
+		msg  []byte
+		want string
+	}{
+		{name: "SHAKE128 empty", new: NewShake128, msg: nil, want: "7f9c2ba4e88f827d616045507605853ed73b8093f6efbc88eb1a6eacfa66ef26"},
+		{name: "SHAKE256 empty", new: NewShake256, msg: nil, want: "46b9dd2b0ba88d13233b3feb743eeb243fcd52ea62b81b82b50c27646ed5762fd75dc4ddd8c0f200cb05019d67b592f6fc821c49479ab48640292eacb3b7c4be"},
+		{name: "SHAKE128 1600 bits", new: NewShake128, msg: msg1600, want: "131ab8d2b594946b9c81333f9bb6e0ce75c3b93104fa3469d3917457385da037"},
+		{name: "SHAKE256 1600 bits", new: NewShake256, msg: msg1600, want: "cd8a920ed141aa0407a22d59288652e9d9f1a7ee0c1e7c1ca699424da84a904d2d700caae7396ece96604440577da4f3aa22aeb8857f961c4cd8e06f0ae6610b"},
+	}
+	for _, tt := range tests {
+		h := tt.new()
+		h.Write(tt.msg)
+		want, _ := hex.DecodeString(tt.want)
+		got := make([]byte, len(want))
+		h.Read(got)
+		if !bytes.Equal(got, want) {
+			t.Errorf("%s = %x, want %x", tt.name, got, want)
+		}
+	}
+}
+
+// 入力を分けて書き込んでも、出力を分けて読み出しても、rate の境界をまたいで同じ結果になる
+func TestShake_split(t *testing.T) {
+	msg := bytes.Repeat([]byte("0123456789"), 50)
+	for _, newShake := range []func() *Shake{NewShake128, NewShake256} {
+		h := newShake()
+		h.Write(msg)
+		want := make([]byte, 1000)
+		h.Read(want)
+
+		h.Reset()
+		rest := msg
+		for _, n := range []int{1, 135, 168, 196} {
+			h.Write(rest[:n])
+			rest = rest[n:]
+		}
+		h.Write(rest)
+		got := make([]byte, 0, 1000)
+		for _, n := range []int{7, 161, 136, 400, 296} {
+			b := make([]byte, n)
+			h.Read(b)
+			got = append(got, b...)
+		}
+		if !bytes.Equal(got, want) {
+			t.Errorf("split write/read = %x, want %x", got, want)
+		}
+	}
+}
+
+func TestShake_writeAfterRead(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Write after Read did not panic")
+		}
+	}()
+	h := NewShake128()
+	h.Read(make([]byte, 1))
+	h.Write([]byte{0})
+}
@@ -0,0 +1,114 @@
+package sss
+
+import (
+	"errors"
+	"io"
+
+	"github.com/convto/mycrypto/gf2m"
+)
+
+// gf256 は AES と同じ既約多項式 x^8 + x^4 + x^3 + x + 1 による GF(2^8) です
+var gf256 = gf2m.New(8, 4, 3, 1, 0)
+
+// SplitBytes は r から読み込んだ乱数で、秘密の各バイトを GF(2^8) 上の別々のランダムな k-1 次多項式で分散し、x = 1, ..., n での値を並べた分散値を返します
+// 体の大きさが 256 なので任意の長さの秘密を扱え、分散値の Value は秘密と同じ長さになります
+func SplitBytes(r io.Reader, secret []byte, n, k int) ([]*Share, error) {
+	if err := checkThreshold(n, k); err != nil {
+		return nil, err
+	}
+	if len(secret) == 0 {
+		return nil, errors.New("sss: SplitBytes: empty secret")
+	}
+	// 各バイトの多項式の1次以上の係数をまとめて読み込む
+	rnd := make([]byte, len(secret)*(k-1))
+	if _, err := io.ReadFull(r, rnd); err != nil {
+		return nil, err
+	}
+	shares := make([]*Share, n)
+	for i := range shares {
+		shares[i] = &Share{Mode: ModeBytes, Threshold: byte(k), Index: byte(i + 1), Value: make([]byte, len(secret))}
+	}
+	coeffs := make([]*gf2m.Element, k)
+	for j, b := range secret {
+		coeffs[0] = gf256.NewElement(uint64(b))
+		for i := 1; i < k; i++ {
+			coeffs[i] = gf256.NewElement(uint64(rnd[j*(k-1)+i-1]))
+		}
+		for _, s := range shares {
+			s.Value[j] = eval(coeffs, gf256.NewElement(uint64(s.Index))).Bytes()[0]
+		}
+	}
+	return shares, nil
+}
+
+// CombineBytes は SplitBytes で作った分散値からバイトごとにラグランジュ補間で秘密を復元します
+// Split で作った分散値が含まれるときは ErrModeMismatch を返します
+// k 個より多い分散値を渡したときは、先頭の k 個で決まる多項式が残りの分散値も通ることを確かめ、そうでなければ ErrInconsistentShares を返します
+func CombineBytes(shares []*Share) ([]byte, error) {
+	k, err := checkShares(ModeBytes, shares)
+	if err != nil {
+		return nil, err
+	}
+	xs := make([]*gf2m.Element, k)
+	for i, s := range shares[:k] {
+		xs[i] = gf256.NewElement(uint64(s.Index))
+	}
+	// 係数は x 座標だけで決まるので、秘密の x = 0 と残りの分散値の x について先に求めておく
+	secretCoeffs := lagrange(xs, gf256.Zero())
+	extra := shares[k:]
+	extraCoeffs := make([][]*gf2m.Element, len(extra))
+	for i, s := range extra {
+		extraCoeffs[i] = lagrange(xs, gf256.NewElement(uint64(s.Index)))
+	}
+
+	secret := make([]byte, len(shares[0].Value))
+	ys := make([]*gf2m.Element, k)
+	for j := range secret {
+		for i, s := range shares[:k] {
+			ys[i] = gf256.NewElement(uint64(s.Value[j]))
+		}
+		for i, s := range extra {
+			if combine(extraCoeffs[i], ys) != s.Value[j] {
+				return nil, ErrInconsistentShares
+			}
+		}
+		secret[j] = combine(secretCoeffs, ys)
+	}
+	return secret, nil
+}
+
+// eval は低い次数から順に並べた係数の多項式の x における値をホーナー法で求める
+func eval(coeffs []*gf2m.Element, x *gf2m.Element) *gf2m.Element {
+	y := gf256.Zero()
+	for i := len(coeffs) - 1; i >= 0; i-- {
+		y = y.Mul(x).Add(coeffs[i])
+	}
+	return y
+}
+
+// lagrange は xs を通る多項式の x0 での値を Σ l_i * y_i で求めるための係数 l_i = Π_(j!=i) (x0 - x_j) / (x_i - x_j) を返す
+// 標数2なので減算は加算と同じになる
+func lagrange(xs []*gf2m.Element, x0 *gf2m.Element) []*gf2m.Element {
+	l := make([]*gf2m.Element, len(xs))
+	for i, xi := range xs {
+		num, den := gf256.One(), gf256.One()
+		for j, xj := range xs {
+			if i == j {
+				continue
+			}
+			num = num.Mul(x0.Add(xj))
+			den = den.Mul(xi.Add(xj))
+		}
+		l[i] = num.Mul(den.Inv())
+	}
+	return l
+}
+
+// combine は Σ l_i * y_i をバイトで返す
+func combine(l, ys []*gf2m.Element) byte {
+	y := gf256.Zero()
+	for i := range l {
+		y = y.Add(l[i].Mul(ys[i]))
+	}
+	return y.Bytes()[0]
+}
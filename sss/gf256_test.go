@@ -0,0 +1,82 @@
+package sss
+
+import (
+	"bytes"
+	"crypto/rand"
+	"errors"
+	"testing"
+)
+
+func TestSplitBytes(t *testing.T) {
+	secret := []byte("backup key: 0123456789abcdef\x00\xff")
+	tests := []struct {
+		n, k int
+	}{
+		{n: 2, k: 2},
+		{n: 5, k: 3},
+		{n: 6, k: 6},
+	}
+	for _, tt := range tests {
+		shares, err := SplitBytes(rand.Reader, secret, tt.n, tt.k)
+		if err != nil {
+			t.Fatalf("SplitBytes(n = %v, k = %v) error = %v", tt.n, tt.k, err)
+		}
+		for m := tt.k; m <= tt.n; m++ {
+			for _, idx := range subsets(tt.n, m) {
+				got, err := CombineBytes(pick(shares, idx))
+				if err != nil {
+					t.Fatalf("CombineBytes(%v) error = %v", idx, err)
+				}
+				if !bytes.Equal(got, secret) {
+					t.Errorf("CombineBytes(%v) = %x, want %x", idx, got, secret)
+				}
+			}
+		}
+		if _, err := CombineBytes(shares[:tt.k-1]); !errors.Is(err, ErrNotEnoughShares) {
+			t.Errorf("CombineBytes(k-1 shares) error = %v, want %v", err, ErrNotEnoughShares)
+		}
+	}
+}
+
+// TestSplitBytes_known は乱数を固定して、k = 2 の分散値が s + a * x (GF(2^8) の演算) になることを確かめる
+func TestSplitBytes_known(t *testing.T) {
+	// a = 0x03 のとき、x = 1, 2, 3 で a * x = 0x03, 0x06, 0x05 になる
+	shares, err := SplitBytes(bytes.NewReader([]byte{0x03}), []byte{0x53}, 3, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []byte{0x53 ^ 0x03, 0x53 ^ 0x06, 0x53 ^ 0x05}
+	for i, s := range shares {
+		if s.Index != byte(i+1) || s.Value[0] != want[i] {
+			t.Errorf("share %v = (%v, %#x), want (%v, %#x)", i, s.Index, s.Value[0], i+1, want[i])
+		}
+	}
+}
+
+func TestSplitBytes_invalid(t *testing.T) {
+	if _, err := SplitBytes(rand.Reader, []byte{1}, 3, 1); !errors.Is(err, ErrInvalidThreshold) {
+		t.Errorf("SplitBytes(k = 1) error = %v, want %v", err, ErrInvalidThreshold)
+	}
+	if _, err := SplitBytes(rand.Reader, nil, 3, 2); err == nil {
+		t.Errorf("SplitBytes(empty) error = nil, want error")
+	}
+}
+
+func TestCombineBytes_inconsistent(t *testing.T) {
+	secret := []byte("secret")
+	shares, err := SplitBytes(rand.Reader, secret, 5, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range shares {
+		bad := *shares[i]
+		bad.Value = append([]byte(nil), bad.Value...)
+		bad.Value[len(bad.Value)-1] ^= 0x80
+		tampered := append([]*Share(nil), shares...)
+		tampered[i] = &bad
+		// 先頭の k 個に含まれていても、残りの分散値と矛盾するので検出できる
+		if _, err := CombineBytes(tampered); !errors.Is(err, ErrInconsistentShares) {
+			t.Errorf("CombineBytes(tampered share %v) error = %v, want %v", i, err, ErrInconsistentShares)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package sss
+
+import (
+	"errors"
+	"io"
+
+	"github.com/convto/mycrypto/big"
+	"github.com/convto/mycrypto/field"
+	"github.com/convto/mycrypto/poly"
+)
+
+// ErrSecretOutOfRange は秘密が 0 <= secret < p の範囲にないことを表します
+var ErrSecretOutOfRange = errors.New("sss: secret out of range")
+
+// Split は r から読み込んだ乱数で、GF(p) 上の f(0) = secret となるランダムな k-1 次多項式を作り、x = 1, ..., n での値を分散値として返します
+// p は分散数 n より大きい素数でなければならず、secret は 0 <= secret < p の範囲になければなりません
+func Split(r io.Reader, secret, p *big.Int, n, k int) ([]*Share, error) {
+	if err := checkThreshold(n, k); err != nil {
+		return nil, err
+	}
+	if big.Cmp(p, big.NewInt(int64(n))) <= 0 || !p.ProbablyPrime(20) {
+		return nil, errors.New("sss: Split: p must be a prime greater than n")
+	}
+	if big.Cmp(secret, big.Zero) < 0 || big.Cmp(secret, p) >= 0 {
+		return nil, ErrSecretOutOfRange
+	}
+	f := field.New(p)
+	coeffs := make([]*field.Element, k)
+	coeffs[0] = f.NewElement(secret)
+	for i := 1; i < k; i++ {
+		c, err := f.Random(r)
+		if err != nil {
+			return nil, err
+		}
+		coeffs[i] = c
+	}
+	g := poly.FromElements(f, coeffs)
+	shares := make([]*Share, n)
+	for i := range shares {
+		x := byte(i + 1)
+		shares[i] = &Share{
+			Mode:      ModePrime,
+			Threshold: byte(k),
+			Index:     x,
+			Value:     g.Eval(f.NewElement(big.NewInt(int64(x)))).Bytes(),
+		}
+	}
+	return shares, nil
+}
+
+// Combine は Split で作った GF(p) 上の分散値からラグランジュ補間で秘密を復元します
+// SplitBytes で作った分散値が含まれるときは ErrModeMismatch を返します
+// k 個より多い分散値を渡したときは、すべてを通る多項式が k-1 次以下になることを確かめ、そうでなければ ErrInconsistentShares を返します
+func Combine(p *big.Int, shares []*Share) (*big.Int, error) {
+	k, err := checkShares(ModePrime, shares)
+	if err != nil {
+		return nil, err
+	}
+	f := field.New(p)
+	xs := make([]*field.Element, len(shares))
+	ys := make([]*field.Element, len(shares))
+	for i, s := range shares {
+		if big.Cmp(p, big.NewInt(int64(s.Index))) <= 0 {
+			return nil, ErrInvalidShare
+		}
+		y, err := f.SetBytes(s.Value)
+		if err != nil {
+			return nil, ErrInvalidShare
+		}
+		xs[i] = f.NewElement(big.NewInt(int64(s.Index)))
+		ys[i] = y
+	}
+	g, err := poly.Interpolate(f, xs, ys)
+	if err != nil {
+		return nil, ErrInvalidShare
+	}
+	if g.Degree() >= k {
+		return nil, ErrInconsistentShares
+	}
+	return g.Coeff(0).Int(), nil
+}
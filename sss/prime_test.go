@@ -0,0 +1,142 @@
+package sss
+
+import (
+	"crypto/rand"
+	"errors"
+	"testing"
+
+	"github.com/convto/mycrypto/big"
+)
+
+// testPrime は 2^127 - 1 のメルセンヌ素数
+var testPrime = big.Sub(big.Exp(big.NewInt(2), big.NewInt(127), nil), big.NewInt(1))
+
+func TestSplit(t *testing.T) {
+	secret := new(big.Int).SetString("0x0123456789abcdef0123456789abcdef")
+	secret = big.Mod(secret, testPrime)
+	tests := []struct {
+		n, k int
+	}{
+		{n: 2, k: 2},
+		{n: 5, k: 3},
+		{n: 6, k: 6},
+	}
+	for _, tt := range tests {
+		shares, err := Split(rand.Reader, secret, testPrime, tt.n, tt.k)
+		if err != nil {
+			t.Fatalf("Split(n = %v, k = %v) error = %v", tt.n, tt.k, err)
+		}
+		// k 個以上のどの組み合わせからも、バイト表現を経由して復元できる
+		for m := tt.k; m <= tt.n; m++ {
+			for _, idx := range subsets(tt.n, m) {
+				var parsed []*Share
+				for _, s := range pick(shares, idx) {
+					p, err := ParseShare(s.Bytes())
+					if err != nil {
+						t.Fatal(err)
+					}
+					parsed = append(parsed, p)
+				}
+				got, err := Combine(testPrime, parsed)
+				if err != nil {
+					t.Fatalf("Combine(%v) error = %v", idx, err)
+				}
+				if big.Cmp(got, secret) != 0 {
+					t.Errorf("Combine(%v) = %v, want %v", idx, got, secret)
+				}
+			}
+		}
+		// k-1 個では復元できない
+		if _, err := Combine(testPrime, shares[:tt.k-1]); !errors.Is(err, ErrNotEnoughShares) {
+			t.Errorf("Combine(k-1 shares) error = %v, want %v", err, ErrNotEnoughShares)
+		}
+	}
+}
+
+func TestSplit_invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		secret  *big.Int
+		p       *big.Int
+		n, k    int
+		wantErr error
+	}{
+		{name: "k = 1", secret: big.NewInt(1), p: testPrime, n: 3, k: 1, wantErr: ErrInvalidThreshold},
+		{name: "k > n", secret: big.NewInt(1), p: testPrime, n: 3, k: 4, wantErr: ErrInvalidThreshold},
+		{name: "n > 255", secret: big.NewInt(1), p: testPrime, n: 256, k: 2, wantErr: ErrInvalidThreshold},
+		{name: "secret = p", secret: testPrime, p: testPrime, n: 3, k: 2, wantErr: ErrSecretOutOfRange},
+		{name: "negative secret", secret: big.NewInt(-1), p: testPrime, n: 3, k: 2, wantErr: ErrSecretOutOfRange},
+		{name: "p not prime", secret: big.NewInt(1), p: big.NewInt(1000), n: 3, k: 2},
+		{name: "p <= n", secret: big.NewInt(1), p: big.NewInt(5), n: 5, k: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Split(rand.Reader, tt.secret, tt.p, tt.n, tt.k)
+			if err == nil || (tt.wantErr != nil && !errors.Is(err, tt.wantErr)) {
+				t.Errorf("Split() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCombine_inconsistent(t *testing.T) {
+	secret := big.NewInt(123456789)
+	shares, err := Split(rand.Reader, secret, testPrime, 5, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 1つの分散値の値をずらすと、k+1 個以上のときは検出できる
+	bad := *shares[4]
+	v := new(big.Int).SetBytes(bad.Value)
+	bad.Value = big.Mod(big.Add(v, big.NewInt(1)), testPrime).FillBytes(make([]byte, len(bad.Value)))
+	tampered := append(append([]*Share(nil), shares[:4]...), &bad)
+
+	if _, err := Combine(testPrime, tampered); !errors.Is(err, ErrInconsistentShares) {
+		t.Errorf("Combine(5 shares) error = %v, want %v", err, ErrInconsistentShares)
+	}
+	if _, err := Combine(testPrime, tampered[1:]); !errors.Is(err, ErrInconsistentShares) {
+		t.Errorf("Combine(4 shares) error = %v, want %v", err, ErrInconsistentShares)
+	}
+	// ちょうど k 個では常に何らかの多項式が通るので、検出できずに誤った値になる
+	got, err := Combine(testPrime, tampered[2:])
+	if err != nil {
+		t.Fatalf("Combine(3 shares) error = %v", err)
+	}
+	if big.Cmp(got, secret) == 0 {
+		t.Errorf("Combine(3 shares) = secret, want a different value")
+	}
+}
+
+func TestCombine_invalid(t *testing.T) {
+	shares, err := Split(rand.Reader, big.NewInt(42), testPrime, 3, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dup := *shares[1]
+	dup.Index = shares[0].Index
+	mismatched := *shares[1]
+	mismatched.Threshold = 3
+	short := *shares[1]
+	short.Value = short.Value[1:]
+	tooLarge := *shares[1]
+	tooLarge.Value = testPrime.FillBytes(make([]byte, len(tooLarge.Value)))
+
+	tests := []struct {
+		name    string
+		shares  []*Share
+		wantErr error
+	}{
+		{name: "no shares", shares: nil, wantErr: ErrNotEnoughShares},
+		{name: "duplicate index", shares: []*Share{shares[0], &dup}, wantErr: ErrInvalidShare},
+		{name: "mismatched threshold", shares: []*Share{shares[0], &mismatched}, wantErr: ErrInvalidShare},
+		{name: "mismatched length", shares: []*Share{shares[0], &short}, wantErr: ErrInvalidShare},
+		{name: "value >= p", shares: []*Share{shares[0], &tooLarge}, wantErr: ErrInvalidShare},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Combine(testPrime, tt.shares); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Combine() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
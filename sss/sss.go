@@ -0,0 +1,126 @@
+// Package sss は Shamir の秘密分散を提供します
+// 秘密を定数項とするランダムな k-1 次多項式の n 点での値を分散値として配り、k 個の分散値からラグランジュ補間で秘密を復元します
+// k-1 個以下の分散値からは秘密について何の情報も得られません
+// 素数 p を法とする GF(p) 上で整数の秘密を分散する Split/Combine と、GF(2^8) 上でバイト列の秘密をバイトごとに分散する SplitBytes/CombineBytes があります
+package sss
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+)
+
+var (
+	// ErrInvalidThreshold は閾値 k と分散数 n が 2 <= k <= n <= 255 を満たさないことを表します
+	ErrInvalidThreshold = errors.New("sss: invalid threshold")
+	// ErrInvalidShare は分散値の形式が正しくないことを表します
+	ErrInvalidShare = errors.New("sss: invalid share")
+	// ErrChecksum は分散値のチェックサムが一致しないことを表します
+	ErrChecksum = errors.New("sss: checksum mismatch")
+	// ErrNotEnoughShares は復元に必要な k 個の分散値がないことを表します
+	ErrNotEnoughShares = errors.New("sss: not enough shares")
+	// ErrInconsistentShares は k 個より多い分散値が同じ k-1 次多項式の上にないことを表します
+	ErrInconsistentShares = errors.New("sss: inconsistent shares")
+	// ErrModeMismatch は分散値が復元に使う関数と異なる方式で作られたことを表します
+	ErrModeMismatch = errors.New("sss: share mode mismatch")
+)
+
+// checksumSize はバイト表現の末尾につける SHA-256 のチェックサムの長さです
+const checksumSize = 4
+
+// Mode は分散値を作った方式です
+// 同じ k, x, f(x) でも GF(p) と GF(2^8) では意味が異なるので、取り違えて復元しないように分散値に記録します
+type Mode byte
+
+const (
+	// ModePrime は Split による GF(p) 上の分散値です
+	ModePrime Mode = 1
+	// ModeBytes は SplitBytes による GF(2^8) 上の分散値です
+	ModeBytes Mode = 2
+)
+
+// Share は1つの分散値です
+type Share struct {
+	// Mode は分散値を作った方式です
+	Mode Mode
+	// Threshold は復元に必要な分散値の数 k です
+	Threshold byte
+	// Index は多項式を評価した点 x で、1 から n の値です
+	Index byte
+	// Value は評価した値 f(x) です
+	// Split では p のバイト長のビッグエンディアン、SplitBytes では秘密と同じ長さのバイト列になります
+	Value []byte
+}
+
+// Bytes は分散値を mode || k || x || f(x) || checksum のバイト列で返します
+// checksum は先行するバイト列の SHA-256 の先頭4バイトで、保管や転記の際の破損を検出するためのものです
+func (s *Share) Bytes() []byte {
+	b := make([]byte, 0, 3+len(s.Value)+checksumSize)
+	b = append(b, byte(s.Mode), s.Threshold, s.Index)
+	b = append(b, s.Value...)
+	return append(b, checksum(b)...)
+}
+
+// ParseShare は Bytes の形式のバイト列から分散値を読み込みます
+// 長さや mode, k, x が正しくないときは ErrInvalidShare、チェックサムが一致しないときは ErrChecksum を返します
+func ParseShare(b []byte) (*Share, error) {
+	if len(b) <= 3+checksumSize {
+		return nil, ErrInvalidShare
+	}
+	body, sum := b[:len(b)-checksumSize], b[len(b)-checksumSize:]
+	if !bytes.Equal(checksum(body), sum) {
+		return nil, ErrChecksum
+	}
+	s := &Share{
+		Mode:      Mode(body[0]),
+		Threshold: body[1],
+		Index:     body[2],
+		Value:     append([]byte(nil), body[3:]...),
+	}
+	if (s.Mode != ModePrime && s.Mode != ModeBytes) || s.Threshold < 2 || s.Index == 0 {
+		return nil, ErrInvalidShare
+	}
+	return s, nil
+}
+
+// checksum は b の SHA-256 の先頭 checksumSize バイトを返す
+func checksum(b []byte) []byte {
+	h := sha256.Sum256(b)
+	return h[:checksumSize]
+}
+
+// checkThreshold は 2 <= k <= n <= 255 かどうかを確かめる
+func checkThreshold(n, k int) error {
+	if k < 2 || k > n || n > 255 {
+		return ErrInvalidThreshold
+	}
+	return nil
+}
+
+// checkShares は分散値がすべて mode で作られ、k 個以上あり、k と値の長さが揃っていて、x が重複していないことを確かめ、k を返す
+func checkShares(mode Mode, shares []*Share) (int, error) {
+	if len(shares) == 0 {
+		return 0, ErrNotEnoughShares
+	}
+	for _, s := range shares {
+		if s.Mode != mode {
+			return 0, ErrModeMismatch
+		}
+	}
+	k := shares[0].Threshold
+	size := len(shares[0].Value)
+	var seen [256]bool
+	for _, s := range shares {
+		if s.Threshold != k || len(s.Value) != size || s.Index == 0 || seen[s.Index] {
+			return 0, ErrInvalidShare
+		}
+		seen[s.Index] = true
+	}
+	if k < 2 || size == 0 {
+		return 0, ErrInvalidShare
+	}
+	if len(shares) < int(k) {
+		return 0, ErrNotEnoughShares
+	}
+	return int(k), nil
+}
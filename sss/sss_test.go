@@ -0,0 +1,106 @@
+package sss
+
+import (
+	"bytes"
+	"crypto/rand"
+	"errors"
+	"testing"
+
+	"github.com/convto/mycrypto/big"
+)
+
+func TestShareBytes(t *testing.T) {
+	s := &Share{Mode: ModeBytes, Threshold: 3, Index: 7, Value: []byte{0x01, 0x02, 0x03}}
+	b := s.Bytes()
+	got, err := ParseShare(b)
+	if err != nil {
+		t.Fatalf("ParseShare() error = %v", err)
+	}
+	if got.Mode != s.Mode || got.Threshold != s.Threshold || got.Index != s.Index || !bytes.Equal(got.Value, s.Value) {
+		t.Errorf("ParseShare() = %+v, want %+v", got, s)
+	}
+
+	flip := func(i int) []byte {
+		c := append([]byte(nil), b...)
+		c[i] ^= 0x01
+		return c
+	}
+	tests := []struct {
+		name    string
+		b       []byte
+		wantErr error
+	}{
+		{name: "flipped mode", b: flip(0), wantErr: ErrChecksum},
+		{name: "flipped threshold", b: flip(1), wantErr: ErrChecksum},
+		{name: "flipped index", b: flip(2), wantErr: ErrChecksum},
+		{name: "flipped value", b: flip(4), wantErr: ErrChecksum},
+		{name: "flipped checksum", b: flip(len(b) - 1), wantErr: ErrChecksum},
+		{name: "truncated", b: b[:len(b)-1], wantErr: ErrChecksum},
+		{name: "too short", b: b[:7], wantErr: ErrInvalidShare},
+		{name: "mode 0", b: (&Share{Threshold: 3, Index: 1, Value: []byte{1}}).Bytes(), wantErr: ErrInvalidShare},
+		{name: "unknown mode", b: (&Share{Mode: 3, Threshold: 3, Index: 1, Value: []byte{1}}).Bytes(), wantErr: ErrInvalidShare},
+		{name: "index 0", b: (&Share{Mode: ModePrime, Threshold: 3, Value: []byte{1}}).Bytes(), wantErr: ErrInvalidShare},
+		{name: "threshold 1", b: (&Share{Mode: ModePrime, Threshold: 1, Index: 1, Value: []byte{1}}).Bytes(), wantErr: ErrInvalidShare},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseShare(tt.b); !errors.Is(err, tt.wantErr) {
+				t.Errorf("ParseShare() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestCombine_modeMismatch は GF(p) と GF(2^8) の分散値を取り違えて復元できないことを確かめる
+func TestCombine_modeMismatch(t *testing.T) {
+	prime, err := Split(rand.Reader, big.NewInt(42), testPrime, 3, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bytesShares, err := SplitBytes(rand.Reader, []byte{42}, 3, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Combine(testPrime, bytesShares); !errors.Is(err, ErrModeMismatch) {
+		t.Errorf("Combine(SplitBytes shares) error = %v, want %v", err, ErrModeMismatch)
+	}
+	if _, err := CombineBytes(prime); !errors.Is(err, ErrModeMismatch) {
+		t.Errorf("CombineBytes(Split shares) error = %v, want %v", err, ErrModeMismatch)
+	}
+	// バイト表現を経由しても方式は保たれる
+	mixed := make([]*Share, 2)
+	for i, s := range []*Share{prime[0], bytesShares[1]} {
+		if mixed[i], err = ParseShare(s.Bytes()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := Combine(testPrime, mixed); !errors.Is(err, ErrModeMismatch) {
+		t.Errorf("Combine(mixed shares) error = %v, want %v", err, ErrModeMismatch)
+	}
+	if _, err := CombineBytes(mixed); !errors.Is(err, ErrModeMismatch) {
+		t.Errorf("CombineBytes(mixed shares) error = %v, want %v", err, ErrModeMismatch)
+	}
+}
+
+// subsets は 0, ..., n-1 から k 個を選ぶすべての組み合わせを返す
+func subsets(n, k int) [][]int {
+	if k == 0 {
+		return [][]int{nil}
+	}
+	var res [][]int
+	for i := n - 1; i >= k-1; i-- {
+		for _, s := range subsets(i, k-1) {
+			res = append(res, append(s, i))
+		}
+	}
+	return res
+}
+
+// pick は shares から idx の位置の分散値を選ぶ
+func pick(shares []*Share, idx []int) []*Share {
+	res := make([]*Share, len(idx))
+	for i, j := range idx {
+		res[i] = shares[j]
+	}
+	return res
+}
@@ -0,0 +1,221 @@
+package tower
+
+import (
+	"io"
+
+	"github.com/convto/mycrypto/big"
+)
+
+// Fp12 は c0 + c1*w の形の Fp12 の元です
+type Fp12 struct {
+	t  *Tower
+	c0 *Fp6
+	c1 *Fp6
+}
+
+// NewFp12 は c0 + c1*w を返します
+func (t *Tower) NewFp12(c0, c1 *Fp6) *Fp12 {
+	return &Fp12{t: t, c0: c0, c1: c1}
+}
+
+// ZeroFp12 は Fp12 の0を返します
+func (t *Tower) ZeroFp12() *Fp12 {
+	return &Fp12{t: t, c0: t.ZeroFp6(), c1: t.ZeroFp6()}
+}
+
+// OneFp12 は Fp12 の1を返します
+func (t *Tower) OneFp12() *Fp12 {
+	return &Fp12{t: t, c0: t.OneFp6(), c1: t.ZeroFp6()}
+}
+
+// RandomFp12 は r から読み込んだ乱数で一様な Fp12 の元を返します
+func (t *Tower) RandomFp12(r io.Reader) (*Fp12, error) {
+	c0, err := t.RandomFp6(r)
+	if err != nil {
+		return nil, err
+	}
+	c1, err := t.RandomFp6(r)
+	if err != nil {
+		return nil, err
+	}
+	return &Fp12{t: t, c0: c0, c1: c1}, nil
+}
+
+// C0 は x の定数項を返します
+func (x *Fp12) C0() *Fp6 {
+	return x.c0
+}
+
+// C1 は x の w の係数を返します
+func (x *Fp12) C1() *Fp6 {
+	return x.c1
+}
+
+func (x *Fp12) String() string {
+	return "(" + x.c0.String() + ")+(" + x.c1.String() + ")*w"
+}
+
+// Add は x + y を返します
+func (x *Fp12) Add(y *Fp12) *Fp12 {
+	return &Fp12{t: x.t, c0: x.c0.Add(y.c0), c1: x.c1.Add(y.c1)}
+}
+
+// Sub は x - y を返します
+func (x *Fp12) Sub(y *Fp12) *Fp12 {
+	return &Fp12{t: x.t, c0: x.c0.Sub(y.c0), c1: x.c1.Sub(y.c1)}
+}
+
+// Neg は -x を返します
+func (x *Fp12) Neg() *Fp12 {
+	return &Fp12{t: x.t, c0: x.c0.Neg(), c1: x.c1.Neg()}
+}
+
+// Mul は x * y を返します
+func (x *Fp12) Mul(y *Fp12) *Fp12 {
+	v0 := x.c0.Mul(y.c0)
+	v1 := x.c1.Mul(y.c1)
+	c1 := x.c0.Add(x.c1).Mul(y.c0.Add(y.c1)).Sub(v0).Sub(v1)
+	return &Fp12{t: x.t, c0: v1.mulByV().Add(v0), c1: c1}
+}
+
+// Square は x^2 を返します
+func (x *Fp12) Square() *Fp12 {
+	// (c0 + c1)(c0 + v*c1) - c0*c1 - v*c0*c1 = c0^2 + v*c1^2
+	v := x.c0.Mul(x.c1)
+	c0 := x.c0.Add(x.c1).Mul(x.c0.Add(x.c1.mulByV())).Sub(v).Sub(v.mulByV())
+	return &Fp12{t: x.t, c0: c0, c1: v.Add(v)}
+}
+
+// CyclotomicSquare は円分部分群の元 x について x^2 を返します
+// x^(p^4 - p^2 + 1) = 1 を満たす元 (最終冪の easy part を適用した後の元) にだけ使えます
+// それ以外の元では正しい結果になりません
+func (x *Fp12) CyclotomicSquare() *Fp12 {
+	// Granger-Scott の方法で Fp12 を Fp4 = Fp2[w^3]/((w^3)^2 - ξ) の3次拡大とみなして二乗する
+	z0, z4, z3 := x.c0.c0, x.c0.c1, x.c0.c2
+	z2, z1, z5 := x.c1.c0, x.c1.c1, x.c1.c2
+
+	t0, t1 := fp4Square(z0, z1)
+	z0 = t0.Sub(z0).Double().Add(t0)
+	z1 = t1.Add(z1).Double().Add(t1)
+
+	t0, t1 = fp4Square(z2, z3)
+	t2, t3 := fp4Square(z4, z5)
+	z4 = t0.Sub(z4).Double().Add(t0)
+	z5 = t1.Add(z5).Double().Add(t1)
+
+	t0 = t3.mulByXi()
+	z2 = t0.Add(z2).Double().Add(t0)
+	z3 = t2.Sub(z3).Double().Add(t2)
+
+	return &Fp12{
+		t:  x.t,
+		c0: &Fp6{t: x.t, c0: z0, c1: z4, c2: z3},
+		c1: &Fp6{t: x.t, c0: z2, c1: z1, c2: z5},
+	}
+}
+
+// Conjugate は共役 c0 - c1*w を返します
+// これは x^(p^6) に等しく、円分部分群の元では逆元になります
+func (x *Fp12) Conjugate() *Fp12 {
+	return &Fp12{t: x.t, c0: x.c0, c1: x.c1.Neg()}
+}
+
+// Inv は x の乗法の逆元を返します
+// x == 0 のときpanicします
+func (x *Fp12) Inv() *Fp12 {
+	// x * conj(x) = c0^2 - v*c1^2 は Fp6 の元なので、それで割る
+	d := x.c0.Square().Sub(x.c1.Square().mulByV())
+	if d.IsZero() {
+		panic("tower: inverse of zero")
+	}
+	d = d.Inv()
+	return &Fp12{t: x.t, c0: x.c0.Mul(d), c1: x.c1.Mul(d).Neg()}
+}
+
+// Exp は x^k を返します
+// k < 0 のときは x の逆元について計算します
+func (x *Fp12) Exp(k *big.Int) *Fp12 {
+	if big.Cmp(k, big.Zero) < 0 {
+		x = x.Inv()
+		k = big.Sub(big.Zero, k)
+	}
+	r := x.t.OneFp12()
+	for _, b := range k.Bytes() {
+		for i := 7; i >= 0; i-- {
+			r = r.Square()
+			if b>>uint(i)&1 == 1 {
+				r = r.Mul(x)
+			}
+		}
+	}
+	return r
+}
+
+// CyclotomicExp は円分部分群の元 x について x^k を返します
+// 二乗に CyclotomicSquare を、負の冪に Conjugate を使います
+func (x *Fp12) CyclotomicExp(k *big.Int) *Fp12 {
+	if big.Cmp(k, big.Zero) < 0 {
+		x = x.Conjugate()
+		k = big.Sub(big.Zero, k)
+	}
+	r := x.t.OneFp12()
+	for _, b := range k.Bytes() {
+		for i := 7; i >= 0; i-- {
+			r = r.CyclotomicSquare()
+			if b>>uint(i)&1 == 1 {
+				r = r.Mul(x)
+			}
+		}
+	}
+	return r
+}
+
+// Frobenius は x^(p^k) を返します
+func (x *Fp12) Frobenius(k int) *Fp12 {
+	r := x
+	for i := 0; i < mod(k, 12); i++ {
+		r = r.frobenius()
+	}
+	return r
+}
+
+// Equal は x と y が等しいかどうかを判定します
+func (x *Fp12) Equal(y *Fp12) bool {
+	return x.c0.Equal(y.c0) && x.c1.Equal(y.c1)
+}
+
+// IsZero は x が0かどうかを判定します
+func (x *Fp12) IsZero() bool {
+	return x.c0.IsZero() && x.c1.IsZero()
+}
+
+// frobenius は x^p を返します
+func (x *Fp12) frobenius() *Fp12 {
+	// w^i の係数 a_i について (a_i w^i)^p = conj(a_i) * γi * w^i
+	// c0 = a0 + a2 w^2 + a4 w^4, c1 w = a1 w + a3 w^3 + a5 w^5
+	g := x.t.gamma
+	return &Fp12{
+		t: x.t,
+		c0: &Fp6{
+			t:  x.t,
+			c0: x.c0.c0.Conjugate(),
+			c1: x.c0.c1.Conjugate().Mul(g[2]),
+			c2: x.c0.c2.Conjugate().Mul(g[4]),
+		},
+		c1: &Fp6{
+			t:  x.t,
+			c0: x.c1.c0.Conjugate().Mul(g[1]),
+			c1: x.c1.c1.Conjugate().Mul(g[3]),
+			c2: x.c1.c2.Conjugate().Mul(g[5]),
+		},
+	}
+}
+
+// fp4Square は Fp4 = Fp2[s]/(s^2 - ξ) の元 a + b*s の二乗を返します
+func fp4Square(a, b *Fp2) (c0, c1 *Fp2) {
+	t0 := a.Square()
+	t1 := b.Square()
+	c0 = t1.mulByXi().Add(t0)
+	c1 = a.Add(b).Square().Sub(t0).Sub(t1)
+	return c0, c1
+}
@@ -0,0 +1,132 @@
+package tower
+
+import (
+	"crypto/rand"
+	"testing"
+
+	"github.com/convto/mycrypto/big"
+)
+
+// toW は x を w^i (i = 0, ..., 5) の係数に分解します
+func toW(x *Fp12) []*Fp2 {
+	return []*Fp2{x.c0.c0, x.c1.c0, x.c0.c1, x.c1.c1, x.c0.c2, x.c1.c2}
+}
+
+// fromW は w^i の係数から Fp12 の元を組み立てます
+func fromW(tw *Tower, a []*Fp2) *Fp12 {
+	return tw.NewFp12(tw.NewFp6(a[0], a[2], a[4]), tw.NewFp6(a[1], a[3], a[5]))
+}
+
+// mulFp12Naive は w^6 = ξ を使って筆算で Fp12 の積を計算します
+func mulFp12Naive(x, y *Fp12) *Fp12 {
+	tw := x.t
+	a, b := toW(x), toW(y)
+	c := make([]*Fp2, 11)
+	for i := range c {
+		c[i] = tw.ZeroFp2()
+	}
+	for i := range a {
+		for j := range b {
+			c[i+j] = c[i+j].Add(a[i].Mul(b[j]))
+		}
+	}
+	for i := 10; i >= 6; i-- {
+		c[i-6] = c[i-6].Add(c[i].Mul(tw.xi))
+	}
+	return fromW(tw, c[:6])
+}
+
+func randomFp12(t *testing.T, tw *Tower) *Fp12 {
+	t.Helper()
+	x, err := tw.RandomFp12(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return x
+}
+
+// cyclotomic は x^((p^6 - 1)(p^2 + 1)) を計算して円分部分群の元にします
+func cyclotomic(x *Fp12) *Fp12 {
+	y := x.Conjugate().Mul(x.Inv())
+	return y.Frobenius(2).Mul(y)
+}
+
+func TestFp12_Mul(t *testing.T) {
+	for _, tw := range []*Tower{testTower, bn254, bls12381} {
+		x, y := randomFp12(t, tw), randomFp12(t, tw)
+		if got, want := x.Mul(y), mulFp12Naive(x, y); !got.Equal(want) {
+			t.Errorf("Mul() = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestFp12_Square(t *testing.T) {
+	for _, tw := range []*Tower{testTower, bn254, bls12381} {
+		x := randomFp12(t, tw)
+		if got, want := x.Square(), mulFp12Naive(x, x); !got.Equal(want) {
+			t.Errorf("Square() = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestFp12_Inv(t *testing.T) {
+	for _, tw := range []*Tower{testTower, bn254, bls12381} {
+		x := randomFp12(t, tw)
+		if got := x.Mul(x.Inv()); !got.Equal(tw.OneFp12()) {
+			t.Errorf("x * Inv() = %v, want 1", got)
+		}
+	}
+}
+
+func TestFp12_Frobenius(t *testing.T) {
+	x := randomFp12(t, testTower)
+	p := testTower.Fp().Modulus()
+	want := x
+	for k := 1; k <= 12; k++ {
+		want = want.Exp(p)
+		if got := x.Frobenius(k); !got.Equal(want) {
+			t.Errorf("Frobenius(%d) = %v, want %v", k, got, want)
+		}
+	}
+	if got := x.Frobenius(6); !got.Equal(x.Conjugate()) {
+		t.Errorf("Frobenius(6) = %v, want %v", got, x.Conjugate())
+	}
+	// 大きな素数でも Frobenius 写像は環準同型になる
+	for _, tw := range []*Tower{bn254, bls12381} {
+		x, y := randomFp12(t, tw), randomFp12(t, tw)
+		if got, want := x.Mul(y).Frobenius(1), x.Frobenius(1).Mul(y.Frobenius(1)); !got.Equal(want) {
+			t.Errorf("Frobenius(1) is not multiplicative: %v, want %v", got, want)
+		}
+		if got := x.Frobenius(3).Frobenius(9); !got.Equal(x) {
+			t.Errorf("Frobenius(12) = %v, want %v", got, x)
+		}
+	}
+}
+
+func TestFp12_CyclotomicSquare(t *testing.T) {
+	for _, tw := range []*Tower{testTower, bn254, bls12381} {
+		x := cyclotomic(randomFp12(t, tw))
+		if got, want := x.CyclotomicSquare(), x.Square(); !got.Equal(want) {
+			t.Errorf("CyclotomicSquare() = %v, want %v", got, want)
+		}
+		// 円分部分群の元では共役が逆元になる
+		if got, want := x.Conjugate(), x.Inv(); !got.Equal(want) {
+			t.Errorf("Conjugate() = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestFp12_CyclotomicExp(t *testing.T) {
+	x := cyclotomic(randomFp12(t, testTower))
+	// 円分部分群の位数 p^4 - p^2 + 1 で1に戻る
+	p := testTower.Fp().Modulus()
+	p2 := big.Mul(p, p)
+	order := big.Add(big.Sub(big.Mul(p2, p2), p2), big.NewInt(1))
+	if got := x.CyclotomicExp(order); !got.Equal(testTower.OneFp12()) {
+		t.Errorf("CyclotomicExp(p^4 - p^2 + 1) = %v, want 1", got)
+	}
+	k := big.NewInt(-12345)
+	if got, want := x.CyclotomicExp(k), x.Exp(k); !got.Equal(want) {
+		t.Errorf("CyclotomicExp(%v) = %v, want %v", k, got, want)
+	}
+}
@@ -0,0 +1,199 @@
+package tower
+
+import (
+	"io"
+
+	"github.com/convto/mycrypto/big"
+	"github.com/convto/mycrypto/field"
+)
+
+// Fp2 は a0 + a1*u の形の Fp2 の元です
+type Fp2 struct {
+	t  *Tower
+	a0 *field.Element
+	a1 *field.Element
+}
+
+// NewFp2 は a0 + a1*u を返します
+func (t *Tower) NewFp2(a0, a1 *big.Int) *Fp2 {
+	return &Fp2{t: t, a0: t.fp.NewElement(a0), a1: t.fp.NewElement(a1)}
+}
+
+// Fp2FromElements は Fp の元 a0, a1 から a0 + a1*u を返します
+func (t *Tower) Fp2FromElements(a0, a1 *field.Element) *Fp2 {
+	return &Fp2{t: t, a0: a0, a1: a1}
+}
+
+// ZeroFp2 は Fp2 の0を返します
+func (t *Tower) ZeroFp2() *Fp2 {
+	return &Fp2{t: t, a0: t.fp.Zero(), a1: t.fp.Zero()}
+}
+
+// OneFp2 は Fp2 の1を返します
+func (t *Tower) OneFp2() *Fp2 {
+	return &Fp2{t: t, a0: t.fp.One(), a1: t.fp.Zero()}
+}
+
+// RandomFp2 は r から読み込んだ乱数で一様な Fp2 の元を返します
+func (t *Tower) RandomFp2(r io.Reader) (*Fp2, error) {
+	a0, err := t.fp.Random(r)
+	if err != nil {
+		return nil, err
+	}
+	a1, err := t.fp.Random(r)
+	if err != nil {
+		return nil, err
+	}
+	return &Fp2{t: t, a0: a0, a1: a1}, nil
+}
+
+// A0 は x の定数項を返します
+func (x *Fp2) A0() *field.Element {
+	return x.a0
+}
+
+// A1 は x の u の係数を返します
+func (x *Fp2) A1() *field.Element {
+	return x.a1
+}
+
+func (x *Fp2) String() string {
+	return x.a0.String() + "+" + x.a1.String() + "*u"
+}
+
+// Add は x + y を返します
+func (x *Fp2) Add(y *Fp2) *Fp2 {
+	return &Fp2{t: x.t, a0: x.a0.Add(y.a0), a1: x.a1.Add(y.a1)}
+}
+
+// Sub は x - y を返します
+func (x *Fp2) Sub(y *Fp2) *Fp2 {
+	return &Fp2{t: x.t, a0: x.a0.Sub(y.a0), a1: x.a1.Sub(y.a1)}
+}
+
+// Neg は -x を返します
+func (x *Fp2) Neg() *Fp2 {
+	return &Fp2{t: x.t, a0: x.a0.Neg(), a1: x.a1.Neg()}
+}
+
+// Double は 2x を返します
+func (x *Fp2) Double() *Fp2 {
+	return x.Add(x)
+}
+
+// Mul は x * y を返します
+func (x *Fp2) Mul(y *Fp2) *Fp2 {
+	// Karatsuba で Fp の乗算を3回にする
+	v0 := x.a0.Mul(y.a0)
+	v1 := x.a1.Mul(y.a1)
+	c1 := x.a0.Add(x.a1).Mul(y.a0.Add(y.a1)).Sub(v0).Sub(v1)
+	return &Fp2{t: x.t, a0: v0.Add(x.t.beta.Mul(v1)), a1: c1}
+}
+
+// MulElement は Fp の元 k との積 k * x を返します
+func (x *Fp2) MulElement(k *field.Element) *Fp2 {
+	return &Fp2{t: x.t, a0: x.a0.Mul(k), a1: x.a1.Mul(k)}
+}
+
+// Square は x^2 を返します
+func (x *Fp2) Square() *Fp2 {
+	// (a0 + a1)(a0 + β*a1) - a0*a1 - β*a0*a1 = a0^2 + β*a1^2
+	v := x.a0.Mul(x.a1)
+	c0 := x.a0.Add(x.a1).Mul(x.a0.Add(x.t.beta.Mul(x.a1))).Sub(v).Sub(x.t.beta.Mul(v))
+	return &Fp2{t: x.t, a0: c0, a1: v.Add(v)}
+}
+
+// Conjugate は共役 a0 - a1*u を返します
+func (x *Fp2) Conjugate() *Fp2 {
+	return &Fp2{t: x.t, a0: x.a0, a1: x.a1.Neg()}
+}
+
+// Inv は x の乗法の逆元を返します
+// x == 0 のときpanicします
+func (x *Fp2) Inv() *Fp2 {
+	// x * conj(x) = a0^2 - β*a1^2 は Fp の元なので、それで割る
+	n := x.norm().Inv()
+	if n == nil {
+		panic("tower: inverse of zero")
+	}
+	return x.Conjugate().MulElement(n)
+}
+
+// Sqrt は y^2 = x となる y を返します
+// x が Fp2 で平方剰余でないときは ok = false を返します
+func (x *Fp2) Sqrt() (y *Fp2, ok bool) {
+	if x.a1.IsZero() {
+		// a0 が Fp の平方剰余ならその平方根、そうでなければ a0/β の平方根に u をかける
+		if r, ok := x.a0.Sqrt(); ok {
+			return &Fp2{t: x.t, a0: r, a1: x.t.fp.Zero()}, true
+		}
+		r, ok := x.a0.Mul(x.t.beta.Inv()).Sqrt()
+		if !ok {
+			return nil, false
+		}
+		return &Fp2{t: x.t, a0: x.t.fp.Zero(), a1: r}, true
+	}
+	// y = b0 + b1*u とすると b0^2 + β*b1^2 = a0, 2*b0*b1 = a1 なので
+	// b0^2 は (a0 ± sqrt(norm(x)))/2 のどちらかになる
+	n, ok := x.norm().Sqrt()
+	if !ok {
+		return nil, false
+	}
+	half := x.t.fp.NewElement(big.NewInt(2)).Inv()
+	b0, ok := x.a0.Add(n).Mul(half).Sqrt()
+	if !ok {
+		if b0, ok = x.a0.Sub(n).Mul(half).Sqrt(); !ok {
+			return nil, false
+		}
+	}
+	b1 := x.a1.Mul(b0.Add(b0).Inv())
+	return &Fp2{t: x.t, a0: b0, a1: b1}, true
+}
+
+// Exp は x^k を返します
+// k < 0 のときは x の逆元について計算します
+func (x *Fp2) Exp(k *big.Int) *Fp2 {
+	if big.Cmp(k, big.Zero) < 0 {
+		x = x.Inv()
+		k = big.Sub(big.Zero, k)
+	}
+	r := x.t.OneFp2()
+	for _, b := range k.Bytes() {
+		for i := 7; i >= 0; i-- {
+			r = r.Square()
+			if b>>uint(i)&1 == 1 {
+				r = r.Mul(x)
+			}
+		}
+	}
+	return r
+}
+
+// Frobenius は x^(p^k) を返します
+func (x *Fp2) Frobenius(k int) *Fp2 {
+	// u^p = β^((p-1)/2) * u = -u なので、奇数回なら共役になる
+	if k%2 != 0 {
+		return x.Conjugate()
+	}
+	return x
+}
+
+// Equal は x と y が等しいかどうかを判定します
+func (x *Fp2) Equal(y *Fp2) bool {
+	return x.a0.Equal(y.a0) && x.a1.Equal(y.a1)
+}
+
+// IsZero は x が0かどうかを判定します
+func (x *Fp2) IsZero() bool {
+	return x.a0.IsZero() && x.a1.IsZero()
+}
+
+// mulByXi は ξ * x を返します
+func (x *Fp2) mulByXi() *Fp2 {
+	return x.Mul(x.t.xi)
+}
+
+// norm は x * conj(x) = a0^2 - β*a1^2 を返します
+func (x *Fp2) norm() *field.Element {
+	return x.a0.Square().Sub(x.t.beta.Mul(x.a1.Square()))
+}
@@ -0,0 +1,132 @@
+package tower
+
+import (
+	"crypto/rand"
+	"testing"
+
+	"github.com/convto/mycrypto/big"
+)
+
+func TestFp2_Mul(t *testing.T) {
+	type args struct {
+		x [2]int64
+		y [2]int64
+	}
+	tests := []struct {
+		name string
+		args args
+		want [2]int64
+	}{
+		{
+			name: "(1 + 2u)(3 + 4u) = -5 + 10u",
+			args: args{x: [2]int64{1, 2}, y: [2]int64{3, 4}},
+			want: [2]int64{98, 10},
+		},
+		{
+			name: "u * u = β",
+			args: args{x: [2]int64{0, 1}, y: [2]int64{0, 1}},
+			want: [2]int64{102, 0},
+		},
+		{
+			name: "x * 0 = 0",
+			args: args{x: [2]int64{57, 31}, y: [2]int64{0, 0}},
+			want: [2]int64{0, 0},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			x := testTower.NewFp2(big.NewInt(tt.args.x[0]), big.NewInt(tt.args.x[1]))
+			y := testTower.NewFp2(big.NewInt(tt.args.y[0]), big.NewInt(tt.args.y[1]))
+			want := testTower.NewFp2(big.NewInt(tt.want[0]), big.NewInt(tt.want[1]))
+			if got := x.Mul(y); !got.Equal(want) {
+				t.Errorf("Mul() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestFp2_Square(t *testing.T) {
+	for _, tw := range []*Tower{testTower, bn254, bls12381} {
+		x, err := tw.RandomFp2(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := x.Square(), x.Mul(x); !got.Equal(want) {
+			t.Errorf("Square() = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestFp2_Inv(t *testing.T) {
+	for _, tw := range []*Tower{testTower, bn254, bls12381} {
+		x, err := tw.RandomFp2(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if x.IsZero() {
+			continue
+		}
+		if got := x.Mul(x.Inv()); !got.Equal(tw.OneFp2()) {
+			t.Errorf("x * Inv() = %v, want 1", got)
+		}
+	}
+}
+
+func TestFp2_Exp(t *testing.T) {
+	x := testTower.NewFp2(big.NewInt(5), big.NewInt(7))
+	// Fp2 の乗法群の位数は p^2 - 1
+	if got := x.Exp(big.NewInt(103*103 - 1)); !got.Equal(testTower.OneFp2()) {
+		t.Errorf("Exp(p^2 - 1) = %v, want 1", got)
+	}
+	if got, want := x.Exp(big.NewInt(-3)), x.Mul(x).Mul(x).Inv(); !got.Equal(want) {
+		t.Errorf("Exp(-3) = %v, want %v", got, want)
+	}
+	if got := x.Exp(big.Zero); !got.Equal(testTower.OneFp2()) {
+		t.Errorf("Exp(0) = %v, want 1", got)
+	}
+}
+
+func TestFp2_Sqrt(t *testing.T) {
+	for _, tw := range []*Tower{testTower, bn254, bls12381} {
+		for i := 0; i < 8; i++ {
+			x, err := tw.RandomFp2(rand.Reader)
+			if err != nil {
+				t.Fatal(err)
+			}
+			// 平方数は必ず平方根を持つ
+			y, ok := x.Square().Sqrt()
+			if !ok || !y.Square().Equal(x.Square()) {
+				t.Errorf("Sqrt(x^2) = %v, %v, want ±%v", y, ok, x)
+			}
+			// ξ は平方非剰余なので ξ * x^2 は平方根を持たない
+			if !x.IsZero() {
+				if y, ok := x.Square().Mul(tw.Xi()).Sqrt(); ok {
+					t.Errorf("Sqrt(ξ * x^2) = %v, true, want false", y)
+				}
+			}
+		}
+	}
+	// 虚部が0の元
+	for _, a0 := range []int64{4, 5, 0} {
+		x := testTower.NewFp2(big.NewInt(a0), big.Zero)
+		if y, ok := x.Sqrt(); !ok || !y.Square().Equal(x) {
+			t.Errorf("Sqrt(%v) = %v, %v", x, y, ok)
+		}
+	}
+}
+
+func TestFp2_Frobenius(t *testing.T) {
+	for _, tw := range []*Tower{testTower, bn254} {
+		x, err := tw.RandomFp2(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		p := tw.Fp().Modulus()
+		if got, want := x.Frobenius(1), x.Exp(p); !got.Equal(want) {
+			t.Errorf("Frobenius(1) = %v, want %v", got, want)
+		}
+		if got := x.Frobenius(2); !got.Equal(x) {
+			t.Errorf("Frobenius(2) = %v, want %v", got, x)
+		}
+	}
+}
@@ -0,0 +1,178 @@
+package tower
+
+import (
+	"io"
+
+	"github.com/convto/mycrypto/big"
+)
+
+// Fp6 は c0 + c1*v + c2*v^2 の形の Fp6 の元です
+type Fp6 struct {
+	t  *Tower
+	c0 *Fp2
+	c1 *Fp2
+	c2 *Fp2
+}
+
+// NewFp6 は c0 + c1*v + c2*v^2 を返します
+func (t *Tower) NewFp6(c0, c1, c2 *Fp2) *Fp6 {
+	return &Fp6{t: t, c0: c0, c1: c1, c2: c2}
+}
+
+// ZeroFp6 は Fp6 の0を返します
+func (t *Tower) ZeroFp6() *Fp6 {
+	return &Fp6{t: t, c0: t.ZeroFp2(), c1: t.ZeroFp2(), c2: t.ZeroFp2()}
+}
+
+// OneFp6 は Fp6 の1を返します
+func (t *Tower) OneFp6() *Fp6 {
+	return &Fp6{t: t, c0: t.OneFp2(), c1: t.ZeroFp2(), c2: t.ZeroFp2()}
+}
+
+// RandomFp6 は r から読み込んだ乱数で一様な Fp6 の元を返します
+func (t *Tower) RandomFp6(r io.Reader) (*Fp6, error) {
+	var c [3]*Fp2
+	for i := range c {
+		x, err := t.RandomFp2(r)
+		if err != nil {
+			return nil, err
+		}
+		c[i] = x
+	}
+	return &Fp6{t: t, c0: c[0], c1: c[1], c2: c[2]}, nil
+}
+
+// C0 は x の定数項を返します
+func (x *Fp6) C0() *Fp2 {
+	return x.c0
+}
+
+// C1 は x の v の係数を返します
+func (x *Fp6) C1() *Fp2 {
+	return x.c1
+}
+
+// C2 は x の v^2 の係数を返します
+func (x *Fp6) C2() *Fp2 {
+	return x.c2
+}
+
+func (x *Fp6) String() string {
+	return "(" + x.c0.String() + ")+(" + x.c1.String() + ")*v+(" + x.c2.String() + ")*v^2"
+}
+
+// Add は x + y を返します
+func (x *Fp6) Add(y *Fp6) *Fp6 {
+	return &Fp6{t: x.t, c0: x.c0.Add(y.c0), c1: x.c1.Add(y.c1), c2: x.c2.Add(y.c2)}
+}
+
+// Sub は x - y を返します
+func (x *Fp6) Sub(y *Fp6) *Fp6 {
+	return &Fp6{t: x.t, c0: x.c0.Sub(y.c0), c1: x.c1.Sub(y.c1), c2: x.c2.Sub(y.c2)}
+}
+
+// Neg は -x を返します
+func (x *Fp6) Neg() *Fp6 {
+	return &Fp6{t: x.t, c0: x.c0.Neg(), c1: x.c1.Neg(), c2: x.c2.Neg()}
+}
+
+// Mul は x * y を返します
+func (x *Fp6) Mul(y *Fp6) *Fp6 {
+	// Karatsuba で Fp2 の乗算を6回にする
+	v0 := x.c0.Mul(y.c0)
+	v1 := x.c1.Mul(y.c1)
+	v2 := x.c2.Mul(y.c2)
+	c0 := x.c1.Add(x.c2).Mul(y.c1.Add(y.c2)).Sub(v1).Sub(v2).mulByXi().Add(v0)
+	c1 := x.c0.Add(x.c1).Mul(y.c0.Add(y.c1)).Sub(v0).Sub(v1).Add(v2.mulByXi())
+	c2 := x.c0.Add(x.c2).Mul(y.c0.Add(y.c2)).Sub(v0).Sub(v2).Add(v1)
+	return &Fp6{t: x.t, c0: c0, c1: c1, c2: c2}
+}
+
+// MulFp2 は Fp2 の元 k との積 k * x を返します
+func (x *Fp6) MulFp2(k *Fp2) *Fp6 {
+	return &Fp6{t: x.t, c0: x.c0.Mul(k), c1: x.c1.Mul(k), c2: x.c2.Mul(k)}
+}
+
+// Square は x^2 を返します
+func (x *Fp6) Square() *Fp6 {
+	// Chung-Hasan の CH-SQR2
+	s0 := x.c0.Square()
+	s1 := x.c0.Mul(x.c1).Double()
+	s2 := x.c0.Sub(x.c1).Add(x.c2).Square()
+	s3 := x.c1.Mul(x.c2).Double()
+	s4 := x.c2.Square()
+	return &Fp6{
+		t:  x.t,
+		c0: s3.mulByXi().Add(s0),
+		c1: s4.mulByXi().Add(s1),
+		c2: s1.Add(s2).Add(s3).Sub(s0).Sub(s4),
+	}
+}
+
+// Inv は x の乗法の逆元を返します
+// x == 0 のときpanicします
+func (x *Fp6) Inv() *Fp6 {
+	t0 := x.c0.Square().Sub(x.c1.Mul(x.c2).mulByXi())
+	t1 := x.c2.Square().mulByXi().Sub(x.c0.Mul(x.c1))
+	t2 := x.c1.Square().Sub(x.c0.Mul(x.c2))
+	d := x.c2.Mul(t1).Add(x.c1.Mul(t2)).mulByXi().Add(x.c0.Mul(t0))
+	if d.IsZero() {
+		panic("tower: inverse of zero")
+	}
+	d = d.Inv()
+	return &Fp6{t: x.t, c0: t0.Mul(d), c1: t1.Mul(d), c2: t2.Mul(d)}
+}
+
+// Exp は x^k を返します
+// k < 0 のときは x の逆元について計算します
+func (x *Fp6) Exp(k *big.Int) *Fp6 {
+	if big.Cmp(k, big.Zero) < 0 {
+		x = x.Inv()
+		k = big.Sub(big.Zero, k)
+	}
+	r := x.t.OneFp6()
+	for _, b := range k.Bytes() {
+		for i := 7; i >= 0; i-- {
+			r = r.Square()
+			if b>>uint(i)&1 == 1 {
+				r = r.Mul(x)
+			}
+		}
+	}
+	return r
+}
+
+// Frobenius は x^(p^k) を返します
+func (x *Fp6) Frobenius(k int) *Fp6 {
+	r := x
+	for i := 0; i < mod(k, 6); i++ {
+		// v = w^2 なので v^p = γ2 * v, (v^2)^p = γ4 * v^2
+		r = &Fp6{
+			t:  x.t,
+			c0: r.c0.Conjugate(),
+			c1: r.c1.Conjugate().Mul(x.t.gamma[2]),
+			c2: r.c2.Conjugate().Mul(x.t.gamma[4]),
+		}
+	}
+	return r
+}
+
+// Equal は x と y が等しいかどうかを判定します
+func (x *Fp6) Equal(y *Fp6) bool {
+	return x.c0.Equal(y.c0) && x.c1.Equal(y.c1) && x.c2.Equal(y.c2)
+}
+
+// IsZero は x が0かどうかを判定します
+func (x *Fp6) IsZero() bool {
+	return x.c0.IsZero() && x.c1.IsZero() && x.c2.IsZero()
+}
+
+// mulByV は v * x を返します
+func (x *Fp6) mulByV() *Fp6 {
+	return &Fp6{t: x.t, c0: x.c2.mulByXi(), c1: x.c0, c2: x.c1}
+}
+
+// mod は a を n で割った非負の余りを返します
+func mod(a, n int) int {
+	return (a%n + n) % n
+}
@@ -0,0 +1,98 @@
+package tower
+
+import (
+	"crypto/rand"
+	"testing"
+
+	"github.com/convto/mycrypto/big"
+)
+
+// mulFp6Naive は v^3 = ξ を使って筆算で Fp6 の積を計算します
+func mulFp6Naive(x, y *Fp6) *Fp6 {
+	tw := x.t
+	a := []*Fp2{x.c0, x.c1, x.c2}
+	b := []*Fp2{y.c0, y.c1, y.c2}
+	c := make([]*Fp2, 5)
+	for i := range c {
+		c[i] = tw.ZeroFp2()
+	}
+	for i := range a {
+		for j := range b {
+			c[i+j] = c[i+j].Add(a[i].Mul(b[j]))
+		}
+	}
+	return tw.NewFp6(c[0].Add(c[3].Mul(tw.xi)), c[1].Add(c[4].Mul(tw.xi)), c[2])
+}
+
+func randomFp6(t *testing.T, tw *Tower) *Fp6 {
+	t.Helper()
+	x, err := tw.RandomFp6(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return x
+}
+
+func TestFp6_Mul(t *testing.T) {
+	for _, tw := range []*Tower{testTower, bn254, bls12381} {
+		x, y := randomFp6(t, tw), randomFp6(t, tw)
+		if got, want := x.Mul(y), mulFp6Naive(x, y); !got.Equal(want) {
+			t.Errorf("Mul() = %v, want %v", got, want)
+		}
+		if got, want := x.mulByV(), mulFp6Naive(x, tw.NewFp6(tw.ZeroFp2(), tw.OneFp2(), tw.ZeroFp2())); !got.Equal(want) {
+			t.Errorf("mulByV() = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestFp6_Square(t *testing.T) {
+	for _, tw := range []*Tower{testTower, bn254, bls12381} {
+		x := randomFp6(t, tw)
+		if got, want := x.Square(), mulFp6Naive(x, x); !got.Equal(want) {
+			t.Errorf("Square() = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestFp6_Inv(t *testing.T) {
+	for _, tw := range []*Tower{testTower, bn254, bls12381} {
+		x := randomFp6(t, tw)
+		if got := x.Mul(x.Inv()); !got.Equal(tw.OneFp6()) {
+			t.Errorf("x * Inv() = %v, want 1", got)
+		}
+	}
+}
+
+func TestFp6_Inv_zero(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Inv() did not panic")
+		}
+	}()
+	testTower.ZeroFp6().Inv()
+}
+
+func TestFp6_Frobenius(t *testing.T) {
+	x := randomFp6(t, testTower)
+	p := testTower.Fp().Modulus()
+	want := x
+	for k := 1; k <= 6; k++ {
+		want = want.Exp(p)
+		if got := x.Frobenius(k); !got.Equal(want) {
+			t.Errorf("Frobenius(%d) = %v, want %v", k, got, want)
+		}
+	}
+	if got := x.Frobenius(6); !got.Equal(x) {
+		t.Errorf("Frobenius(6) = %v, want %v", got, x)
+	}
+	if got := x.Frobenius(-1).Frobenius(1); !got.Equal(x) {
+		t.Errorf("Frobenius(-1).Frobenius(1) = %v, want %v", got, x)
+	}
+}
+
+func TestFp6_Exp(t *testing.T) {
+	x := randomFp6(t, testTower)
+	if got, want := x.Exp(big.NewInt(-2)), x.Square().Inv(); !got.Equal(want) {
+		t.Errorf("Exp(-2) = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,72 @@
+// Package tower はペアリングに使う素体 Fp 上の拡大体の塔 Fp2, Fp6, Fp12 の演算を提供します
+//
+//	Fp2  = Fp[u]  / (u^2 - β)
+//	Fp6  = Fp2[v] / (v^3 - ξ)
+//	Fp12 = Fp6[w] / (w^2 - v)
+//
+// β は Fp の平方非剰余、ξ は Fp2 の平方非剰余かつ立方非剰余である必要があります
+// BN254 では β = -1, ξ = 9 + u、BLS12-381 では β = -1, ξ = 1 + u が使われます
+package tower
+
+import (
+	"github.com/convto/mycrypto/big"
+	"github.com/convto/mycrypto/field"
+)
+
+// Tower は素体 Fp と β, ξ で決まる拡大体の塔です
+type Tower struct {
+	fp   *field.Field
+	beta *field.Element
+	xi   *Fp2
+	// gamma[i] = ξ^(i(p-1)/6) は Frobenius 写像で w^i にかかる係数
+	gamma [6]*Fp2
+}
+
+// New は素数 p, Fp の元 β, Fp2 の元 ξ = xi0 + xi1*u から拡大体の塔を返します
+// Frobenius 写像の係数を Fp2 で表すために p ≡ 1 (mod 6) が必要です
+// p ≡ 1 (mod 6) でないときや β, ξ が条件を満たさないときはpanicします
+func New(p, beta, xi0, xi1 *big.Int) *Tower {
+	fp := field.New(p)
+	if _, r := big.Div(p, big.NewInt(6)); big.Cmp(r, big.NewInt(1)) != 0 {
+		panic("tower: p must be 1 mod 6")
+	}
+	t := &Tower{fp: fp, beta: fp.NewElement(beta)}
+	if big.Jacobi(t.beta.Int(), p) != -1 {
+		panic("tower: β must be a quadratic non-residue in Fp")
+	}
+	t.xi = t.NewFp2(xi0, xi1)
+
+	// ξ が Fp2 で平方剰余であることとノルム ξ * conj(ξ) が Fp で平方剰余であることは同値
+	if big.Jacobi(t.xi.norm().Int(), p) != -1 {
+		panic("tower: ξ must be a quadratic non-residue in Fp2")
+	}
+	// ξ が立方剰余なら ξ^((p^2-1)/3) = 1 になる
+	p2m1 := big.Sub(big.Mul(p, p), big.NewInt(1))
+	e, _ := big.Div(p2m1, big.NewInt(3))
+	if t.xi.Exp(e).Equal(t.OneFp2()) {
+		panic("tower: ξ must be a cubic non-residue in Fp2")
+	}
+
+	e, _ = big.Div(big.Sub(p, big.NewInt(1)), big.NewInt(6))
+	g := t.xi.Exp(e)
+	t.gamma[0] = t.OneFp2()
+	for i := 1; i < 6; i++ {
+		t.gamma[i] = t.gamma[i-1].Mul(g)
+	}
+	return t
+}
+
+// Fp は基礎となる素体 Fp を返します
+func (t *Tower) Fp() *field.Field {
+	return t.fp
+}
+
+// Beta は Fp2 の定義に使う β を返します
+func (t *Tower) Beta() *field.Element {
+	return t.beta
+}
+
+// Xi は Fp6 の定義に使う ξ を返します
+func (t *Tower) Xi() *Fp2 {
+	return t.xi
+}
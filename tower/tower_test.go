@@ -0,0 +1,54 @@
+package tower
+
+import (
+	"testing"
+
+	"github.com/convto/mycrypto/big"
+)
+
+var (
+	// 103 ≡ 1 (mod 6), 103 ≡ 3 (mod 4) なので β = -1 が使える
+	testTower = New(big.NewInt(103), big.NewInt(-1), big.NewInt(2), big.NewInt(1))
+	bn254     = New(new(big.Int).SetString("21888242871839275222246405745257275088696311157297823662689037894645226208583"), big.NewInt(-1), big.NewInt(9), big.NewInt(1))
+	bls12381  = New(new(big.Int).SetString("4002409555221667393417789825735904156556882819939007885332058136124031650490837864442687629129015664037894272559787"), big.NewInt(-1), big.NewInt(1), big.NewInt(1))
+)
+
+func TestNew_invalid(t *testing.T) {
+	type args struct {
+		p    *big.Int
+		beta *big.Int
+		xi0  *big.Int
+		xi1  *big.Int
+	}
+	tests := []struct {
+		name string
+		args args
+	}{
+		{
+			name: "p is not 1 mod 6",
+			args: args{p: big.NewInt(107), beta: big.NewInt(-1), xi0: big.NewInt(1), xi1: big.NewInt(1)},
+		},
+		{
+			name: "β is a quadratic residue",
+			args: args{p: big.NewInt(103), beta: big.NewInt(4), xi0: big.NewInt(2), xi1: big.NewInt(1)},
+		},
+		{
+			name: "ξ is a quadratic residue",
+			args: args{p: big.NewInt(103), beta: big.NewInt(-1), xi0: big.NewInt(1), xi1: big.NewInt(1)},
+		},
+		{
+			name: "ξ is a cubic residue",
+			args: args{p: big.NewInt(103), beta: big.NewInt(-1), xi0: big.NewInt(3), xi1: big.NewInt(1)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("New() did not panic")
+				}
+			}()
+			New(tt.args.p, tt.args.beta, tt.args.xi0, tt.args.xi1)
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package vss
+
+import (
+	"io"
+
+	"github.com/convto/mycrypto/big"
+	"github.com/convto/mycrypto/dlog"
+	"github.com/convto/mycrypto/field"
+)
+
+// FeldmanCommitment は Feldman VSS のディーラーが公開する、多項式の係数 a_j へのコミットメント C_j = g^(a_j) です
+// C_0 = g^s から秘密 s の離散対数が漏れる以外は秘匿されるので、g^s を公開鍵とする分散鍵生成などに使えます
+type FeldmanCommitment struct {
+	Group dlog.PrimeOrderGroup
+	C     []dlog.Element
+}
+
+// SplitFeldman は r から読み込んだ乱数で、grp の位数 q を法として secret を n 個の分散値に分け、閾値 k の分散値と係数へのコミットメントを返します
+func SplitFeldman(r io.Reader, grp dlog.PrimeOrderGroup, secret *big.Int, n, k int) ([]*Share, *FeldmanCommitment, error) {
+	if err := checkSplit(grp, secret, n, k); err != nil {
+		return nil, nil, err
+	}
+	f := field.New(grp.Order())
+	coeffs, err := randomPoly(r, f, secret, k)
+	if err != nil {
+		return nil, nil, err
+	}
+	g := grp.Generator()
+	c := make([]dlog.Element, k)
+	for j, a := range coeffs {
+		c[j] = grp.Exp(g, a.Int())
+	}
+	return evalShares(f, coeffs, n), &FeldmanCommitment{Group: grp, C: c}, nil
+}
+
+// Threshold は復元に必要な分散値の数 k を返します
+func (c *FeldmanCommitment) Threshold() int {
+	return len(c.C)
+}
+
+// PublicKey は秘密 s に対する g^s を返します
+func (c *FeldmanCommitment) PublicKey() dlog.Element {
+	return c.C[0]
+}
+
+// PublicShare は参加者 x の分散値 f(x) に対する g^(f(x)) = Π C_j^(x^j) を返します
+func (c *FeldmanCommitment) PublicShare(x byte) dlog.Element {
+	return evalCommitments(c.Group, c.C, x)
+}
+
+// Verify は g^(f(x)) = Π C_j^(x^j) を確かめます
+func (c *FeldmanCommitment) Verify(s *Share) bool {
+	grp := c.Group
+	if !checkCommitments(grp, c.C) {
+		return false
+	}
+	v, ok := shareValue(field.New(grp.Order()), s, len(c.C))
+	if !ok {
+		return false
+	}
+	return grp.Equal(grp.Exp(grp.Generator(), v), c.PublicShare(s.Index))
+}
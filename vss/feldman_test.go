@@ -0,0 +1,103 @@
+package vss
+
+import (
+	"crypto/rand"
+	"errors"
+	stdbig "math/big"
+	"testing"
+
+	"github.com/convto/mycrypto/big"
+	"github.com/convto/mycrypto/dlog"
+	"github.com/convto/mycrypto/sss"
+)
+
+func TestSplitFeldman(t *testing.T) {
+	for _, tt := range testGroups {
+		t.Run(tt.name, func(t *testing.T) {
+			grp := tt.grp
+			secret := testSecret(t, grp)
+			shares, c, err := SplitFeldman(rand.Reader, grp, secret, 5, 3)
+			if err != nil {
+				t.Fatalf("SplitFeldman() error = %v", err)
+			}
+			if got := c.Threshold(); got != 3 {
+				t.Errorf("Threshold() = %v, want 3", got)
+			}
+			if !grp.Equal(c.PublicKey(), grp.Exp(grp.Generator(), secret)) {
+				t.Errorf("PublicKey() != g^secret")
+			}
+			for _, s := range shares {
+				if !c.Verify(s) {
+					t.Errorf("Verify(share %v) = false, want true", s.Index)
+				}
+			}
+
+			other := *shares[1]
+			other.Index = shares[0].Index
+			mismatched := *shares[0]
+			mismatched.Threshold = 4
+			modified := *c
+			modified.C = append([]dlog.Element(nil), c.C...)
+			modified.C[2] = grp.Op(c.C[2], grp.Generator())
+			tests := []struct {
+				name string
+				c    *FeldmanCommitment
+				s    *Share
+			}{
+				{name: "tampered value", c: c, s: tamper(shares[0])},
+				{name: "wrong index", c: c, s: &other},
+				{name: "mismatched threshold", c: c, s: &mismatched},
+				{name: "modified commitment", c: &modified, s: shares[0]},
+				{name: "short commitment", c: &FeldmanCommitment{Group: grp, C: c.C[:2]}, s: shares[0]},
+			}
+			for _, tc := range tests {
+				if tc.c.Verify(tc.s) {
+					t.Errorf("Verify(%v) = true, want false", tc.name)
+				}
+			}
+		})
+	}
+}
+
+func TestFeldmanCommitment_Verify_notInGroup(t *testing.T) {
+	grp := testZpGroup
+	shares, c, err := SplitFeldman(rand.Reader, grp, big.NewInt(42), 3, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// p-1 は位数2の元なので、位数 q の部分群に含まれない
+	modified := *c
+	modified.C = []dlog.Element{c.C[0], big.Mod(big.Mul(c.C[1].(*big.Int), big.Sub(grp.P, big.NewInt(1))), grp.P)}
+	for _, s := range shares {
+		if modified.Verify(s) {
+			t.Errorf("Verify(share %v) = true, want false", s.Index)
+		}
+	}
+}
+
+func TestSplitFeldman_invalid(t *testing.T) {
+	grp := testZpGroup
+	tests := []struct {
+		name    string
+		secret  *big.Int
+		n, k    int
+		wantErr error
+	}{
+		{name: "k = 1", secret: big.NewInt(1), n: 3, k: 1, wantErr: sss.ErrInvalidThreshold},
+		{name: "k > n", secret: big.NewInt(1), n: 3, k: 4, wantErr: sss.ErrInvalidThreshold},
+		{name: "secret = q", secret: grp.Q, n: 3, k: 2, wantErr: sss.ErrSecretOutOfRange},
+		{name: "negative secret", secret: big.NewInt(-1), n: 3, k: 2, wantErr: sss.ErrSecretOutOfRange},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := SplitFeldman(rand.Reader, grp, tt.secret, tt.n, tt.k); !errors.Is(err, tt.wantErr) {
+				t.Errorf("SplitFeldman() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// toStd は math/big の整数に変換する
+func toStd(x *big.Int) *stdbig.Int {
+	return new(stdbig.Int).SetBytes(x.Bytes())
+}
@@ -0,0 +1,70 @@
+package vss
+
+import (
+	"io"
+
+	"github.com/convto/mycrypto/big"
+	"github.com/convto/mycrypto/dlog"
+	"github.com/convto/mycrypto/field"
+)
+
+// PedersenCommitment は Pedersen VSS のディーラーが公開する、2つの多項式 f, f' の係数 a_j, b_j へのコミットメント C_j = g^(a_j) h^(b_j) です
+// b_j が一様な乱数なので、計算能力に制限のない相手にもコミットメントから秘密の情報は漏れません
+// 一方で束縛性は log_g h を誰も知らないことに依存するので、h は g と独立に導出した元を使わなければなりません
+type PedersenCommitment struct {
+	Group dlog.PrimeOrderGroup
+	H     dlog.Element
+	C     []dlog.Element
+}
+
+// SplitPedersen は r から読み込んだ乱数で、grp の位数 q を法として secret を n 個の分散値に分け、閾値 k の分散値と係数へのコミットメントを返します
+// h は grp の生成元 g との離散対数が誰にも知られていない群の元でなければなりません
+func SplitPedersen(r io.Reader, grp dlog.PrimeOrderGroup, h dlog.Element, secret *big.Int, n, k int) ([]*Share, *PedersenCommitment, error) {
+	if err := checkSplit(grp, secret, n, k); err != nil {
+		return nil, nil, err
+	}
+	f := field.New(grp.Order())
+	coeffs, err := randomPoly(r, f, secret, k)
+	if err != nil {
+		return nil, nil, err
+	}
+	b0, err := f.Random(r)
+	if err != nil {
+		return nil, nil, err
+	}
+	blind, err := randomPoly(r, f, b0.Int(), k)
+	if err != nil {
+		return nil, nil, err
+	}
+	g := grp.Generator()
+	c := make([]dlog.Element, k)
+	for j := range coeffs {
+		c[j] = grp.Op(grp.Exp(g, coeffs[j].Int()), grp.Exp(h, blind[j].Int()))
+	}
+	shares := evalShares(f, coeffs, n)
+	for i, bs := range evalShares(f, blind, n) {
+		b, _ := f.SetBytes(bs.Value)
+		shares[i].Blinding = b.Int()
+	}
+	return shares, &PedersenCommitment{Group: grp, H: h, C: c}, nil
+}
+
+// Threshold は復元に必要な分散値の数 k を返します
+func (c *PedersenCommitment) Threshold() int {
+	return len(c.C)
+}
+
+// Verify は g^(f(x)) h^(f'(x)) = Π C_j^(x^j) を確かめます
+func (c *PedersenCommitment) Verify(s *Share) bool {
+	grp := c.Group
+	if c.H == nil || !grp.Contains(c.H) || !checkCommitments(grp, c.C) {
+		return false
+	}
+	q := grp.Order()
+	v, ok := shareValue(field.New(q), s, len(c.C))
+	if !ok || s.Blinding == nil || big.Cmp(s.Blinding, big.Zero) < 0 || big.Cmp(s.Blinding, q) >= 0 {
+		return false
+	}
+	lhs := grp.Op(grp.Exp(grp.Generator(), v), grp.Exp(c.H, s.Blinding))
+	return grp.Equal(lhs, evalCommitments(grp, c.C, s.Index))
+}
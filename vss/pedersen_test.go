@@ -0,0 +1,82 @@
+package vss
+
+import (
+	"crypto/rand"
+	"testing"
+
+	"github.com/convto/mycrypto/big"
+)
+
+func TestSplitPedersen(t *testing.T) {
+	for _, tt := range testGroups {
+		t.Run(tt.name, func(t *testing.T) {
+			grp := tt.grp
+			h := testH(t, grp)
+			secret := testSecret(t, grp)
+			shares, c, err := SplitPedersen(rand.Reader, grp, h, secret, 5, 3)
+			if err != nil {
+				t.Fatalf("SplitPedersen() error = %v", err)
+			}
+			if got := c.Threshold(); got != 3 {
+				t.Errorf("Threshold() = %v, want 3", got)
+			}
+			for _, s := range shares {
+				if !c.Verify(s) {
+					t.Errorf("Verify(share %v) = false, want true", s.Index)
+				}
+			}
+			got, err := Combine(grp, c, shares[2:])
+			if err != nil {
+				t.Fatalf("Combine() error = %v", err)
+			}
+			if big.Cmp(got, secret) != 0 {
+				t.Errorf("Combine() = %v, want %v", got, secret)
+			}
+
+			blinded := *shares[0]
+			blinded.Blinding = big.Mod(big.Add(blinded.Blinding, big.NewInt(1)), grp.Order())
+			noBlinding := *shares[0]
+			noBlinding.Blinding = nil
+			outOfRange := *shares[0]
+			outOfRange.Blinding = big.Add(outOfRange.Blinding, grp.Order())
+			tests := []struct {
+				name string
+				c    *PedersenCommitment
+				s    *Share
+			}{
+				{name: "tampered value", c: c, s: tamper(shares[0])},
+				{name: "tampered blinding", c: c, s: &blinded},
+				{name: "missing blinding", c: c, s: &noBlinding},
+				{name: "blinding out of range", c: c, s: &outOfRange},
+				{name: "wrong h", c: &PedersenCommitment{Group: grp, H: grp.Generator(), C: c.C}, s: shares[0]},
+				{name: "missing h", c: &PedersenCommitment{Group: grp, C: c.C}, s: shares[0]},
+			}
+			for _, tc := range tests {
+				if tc.c.Verify(tc.s) {
+					t.Errorf("Verify(%v) = true, want false", tc.name)
+				}
+			}
+		})
+	}
+}
+
+// TestSplitPedersen_hiding は同じ秘密でもコミットメントの C_0 が毎回変わり、g^s とも一致しないことを確かめる
+func TestSplitPedersen_hiding(t *testing.T) {
+	grp := testZpGroup
+	h := testH(t, grp)
+	secret := big.NewInt(7)
+	_, c1, err := SplitPedersen(rand.Reader, grp, h, secret, 3, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, c2, err := SplitPedersen(rand.Reader, grp, h, secret, 3, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if grp.Equal(c1.C[0], c2.C[0]) {
+		t.Errorf("C_0 is the same for the same secret")
+	}
+	if grp.Equal(c1.C[0], grp.Exp(grp.Generator(), secret)) {
+		t.Errorf("C_0 = g^secret")
+	}
+}
@@ -0,0 +1,175 @@
+// Package vss は検証可能な秘密分散 (VSS) を提供します
+// ディーラーは sss と同じく秘密を定数項とする多項式で分散し、あわせて係数へのコミットメントを公開します
+// 各参加者はコミットメントを使って、自分の分散値が他の参加者と同じ多項式から作られたものかを確かめられます
+// 素数位数の群の上で g^(a_i) にコミットする Feldman VSS と、g^(a_i) h^(b_i) にコミットする Pedersen VSS があります
+package vss
+
+import (
+	"errors"
+	"io"
+
+	"github.com/convto/mycrypto/big"
+	"github.com/convto/mycrypto/dlog"
+	"github.com/convto/mycrypto/field"
+	"github.com/convto/mycrypto/poly"
+	"github.com/convto/mycrypto/sss"
+)
+
+// ErrInvalidShare はコミットメントで検証できない分散値が含まれていることを表します
+var ErrInvalidShare = errors.New("vss: invalid share")
+
+// Share は参加者に配る分散値です
+// 埋め込んだ sss.Share は群の位数 q を法とする sss.Split の分散値と同じ形式で、sss.Combine でも復元できます
+type Share struct {
+	sss.Share
+	// Blinding は Pedersen VSS で秘密を隠す多項式の値 f'(x) で、Feldman VSS では nil です
+	Blinding *big.Int
+}
+
+// Verifier はディーラーが公開したコミットメントで分散値を検証します
+type Verifier interface {
+	// Verify は s がコミットメントした多項式の上の分散値かどうかを判定します
+	Verify(s *Share) bool
+	// Threshold は復元に必要な分散値の数 k を返します
+	Threshold() int
+}
+
+// Combine は v で検証した分散値から秘密を復元します
+// 検証に失敗する分散値があるときは ErrInvalidShare を返します
+func Combine(grp dlog.PrimeOrderGroup, v Verifier, shares []*Share) (*big.Int, error) {
+	ss := make([]*sss.Share, len(shares))
+	for i, s := range shares {
+		if !v.Verify(s) {
+			return nil, ErrInvalidShare
+		}
+		ss[i] = &s.Share
+	}
+	return sss.Combine(grp.Order(), ss)
+}
+
+// Complaint は参加者 Index が受け取った分散値の検証に失敗したことを表す申し立てです
+// 申し立てを受けたディーラーは、その参加者の分散値を全員に公開して応答します
+type Complaint struct {
+	Index byte
+}
+
+// Answer はディーラーが申し立てに応答して公開する分散値を shares から選んで返します
+func Answer(shares []*Share, complaints []Complaint) []*Share {
+	var res []*Share
+	for _, c := range complaints {
+		for _, s := range shares {
+			if s.Index == c.Index {
+				res = append(res, s)
+				break
+			}
+		}
+	}
+	return res
+}
+
+// Resolve は申し立てとディーラーの応答から、ディーラーを失格にすべきかを判定し、失格でないときに true を返します
+// 申し立てが k 件以上あるとき (公開される分散値から秘密が復元できてしまうとき) や、申し立てに対応する正しい分散値が公開されていないときは失格になります
+// 失格でなければ、申し立てた参加者は公開された分散値を自分の分散値として使えます
+func Resolve(v Verifier, complaints []Complaint, answers []*Share) bool {
+	var accused [256]bool
+	n := 0
+	for _, c := range complaints {
+		if !accused[c.Index] {
+			accused[c.Index] = true
+			n++
+		}
+	}
+	if n >= v.Threshold() {
+		return false
+	}
+	var answered [256]bool
+	for _, s := range answers {
+		if !accused[s.Index] {
+			continue
+		}
+		if !v.Verify(s) {
+			return false
+		}
+		answered[s.Index] = true
+	}
+	return answered == accused
+}
+
+// randomPoly は r から読み込んだ乱数で f(0) = c0 となる GF(q) 上のランダムな k-1 次多項式の係数を返す
+func randomPoly(r io.Reader, f *field.Field, c0 *big.Int, k int) ([]*field.Element, error) {
+	coeffs := make([]*field.Element, k)
+	coeffs[0] = f.NewElement(c0)
+	for i := 1; i < k; i++ {
+		c, err := f.Random(r)
+		if err != nil {
+			return nil, err
+		}
+		coeffs[i] = c
+	}
+	return coeffs, nil
+}
+
+// checkSplit は分散の引数を確かめる
+func checkSplit(grp dlog.PrimeOrderGroup, secret *big.Int, n, k int) error {
+	if k < 2 || k > n || n > 255 || big.Cmp(grp.Order(), big.NewInt(int64(n))) <= 0 {
+		return sss.ErrInvalidThreshold
+	}
+	if big.Cmp(secret, big.Zero) < 0 || big.Cmp(secret, grp.Order()) >= 0 {
+		return sss.ErrSecretOutOfRange
+	}
+	return nil
+}
+
+// evalShares は x = 1, ..., n での f の値を分散値として返す
+func evalShares(f *field.Field, coeffs []*field.Element, n int) []*Share {
+	p := poly.FromElements(f, coeffs)
+	shares := make([]*Share, n)
+	for i := range shares {
+		x := byte(i + 1)
+		shares[i] = &Share{Share: sss.Share{
+			Mode:      sss.ModePrime,
+			Threshold: byte(len(coeffs)),
+			Index:     x,
+			Value:     p.Eval(f.NewElement(big.NewInt(int64(x)))).Bytes(),
+		}}
+	}
+	return shares
+}
+
+// checkCommitments は k 個のコミットメントがすべて群の元かどうかを判定する
+func checkCommitments(grp dlog.PrimeOrderGroup, c []dlog.Element) bool {
+	if len(c) < 2 {
+		return false
+	}
+	for _, e := range c {
+		if e == nil || !grp.Contains(e) {
+			return false
+		}
+	}
+	return true
+}
+
+// evalCommitments は Π C_j^(x^j) を返す
+// C_j が係数 a_j へのコミットメントなら、f(x) へのコミットメントになる
+func evalCommitments(grp dlog.PrimeOrderGroup, c []dlog.Element, x byte) dlog.Element {
+	q := grp.Order()
+	res := grp.Identity()
+	xj := big.NewInt(1)
+	for _, cj := range c {
+		res = grp.Op(res, grp.Exp(cj, xj))
+		xj = big.Mod(big.Mul(xj, big.NewInt(int64(x))), q)
+	}
+	return res
+}
+
+// shareValue は s の値を 0 <= v < q の整数として返す
+func shareValue(f *field.Field, s *Share, k int) (*big.Int, bool) {
+	if s == nil || s.Index == 0 || int(s.Threshold) != k {
+		return nil, false
+	}
+	v, err := f.SetBytes(s.Value)
+	if err != nil {
+		return nil, false
+	}
+	return v.Int(), true
+}
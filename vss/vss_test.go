@@ -0,0 +1,155 @@
+package vss
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"testing"
+
+	"github.com/convto/mycrypto/big"
+	"github.com/convto/mycrypto/dlog"
+	"github.com/convto/mycrypto/ec"
+	"github.com/convto/mycrypto/sss"
+)
+
+// testZpGroup は p が1024ビット、q が160ビットの FIPS 186-4 の DSA パラメータによる群です
+var testZpGroup = dlog.NewZpGroup(
+	new(big.Int).SetString("0x8f0e2cfd1ca6047c541b387aa36676e91c29aa6475dc61b601d4fc51cbae565817a223ed05cc28a8047dbe68516c9a04c3b688de6ed95ef4e7dd4d626721e2915ae20eda84e161b81f62c2067cf0349668516536150aba77d91c7a9e0d9f6715d8d2c10ff872bba84973dfc09414a183af6a319b7e4d9f6bc5383872c72ddf8b"),
+	new(big.Int).SetString("0xf82bf6fd6aa20c4706dd36e19202f835d333cb53"),
+	new(big.Int).SetString("0x390267fd31c85bfa709afb3eade45fc83db97a971daa3fc7df23817046288c8068ba2fca2604097d3d4b1f1ffe181773df52ca8132ee00baf8966fae831ed04ce653288a410cff1920036fa8502f90248fcb8ddda44ab5f2dddd268045b42904ab20c3670e60fdfb5be06d4f633bdeaee4909d1bbbbf2b8c42f9b0b26bd7ce1"),
+)
+
+// testGroups はテストに使う群で、Z_p* の部分群と楕円曲線の両方を含みます
+var testGroups = []struct {
+	name string
+	grp  dlog.PrimeOrderGroup
+}{
+	{name: "Zp 1024/160", grp: testZpGroup},
+	{name: "P-256", grp: dlog.NewCurveGroup(ec.P256())},
+	{name: "secp256k1", grp: dlog.NewCurveGroup(ec.Secp256k1())},
+}
+
+// testH は Pedersen VSS の2つ目の生成元 h を返す
+// Z_p* の部分群では SHA-256 の出力を (p-1)/q 乗して log_g h を誰も知らない元を導出する
+// 楕円曲線ではハッシュから点を導出する手段がないので、テストに限り離散対数を捨てた g^x を使う
+func testH(t *testing.T, grp dlog.PrimeOrderGroup) dlog.Element {
+	if zp, ok := grp.(*dlog.ZpGroup); ok {
+		sum := sha256.Sum256([]byte("vss test generator"))
+		e, _ := big.Div(big.Sub(zp.P, big.NewInt(1)), zp.Q)
+		return big.Exp(new(big.Int).SetBytes(sum[:]), e, zp.P)
+	}
+	x, err := rand.Int(rand.Reader, toStd(grp.Order()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return grp.Exp(grp.Generator(), new(big.Int).SetBytes(x.Bytes()))
+}
+
+// testSecret は grp の位数未満のランダムな秘密を返す
+func testSecret(t *testing.T, grp dlog.PrimeOrderGroup) *big.Int {
+	x, err := rand.Int(rand.Reader, toStd(grp.Order()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return new(big.Int).SetBytes(x.Bytes())
+}
+
+func TestCombine(t *testing.T) {
+	grp := testZpGroup
+	secret := testSecret(t, grp)
+	shares, c, err := SplitFeldman(rand.Reader, grp, secret, 5, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := Combine(grp, c, shares[1:4])
+	if err != nil {
+		t.Fatalf("Combine() error = %v", err)
+	}
+	if big.Cmp(got, secret) != 0 {
+		t.Errorf("Combine() = %v, want %v", got, secret)
+	}
+
+	// 埋め込んだ分散値は sss.Combine でも復元できる
+	ss := []*sss.Share{&shares[0].Share, &shares[2].Share, &shares[4].Share}
+	got, err = sss.Combine(grp.Order(), ss)
+	if err != nil {
+		t.Fatalf("sss.Combine() error = %v", err)
+	}
+	if big.Cmp(got, secret) != 0 {
+		t.Errorf("sss.Combine() = %v, want %v", got, secret)
+	}
+
+	// 検証に失敗する分散値は復元に使わない
+	bad := tamper(shares[0])
+	if _, err := Combine(grp, c, []*Share{bad, shares[1], shares[2]}); !errors.Is(err, ErrInvalidShare) {
+		t.Errorf("Combine(tampered) error = %v, want %v", err, ErrInvalidShare)
+	}
+}
+
+func TestResolve(t *testing.T) {
+	grp := testZpGroup
+	shares, c, err := SplitFeldman(rand.Reader, grp, testSecret(t, grp), 5, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name       string
+		complaints []Complaint
+		answers    []*Share
+		want       bool
+	}{
+		{name: "no complaints", want: true},
+		{
+			name:       "answered",
+			complaints: []Complaint{{Index: 2}},
+			answers:    Answer(shares, []Complaint{{Index: 2}}),
+			want:       true,
+		},
+		{
+			name:       "duplicate complaints",
+			complaints: []Complaint{{Index: 2}, {Index: 2}, {Index: 4}},
+			answers:    Answer(shares, []Complaint{{Index: 2}, {Index: 4}}),
+			want:       true,
+		},
+		{
+			name:       "not answered",
+			complaints: []Complaint{{Index: 2}, {Index: 4}},
+			answers:    Answer(shares, []Complaint{{Index: 2}}),
+			want:       false,
+		},
+		{
+			name:       "answered with another share",
+			complaints: []Complaint{{Index: 2}},
+			answers:    []*Share{shares[0]},
+			want:       false,
+		},
+		{
+			name:       "invalid answer",
+			complaints: []Complaint{{Index: 2}},
+			answers:    []*Share{tamper(shares[1])},
+			want:       false,
+		},
+		{
+			// k 個の分散値を公開すると秘密が漏れるので、応答できても失格にする
+			name:       "too many complaints",
+			complaints: []Complaint{{Index: 1}, {Index: 2}, {Index: 3}},
+			answers:    shares[:3],
+			want:       false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Resolve(c, tt.complaints, tt.answers); got != tt.want {
+				t.Errorf("Resolve() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// tamper は s の値を1だけずらした分散値を返す
+func tamper(s *Share) *Share {
+	c := *s
+	c.Value = append([]byte(nil), s.Value...)
+	c.Value[len(c.Value)-1] ^= 0x01
+	return &c
+}
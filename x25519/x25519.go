@@ -0,0 +1,155 @@
+// Package x25519 は RFC 7748 の X25519 と X448 による Diffie-Hellman 鍵共有を提供します
+// Montgomery 曲線 v^2 = u^3 + Au^2 + u の u 座標だけを使う Montgomery ladder でスカラー倍算を行います
+package x25519
+
+import (
+	"crypto/subtle"
+	"errors"
+
+	"github.com/convto/mycrypto/big"
+	"github.com/convto/mycrypto/field"
+)
+
+const (
+	// X25519Size は X25519 のスカラーと u 座標のバイト長です
+	X25519Size = 32
+	// X448Size は X448 のスカラーと u 座標のバイト長です
+	X448Size = 56
+)
+
+var (
+	// ErrInvalidLength はスカラーか u 座標の長さが曲線に合わないことを表します
+	ErrInvalidLength = errors.New("x25519: invalid input length")
+	// ErrLowOrderPoint は共有秘密がすべて0になった (相手の点の位数が小さい) ことを表します
+	ErrLowOrderPoint = errors.New("x25519: low order point")
+)
+
+var (
+	// Basepoint25519 は curve25519 の基点 u = 9 です
+	Basepoint25519 = append([]byte{9}, make([]byte, X25519Size-1)...)
+	// Basepoint448 は curve448 の基点 u = 5 です
+	Basepoint448 = append([]byte{5}, make([]byte, X448Size-1)...)
+)
+
+// curve は ladder に必要な Montgomery 曲線のパラメータです
+type curve struct {
+	f *field.Field
+	// a24 = (A - 2) / 4
+	a24 *field.Element
+	// bits はスカラーのビット長で、u 座標の読み込みでもこれを超える上位のビットを捨てる
+	bits int
+	size int
+	// clamp は RFC 7748 5 の decodeScalar のビット調整を行う
+	clamp func(k []byte)
+}
+
+var (
+	curve25519 = func() *curve {
+		// p = 2^255 - 19, A = 486662
+		f := field.New(big.Sub(big.Exp(big.NewInt(2), big.NewInt(255), nil), big.NewInt(19)))
+		return &curve{
+			f:    f,
+			a24:  f.NewElement(big.NewInt(121665)),
+			bits: 255,
+			size: X25519Size,
+			clamp: func(k []byte) {
+				k[0] &= 248
+				k[31] &= 127
+				k[31] |= 64
+			},
+		}
+	}()
+	curve448 = func() *curve {
+		// p = 2^448 - 2^224 - 1, A = 156326
+		two := big.NewInt(2)
+		p := big.Sub(big.Sub(big.Exp(two, big.NewInt(448), nil), big.Exp(two, big.NewInt(224), nil)), big.NewInt(1))
+		f := field.New(p)
+		return &curve{
+			f:    f,
+			a24:  f.NewElement(big.NewInt(39081)),
+			bits: 448,
+			size: X448Size,
+			clamp: func(k []byte) {
+				k[0] &= 252
+				k[55] |= 128
+			},
+		}
+	}()
+)
+
+// X25519 は curve25519 上で u 座標 point の点をスカラー scalar 倍した点の u 座標を返します
+// point に Basepoint25519 を渡すと公開鍵が、相手の公開鍵を渡すと共有秘密が得られます
+func X25519(scalar, point []byte) ([]byte, error) {
+	return curve25519.x(scalar, point)
+}
+
+// X448 は curve448 上で u 座標 point の点をスカラー scalar 倍した点の u 座標を返します
+// point に Basepoint448 を渡すと公開鍵が、相手の公開鍵を渡すと共有秘密が得られます
+func X448(scalar, point []byte) ([]byte, error) {
+	return curve448.x(scalar, point)
+}
+
+// x は RFC 7748 5 の X25519/X448 関数で、結果がすべて0のときは ErrLowOrderPoint を返す
+func (c *curve) x(scalar, point []byte) ([]byte, error) {
+	if len(scalar) != c.size || len(point) != c.size {
+		return nil, ErrInvalidLength
+	}
+	k := make([]byte, c.size)
+	copy(k, scalar)
+	c.clamp(k)
+	out := c.ladder(k, c.decodeU(point))
+	if subtle.ConstantTimeCompare(out, make([]byte, c.size)) == 1 {
+		return nil, ErrLowOrderPoint
+	}
+	return out, nil
+}
+
+// decodeU はリトルエンディアンの u 座標を読み込みます
+// bits を超える上位のビットは捨て、p 以上の値は p で還元して受け入れます
+func (c *curve) decodeU(b []byte) *field.Element {
+	u := make([]byte, c.size)
+	copy(u, b)
+	if r := c.bits % 8; r != 0 {
+		u[c.size-1] &= byte(1<<uint(r)) - 1
+	}
+	return c.f.NewElement(new(big.Int).SetBytesLE(u))
+}
+
+// ladder は RFC 7748 5 の Montgomery ladder で、リトルエンディアンのスカラー k について ku を計算し、u 座標をリトルエンディアンで返します
+func (c *curve) ladder(k []byte, u *field.Element) []byte {
+	x1 := u
+	x2, z2 := c.f.One(), c.f.Zero()
+	x3, z3 := u, c.f.One()
+	swap := 0
+	for t := c.bits - 1; t >= 0; t-- {
+		kt := int(k[t/8]>>uint(t%8)) & 1
+		swap ^= kt
+		x2, x3 = cswap(x2, x3, swap)
+		z2, z3 = cswap(z2, z3, swap)
+		swap = kt
+
+		a := x2.Add(z2)
+		aa := a.Square()
+		b := x2.Sub(z2)
+		bb := b.Square()
+		e := aa.Sub(bb)
+		cc := x3.Add(z3)
+		d := x3.Sub(z3)
+		da := d.Mul(a)
+		cb := cc.Mul(b)
+		x3 = da.Add(cb).Square()
+		z3 = x1.Mul(da.Sub(cb).Square())
+		x2 = aa.Mul(bb)
+		z2 = e.Mul(aa.Add(c.a24.Mul(e)))
+	}
+	x2, _ = cswap(x2, x3, swap)
+	z2, _ = cswap(z2, z3, swap)
+	// z2 = 0 のときは 0^(p-2) = 0 になるように逆元ではなく累乗で計算する
+	r := x2.Mul(z2.Exp(big.Sub(c.f.Modulus(), big.NewInt(2))))
+	return r.Int().FillBytesLE(make([]byte, c.size))
+}
+
+// cswap は swap == 1 のときだけ a と b を入れ替えた組を、分岐せずに返します
+func cswap(a, b *field.Element, swap int) (*field.Element, *field.Element) {
+	return field.Select(swap, b, a), field.Select(swap, a, b)
+}
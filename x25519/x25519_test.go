@@ -0,0 +1,240 @@
+package x25519
+
+import (
+	"crypto/ecdh"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func mustHex(t *testing.T, s string) []byte {
+	t.Helper()
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func TestX25519(t *testing.T) {
+	// RFC 7748 5.2 と 6.1 のテストベクタ
+	tests := []struct {
+		name   string
+		scalar string
+		point  string
+		want   string
+	}{
+		{
+			name:   "5.2 vector 1",
+			scalar: "a546e36bf0527c9d3b16154b82465edd62144c0ac1fc5a18506a2244ba449ac4",
+			point:  "e6db6867583030db3594c1a424b15f7c726624ec26b3353b10a903a6d0ab1c4c",
+			want:   "c3da55379de9c6908e94ea4df28d084f32eccf03491c71f754b4075577a28552",
+		},
+		{
+			// u 座標の最上位ビットが立っているが、読み込みで捨てられる
+			name:   "5.2 vector 2",
+			scalar: "4b66e9d4d1b4673c5ad22691957d6af5c11b6421e0ea01d42ca4169e7918ba0d",
+			point:  "e5210f12786811d3f4b7959d0538ae2c31dbe7106fc03c3efc4cd549c715a493",
+			want:   "95cbde9476e8907d7aade45cb4b873f88b595a68799fa152e6f8f7647aac7957",
+		},
+		{
+			name:   "6.1 Alice public key",
+			scalar: "77076d0a7318a57d3c16c17251b26645df4c2f87ebc0992ab177fba51db92c2a",
+			point:  "0900000000000000000000000000000000000000000000000000000000000000",
+			want:   "8520f0098930a754748b7ddcb43ef75a0dbf3a0d26381af4eba4a98eaa9b4e6a",
+		},
+		{
+			name:   "6.1 Bob public key",
+			scalar: "5dab087e624a8a4b79e17f8b83800ee66f3bb1292618b6fd1c2f8b27ff88e0eb",
+			point:  "0900000000000000000000000000000000000000000000000000000000000000",
+			want:   "de9edb7d7b7dc1b4d35b61c2ece435373f8343c85b78674dadfc7e146f882b4f",
+		},
+		{
+			name:   "6.1 Alice shared secret",
+			scalar: "77076d0a7318a57d3c16c17251b26645df4c2f87ebc0992ab177fba51db92c2a",
+			point:  "de9edb7d7b7dc1b4d35b61c2ece435373f8343c85b78674dadfc7e146f882b4f",
+			want:   "4a5d9d5ba4ce2de1728e3bf480350f25e07e21c947d19e3376f09b3c1e161742",
+		},
+		{
+			name:   "6.1 Bob shared secret",
+			scalar: "5dab087e624a8a4b79e17f8b83800ee66f3bb1292618b6fd1c2f8b27ff88e0eb",
+			point:  "8520f0098930a754748b7ddcb43ef75a0dbf3a0d26381af4eba4a98eaa9b4e6a",
+			want:   "4a5d9d5ba4ce2de1728e3bf480350f25e07e21c947d19e3376f09b3c1e161742",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := X25519(mustHex(t, tt.scalar), mustHex(t, tt.point))
+			if err != nil {
+				t.Fatalf("X25519() error = %v", err)
+			}
+			if hex.EncodeToString(got) != tt.want {
+				t.Errorf("X25519() = %x, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestX448(t *testing.T) {
+	// RFC 7748 5.2 と 6.2 のテストベクタ
+	tests := []struct {
+		name   string
+		scalar string
+		point  string
+		want   string
+	}{
+		{
+			name:   "5.2 vector 1",
+			scalar: "3d262fddf9ec8e88495266fea19a34d28882acef045104d0d1aae121700a779c984c24f8cdd78fbff44943eba368f54b29259a4f1c600ad3",
+			point:  "06fce640fa3487bfda5f6cf2d5263f8aad88334cbd07437f020f08f9814dc031ddbdc38c19c6da2583fa5429db94ada18aa7a7fb4ef8a086",
+			want:   "ce3e4ff95a60dc6697da1db1d85e6afbdf79b50a2412d7546d5f239fe14fbaadeb445fc66a01b0779d98223961111e21766282f73dd96b6f",
+		},
+		{
+			name:   "5.2 vector 2",
+			scalar: "203d494428b8399352665ddca42f9de8fef600908e0d461cb021f8c538345dd77c3e4806e25f46d3315c44e0a5b4371282dd2c8d5be3095f",
+			point:  "0fbcc2f993cd56d3305b0b7d9e55d4c1a8fb5dbb52f8e9a1e9b6201b165d015894e56c4d3570bee52fe205e28a78b91cdfbde71ce8d157db",
+			want:   "884a02576239ff7a2f2f63b2db6a9ff37047ac13568e1e30fe63c4a7ad1b3ee3a5700df34321d62077e63633c575c1c954514e99da7c179d",
+		},
+		{
+			name:   "6.2 Alice public key",
+			scalar: "9a8f4925d1519f5775cf46b04b5800d4ee9ee8bae8bc5565d498c28dd9c9baf574a9419744897391006382a6f127ab1d9ac2d8c0a598726b",
+			point:  "0500000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000",
+			want:   "9b08f7cc31b7e3e67d22d5aea121074a273bd2b83de09c63faa73d2c22c5d9bbc836647241d953d40c5b12da88120d53177f80e532c41fa0",
+		},
+		{
+			name:   "6.2 Bob public key",
+			scalar: "1c306a7ac2a0e2e0990b294470cba339e6453772b075811d8fad0d1d6927c120bb5ee8972b0d3e21374c9c921b09d1b0366f10b65173992d",
+			point:  "0500000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000",
+			want:   "3eb7a829b0cd20f5bcfc0b599b6feccf6da4627107bdb0d4f345b43027d8b972fc3e34fb4232a13ca706dcb57aec3dae07bdc1c67bf33609",
+		},
+		{
+			name:   "6.2 Alice shared secret",
+			scalar: "9a8f4925d1519f5775cf46b04b5800d4ee9ee8bae8bc5565d498c28dd9c9baf574a9419744897391006382a6f127ab1d9ac2d8c0a598726b",
+			point:  "3eb7a829b0cd20f5bcfc0b599b6feccf6da4627107bdb0d4f345b43027d8b972fc3e34fb4232a13ca706dcb57aec3dae07bdc1c67bf33609",
+			want:   "07fff4181ac6cc95ec1c16a94a0f74d12da232ce40a77552281d282bb60c0b56fd2464c335543936521c24403085d59a449a5037514a879d",
+		},
+		{
+			name:   "6.2 Bob shared secret",
+			scalar: "1c306a7ac2a0e2e0990b294470cba339e6453772b075811d8fad0d1d6927c120bb5ee8972b0d3e21374c9c921b09d1b0366f10b65173992d",
+			point:  "9b08f7cc31b7e3e67d22d5aea121074a273bd2b83de09c63faa73d2c22c5d9bbc836647241d953d40c5b12da88120d53177f80e532c41fa0",
+			want:   "07fff4181ac6cc95ec1c16a94a0f74d12da232ce40a77552281d282bb60c0b56fd2464c335543936521c24403085d59a449a5037514a879d",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := X448(mustHex(t, tt.scalar), mustHex(t, tt.point))
+			if err != nil {
+				t.Fatalf("X448() error = %v", err)
+			}
+			if hex.EncodeToString(got) != tt.want {
+				t.Errorf("X448() = %x, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIterated(t *testing.T) {
+	// RFC 7748 5.2 の繰り返しのテスト
+	// k と u を基点で始め、k, u = f(k, u), k を繰り返す
+	// 1,000,000 回のベクタは時間がかかりすぎるので含めない
+	tests := []struct {
+		name  string
+		f     func(scalar, point []byte) ([]byte, error)
+		base  []byte
+		times int
+		want  string
+	}{
+		{name: "X25519 1", f: X25519, base: Basepoint25519, times: 1, want: "422c8e7a6227d7bca1350b3e2bb7279f7897b87bb6854b783c60e80311ae3079"},
+		{name: "X25519 1000", f: X25519, base: Basepoint25519, times: 1000, want: "684cf59ba83309552800ef566f2f4d3c1c3887c49360e3875f2eb94d99532c51"},
+		{name: "X448 1", f: X448, base: Basepoint448, times: 1, want: "3f482c8a9f19b01e6c46ee9711d9dc14fd4bf67af30765c2ae2b846a4d23a8cd0db897086239492caf350b51f833868b9bc2b3bca9cf4113"},
+		{name: "X448 1000", f: X448, base: Basepoint448, times: 1000, want: "aa3b4749d55b9daf1e5b00288826c467274ce3ebbdd5c17b975e09d4af6c67cf10d087202db88286e2b79fceea3ec353ef54faa26e219f38"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.times > 1 && testing.Short() {
+				t.Skip("skipping in short mode")
+			}
+			k := append([]byte{}, tt.base...)
+			u := append([]byte{}, tt.base...)
+			for i := 0; i < tt.times; i++ {
+				r, err := tt.f(k, u)
+				if err != nil {
+					t.Fatalf("iteration %d: error = %v", i, err)
+				}
+				k, u = r, k
+			}
+			if hex.EncodeToString(k) != tt.want {
+				t.Errorf("got %x, want %v", k, tt.want)
+			}
+		})
+	}
+}
+
+func TestX25519_lowOrder(t *testing.T) {
+	scalar := mustHex(t, "a546e36bf0527c9d3b16154b82465edd62144c0ac1fc5a18506a2244ba449ac4")
+	scalar448 := mustHex(t, "9a8f4925d1519f5775cf46b04b5800d4ee9ee8bae8bc5565d498c28dd9c9baf574a9419744897391006382a6f127ab1d9ac2d8c0a598726b")
+	tests := []struct {
+		name   string
+		f      func(scalar, point []byte) ([]byte, error)
+		scalar []byte
+		point  string
+	}{
+		{name: "X25519 u = 0", f: X25519, scalar: scalar, point: "0000000000000000000000000000000000000000000000000000000000000000"},
+		{name: "X25519 u = 1", f: X25519, scalar: scalar, point: "0100000000000000000000000000000000000000000000000000000000000000"},
+		{name: "X25519 u = p - 1", f: X25519, scalar: scalar, point: "ecffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff7f"},
+		{name: "X25519 u = p", f: X25519, scalar: scalar, point: "edffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff7f"},
+		{name: "X448 u = 0", f: X448, scalar: scalar448, point: "0000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000"},
+		{name: "X448 u = 1", f: X448, scalar: scalar448, point: "0100000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000"},
+		{name: "X448 u = p - 1", f: X448, scalar: scalar448, point: "fefffffffffffffffffffffffffffffffffffffffffffffffffffffffeffffffffffffffffffffffffffffffffffffffffffffffffffffff"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.f(tt.scalar, mustHex(t, tt.point)); !errors.Is(err, ErrLowOrderPoint) {
+				t.Errorf("error = %v, want %v", err, ErrLowOrderPoint)
+			}
+		})
+	}
+}
+
+func TestX25519_invalidLength(t *testing.T) {
+	if _, err := X25519(make([]byte, 31), Basepoint25519); !errors.Is(err, ErrInvalidLength) {
+		t.Errorf("X25519() error = %v, want %v", err, ErrInvalidLength)
+	}
+	if _, err := X448(make([]byte, X448Size), Basepoint25519); !errors.Is(err, ErrInvalidLength) {
+		t.Errorf("X448() error = %v, want %v", err, ErrInvalidLength)
+	}
+}
+
+func TestX25519_crypto(t *testing.T) {
+	// crypto/ecdh の X25519 と同じ共有秘密になることを確かめる
+	for i := 0; i < 4; i++ {
+		priv, err := ecdh.X25519().GenerateKey(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		peer, err := ecdh.X25519().GenerateKey(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want, err := priv.ECDH(peer.PublicKey())
+		if err != nil {
+			t.Fatal(err)
+		}
+		pub, err := X25519(priv.Bytes(), Basepoint25519)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(pub, priv.PublicKey().Bytes()) {
+			t.Errorf("public key = %x, want %x", pub, priv.PublicKey().Bytes())
+		}
+		got, err := X25519(priv.Bytes(), peer.PublicKey().Bytes())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("shared secret = %x, want %x", got, want)
+		}
+	}
+}